@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+const defaultBaselinePath = "tflint-baseline.json"
+
+func baselinePath(opts Options) string {
+	if opts.Baseline != "" {
+		return opts.Baseline
+	}
+	return defaultBaselinePath
+}
+
+// generateBaseline writes the issues to the baseline file instead of reporting them.
+func (cli *CLI) generateBaseline(issues tflint.Issues, opts Options) int {
+	path := baselinePath(opts)
+	if err := issues.WriteBaseline(path); err != nil {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to write the baseline file; %w", err), cli.sources)
+		return ExitCodeError
+	}
+
+	fmt.Fprintf(cli.outStream, "Baseline with %d issue(s) written to %s\n", len(issues), path)
+	return ExitCodeOK
+}
+
+// applyBaseline removes issues recorded in the baseline file.
+// Suppressed issues are not reported and do not affect the exit status.
+func (cli *CLI) applyBaseline(issues tflint.Issues, opts Options) (tflint.Issues, error) {
+	if opts.Baseline == "" {
+		return issues, nil
+	}
+
+	issues, suppressed, err := issues.ApplyBaseline(opts.Baseline)
+	if err != nil {
+		return issues, fmt.Errorf("Failed to apply the baseline file; %w", err)
+	}
+	if suppressed > 0 {
+		fmt.Fprintf(cli.errStream, "%d issue(s) suppressed by the baseline\n", suppressed)
+	}
+	return issues, nil
+}
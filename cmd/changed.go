@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/terraform-linters/tflint/terraform"
+	"github.com/terraform-linters/tflint/terraform/addrs"
+)
+
+// changedFiles returns absolute paths of files changed in the git repository containing the directory.
+// Without a base ref, files changed from the merge base with the default branch are returned,
+// including uncommitted changes and untracked files.
+// With a base ref, files changed between the merge base of the ref and HEAD are returned.
+func changedFiles(dir string, baseRef string) ([]string, error) {
+	root, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return []string{}, fmt.Errorf("--changed-only requires a git repository; %w", err)
+	}
+	root = strings.TrimSpace(root)
+
+	args := []string{"diff", "-z", "--name-only", "--merge-base", baseRef, "HEAD"}
+	if baseRef == "" {
+		branch, err := defaultBranch(root)
+		if err != nil {
+			return []string{}, err
+		}
+		log.Printf("[DEBUG] Compare with the default branch: %s", branch)
+		// Compare the working tree to include uncommitted changes
+		args = []string{"diff", "-z", "--name-only", "--merge-base", branch}
+	}
+	out, err := git(root, args...)
+	if err != nil {
+		return []string{}, fmt.Errorf("Failed to get changed files; %w", err)
+	}
+	paths := strings.Split(out, "\x00")
+
+	if baseRef == "" {
+		out, err = git(root, "ls-files", "-z", "--others", "--exclude-standard")
+		if err != nil {
+			return []string{}, fmt.Errorf("Failed to get untracked files; %w", err)
+		}
+		paths = append(paths, strings.Split(out, "\x00")...)
+	}
+
+	files := []string{}
+	for _, path := range paths {
+		if path != "" {
+			files = append(files, filepath.Join(root, filepath.FromSlash(path)))
+		}
+	}
+	log.Printf("[DEBUG] Changed files: %s", strings.Join(files, ", "))
+
+	return files, nil
+}
+
+// defaultBranch returns the default branch of the git repository.
+// The remote HEAD is used if it is set, e.g. by `git clone`. Otherwise, main or master is used if it exists.
+func defaultBranch(dir string) (string, error) {
+	if out, err := git(dir, "symbolic-ref", "--quiet", "refs/remotes/origin/HEAD"); err == nil {
+		return strings.TrimSpace(out), nil
+	}
+
+	for _, ref := range []string{"refs/heads/main", "refs/heads/master", "refs/remotes/origin/main", "refs/remotes/origin/master"} {
+		if _, err := git(dir, "rev-parse", "--verify", "--quiet", ref); err == nil {
+			return ref, nil
+		}
+	}
+	return "", errors.New("Failed to find the default branch. Set the remote HEAD with `git remote set-head origin --auto`, or pass the base ref with --base-ref")
+}
+
+func git(dir string, args ...string) (string, error) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
+
+// changedFilesIn returns paths of changed files under the directory, relative to the directory.
+// Files outside the directory are excluded.
+func changedFilesIn(dir string, files []string) ([]string, error) {
+	abs, err := absPath(dir)
+	if err != nil {
+		return []string{}, err
+	}
+
+	ret := []string{}
+	for _, file := range files {
+		rel, err := filepath.Rel(abs, file)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		ret = append(ret, rel)
+	}
+	return ret, nil
+}
+
+// hasChangedConfigFiles returns true if any changed files are Terraform configuration files in the directory.
+// Files in subdirectories are not counted because they are not loaded as part of the module.
+func hasChangedConfigFiles(dir string, files []string) (bool, error) {
+	changed, err := changedFilesIn(dir, files)
+	if err != nil {
+		return false, err
+	}
+
+	for _, file := range changed {
+		if filepath.Dir(file) != "." {
+			continue
+		}
+		if strings.HasSuffix(file, ".tf") || strings.HasSuffix(file, ".tf.json") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// absPath returns the absolute path with symlinks resolved, so that it can be compared with paths output by git.
+func absPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(abs)
+}
+
+// filterChangedFiles returns files changed in the current directory to filter issues.
+// If --filter is also given, only changed files that match the filter are returned.
+func filterChangedFiles(opts Options) ([]string, error) {
+	files, err := changedFiles(".", opts.baseRef())
+	if err != nil {
+		return []string{}, err
+	}
+	changed, err := changedFilesIn(".", files)
+	if err != nil {
+		return []string{}, err
+	}
+
+	filters := make([]string, len(opts.Filter))
+	for i, pattern := range opts.Filter {
+		filters[i] = filepath.ToSlash(pattern)
+	}
+	ret := []string{}
+	for _, file := range changed {
+		if matchFilters(filters, file) {
+			ret = append(ret, file)
+		}
+	}
+	return ret, nil
+}
+
+// filterChangedDirs returns working directories that contain changed configuration files,
+// or call local modules that contain them, and the latter directories as dependents.
+// Dependents are inspected entirely since changes in modules can cause issues in any files of callers.
+func filterChangedDirs(opts Options, workingDirs []string) ([]string, map[string]bool, error) {
+	baseDir := opts.chdir()
+	if baseDir == "" {
+		baseDir = "."
+	}
+	files, err := changedFiles(baseDir, opts.baseRef())
+	if err != nil {
+		return []string{}, map[string]bool{}, err
+	}
+
+	ret := []string{}
+	dependents := map[string]bool{}
+	for _, dir := range workingDirs {
+		changed, err := hasChangedConfigFiles(dir, files)
+		if err != nil {
+			return []string{}, map[string]bool{}, err
+		}
+		if changed {
+			ret = append(ret, dir)
+			continue
+		}
+
+		changed, err = hasChangedLocalModules(dir, files, map[string]bool{})
+		if err != nil {
+			return []string{}, map[string]bool{}, err
+		}
+		if changed {
+			log.Printf("[DEBUG] Inspect %s because local modules are changed", dir)
+			ret = append(ret, dir)
+			dependents[dir] = true
+		} else {
+			log.Printf("[DEBUG] Skip %s because no files are changed", dir)
+		}
+	}
+	return ret, dependents, nil
+}
+
+// hasChangedLocalModules returns true if any local modules called from the directory, including nested ones,
+// contain changed configuration files. Visited directories are recorded to avoid infinite loops.
+func hasChangedLocalModules(dir string, files []string, visited map[string]bool) (bool, error) {
+	abs, err := absPath(dir)
+	if err != nil {
+		return false, err
+	}
+	if visited[abs] {
+		return false, nil
+	}
+	visited[abs] = true
+
+	// Errors are reported by the inspection, so modules are found as far as possible
+	mod, diags := terraform.NewParser(afero.NewOsFs()).LoadConfigDir(".", dir)
+	if diags.HasErrors() {
+		log.Printf("[DEBUG] Failed to load %s to find local modules: %s", dir, diags)
+	}
+	if mod == nil {
+		return false, nil
+	}
+
+	for _, call := range mod.ModuleCalls {
+		source, ok := call.SourceAddr.(addrs.ModuleSourceLocal)
+		if !ok {
+			continue
+		}
+		moduleDir := filepath.Join(dir, filepath.FromSlash(string(source)))
+		// Module sources that do not exist are reported by the inspection
+		if _, err := os.Stat(moduleDir); err != nil {
+			continue
+		}
+
+		changed, err := hasChangedConfigFiles(moduleDir, files)
+		if err != nil {
+			return false, err
+		}
+		if !changed {
+			changed, err = hasChangedLocalModules(moduleDir, files, visited)
+			if err != nil {
+				return false, err
+			}
+		}
+		if changed {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -1,17 +1,21 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"slices"
 	"strings"
 	"syscall"
+	"text/template"
 
-	"github.com/fatih/color"
+	"github.com/bmatcuk/doublestar"
 	"github.com/hashicorp/logutils"
 	flags "github.com/jessevdk/go-flags"
 	"github.com/spf13/afero"
@@ -32,13 +36,35 @@ type CLI struct {
 	// outStream and errStream are the stdout and stderr
 	// to write message from the CLI.
 	outStream, errStream io.Writer
-	originalWorkingDir   string
-	sources              map[string][]byte
+	// inStream is the stdin to read the file list or the configuration from.
+	inStream           io.Reader
+	originalWorkingDir string
+	sources            map[string][]byte
+
+	// failOnSeverity is the minimum severity for exiting with ExitCodeIssuesFound.
+	// All issues are counted if empty.
+	failOnSeverity string
+
+	// fileList is absolute paths of files given by --file-list. It is nil if not given.
+	fileList []string
+	// stdinSource is the configuration read from stdin in --stdin mode.
+	stdinSource []byte
+	// ignoredFile reports whether a file in the working directory is ignored by .tflintignore or TFLINT_IGNORE.
+	// It is nil if there are no patterns.
+	ignoredFile func(path string) bool
+
+	// loaderCache is shared between loaders, so that unchanged files are not parsed
+	// again when the inspection is repeated in watch mode.
+	loaderCache *terraform.LoaderCache
 
 	// fields for each module
 	config    *tflint.Config
 	loader    *terraform.Loader
 	formatter *formatter.Formatter
+	// evaluatedRules are rules evaluated in the module. It is nil if the module is not inspected.
+	evaluatedRules []string
+	// originals are sources of files in the module before autofixes are applied.
+	originals map[string][]byte
 }
 
 // NewCLI returns new CLI initialized by input streams
@@ -48,13 +74,15 @@ func NewCLI(outStream io.Writer, errStream io.Writer) (*CLI, error) {
 	return &CLI{
 		outStream:          outStream,
 		errStream:          errStream,
+		inStream:           os.Stdin,
 		originalWorkingDir: wd,
 		sources:            map[string][]byte{},
+		loaderCache:        terraform.NewLoaderCache(),
 	}, err
 }
 
 // Run invokes the CLI with the given arguments.
-func (cli *CLI) Run(args []string) int {
+func (cli *CLI) Run(args []string) (status int) {
 	cli.formatter = &formatter.Formatter{
 		Stdout: cli.outStream,
 		Stderr: cli.errStream,
@@ -62,12 +90,12 @@ func (cli *CLI) Run(args []string) int {
 
 	// Configure options parser
 	var opts Options
-	parser := flags.NewParser(&opts, flags.HelpFlag)
-	parser.Usage = "--chdir=DIR/--recursive [OPTIONS]"
-	parser.UnknownOptionHandler = unknownOptionHandler
+	parser := newOptionsParser(&opts)
 
-	// Parse command line options
-	args, err := parser.ParseArgs(args)
+	// Parse command line options.
+	// The program name is excluded, as it would otherwise be taken as a positional argument before subcommands.
+	rest, err := parser.ParseArgs(args[1:])
+	args = append(args[:1:1], rest...)
 	if err != nil {
 		if flagsErr, ok := err.(*flags.Error); ok && flagsErr.Type == flags.ErrHelp {
 			fmt.Fprintln(cli.outStream, err)
@@ -77,32 +105,176 @@ func (cli *CLI) Run(args []string) int {
 		return ExitCodeError
 	}
 
+	// Only inspection supports multiple working directories
+	if opts.multipleChdirs() && (parser.Active != nil || len(args) > 1 || opts.Version || opts.Init || opts.Langserver || opts.PrintConfig || opts.ListRules) {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Multiple --chdir can only be used in inspection"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if len(args) > 1 && args[1] == "explain" {
+		if len(args) != 3 {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Usage: tflint explain RULE_NAME"), map[string][]byte{})
+			return ExitCodeError
+		}
+		return cli.explain(opts, args[2])
+	}
+	if parser.Active != nil && parser.Active.Name == "generate-config" {
+		if len(args) != 1 {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Usage: tflint generate-config [--output=FILE] [--overwrite]"), map[string][]byte{})
+			return ExitCodeError
+		}
+		return cli.generateConfig(opts)
+	}
+	if parser.Active != nil && parser.Active.Name == "validate-config" {
+		if len(args) != 1 {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Usage: tflint validate-config [--config=FILE] [--allow-unknown-rules]"), map[string][]byte{})
+			return ExitCodeError
+		}
+		return cli.validateConfig(opts)
+	}
+	if parser.Active != nil && parser.Active.Name == "migrate-config" {
+		if len(args) != 1 {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Usage: tflint migrate-config [--from=VERSION] [--to=VERSION] [--config=FILE]"), map[string][]byte{})
+			return ExitCodeError
+		}
+		return cli.migrateConfig(opts)
+	}
+	// "-" is accepted as a conventional marker of reading from stdin
+	if len(args) == 2 && args[1] == "-" {
+		if !opts.stdin() {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--stdin or --stdin-filename is required to read configuration from stdin with -"), map[string][]byte{})
+			return ExitCodeError
+		}
+		args = args[:1]
+	}
 	if len(args) > 1 {
 		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Command line arguments support was dropped in v0.47. Use --chdir or --filter instead."), map[string][]byte{})
 		return ExitCodeError
 	}
 
+	if opts.Profile != "" {
+		profiler, err := startProfiling(opts.Profile)
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+			return ExitCodeError
+		}
+		defer func() {
+			if err := profiler.stop(); err != nil {
+				fmt.Fprintf(cli.errStream, "%s\n", err)
+				status = ExitCodeError
+			}
+		}()
+	}
+
 	// Setup config
-	cfg, err := tflint.LoadConfig(afero.Afero{Fs: afero.NewOsFs()}, opts.Config)
+	cfg, err := loadConfig(opts)
 	if err != nil {
 		fmt.Fprintf(cli.errStream, "Failed to load TFLint config; %s\n", err)
 		return ExitCodeError
 	}
 
 	cfg.Merge(opts.toConfig())
+	// The coordinator walks directories, so exclude_dirs and skip_dirs are read from the config of the current directory
+	opts.excludeDirs = cfg.ExcludeDirs
+	opts.skipDirs = cfg.SkipDirs
+
+	// Annotate pull requests by default when running in GitHub Actions.
+	// Workers are excluded because errors must be output to stderr.
+	if !cfg.FormatSet && !opts.ActAsWorker && os.Getenv("GITHUB_ACTIONS") == "true" {
+		cfg.Format = "github"
+	}
 
 	// Set formatter fields from options/config
 	cli.formatter.Format = cfg.Format
 	cli.formatter.Fix = opts.Fix
+	cli.formatter.DryRun = opts.DryRun && !opts.ActAsWorker
+	// Format options in the config file are applied first, so that flags take precedence
+	format := cfg.Format
+	if format == "" {
+		format = "default"
+	}
+	if err := cli.formatter.ApplyFormatOptions(format, cfg.FormatOptions[format]); err != nil {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to apply format options in the config file; %w", err), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.NoSummary {
+		cli.formatter.NoSummary = true
+	}
+	if opts.GroupByFile {
+		cli.formatter.GroupByFile = true
+	}
+	if opts.CompactLinks {
+		cli.formatter.CompactLinks = true
+	}
+	if opts.CompactRanges {
+		cli.formatter.CompactRanges = true
+	}
+	if opts.QuietSuccess {
+		cli.formatter.QuietSuccess = true
+	}
+	if n := max(opts.Context, opts.SnippetContext); n > 0 {
+		cli.formatter.SnippetContext = n
+	}
+	if opts.IncludeSource {
+		cli.formatter.IncludeSource = true
+	}
+	if opts.SourceMaxLength != nil {
+		cli.formatter.SourceMaxLength = *opts.SourceMaxLength
+	}
+	if opts.DiffMaxLines != nil {
+		cli.formatter.DiffMaxLines = *opts.DiffMaxLines
+	}
+	cli.formatter.PathMode = opts.PathMode
+	cli.formatter.MaxIssues = opts.MaxIssues
+	if opts.JSONVersion != "" {
+		cli.formatter.JSONVersion = opts.JSONVersion
+	}
+	formatOptions, err := formatter.ParseFormatOptions(opts.FormatOptions)
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to parse --format-option options; %w", err), map[string][]byte{})
+		return ExitCodeError
+	}
+	if err := cli.formatter.ApplyFormatOptions(format, formatOptions); err != nil {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to apply --format-option options; %w", err), map[string][]byte{})
+		return ExitCodeError
+	}
+	// In recursive inspection, the module directories are replaced with the working directories
+	if opts.chdir() != "" {
+		cli.formatter.ModuleDirs = []string{opts.chdir()}
+	} else {
+		cli.formatter.ModuleDirs = []string{"."}
+	}
+	cli.formatter.MinimumReportSeverity = opts.MinimumReportSeverity
+	cli.failOnSeverity = cfg.MinimumFailureSeverity
+	if opts.Recursive || opts.multipleChdirs() {
+		// Each working directory can have a different config file, so the config of each directory
+		// is applied to its issues by the coordinator unless the flag is passed
+		cli.failOnSeverity = opts.minimumFailureSeverity()
+	}
+
+	sonarQubeSeverities, err := formatter.ParseSonarQubeSeverities(opts.SonarQubeSeverities)
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to parse --sonarqube-severity options; %w", err), map[string][]byte{})
+		return ExitCodeError
+	}
+	cli.formatter.SonarQubeSeverities = sonarQubeSeverities
 
-	if opts.Color {
-		color.NoColor = false
-		cli.formatter.NoColor = false
+	if opts.FormatTemplate != "" && opts.FormatTemplateFile != "" {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--format-template and --format-template-file cannot be used together"), map[string][]byte{})
+		return ExitCodeError
 	}
-	if opts.NoColor {
-		color.NoColor = true
-		cli.formatter.NoColor = true
+	// Workers always output serialized issues, so the template is not needed
+	if cfg.Format == "template" && !opts.ActAsWorker {
+		tmpl, err := loadFormatTemplate(opts)
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+			return ExitCodeError
+		}
+		cli.formatter.Template = tmpl
 	}
+
+	// Colors are decided for each stream, e.g. stderr is colorized even if stdout is piped
+	cli.formatter.Color = shouldColorize(opts.colorMode(), cli.outStream)
+	cli.formatter.ColorStderr = shouldColorize(opts.colorMode(), cli.errStream)
 	level := os.Getenv("TFLINT_LOG")
 	log.SetOutput(&logutils.LevelFilter{
 		Levels:   []logutils.LogLevel{"TRACE", "DEBUG", "INFO", "WARN", "ERROR"},
@@ -115,6 +287,164 @@ func (cli *CLI) Run(args []string) int {
 		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Max workers should be greater than 0"), map[string][]byte{})
 		return ExitCodeError
 	}
+	if opts.MaxDepth != nil && *opts.MaxDepth < 0 {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Max depth should be greater than or equal to 0"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.JSONVersion != "" && !slices.Contains(formatter.JSONFormatVersions, opts.JSONVersion) {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf(`JSON format version "%s" is not supported. Supported versions: %s`, opts.JSONVersion, strings.Join(formatter.JSONFormatVersions, ", ")), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.MaxIssues < 0 {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Max issues should be greater than or equal to 0"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.Context < 0 {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Context should be greater than or equal to 0"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.SnippetContext < 0 {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Snippet context should be greater than or equal to 0"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.SourceMaxLength != nil && *opts.SourceMaxLength <= 0 {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Source max length should be greater than 0"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.DiffMaxLines != nil && *opts.DiffMaxLines <= 0 {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Diff max lines should be greater than 0"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.Watch && opts.Recursive {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--watch cannot be used with --recursive"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.multipleChdirs() && opts.Recursive {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Multiple --chdir cannot be used with --recursive"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.multipleChdirs() && opts.Watch {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Multiple --chdir cannot be used with --watch"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.Watch && opts.Fix {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--watch cannot be used with --fix"), map[string][]byte{})
+		return ExitCodeError
+	}
+	// The stream format prints results before all issues are found, but autofixes need all of them
+	if cfg.Format == "stream" && opts.Fix {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--fix cannot be used with --format=stream"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if len(opts.FixRules) > 0 && !opts.Fix {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--fix-rule can only be used with --fix"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.Interactive && !opts.Fix {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--interactive can only be used with --fix"), map[string][]byte{})
+		return ExitCodeError
+	}
+	// Workers in recursive inspection write changes by themselves
+	if opts.Interactive && (opts.Recursive || opts.multipleChdirs()) {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--interactive cannot be used with --recursive or multiple --chdir"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.DryRun && !opts.Fix {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--dry-run can only be used with --fix"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.DryRun && opts.Interactive {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--dry-run cannot be used with --interactive"), map[string][]byte{})
+		return ExitCodeError
+	}
+	// The patch is printed instead of the results, so other formats are never output.
+	// The sarif format is the exception, since it outputs the results with fixes.
+	if opts.DryRun && cfg.FormatSet && cfg.Format != "default" && cfg.Format != "sarif" && !opts.ActAsWorker {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--dry-run cannot be used with --format=%s", cfg.Format), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.GenerateBaseline && opts.Fix {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--generate-baseline cannot be used with --fix"), map[string][]byte{})
+		return ExitCodeError
+	}
+	// The baseline must have all issues, so that issues beyond the limit are not reported as new later
+	if opts.GenerateBaseline && opts.MaxIssues > 0 {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--generate-baseline cannot be used with --max-issues"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.GenerateBaseline && opts.changedOnly() {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--generate-baseline cannot be used with --changed-only"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.BaseRef != "" && !opts.changedOnly() {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--base-ref can only be used with --changed-only"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.BaseRef != "" && opts.ChangedOnly != "HEAD" {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--base-ref cannot be used with --changed-only=REF"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.Filename != "" && opts.StdinFilename != "" {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--filename cannot be used with --stdin-filename"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if stdin := opts.stdinFlag(); stdin != "" {
+		if opts.Recursive {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("%s cannot be used with --recursive", stdin), map[string][]byte{})
+			return ExitCodeError
+		}
+		if len(opts.Chdir) > 0 {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("%s cannot be used with --chdir", stdin), map[string][]byte{})
+			return ExitCodeError
+		}
+		// Changes would be written to the file with the same name on disk, not to stdout
+		if opts.Fix {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("%s cannot be used with --fix", stdin), map[string][]byte{})
+			return ExitCodeError
+		}
+		if opts.Watch {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("%s cannot be used with --watch", stdin), map[string][]byte{})
+			return ExitCodeError
+		}
+		// Only issues in the file are reported as if it were the only file in the list
+		if opts.FileList != "" {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("%s cannot be used with --file-list", stdin), map[string][]byte{})
+			return ExitCodeError
+		}
+	}
+	if opts.PrintConfig && opts.Recursive {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--print-config cannot be used with --recursive"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.ListRules && opts.Recursive {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--list-rules cannot be used with --recursive"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.EnabledOnly && !opts.ListRules {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--enabled-only can only be used with --list-rules"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.WatchDebounce != nil && *opts.WatchDebounce <= 0 {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Watch debounce should be greater than 0"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.DirTimeout != nil && *opts.DirTimeout <= 0 {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Directory timeout should be greater than 0"), map[string][]byte{})
+		return ExitCodeError
+	}
+	var reportPath, reportFormat string
+	if opts.ReportFile != "" {
+		reportPath, reportFormat, err = opts.reportFile()
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+			return ExitCodeError
+		}
+	}
+	// Results are printed for each change, but the report file is written only once at exit
+	if opts.ReportFile != "" && opts.Watch {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--report-file cannot be used with --watch"), map[string][]byte{})
+		return ExitCodeError
+	}
 
 	switch {
 	case opts.Version:
@@ -123,18 +453,236 @@ func (cli *CLI) Run(args []string) int {
 		return cli.init(opts)
 	case opts.Langserver:
 		return cli.startLanguageServer(opts)
+	case opts.PrintConfig:
+		return cli.printConfig(opts)
+	case opts.ListRules:
+		return cli.listRules(opts)
 	case opts.ActAsBundledPlugin:
 		return cli.actAsBundledPlugin()
 	default:
-		if opts.Recursive {
-			return cli.inspectParallel(opts)
-		} else {
-			return cli.inspect(opts)
+		// The list is read only once, because stdin cannot be read again in watch mode
+		if opts.FileList != "" {
+			cli.fileList, err = loadFileList(opts.FileList, opts.chdir(), cli.inStream)
+			if err != nil {
+				cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+				return ExitCodeError
+			}
+		}
+		if opts.stdin() {
+			cli.stdinSource, err = io.ReadAll(cli.inStream)
+			if err != nil {
+				cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to read configuration from stdin; %w", err), map[string][]byte{})
+				return ExitCodeError
+			}
+			file, err := filepath.Abs(opts.stdinFilename())
+			if err != nil {
+				cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to determine the path of the file read from stdin; %w", err), map[string][]byte{})
+				return ExitCodeError
+			}
+			cli.fileList = []string{file}
+		}
+		// In recursive inspection, workers load ignore patterns for each directory
+		if !opts.Recursive && !opts.multipleChdirs() {
+			cli.ignoredFile, err = ignoredFileFunc(opts)
+			if err != nil {
+				cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+				return ExitCodeError
+			}
+		}
+
+		if reportPath != "" && !opts.ActAsWorker {
+			report, err := newOutputFile(reportPath)
+			if err != nil {
+				cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to create the report file; %w", err), map[string][]byte{})
+				return ExitCodeError
+			}
+			defer report.discard()
+			defer cli.commitReportFile(report)
+
+			cli.formatter.ReportStdout = report
+			cli.formatter.ReportFormat = reportFormat
+		}
+
+		if cfg.OutputFile != "" && !opts.ActAsWorker {
+			file, err := createOutputFile(cfg.OutputFile)
+			if err != nil {
+				cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+				return ExitCodeError
+			}
+			defer file.discard()
+
+			cli.formatter.Stdout = file
+			// Print human-readable results to stdout while the report is written to the file
+			cli.formatter.SummaryStdout = cli.outStream
+			// Escape sequences are not useful in files, so disable colorized output unless explicitly enabled
+			cli.formatter.SummaryColor = cli.formatter.Color
+			cli.formatter.Color = opts.colorMode() == colorAlways
+
+			status := cli.run(opts)
+			if err := file.commit(); err != nil {
+				// The formatter writes to the file, so print the error to stderr directly
+				fmt.Fprintf(cli.errStream, "Failed to write the output file; %s\n", err)
+				return ExitCodeError
+			}
+			return status
 		}
+
+		return cli.run(opts)
+	}
+}
+
+// run runs inspection according to the mode.
+func (cli *CLI) run(opts Options) int {
+	if opts.Watch {
+		return cli.watch(opts)
 	}
+	if opts.Recursive || opts.multipleChdirs() {
+		return cli.inspectParallel(opts)
+	}
+	if opts.ActAsWorker {
+		return cli.runAsWorker(func() int { return cli.inspect(opts) })
+	}
+	return cli.inspect(opts)
+}
+
+// loadConfig loads the config file according to --config and --no-config-discovery.
+func loadConfig(opts Options) (*tflint.Config, error) {
+	fs := afero.Afero{Fs: afero.NewOsFs()}
+	if opts.NoConfigDiscovery {
+		return tflint.LoadConfigWithoutDiscovery(fs, opts.Config)
+	}
+	return tflint.LoadConfig(fs, opts.Config)
+}
+
+// loadFormatTemplate parses the template given by --format-template or --format-template-file.
+func loadFormatTemplate(opts Options) (*template.Template, error) {
+	text := opts.FormatTemplate
+	if opts.FormatTemplateFile != "" {
+		content, err := os.ReadFile(opts.FormatTemplateFile)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read the format template file; %w", err)
+		}
+		text = string(content)
+	}
+	if text == "" {
+		return nil, fmt.Errorf("--format-template or --format-template-file is required for the template format")
+	}
+
+	tmpl, err := formatter.ParseTemplate(text)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse the format template; %w", err)
+	}
+	return tmpl, nil
+}
+
+// outputFile is a file to write results atomically.
+// Results are written to a temporary file in the same directory, and it is renamed to the path on commit,
+// so that an incomplete report is never left at the path.
+type outputFile struct {
+	path string
+	file *os.File
+	// err is the first error occurred while writing.
+	// The formatter ignores write errors, so they are recorded here.
+	err error
+}
+
+// createOutputFile creates a file to write results.
+// The file is created before inspection so that an invalid path fails early.
+func createOutputFile(path string) (*outputFile, error) {
+	file, err := newOutputFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create the output file; %w", err)
+	}
+	return file, nil
+}
+
+// commitReportFile moves the report file to the path. Unlike the output file, failures are printed
+// as warnings and do not change the exit status. Failures to write are already printed by the formatter.
+func (cli *CLI) commitReportFile(report *outputFile) {
+	if report.err != nil {
+		return
+	}
+	if err := report.commit(); err != nil {
+		fmt.Fprintf(cli.errStream, "Warning: Failed to write the report file; %s\n", err)
+	}
+}
+
+// newOutputFile creates a temporary file next to the path. It is renamed to the path when committed.
+func newOutputFile(path string) (*outputFile, error) {
+	if _, err := os.Stat(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	// Temporary files are created with 0600, so make it readable as with os.Create
+	if err := file.Chmod(0o644); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, err
+	}
+	return &outputFile{path: path, file: file}, nil
+}
+
+func (f *outputFile) Write(p []byte) (int, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	n, err := f.file.Write(p)
+	if err != nil {
+		f.err = err
+	}
+	return n, err
+}
+
+// commit moves the temporary file to the path.
+// If writing fails, the temporary file is removed and the existing file at the path is left as is.
+func (f *outputFile) commit() error {
+	if f.err != nil {
+		f.discard()
+		return f.err
+	}
+	if err := f.file.Close(); err != nil {
+		f.discard()
+		return err
+	}
+	if err := os.Rename(f.file.Name(), f.path); err != nil {
+		f.discard()
+		return err
+	}
+	return nil
+}
+
+// discard removes the temporary file. It does nothing after commit.
+func (f *outputFile) discard() {
+	f.file.Close()
+	os.Remove(f.file.Name())
+}
+
+// newOptionsParser returns a parser of command line options.
+// Subcommands are optional since inspection is run without a command.
+func newOptionsParser(opts *Options) *flags.Parser {
+	parser := flags.NewParser(opts, flags.HelpFlag)
+	parser.Usage = "--chdir=DIR/--recursive [OPTIONS]"
+	parser.UnknownOptionHandler = unknownOptionHandler
+	parser.SubcommandsOptional = true
+	return parser
+}
+
+// subcommandOptions are options of subcommands, which are unknown outside of the subcommand.
+var subcommandOptions = map[string]string{
+	"output":              "generate-config",
+	"overwrite":           "generate-config",
+	"allow-unknown-rules": "validate-config",
+	"from":                "migrate-config",
+	"to":                  "migrate-config",
 }
 
 func unknownOptionHandler(option string, arg flags.SplitArgument, args []string) ([]string, error) {
+	if command, ok := subcommandOptions[option]; ok {
+		return []string{}, fmt.Errorf("--%s can only be used with %s", option, command)
+	}
 	if option == "debug" {
 		return []string{}, errors.New("--debug option was removed in v0.8.0. Please set TFLINT_LOG environment variables instead")
 	}
@@ -165,46 +713,412 @@ func unknownOptionHandler(option string, arg flags.SplitArgument, args []string)
 	return []string{}, fmt.Errorf(`--%s is unknown option. Please run "tflint --help"`, option)
 }
 
-func findWorkingDirs(opts Options) ([]string, error) {
-	baseDir := opts.Chdir
+// skippedDirs are patterns of directories that never contain root modules, e.g. vendored modules and plugin caches.
+// They are always skipped in recursive inspection, like hidden directories such as .terraform and .terragrunt-cache.
+// Patterns of skip_dirs in the config file are added to them.
+var skippedDirs = []ignorePattern{
+	{glob: "**/terraform.d/plugins"},
+	{glob: "**/vendor/modules"},
+}
+
+// findWorkingDirs returns directories to be inspected, and the number of directories excluded by --exclude-dir
+// and exclude_dirs in recursive inspection. Excluded directories are pruned, so their descendants are not counted.
+// In recursive inspection, directories without Terraform configuration files are walked, but not inspected.
+func findWorkingDirs(opts Options) ([]string, int, error) {
+	baseDir := opts.chdir()
 	if baseDir == "" {
 		baseDir = "."
 	}
 	workingDirs := []string{}
+	excluded := 0
 
 	if opts.Recursive {
-		err := filepath.WalkDir(baseDir, func(path string, d os.DirEntry, err error) error {
+		ignorePatterns, err := loadIgnorePatterns(baseDir, baseDir, opts)
+		if err != nil {
+			return []string{}, 0, err
+		}
+		skipPatterns := slices.Clone(skippedDirs)
+		for _, pattern := range opts.skipDirs {
+			skipPatterns = append(skipPatterns, ignorePattern{glob: pattern})
+		}
+		excludePatterns := make([]ignorePattern, len(opts.excludeDirs))
+		for idx, pattern := range opts.excludeDirs {
+			excludePatterns[idx].glob, err = tflint.ParseExcludeDirPattern(pattern)
+			if err != nil {
+				return []string{}, 0, err
+			}
+		}
+
+		// visited is the resolved paths of walked directories with --follow-symlinks.
+		// A directory reachable by multiple paths is walked only once, which also prevents symlink cycles.
+		visited := map[string]bool{}
+
+		// visit returns true if the directory should be walked
+		visit := func(path string, name string) (bool, error) {
+			// hidden directories are skipped
+			if path != "." && strings.HasPrefix(name, ".") {
+				return false, nil
+			}
+			// Symlinks are walked with the paths of the links, so the depth can be calculated from the path
+			if opts.MaxDepth != nil && dirDepth(baseDir, path) > *opts.MaxDepth {
+				return false, nil
+			}
+			if matchIgnorePatterns(skipPatterns, baseDir, path, true) {
+				return false, nil
+			}
+			if matchIgnorePatterns(ignorePatterns, baseDir, path, true) {
+				return false, nil
+			}
+			if matchIgnorePatterns(excludePatterns, baseDir, path, true) {
+				excluded++
+				return false, nil
+			}
+			if opts.FollowSymlinks {
+				resolved, err := resolvePath(path)
+				if err != nil {
+					return false, err
+				}
+				if visited[resolved] {
+					log.Printf("[INFO] Skip %s because %s is already walked", path, resolved)
+					return false, nil
+				}
+				visited[resolved] = true
+			}
+			// .tflintignore in nested directories applies to their descendants
+			if path != baseDir && !opts.noIgnore() {
+				patterns, err := readIgnoreFile(baseDir, path)
+				if err != nil {
+					return false, err
+				}
+				ignorePatterns = append(ignorePatterns, patterns...)
+			}
+
+			// e.g. directories with only .terraform or templates are not modules
+			ok, err := hasConfigFiles(path)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				workingDirs = append(workingDirs, path)
+			}
+			return true, nil
+		}
+
+		var walk fs.WalkDirFunc
+		walk = func(path string, d fs.DirEntry, err error) error {
 			if err != nil {
 				return err
 			}
-			if !d.IsDir() {
+			symlink := opts.FollowSymlinks && d.Type()&fs.ModeSymlink != 0
+			if symlink {
+				info, err := os.Stat(path)
+				if err != nil {
+					log.Printf("[INFO] Skip the broken symlink %s; %s", path, err)
+					return nil
+				}
+				symlink = info.IsDir()
+			}
+			if !d.IsDir() && !symlink {
 				return nil
 			}
-			// hidden directories are skipped
-			if path != "." && strings.HasPrefix(d.Name(), ".") {
-				return filepath.SkipDir
+
+			ok, err := visit(path, d.Name())
+			if err != nil {
+				return err
+			}
+			if !symlink {
+				if !ok {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			// Returning SkipDir for a symlink skips the remaining entries in the parent directory
+			if !ok {
+				return nil
+			}
+
+			// WalkDir does not follow symlinks, so the target is walked separately.
+			// Paths under the symlink are reported as they are seen, not as the resolved paths.
+			target, err := filepath.EvalSymlinks(path)
+			if err != nil {
+				return err
 			}
+			return filepath.WalkDir(target, func(p string, d fs.DirEntry, err error) error {
+				if p == target {
+					// The symlink itself is already visited
+					return err
+				}
+				rel, relErr := filepath.Rel(target, p)
+				if relErr != nil {
+					return relErr
+				}
+				return walk(filepath.Join(path, rel), d, err)
+			})
+		}
 
-			workingDirs = append(workingDirs, path)
-			return nil
-		})
+		err = filepath.WalkDir(baseDir, walk)
 		if err != nil {
-			return []string{}, err
+			return []string{}, 0, err
+		}
+	} else if opts.multipleChdirs() {
+		// Each directory is inspected by a worker like in recursive inspection
+		for _, dir := range opts.Chdir {
+			if !slices.Contains(workingDirs, filepath.Clean(dir)) {
+				workingDirs = append(workingDirs, filepath.Clean(dir))
+			}
 		}
 	} else {
 		workingDirs = []string{baseDir}
 	}
 
-	return workingDirs, nil
+	return workingDirs, excluded, nil
 }
 
+// resolvePath returns the absolute path of the directory with symlinks resolved
+func resolvePath(path string) (string, error) {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(resolved)
+}
+
+// hasConfigFiles returns true if the directory contains Terraform configuration files (.tf or .tf.json).
+// Hidden files and editor backups are ignored like the Terraform loader does.
+func hasConfigFiles(dir string) (bool, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false, err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~") || (strings.HasPrefix(name, "#") && strings.HasSuffix(name, "#")) {
+			continue
+		}
+		if strings.HasSuffix(name, ".tf") || strings.HasSuffix(name, ".tf.json") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ignorePattern is a pattern of directories and files to be skipped, in the syntax of .gitignore.
+type ignorePattern struct {
+	// glob is a doublestar pattern relative to the base directory
+	glob string
+	// negate is true if the pattern starts with "!". Matching paths are no longer ignored.
+	negate bool
+	// dirOnly is true if the pattern ends with "/". It only matches directories.
+	dirOnly bool
+}
+
+func (p ignorePattern) String() string {
+	ret := p.glob
+	if p.negate {
+		ret = "!" + ret
+	}
+	if p.dirOnly {
+		ret += "/"
+	}
+	return ret
+}
+
+// loadIgnorePatterns returns patterns of directories and files to be skipped.
+// Patterns are read from .tflintignore in the base directory and each directory down to dir,
+// and the TFLINT_IGNORE environment variable. All patterns are relative to the base directory.
+func loadIgnorePatterns(baseDir string, dir string, opts Options) ([]ignorePattern, error) {
+	patterns := []ignorePattern{}
+
+	if !opts.noIgnore() {
+		rel, err := filepath.Rel(baseDir, dir)
+		if err != nil {
+			return []ignorePattern{}, err
+		}
+		dirs := []string{baseDir}
+		if rel != "." {
+			for _, name := range strings.Split(rel, string(filepath.Separator)) {
+				dirs = append(dirs, filepath.Join(dirs[len(dirs)-1], name))
+			}
+		}
+
+		for _, d := range dirs {
+			filePatterns, err := readIgnoreFile(baseDir, d)
+			if err != nil {
+				return []ignorePattern{}, err
+			}
+			patterns = append(patterns, filePatterns...)
+		}
+	}
+
+	if env := os.Getenv("TFLINT_IGNORE"); env != "" {
+		for _, pattern := range strings.Split(env, ":") {
+			if pattern = strings.TrimSpace(pattern); pattern == "" {
+				continue
+			}
+			pattern, err := parseIgnorePattern(pattern)
+			if err != nil {
+				return []ignorePattern{}, err
+			}
+			patterns = append(patterns, pattern)
+		}
+	}
+	log.Printf("[DEBUG] Ignore patterns: %s", patterns)
+
+	return patterns, nil
+}
+
+// readIgnoreFile reads patterns from .tflintignore in the directory.
+// Patterns are prefixed with the directory path, so that they are relative to the base directory.
+func readIgnoreFile(baseDir string, dir string) ([]ignorePattern, error) {
+	content, err := os.ReadFile(filepath.Join(dir, ".tflintignore"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []ignorePattern{}, nil
+		}
+		return []ignorePattern{}, fmt.Errorf("Failed to read .tflintignore; %w", err)
+	}
+
+	prefix := ""
+	if rel, err := filepath.Rel(baseDir, dir); err == nil && rel != "." {
+		prefix = escapeGlob(filepath.ToSlash(rel)) + "/"
+	}
+
+	patterns := []ignorePattern{}
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		// Blank lines and comments are ignored
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		pattern, err := parseIgnorePattern(line)
+		if err != nil {
+			return []ignorePattern{}, err
+		}
+		pattern.glob = prefix + pattern.glob
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// parseIgnorePattern normalizes the pattern and checks its syntax.
+// As in .gitignore, a leading "!" negates the pattern, and a trailing slash matches only directories.
+// Use "\!" for patterns starting with a literal "!".
+func parseIgnorePattern(pattern string) (ignorePattern, error) {
+	ret := ignorePattern{glob: filepath.ToSlash(pattern)}
+	if strings.HasPrefix(ret.glob, "!") {
+		ret.negate = true
+		ret.glob = ret.glob[1:]
+	}
+	if strings.HasSuffix(ret.glob, "/") {
+		ret.dirOnly = true
+		ret.glob = strings.TrimRight(ret.glob, "/")
+	}
+	if ret.glob == "" {
+		return ignorePattern{}, fmt.Errorf("Failed to parse ignore pattern `%s`; the pattern is empty", pattern)
+	}
+	// Matching the pattern against itself walks the entire pattern, so that syntax errors are detected
+	if _, err := doublestar.Match(ret.glob, ret.glob); err != nil {
+		return ignorePattern{}, fmt.Errorf("Failed to parse ignore pattern `%s`; %w", pattern, err)
+	}
+	return ret, nil
+}
+
+// matchIgnorePatterns returns true if the path relative to the base directory is ignored by the patterns.
+// As in .gitignore, the last matching pattern decides, so negated patterns re-include paths matched by earlier ones.
+// The base directory itself is never ignored.
+func matchIgnorePatterns(patterns []ignorePattern, baseDir string, path string, isDir bool) bool {
+	rel, err := filepath.Rel(baseDir, path)
+	if err != nil || rel == "." {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+
+	ignored := false
+	for _, pattern := range patterns {
+		if pattern.dirOnly && !isDir {
+			continue
+		}
+		if matched, _ := doublestar.Match(pattern.glob, rel); matched {
+			ignored = !pattern.negate
+		}
+	}
+	return ignored
+}
+
+// ignoredFileFunc returns a function that reports whether a file in the working directory is ignored.
+// A file is ignored if the file or any of its parent directories is ignored by the patterns.
+// As in .gitignore, files in ignored directories cannot be re-included by negated patterns.
+// Workers in recursive inspection are given the base directory of the walk by --ignore-base,
+// so that the same patterns as the coordinator are applied. It returns nil if there are no patterns.
+func ignoredFileFunc(opts Options) (func(path string) bool, error) {
+	workingDir := opts.chdir()
+	if workingDir == "" {
+		workingDir = "."
+	}
+	baseDir := workingDir
+	if opts.IgnoreBase != "" {
+		baseDir = opts.IgnoreBase
+	}
+
+	patterns, err := loadIgnorePatterns(baseDir, workingDir, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+	prefix, err := filepath.Rel(baseDir, workingDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(path string) bool {
+		file := filepath.Join(prefix, path)
+		if matchIgnorePatterns(patterns, ".", file, false) {
+			return true
+		}
+		for dir := filepath.Dir(file); dir != "." && dir != ".."; dir = filepath.Dir(dir) {
+			if matchIgnorePatterns(patterns, ".", dir, true) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// dirDepth returns the depth of the path relative to the base directory.
+// The base directory itself is depth 0.
+func dirDepth(baseDir string, path string) int {
+	rel, err := filepath.Rel(baseDir, path)
+	if err != nil || rel == "." {
+		return 0
+	}
+	return len(strings.Split(rel, string(filepath.Separator)))
+}
+
+// withinChangedDir runs the process in the directory and then changes back to the original working directory.
+// The current directory is global to the process, so it must not be used in parallel.
 func (cli *CLI) withinChangedDir(dir string, proc func() error) (err error) {
+	//nolint:TODO // Remove once config loading, plugin discovery, and runners take the directory explicitly like terraform.NewLoaderWithBaseDir.
 	if dir != "." && dir != "" {
 		chErr := os.Chdir(dir)
 		if chErr != nil {
 			return fmt.Errorf("Failed to switch to a different working directory; %w", chErr)
 		}
+		// os.Getwd returns $PWD if it is the current directory. Keep the path as it is seen, so that
+		// files in a symlinked directory are reported with the path of the symlink, not the resolved path.
+		pwd, pwdSet := os.LookupEnv("PWD")
+		logicalDir := dir
+		if !filepath.IsAbs(logicalDir) {
+			logicalDir = filepath.Join(cli.originalWorkingDir, dir)
+		}
+		os.Setenv("PWD", logicalDir)
 		defer func() {
+			if pwdSet {
+				os.Setenv("PWD", pwd)
+			} else {
+				os.Unsetenv("PWD")
+			}
 			chErr := os.Chdir(cli.originalWorkingDir)
 			if chErr != nil {
 				err = fmt.Errorf("Failed to switch to the original working directory; %s; %w", chErr, err)
@@ -215,15 +1129,23 @@ func (cli *CLI) withinChangedDir(dir string, proc func() error) (err error) {
 	return proc()
 }
 
-func registerShutdownCh() <-chan os.Signal {
+func registerShutdownCh() chan os.Signal {
 	ch := make(chan os.Signal, 1)
 	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
 	return ch
 }
 
-func (cli *CLI) registerShutdownHandler(callback func()) {
+// registerShutdownHandler calls the callback when the process receives SIGINT or SIGTERM.
+// The handler is unregistered when the context is canceled, so that handlers do not pile up
+// when the caller is run repeatedly, e.g. in watch mode.
+func (cli *CLI) registerShutdownHandler(ctx context.Context, callback func()) {
 	ch := registerShutdownCh()
-	sig := <-ch
-	fmt.Fprintf(cli.errStream, "Received %s, shutting down...\n", sig)
-	callback()
+	defer signal.Stop(ch)
+
+	select {
+	case sig := <-ch:
+		fmt.Fprintf(cli.errStream, "Received %s, shutting down...\n", sig)
+		callback()
+	case <-ctx.Done():
+	}
 }
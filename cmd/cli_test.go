@@ -0,0 +1,538 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/terraform-linters/tflint/formatter"
+)
+
+func Test_findWorkingDirs(t *testing.T) {
+	tests := []struct {
+		name         string
+		dirs         []string
+		tflintignore string
+		// nested is .tflintignore files in subdirectories
+		nested map[string]string
+		// emptyDirs are directories without configuration files. Directories in dirs have main.tf.
+		emptyDirs []string
+		// symlinks are symlinks to directories. Keys are paths of links, and values are their targets.
+		symlinks       map[string]string
+		followSymlinks bool
+		env            string
+		noIgnore       bool
+		// excludeDirs are patterns of --exclude-dir and exclude_dirs
+		excludeDirs []string
+		// skipDirs are patterns of skip_dirs
+		skipDirs     []string
+		want         []string
+		wantExcluded int
+		wantErr      bool
+	}{
+		{
+			name: "no ignore patterns",
+			dirs: []string{"modules/instance", "vendor/module", "test/fixtures"},
+			want: []string{".", "modules", "modules/instance", "test", "test/fixtures", "vendor", "vendor/module"},
+		},
+		{
+			name:         "tflintignore",
+			dirs:         []string{"modules/instance", "modules/vendor/module", "vendor/module", "test/fixtures", "modules/test/fixtures"},
+			tflintignore: "# comment\n\n**/vendor\n**/test/fixtures/\n",
+			want:         []string{".", "modules", "modules/instance", "modules/test", "test"},
+		},
+		{
+			name:         "nested tflintignore",
+			dirs:         []string{"modules/instance/examples", "modules/vpc/examples", "vendor/module"},
+			tflintignore: "vendor",
+			nested:       map[string]string{"modules/instance": "examples\n"},
+			want:         []string{".", "modules", "modules/instance", "modules/vpc", "modules/vpc/examples"},
+		},
+		{
+			name:     "nested tflintignore with no-tflintignore",
+			dirs:     []string{"modules/instance/examples"},
+			nested:   map[string]string{"modules/instance": "examples"},
+			noIgnore: true,
+			want:     []string{".", "modules", "modules/instance", "modules/instance/examples"},
+		},
+		{
+			name:         "no-tflintignore",
+			dirs:         []string{"vendor/module"},
+			tflintignore: "**/vendor",
+			noIgnore:     true,
+			want:         []string{".", "vendor", "vendor/module"},
+		},
+		{
+			name: "TFLINT_IGNORE",
+			dirs: []string{"modules/instance", "vendor/module", "test/fixtures"},
+			env:  "**/vendor:test/*",
+			want: []string{".", "modules", "modules/instance", "test"},
+		},
+		{
+			name:         "TFLINT_IGNORE with no-tflintignore",
+			dirs:         []string{"modules/instance", "vendor/module"},
+			tflintignore: "modules",
+			env:          "vendor",
+			noIgnore:     true,
+			want:         []string{".", "modules", "modules/instance"},
+		},
+		{
+			name:         "exclude dirs",
+			dirs:         []string{"modules/instance/examples/basic", "modules/vpc/examples", "examples/complete", "test fixtures/a"},
+			excludeDirs:  []string{"**/examples", "test fixtures/"},
+			want:         []string{".", "modules", "modules/instance", "modules/vpc"},
+			wantExcluded: 4,
+		},
+		{
+			name:         "exclude dirs with tflintignore",
+			dirs:         []string{"modules/instance", "vendor/module"},
+			tflintignore: "vendor",
+			excludeDirs:  []string{"vendor", "modules/*"},
+			want:         []string{".", "modules"},
+			wantExcluded: 1,
+		},
+		{
+			name:         "negation",
+			dirs:         []string{"examples/basic", "examples/complete", "examples/keep"},
+			tflintignore: "examples/*\n!examples/keep\n",
+			want:         []string{".", "examples", "examples/keep"},
+		},
+		{
+			name:         "negation in an ignored directory",
+			dirs:         []string{"examples/keep"},
+			tflintignore: "examples\n!examples/keep\n",
+			want:         []string{"."},
+		},
+		{
+			name:         "negation in a nested tflintignore",
+			dirs:         []string{"modules/instance/examples", "modules/vpc/examples"},
+			tflintignore: "**/examples",
+			nested:       map[string]string{"modules/vpc": "!examples"},
+			want:         []string{".", "modules", "modules/instance", "modules/vpc", "modules/vpc/examples"},
+		},
+		{
+			name:      "directories without configuration files",
+			dirs:      []string{"modules/instance"},
+			emptyDirs: []string{"templates", "envs/dev/.terraform/modules", "modules/instance/files"},
+			want:      []string{".", "modules", "modules/instance"},
+		},
+		{
+			name:      "modules in directories without configuration files",
+			dirs:      []string{"envs/dev", "envs/prod"},
+			emptyDirs: []string{"envs"},
+			want:      []string{".", "envs/dev", "envs/prod"},
+		},
+		{
+			name: "skipped directories",
+			dirs: []string{"modules/instance", "vendor/modules/vpc", "modules/instance/vendor/modules/vpc", "terraform.d/plugins/example", ".terragrunt-cache/abc/module", "vendor/other"},
+			want: []string{".", "modules", "modules/instance", "modules/instance/vendor", "terraform.d", "vendor", "vendor/other"},
+		},
+		{
+			name:      "symlinks",
+			dirs:      []string{"templates/env/modules/instance"},
+			emptyDirs: []string{"envs"},
+			symlinks:  map[string]string{"envs/staging": "../templates/env"},
+			want:      []string{".", "templates", "templates/env", "templates/env/modules", "templates/env/modules/instance"},
+		},
+		{
+			name:           "follow symlinks",
+			dirs:           []string{"_templates/env/modules/instance", "envs/dev"},
+			emptyDirs:      []string{"envs"},
+			symlinks:       map[string]string{"envs/staging": "../_templates/env", "envs/prod": "../_templates/env", "envs/broken": "../missing"},
+			followSymlinks: true,
+			want:           []string{".", "_templates", "_templates/env", "_templates/env/modules", "_templates/env/modules/instance", "envs/dev"},
+		},
+		{
+			name:           "follow symlinks with paths of links",
+			dirs:           []string{"shared/env/modules/instance", "envs/dev"},
+			emptyDirs:      []string{"envs", "shared"},
+			symlinks:       map[string]string{"envs/staging": "../shared/env"},
+			tflintignore:   "shared",
+			followSymlinks: true,
+			want:           []string{".", "envs/dev", "envs/staging", "envs/staging/modules", "envs/staging/modules/instance"},
+		},
+		{
+			name:           "symlink cycles",
+			dirs:           []string{"modules/instance"},
+			symlinks:       map[string]string{"modules/instance/loop": "..", "modules/self": "."},
+			followSymlinks: true,
+			want:           []string{".", "modules", "modules/instance"},
+		},
+		{
+			name:         "invalid pattern",
+			tflintignore: "[",
+			wantErr:      true,
+		},
+		{
+			name:         "empty negation",
+			tflintignore: "!",
+			wantErr:      true,
+		},
+		{
+			name:     "skip dirs",
+			dirs:     []string{"modules/instance", "vendor/modules/vpc", "third_party/modules/vpc", "modules/instance/third_party/modules/vpc"},
+			skipDirs: []string{"**/third_party/modules"},
+			// Skipped directories are added to the built-in ones, and not counted as excluded
+			want: []string{".", "modules", "modules/instance", "modules/instance/third_party", "third_party", "vendor"},
+		},
+		{
+			name:        "invalid exclude pattern",
+			excludeDirs: []string{"["},
+			wantErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte{}, 0644); err != nil {
+				t.Fatal(err)
+			}
+			for _, d := range test.dirs {
+				if err := os.MkdirAll(filepath.Join(dir, d), 0755); err != nil {
+					t.Fatal(err)
+				}
+				// Parent directories also have configuration files unless they are in emptyDirs
+				for p := d; p != "."; p = filepath.Dir(p) {
+					if slices.Contains(test.emptyDirs, filepath.ToSlash(p)) {
+						continue
+					}
+					if err := os.WriteFile(filepath.Join(dir, p, "main.tf"), []byte{}, 0644); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+			for _, d := range test.emptyDirs {
+				if err := os.MkdirAll(filepath.Join(dir, d), 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for link, target := range test.symlinks {
+				if err := os.Symlink(filepath.FromSlash(target), filepath.Join(dir, link)); err != nil {
+					t.Skipf("symlinks are not available: %s", err)
+				}
+			}
+			if test.tflintignore != "" {
+				if err := os.WriteFile(filepath.Join(dir, ".tflintignore"), []byte(test.tflintignore), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for d, content := range test.nested {
+				if err := os.WriteFile(filepath.Join(dir, d, ".tflintignore"), []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			t.Setenv("TFLINT_IGNORE", test.env)
+
+			workingDirs, excluded, err := findWorkingDirs(Options{Chdir: []string{dir}, Recursive: true, NoTflintignore: test.noIgnore, FollowSymlinks: test.followSymlinks, excludeDirs: test.excludeDirs, skipDirs: test.skipDirs})
+			if err != nil {
+				if !test.wantErr {
+					t.Fatal(err)
+				}
+				return
+			}
+			if test.wantErr {
+				t.Fatal("should return an error")
+			}
+
+			got := make([]string, len(workingDirs))
+			for i, wd := range workingDirs {
+				rel, err := filepath.Rel(dir, wd)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got[i] = filepath.ToSlash(rel)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Error(diff)
+			}
+			if excluded != test.wantExcluded {
+				t.Errorf("expected %d excluded directories, but got %d", test.wantExcluded, excluded)
+			}
+		})
+	}
+}
+
+func Test_ignoredFileFunc(t *testing.T) {
+	tests := []struct {
+		name   string
+		files  map[string]string
+		env    string
+		opts   Options
+		paths  []string
+		want   []string
+		noFunc bool
+	}{
+		{
+			name:   "no patterns",
+			paths:  []string{"main.tf"},
+			noFunc: true,
+		},
+		{
+			name:  "files and directories",
+			files: map[string]string{".tflintignore": "generated.tf\nmodules/vendor/\n"},
+			paths: []string{"main.tf", "generated.tf", "modules/vendor/main.tf", "modules/vpc/main.tf"},
+			want:  []string{"generated.tf", "modules/vendor/main.tf"},
+		},
+		{
+			name:  "TFLINT_IGNORE",
+			env:   "**/*_gen.tf",
+			paths: []string{"main.tf", "main_gen.tf", "modules/vpc/vpc_gen.tf"},
+			want:  []string{"main_gen.tf", "modules/vpc/vpc_gen.tf"},
+		},
+		{
+			name:  "no-ignore",
+			files: map[string]string{".tflintignore": "generated.tf"},
+			env:   "other.tf",
+			opts:  Options{NoIgnore: true},
+			paths: []string{"generated.tf", "other.tf"},
+			want:  []string{"other.tf"},
+		},
+		{
+			name: "patterns in the base directory and parent directories",
+			files: map[string]string{
+				".tflintignore":                  "**/generated.tf",
+				"modules/.tflintignore":          "vpc/legacy.tf",
+				"modules/vpc/.tflintignore":      "fixtures",
+				"modules/instance/.tflintignore": "main.tf",
+			},
+			opts:  Options{Chdir: []string{"modules/vpc"}, IgnoreBase: "."},
+			paths: []string{"main.tf", "generated.tf", "legacy.tf", "fixtures/main.tf"},
+			want:  []string{"generated.tf", "legacy.tf", "fixtures/main.tf"},
+		},
+		{
+			name:  "negation",
+			files: map[string]string{".tflintignore": "*_gen.tf\n!keep_gen.tf\n"},
+			paths: []string{"main.tf", "main_gen.tf", "keep_gen.tf"},
+			want:  []string{"main_gen.tf"},
+		},
+		{
+			name:  "negation in a nested file",
+			files: map[string]string{".tflintignore": "**/*_gen.tf", "modules/vpc/.tflintignore": "!vpc_gen.tf"},
+			opts:  Options{Chdir: []string{"modules/vpc"}, IgnoreBase: "."},
+			paths: []string{"vpc_gen.tf", "other_gen.tf"},
+			want:  []string{"other_gen.tf"},
+		},
+		{
+			name:  "negation in an ignored directory",
+			files: map[string]string{".tflintignore": "generated/\n!generated/main.tf\n"},
+			paths: []string{"main.tf", "generated/main.tf"},
+			want:  []string{"generated/main.tf"},
+		},
+		{
+			name:  "directory only",
+			files: map[string]string{".tflintignore": "generated/\n"},
+			paths: []string{"generated", "generated/main.tf", "modules/generated/main.tf"},
+			want:  []string{"generated/main.tf"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range test.files {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			t.Chdir(dir)
+			t.Setenv("TFLINT_IGNORE", test.env)
+
+			ignored, err := ignoredFileFunc(test.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.noFunc {
+				if ignored != nil {
+					t.Fatal("should return nil")
+				}
+				return
+			}
+
+			got := []string{}
+			for _, path := range test.paths {
+				if ignored(filepath.FromSlash(path)) {
+					got = append(got, path)
+				}
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_outputFile(t *testing.T) {
+	t.Run("commit", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "result")
+		if err := os.WriteFile(path, []byte("previous"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		file, err := createOutputFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := file.Write([]byte("current")); err != nil {
+			t.Fatal(err)
+		}
+		// The existing file is not changed until commit
+		assertFileContent(t, path, "previous")
+
+		if err := file.commit(); err != nil {
+			t.Fatal(err)
+		}
+		file.discard()
+		assertFileContent(t, path, "current")
+		assertDirEntries(t, dir, 1)
+	})
+
+	t.Run("write error", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "result")
+		if err := os.WriteFile(path, []byte("previous"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		file, err := createOutputFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Writes to a closed file fail
+		file.file.Close()
+		if _, err := file.Write([]byte("current")); err == nil {
+			t.Fatal("expected an error, but got nil")
+		}
+
+		if err := file.commit(); err == nil {
+			t.Fatal("expected an error, but got nil")
+		}
+		assertFileContent(t, path, "previous")
+		assertDirEntries(t, dir, 1)
+	})
+}
+
+func assertFileContent(t *testing.T, path string, want string) {
+	t.Helper()
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("expected %q, but got %q", want, string(got))
+	}
+}
+
+func assertDirEntries(t *testing.T, dir string, want int) {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != want {
+		t.Errorf("expected %d entries, but got %d", want, len(entries))
+	}
+}
+
+func Test_stdin(t *testing.T) {
+	dir := t.TempDir()
+	// Disable the bundled plugin, which is not available in tests
+	config := `plugin "terraform" { enabled = false }`
+	if err := os.WriteFile(filepath.Join(dir, ".tflint.hcl"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Other files in the working directory are loaded together
+	if err := os.WriteFile(filepath.Join(dir, "other.tf"), []byte(`variable "foo" {}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+	t.Setenv("TFLINT_LOG", "")
+
+	tests := []struct {
+		name string
+		args []string
+		// filename is the file where the syntax error is reported. It is empty if the arguments are invalid.
+		filename string
+		message  string
+	}{
+		{
+			name:     "default file name",
+			args:     []string{"--stdin"},
+			filename: "stdin.tf",
+			message:  "There is no closing brace for this block",
+		},
+		{
+			name:     "file name",
+			args:     []string{"--stdin", "--filename=main.tf"},
+			filename: "main.tf",
+			message:  "There is no closing brace for this block",
+		},
+		{
+			name:     "--stdin-filename",
+			args:     []string{"--stdin-filename=main.tf"},
+			filename: "main.tf",
+			message:  "There is no closing brace for this block",
+		},
+		{
+			name:    "--stdin-filename with --filename",
+			args:    []string{"--stdin-filename=main.tf", "--filename=main.tf"},
+			message: "--filename cannot be used with --stdin-filename",
+		},
+		{
+			name:    "with --recursive",
+			args:    []string{"--stdin", "--recursive"},
+			message: "--stdin cannot be used with --recursive",
+		},
+		{
+			name:    "with --chdir",
+			args:    []string{"--stdin", "--chdir=."},
+			message: "--stdin cannot be used with --chdir",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+			cli, err := NewCLI(stdout, stderr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cli.inStream = strings.NewReader("resource \"null_resource\" \"foo\" {\n")
+
+			status := cli.Run(append([]string{"tflint", "--format=json"}, test.args...))
+			if status != ExitCodeError {
+				t.Errorf("expected status is %d, but got %d: stderr=%s", ExitCodeError, status, stderr.String())
+			}
+
+			var got formatter.JSONOutput
+			if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+				t.Fatalf("failed to parse the output: %s; stdout=%s", err, stdout)
+			}
+			if len(got.Errors) != 1 {
+				t.Fatalf("expected an error, but got %d errors: %v", len(got.Errors), got.Errors)
+			}
+			if !strings.Contains(got.Errors[0].Message, test.message) {
+				t.Errorf("expected error message %q, but got %q", test.message, got.Errors[0].Message)
+			}
+			filename := ""
+			if got.Errors[0].Range != nil {
+				filename = got.Errors[0].Range.Filename
+			}
+			if filename != test.filename {
+				t.Errorf("expected the error in %q, but got %q", test.filename, filename)
+			}
+		})
+	}
+}
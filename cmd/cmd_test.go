@@ -8,6 +8,11 @@ import (
 )
 
 func TestMain(m *testing.M) {
+	// Tests of recursive inspection spawn the test binary as workers
+	if os.Getenv("TFLINT_TEST_FAKE_WORKER") != "" {
+		os.Exit(runFakeWorker(os.Args[1:]))
+	}
+
 	log.SetOutput(io.Discard)
 	os.Exit(m.Run())
 }
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+)
+
+// Modes of --color
+const (
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+)
+
+// colorMode returns the mode specified by --color. --no-color is an alias of --color=never and takes precedence.
+func (opts *Options) colorMode() string {
+	if opts.NoColor {
+		return colorNever
+	}
+	if opts.Color == "" {
+		return colorAuto
+	}
+	return opts.Color
+}
+
+// shouldColorize decides whether output to the stream is colorized in the mode.
+// In the auto mode, NO_COLOR disables colors and CLICOLOR_FORCE enables colors, in that order of precedence.
+// Otherwise, colors are enabled only if the stream itself is a terminal.
+func shouldColorize(mode string, stream io.Writer) bool {
+	switch mode {
+	case colorAlways:
+		return true
+	case colorNever:
+		return false
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if force := os.Getenv("CLICOLOR_FORCE"); force != "" && force != "0" {
+		return true
+	}
+	return isTerminal(stream) && os.Getenv("TERM") != "dumb"
+}
+
+// isTerminal returns true if the stream is a terminal.
+// The stream must expose the file descriptor with Fd(), like *os.File.
+func isTerminal(stream io.Writer) bool {
+	f, ok := stream.(interface{ Fd() uintptr })
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// stdoutColor returns the color for messages printed to stdout outside the formatter.
+// It is enabled only if the formatter colorizes stdout.
+func (cli *CLI) stdoutColor(attrs ...color.Attribute) *color.Color {
+	c := color.New(attrs...)
+	if cli.formatter.Color {
+		c.EnableColor()
+	} else {
+		c.DisableColor()
+	}
+	return c
+}
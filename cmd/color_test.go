@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_colorMode(t *testing.T) {
+	cases := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "default",
+			args: []string{},
+			want: colorAuto,
+		},
+		{
+			name: "--color without a value",
+			args: []string{"--color"},
+			want: colorAlways,
+		},
+		{
+			name: "--color=never",
+			args: []string{"--color=never"},
+			want: colorNever,
+		},
+		{
+			name: "--no-color",
+			args: []string{"--no-color"},
+			want: colorNever,
+		},
+		{
+			name: "--no-color takes precedence",
+			args: []string{"--color=always", "--no-color"},
+			want: colorNever,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var opts Options
+			if _, err := newOptionsParser(&opts).ParseArgs(tc.args); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := opts.colorMode(); got != tc.want {
+				t.Errorf("want=%s, got=%s", tc.want, got)
+			}
+		})
+	}
+}
+
+func Test_shouldColorize(t *testing.T) {
+	cases := []struct {
+		name string
+		mode string
+		env  map[string]string
+		want bool
+	}{
+		{
+			name: "auto and not a terminal",
+			mode: colorAuto,
+			want: false,
+		},
+		{
+			name: "auto with CLICOLOR_FORCE",
+			mode: colorAuto,
+			env:  map[string]string{"CLICOLOR_FORCE": "1"},
+			want: true,
+		},
+		{
+			name: "auto with CLICOLOR_FORCE=0",
+			mode: colorAuto,
+			env:  map[string]string{"CLICOLOR_FORCE": "0"},
+			want: false,
+		},
+		{
+			name: "NO_COLOR takes precedence over CLICOLOR_FORCE",
+			mode: colorAuto,
+			env:  map[string]string{"NO_COLOR": "1", "CLICOLOR_FORCE": "1"},
+			want: false,
+		},
+		{
+			name: "always with NO_COLOR",
+			mode: colorAlways,
+			env:  map[string]string{"NO_COLOR": "1"},
+			want: true,
+		},
+		{
+			name: "never with CLICOLOR_FORCE",
+			mode: colorNever,
+			env:  map[string]string{"CLICOLOR_FORCE": "1"},
+			want: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", "")
+			t.Setenv("CLICOLOR_FORCE", "")
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			if got := shouldColorize(tc.mode, &bytes.Buffer{}); got != tc.want {
+				t.Errorf("want=%t, got=%t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCLIRun_color(t *testing.T) {
+	cases := []struct {
+		name   string
+		args   []string
+		env    map[string]string
+		stdout bool
+		stderr bool
+	}{
+		{
+			name:   "CLICOLOR_FORCE",
+			args:   []string{"tflint", "--version"},
+			env:    map[string]string{"CLICOLOR_FORCE": "1"},
+			stdout: true,
+			stderr: true,
+		},
+		{
+			name:   "NO_COLOR without --no-color",
+			args:   []string{"tflint", "--version"},
+			env:    map[string]string{"NO_COLOR": "1", "CLICOLOR_FORCE": "1"},
+			stdout: false,
+			stderr: false,
+		},
+		{
+			name:   "NO_COLOR with --color",
+			args:   []string{"tflint", "--version", "--color"},
+			env:    map[string]string{"NO_COLOR": "1"},
+			stdout: true,
+			stderr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			t.Setenv("NO_COLOR", "")
+			t.Setenv("CLICOLOR_FORCE", "")
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			cli, err := NewCLI(&bytes.Buffer{}, &bytes.Buffer{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			cli.Run(tc.args)
+
+			if cli.formatter.Color != tc.stdout {
+				t.Errorf("stdout: want=%t, got=%t", tc.stdout, cli.formatter.Color)
+			}
+			if cli.formatter.ColorStderr != tc.stderr {
+				t.Errorf("stderr: want=%t, got=%t", tc.stderr, cli.formatter.ColorStderr)
+			}
+		})
+	}
+}
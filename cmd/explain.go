@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+
+	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint-ruleset-terraform/rules"
+	"github.com/terraform-linters/tflint/plugin"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// builtinRuleDocs are docs of rules in the bundled terraform ruleset, copied from the tflint-ruleset-terraform module.
+// Run `go generate ./cmd` after updating the module to keep them in sync.
+//
+//go:generate sh -c "cp \"$(go list -m -f '{{.Dir}}' github.com/terraform-linters/tflint-ruleset-terraform)\"/docs/rules/terraform_*.md ruledocs/ && chmod 644 ruledocs/*.md"
+//go:embed ruledocs/*.md
+var builtinRuleDocs embed.FS
+
+// explain prints details of the rule. Rules in the bundled plugin are explained with the embedded docs.
+// Other plugins do not expose rule details, so only the plugin name and the status are printed for their rules.
+func (cli *CLI) explain(opts Options, name string) int {
+	var cfg *tflint.Config
+	var pluginName string
+	err := cli.withinChangedDir(opts.chdir(), func() error {
+		var err error
+		cfg, err = loadConfig(opts)
+		if err != nil {
+			return fmt.Errorf("Failed to load TFLint config; %w", err)
+		}
+		cfg.Merge(opts.toConfig())
+
+		if lookupBuiltinRule(name) != nil {
+			return nil
+		}
+		pluginName, err = findRulePlugin(cfg, name)
+		return err
+	})
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+		return ExitCodeError
+	}
+
+	if rule := lookupBuiltinRule(name); rule != nil {
+		fmt.Fprintf(cli.outStream, "%s\n\n", rule.Name())
+		fmt.Fprint(cli.outStream, "Plugin: terraform (bundled)\n")
+		fmt.Fprintf(cli.outStream, "Severity: %s\n", strings.ToLower(rule.Severity().String()))
+		fmt.Fprintf(cli.outStream, "Enabled by default: %t\n", rule.Enabled())
+		fmt.Fprintf(cli.outStream, "Status: %s\n", ruleStatus(cfg, rule.Name()))
+		fmt.Fprintf(cli.outStream, "Link: %s\n", rule.Link())
+
+		doc, err := builtinRuleDocs.ReadFile(fmt.Sprintf("ruledocs/%s.md", rule.Name()))
+		if err == nil {
+			fmt.Fprintf(cli.outStream, "\n%s", doc)
+		}
+		return ExitCodeOK
+	}
+
+	if pluginName == "" {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf(`Rule "%s" not found; run --list-rules to see available rules`, name), map[string][]byte{})
+		return ExitCodeError
+	}
+
+	fmt.Fprintf(cli.outStream, "%s\n\n", name)
+	fmt.Fprintf(cli.outStream, "Plugin: %s\n", pluginName)
+	fmt.Fprintf(cli.outStream, "Status: %s\n", ruleStatus(cfg, name))
+	fmt.Fprint(cli.outStream, "\nThe plugin does not provide details of the rule. See the plugin documentation.\n")
+	return ExitCodeOK
+}
+
+// lookupBuiltinRule returns the rule in the bundled terraform ruleset, or nil if not found.
+func lookupBuiltinRule(name string) sdk.Rule {
+	for _, rule := range rules.PresetRules["all"] {
+		if rule.Name() == name {
+			return rule
+		}
+	}
+	return nil
+}
+
+// findRulePlugin returns the name of the plugin that provides the rule, or an empty string if not found.
+func findRulePlugin(cfg *tflint.Config, name string) (string, error) {
+	rulesetPlugin, err := plugin.Discovery(cfg)
+	if err != nil {
+		return "", fmt.Errorf("Failed to initialize plugins; %w", err)
+	}
+	defer rulesetPlugin.Clean()
+
+	for _, ruleset := range rulesetPlugin.RuleSets {
+		pluginName, err := ruleset.RuleSetName()
+		if err != nil {
+			return "", fmt.Errorf("Failed to get ruleset name; %w", err)
+		}
+		ruleNames, err := ruleset.RuleNames()
+		if err != nil {
+			return "", fmt.Errorf("Failed to get rule names from `%s` plugin; %w", pluginName, err)
+		}
+		for _, ruleName := range ruleNames {
+			if ruleName == name {
+				return pluginName, nil
+			}
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// loadFileList reads paths of files to inspect from the file given by --file-list, or from stdin if it is "-".
+// Relative paths in the list are resolved against the working directory given by --chdir,
+// and absolute paths are returned so that they can be compared with issues in any directory.
+func loadFileList(path string, chdir string, stdin io.Reader) ([]string, error) {
+	r := stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return []string{}, fmt.Errorf("Failed to read the file list; %w", err)
+		}
+		defer file.Close()
+		r = file
+	}
+
+	files, err := parseFileList(r, chdir)
+	if err != nil {
+		return []string{}, fmt.Errorf("Failed to read the file list; %w", err)
+	}
+	return files, nil
+}
+
+// parseFileList parses newline-separated paths. Empty lines and lines starting with "#" are ignored.
+func parseFileList(r io.Reader, baseDir string) ([]string, error) {
+	ret := []string{}
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !filepath.IsAbs(line) {
+			line = filepath.Join(baseDir, line)
+		}
+		abs, err := filepath.Abs(line)
+		if err != nil {
+			return []string{}, err
+		}
+		ret = append(ret, abs)
+	}
+	return ret, sc.Err()
+}
+
+// writeFileList writes the paths to a temporary file, so that the list can be passed to workers
+// in recursive inspection. Workers cannot read the list from stdin, and relative paths in the original
+// list are resolved against a different directory in workers. The caller must remove the returned file.
+func writeFileList(files []string) (string, error) {
+	file, err := os.CreateTemp("", "tflint-file-list-*")
+	if err != nil {
+		return "", fmt.Errorf("Failed to create the file list for workers; %w", err)
+	}
+	defer file.Close()
+
+	for _, path := range files {
+		if _, err := fmt.Fprintln(file, path); err != nil {
+			os.Remove(file.Name())
+			return "", fmt.Errorf("Failed to write the file list for workers; %w", err)
+		}
+	}
+	return file.Name(), nil
+}
+
+// filterListedFiles returns patterns that match only files in the list that also match the filters.
+// The paths are relative to the original working directory, so that they match filenames of issues.
+func filterListedFiles(files []string, filters []string, originalWorkingDir string) []string {
+	ret := []string{}
+	for _, file := range files {
+		rel, err := filepath.Rel(originalWorkingDir, file)
+		if err != nil {
+			continue
+		}
+		if matchFilters(filters, rel) {
+			ret = append(ret, escapeGlob(filepath.ToSlash(rel)))
+		}
+	}
+	return ret
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_parseFileList(t *testing.T) {
+	wd, err := filepath.Abs(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	abs := filepath.Join(wd, "other", "main.tf")
+
+	tests := []struct {
+		name    string
+		in      string
+		baseDir string
+		want    []string
+	}{
+		{
+			name: "empty",
+			in:   "",
+			want: []string{},
+		},
+		{
+			name: "relative paths",
+			in:   "main.tf\nsubdir/main.tf\n",
+			want: []string{filepath.Join(wd, "main.tf"), filepath.Join(wd, "subdir", "main.tf")},
+		},
+		{
+			name:    "relative to the base directory",
+			in:      "main.tf",
+			baseDir: "subdir",
+			want:    []string{filepath.Join(wd, "subdir", "main.tf")},
+		},
+		{
+			name:    "absolute paths",
+			in:      abs,
+			baseDir: "subdir",
+			want:    []string{abs},
+		},
+		{
+			name: "empty lines and comments",
+			in:   "# generated\n\nmain.tf\r\n  \n  # main.tf\n",
+			want: []string{filepath.Join(wd, "main.tf")},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseFileList(strings.NewReader(test.in), test.baseDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_filterListedFiles(t *testing.T) {
+	wd, err := filepath.Abs(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := []string{filepath.Join(wd, "main.tf"), filepath.Join(wd, "subdir", "c*.tf")}
+
+	tests := []struct {
+		name    string
+		filters []string
+		want    []string
+	}{
+		{
+			name:    "no filters",
+			filters: []string{},
+			want:    []string{"main.tf", `subdir/c\*.tf`},
+		},
+		{
+			name:    "intersection with filters",
+			filters: []string{"subdir/*.tf"},
+			want:    []string{`subdir/c\*.tf`},
+		},
+		{
+			name:    "no intersection",
+			filters: []string{"other.tf"},
+			want:    []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := filterListedFiles(files, test.filters, wd)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
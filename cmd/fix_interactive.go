@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/terraform-linters/tflint/formatter"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// openTTY opens the terminal to read answers to prompts, so that prompts work even if stdin is piped.
+var openTTY = func() (*os.File, error) {
+	if runtime.GOOS == "windows" {
+		return os.Open("CONIN$")
+	}
+	return os.Open("/dev/tty")
+}
+
+// fixSummary is the number of fixes applied or skipped in interactive mode.
+type fixSummary struct {
+	Applied int
+	Skipped int
+}
+
+func (s fixSummary) String() string {
+	fixes := func(n int) string {
+		if n == 1 {
+			return "1 fix"
+		}
+		return fmt.Sprintf("%d fixes", n)
+	}
+	return fmt.Sprintf("Applied %s, skipped %s", fixes(s.Applied), fixes(s.Skipped))
+}
+
+// fixPrompt is a set of hunks confirmed at once, and fixed issues whose ranges overlap them.
+type fixPrompt struct {
+	hunks  []tflint.FixHunk
+	issues tflint.Issues
+}
+
+// splitFixPrompts splits the change into hunks and groups them by fixed issues in the file.
+// Adjacent hunks that overlap the same issue are confirmed at once, since the issue is fixed by all of them.
+func splitFixPrompts(change tflint.FileChange, issues tflint.Issues) []*fixPrompt {
+	prompts := []*fixPrompt{}
+	for _, hunk := range tflint.SplitFixHunks(change.Before, change.After) {
+		overlapped := tflint.Issues{}
+		for _, issue := range issues {
+			// Issues not fixed by --fix-rule are fixable, but not fixed by the change
+			if issue.Fixed && issue.Range.Filename == change.Filename && hunk.Overlaps(issue.Range) {
+				overlapped = append(overlapped, issue)
+			}
+		}
+
+		if len(prompts) > 0 {
+			last := prompts[len(prompts)-1]
+			if slices.ContainsFunc(overlapped, func(issue *tflint.Issue) bool { return slices.Contains(last.issues, issue) }) {
+				last.hunks = append(last.hunks, hunk)
+				for _, issue := range overlapped {
+					if !slices.Contains(last.issues, issue) {
+						last.issues = append(last.issues, issue)
+					}
+				}
+				continue
+			}
+		}
+		prompts = append(prompts, &fixPrompt{hunks: []tflint.FixHunk{hunk}, issues: overlapped})
+	}
+	return prompts
+}
+
+// confirmChanges prints the diff of each fix and asks whether to apply it.
+// Changes of files are split into hunks, so that fixes of each issue are confirmed separately.
+// Answers are "y" (yes), "n" (no), "a" (apply the rest without prompting), and "q" (skip the rest).
+// The end of input is the same as "q". It returns the changes to apply.
+// Issues of skipped fixes are no longer marked as fixed. Fixed issues that do not overlap any hunk
+// are marked as fixed only if all fixes in the file are applied.
+func confirmChanges(in io.Reader, out io.Writer, changes []tflint.FileChange, issues tflint.Issues) (map[string][]byte, fixSummary, error) {
+	reader := bufio.NewReader(in)
+	accepted := map[string][]byte{}
+	summary := fixSummary{}
+	answer := ""
+
+	for _, change := range changes {
+		applied := []tflint.FixHunk{}
+		prompted := map[*tflint.Issue]bool{}
+		skipped := false
+
+		for _, prompt := range splitFixPrompts(change, issues) {
+			for _, issue := range prompt.issues {
+				prompted[issue] = true
+			}
+
+			if answer != "a" && answer != "q" {
+				fmt.Fprintf(out, "%s:\n", change.Filename)
+				for _, issue := range prompt.issues {
+					fmt.Fprintf(out, "  %s: %s\n", issue.Rule.Name(), issue.Message)
+				}
+				fmt.Fprint(out, formatter.UnifiedDiff(change.Filename, change.Filename, change.Before, tflint.ApplyFixHunks(change.Before, prompt.hunks)))
+
+				var err error
+				answer, err = readAnswer(reader, out)
+				if err != nil {
+					return nil, summary, err
+				}
+			}
+
+			if answer == "y" || answer == "a" {
+				applied = append(applied, prompt.hunks...)
+				summary.Applied++
+				continue
+			}
+			skipped = true
+			summary.Skipped++
+			for _, issue := range prompt.issues {
+				issue.Fixed = false
+			}
+		}
+
+		if len(applied) > 0 {
+			accepted[change.Filename] = tflint.ApplyFixHunks(change.Before, applied)
+		}
+		if skipped {
+			for _, issue := range issues {
+				if issue.Range.Filename == change.Filename && !prompted[issue] {
+					issue.Fixed = false
+				}
+			}
+		}
+	}
+
+	return accepted, summary, nil
+}
+
+// readAnswer prompts until one of "y", "n", "a", and "q" is entered, and returns it.
+func readAnswer(reader *bufio.Reader, out io.Writer) (string, error) {
+	for {
+		fmt.Fprint(out, "Apply this fix? [y/n/a/q] ")
+		line, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("Failed to read the answer; %w", err)
+		}
+
+		switch answer := strings.ToLower(strings.TrimSpace(line)); answer {
+		case "y", "n", "a", "q":
+			return answer, nil
+		case "yes", "no", "all", "quit":
+			return answer[:1], nil
+		}
+		if err != nil {
+			fmt.Fprintln(out)
+			return "q", nil
+		}
+	}
+}
+
+// confirmFixes asks on the terminal whether to apply each fix, and returns the changes to apply.
+func (cli *CLI) confirmFixes(issues tflint.Issues, changes map[string][]byte) (map[string][]byte, fixSummary, error) {
+	tty, err := openTTY()
+	if err != nil {
+		return nil, fixSummary{}, fmt.Errorf("Failed to open the terminal; %w", err)
+	}
+	defer tty.Close()
+
+	return confirmChanges(tty, cli.outStream, cli.fileChanges(changes), issues)
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/tflint"
+	"golang.org/x/sys/unix"
+)
+
+// openPty opens a pseudo-terminal pair. Input written to the master is read from the slave as terminal input.
+func openPty(t *testing.T) (*os.File, *os.File) {
+	t.Helper()
+
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("pseudo-terminals are not available: %s", err)
+	}
+	t.Cleanup(func() { master.Close() })
+
+	if err := unix.IoctlSetPointerInt(int(master.Fd()), unix.TIOCSPTLCK, 0); err != nil {
+		t.Fatal(err)
+	}
+	n, err := unix.IoctlGetUint32(int(master.Fd()), unix.TIOCGPTN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slave, err := os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("pseudo-terminals are not available: %s", err)
+	}
+	t.Cleanup(func() { slave.Close() })
+
+	// Discard echoed input so that writes to the master never block
+	go func() { _, _ = io.Copy(io.Discard, master) }()
+
+	return master, slave
+}
+
+func Test_confirmChanges(t *testing.T) {
+	changes := []tflint.FileChange{
+		{Filename: "a.tf", Before: []byte("a = \"${var.a}\"\nx = 1\nb = \"${var.b}\"\n"), After: []byte("a = var.a\nx = 1\nb = var.b\n")},
+		{Filename: "c.tf", Before: []byte("c = \"${var.c}\"\n"), After: []byte("c = var.c\n")},
+	}
+	line := func(filename string, n int) hcl.Range {
+		return hcl.Range{Filename: filename, Start: hcl.Pos{Line: n}, End: hcl.Pos{Line: n}}
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		applied map[string]string
+		fixed   []string
+		summary fixSummary
+		prompts int
+	}{
+		{
+			name:    "yes and no",
+			input:   "y\nn\nyes\n",
+			applied: map[string]string{"a.tf": "a = var.a\nx = 1\nb = \"${var.b}\"\n", "c.tf": "c = var.c\n"},
+			fixed:   []string{"fixable issue a", "fixable issue c"},
+			summary: fixSummary{Applied: 2, Skipped: 1},
+			prompts: 3,
+		},
+		{
+			name:    "all",
+			input:   "n\na\n",
+			applied: map[string]string{"a.tf": "a = \"${var.a}\"\nx = 1\nb = var.b\n", "c.tf": "c = var.c\n"},
+			fixed:   []string{"fixable issue b", "fixable issue c"},
+			summary: fixSummary{Applied: 2, Skipped: 1},
+			prompts: 2,
+		},
+		{
+			name:    "quit",
+			input:   "y\nq\n",
+			applied: map[string]string{"a.tf": "a = var.a\nx = 1\nb = \"${var.b}\"\n"},
+			fixed:   []string{"fixable issue a"},
+			summary: fixSummary{Applied: 1, Skipped: 2},
+			prompts: 2,
+		},
+		{
+			name:    "invalid answer",
+			input:   "maybe\nY\nN\nQ\n",
+			applied: map[string]string{"a.tf": "a = var.a\nx = 1\nb = \"${var.b}\"\n"},
+			fixed:   []string{"fixable issue a"},
+			summary: fixSummary{Applied: 1, Skipped: 2},
+			prompts: 4,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			issues := tflint.Issues{
+				{Rule: &testRule{}, Message: "fixable issue a", Range: line("a.tf", 1), Fixable: true, Fixed: true},
+				{Rule: &testRule{}, Message: "unfixable issue", Range: line("a.tf", 2)},
+				{Rule: &testRule{}, Message: "fixable issue b", Range: line("a.tf", 3), Fixable: true, Fixed: true},
+				{Rule: &testRule{}, Message: "unfixed issue", Range: line("a.tf", 3), Fixable: true},
+				{Rule: &testRule{}, Message: "fixable issue c", Range: line("c.tf", 1), Fixable: true, Fixed: true},
+			}
+
+			master, slave := openPty(t)
+			if _, err := master.WriteString(test.input); err != nil {
+				t.Fatal(err)
+			}
+
+			out := new(bytes.Buffer)
+			got, summary, err := confirmChanges(slave, out, changes, issues)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			applied := map[string]string{}
+			for filename, source := range got {
+				applied[filename] = string(source)
+			}
+			if diff := cmp.Diff(test.applied, applied); diff != "" {
+				t.Error(diff)
+			}
+			fixed := []string{}
+			for _, issue := range issues {
+				if issue.Fixed {
+					fixed = append(fixed, issue.Message)
+				}
+			}
+			if diff := cmp.Diff(test.fixed, fixed); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(test.summary, summary); diff != "" {
+				t.Error(diff)
+			}
+			if prompts := strings.Count(out.String(), "Apply this fix? [y/n/a/q] "); prompts != test.prompts {
+				t.Errorf("expected %d prompts, but got %d: %s", test.prompts, prompts, out)
+			}
+			if !strings.Contains(out.String(), "a.tf:\n  test_rule: fixable issue a\n") || strings.Contains(out.String(), "unfixable issue") || strings.Contains(out.String(), "unfixed issue") {
+				t.Errorf("fixable issues are not listed: %s", out)
+			}
+			// The diff of each fix only has the hunk of the issue
+			if !strings.Contains(out.String(), "-a = \"${var.a}\"\n+a = var.a\n x = 1\n b = \"${var.b}\"\n") {
+				t.Errorf("the diff is not printed: %s", out)
+			}
+		})
+	}
+}
+
+func Test_splitFixPrompts(t *testing.T) {
+	change := tflint.FileChange{
+		Filename: "main.tf",
+		Before:   []byte("a = 1\nb = 2\nc = 3\nd = 4\n"),
+		After:    []byte("a = 10\nb = 20\nc = 3\nd = 40\n"),
+	}
+	multiline := &tflint.Issue{Rule: &testRule{}, Message: "a and b", Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1}, End: hcl.Pos{Line: 2}}, Fixed: true}
+	single := &tflint.Issue{Rule: &testRule{}, Message: "d", Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 4}, End: hcl.Pos{Line: 4}}, Fixed: true}
+
+	prompts := splitFixPrompts(change, tflint.Issues{multiline, single})
+	if len(prompts) != 2 {
+		t.Fatalf("expected 2 prompts, but got %d", len(prompts))
+	}
+	// Hunks of the same issue are confirmed at once
+	if len(prompts[0].hunks) != 2 || len(prompts[0].issues) != 1 || prompts[0].issues[0] != multiline {
+		t.Errorf("unexpected first prompt: %#v", prompts[0])
+	}
+	if len(prompts[1].hunks) != 1 || len(prompts[1].issues) != 1 || prompts[1].issues[0] != single {
+		t.Errorf("unexpected second prompt: %#v", prompts[1])
+	}
+}
+
+func Test_fixSummary(t *testing.T) {
+	got := fixSummary{Applied: 1, Skipped: 2}.String()
+	want := "Applied 1 fix, skipped 2 fixes"
+	if got != want {
+		t.Errorf("expected %q, but got %q", want, got)
+	}
+}
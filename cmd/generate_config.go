@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/terraform-linters/tflint-ruleset-terraform/rules"
+	"github.com/terraform-linters/tflint/plugin"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// defaultGeneratedConfig is the file name written by generate-config if --output is not set.
+const defaultGeneratedConfig = ".tflint.hcl"
+
+// ruleSetRules is a ruleset that provides rule names. It is satisfied by plugin clients.
+type ruleSetRules interface {
+	RuleSetName() (string, error)
+	RuleNames() ([]string, error)
+}
+
+// generateConfig writes a config template in which all rules of the bundled plugin
+// and plugins enabled in the config are commented out.
+func (cli *CLI) generateConfig(opts Options) int {
+	output := opts.GenerateConfig.Output
+	if output == "" {
+		output = defaultGeneratedConfig
+	}
+
+	err := cli.withinChangedDir(opts.chdir(), func() error {
+		if !opts.GenerateConfig.Overwrite {
+			if _, err := os.Stat(output); err == nil {
+				return fmt.Errorf(`"%s" already exists. Use --overwrite to overwrite it`, output)
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("Failed to check the output file; %w", err)
+			}
+		}
+
+		cfg, err := loadConfig(opts)
+		if err != nil {
+			return fmt.Errorf("Failed to load TFLint config; %w", err)
+		}
+		cfg.Merge(opts.toConfig())
+
+		rulesetPlugin, err := plugin.Discovery(cfg)
+		if err != nil {
+			return fmt.Errorf("Failed to initialize plugins; %w", err)
+		}
+		defer rulesetPlugin.Clean()
+
+		rulesets := make([]ruleSetRules, 0, len(rulesetPlugin.RuleSets))
+		for _, ruleset := range rulesetPlugin.RuleSets {
+			rulesets = append(rulesets, ruleset)
+		}
+		content, err := generateConfigTemplate(rulesets)
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(output, content, 0o644); err != nil {
+			return fmt.Errorf("Failed to write the config file; %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+		return ExitCodeError
+	}
+
+	fmt.Fprintf(cli.outStream, "Generated %s\n", output)
+	return ExitCodeOK
+}
+
+// generateConfigTemplate returns a config in which all rules are commented out.
+// Rules of the bundled plugin are always included and annotated with their descriptions.
+// Other plugins do not expose rule descriptions, so only their rule names are written.
+func generateConfigTemplate(rulesets []ruleSetRules) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprint(&buf, "# Generated by `tflint generate-config`.\n")
+	fmt.Fprint(&buf, "# Uncomment rule blocks to enable or disable rules.\n")
+	fmt.Fprint(&buf, "# See https://github.com/terraform-linters/tflint/blob/master/docs/user-guide/config.md\n")
+
+	fmt.Fprint(&buf, "\n# Plugin: terraform (bundled)\n")
+	// The bundled plugin enables the recommended preset unless the plugin block is declared
+	recommended := map[string]bool{}
+	for _, rule := range rules.PresetRules["recommended"] {
+		recommended[rule.Name()] = true
+	}
+	builtins := map[string]bool{}
+	for _, rule := range rules.PresetRules["all"] {
+		builtins[rule.Name()] = true
+
+		fmt.Fprintln(&buf)
+		for _, line := range builtinRuleDescription(rule.Name()) {
+			fmt.Fprintf(&buf, "# %s\n", line)
+		}
+		fmt.Fprintf(&buf, "# Enabled by default: %t\n", recommended[rule.Name()])
+		writeCommentedRule(&buf, rule.Name(), !recommended[rule.Name()])
+	}
+
+	type pluginRules struct {
+		name  string
+		rules []string
+	}
+	plugins := []pluginRules{}
+	for _, ruleset := range rulesets {
+		name, err := ruleset.RuleSetName()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get ruleset name; %w", err)
+		}
+		ruleNames, err := ruleset.RuleNames()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get rule names from `%s` plugin; %w", name, err)
+		}
+		// The bundled plugin is also returned from plugins enabled in the config
+		ruleNames = slices.DeleteFunc(slices.Clone(ruleNames), func(ruleName string) bool {
+			return builtins[ruleName]
+		})
+		if len(ruleNames) == 0 {
+			continue
+		}
+		slices.Sort(ruleNames)
+		plugins = append(plugins, pluginRules{name: name, rules: ruleNames})
+	}
+	slices.SortFunc(plugins, func(a, b pluginRules) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	for _, p := range plugins {
+		fmt.Fprintf(&buf, "\n# Plugin: %s\n", p.name)
+		for _, ruleName := range p.rules {
+			fmt.Fprintln(&buf)
+			writeCommentedRule(&buf, ruleName, true)
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// writeCommentedRule writes a commented out rule block that sets the enabled attribute.
+func writeCommentedRule(buf *bytes.Buffer, name string, enabled bool) {
+	fmt.Fprintf(buf, "# rule \"%s\" {\n", name)
+	fmt.Fprintf(buf, "#   enabled = %t\n", enabled)
+	fmt.Fprint(buf, "# }\n")
+}
+
+// builtinRuleDescription returns lines of the first paragraph of the embedded rule doc.
+func builtinRuleDescription(name string) []string {
+	doc, err := builtinRuleDocs.ReadFile(fmt.Sprintf("ruledocs/%s.md", name))
+	if err != nil {
+		return nil
+	}
+
+	lines := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(doc))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			if len(lines) > 0 {
+				break
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			if len(lines) > 0 {
+				break
+			}
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+type mockRuleSet struct {
+	name  string
+	rules []string
+	err   error
+}
+
+func (r *mockRuleSet) RuleSetName() (string, error) {
+	return r.name, nil
+}
+
+func (r *mockRuleSet) RuleNames() ([]string, error) {
+	return r.rules, r.err
+}
+
+func Test_generateConfigTemplate(t *testing.T) {
+	rulesets := []ruleSetRules{
+		&mockRuleSet{name: "terraform", rules: []string{"terraform_comment_syntax"}},
+		&mockRuleSet{name: "aws", rules: []string{"aws_instance_previous_type", "aws_instance_invalid_type"}},
+	}
+
+	got, err := generateConfigTemplate(rulesets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(got)
+
+	for _, name := range []string{"terraform_comment_syntax", "terraform_required_version", "aws_instance_invalid_type", "aws_instance_previous_type"} {
+		if !strings.Contains(content, `# rule "`+name+`" {`) {
+			t.Errorf("%s is not found in the generated config:\n%s", name, content)
+		}
+	}
+	if strings.Count(content, `rule "terraform_comment_syntax"`) != 1 {
+		t.Errorf("the bundled rule must be written once:\n%s", content)
+	}
+	if !strings.Contains(content, "# Enforce usage of `#` for comments.\n") {
+		t.Errorf("the description of the bundled rule is not found:\n%s", content)
+	}
+	if strings.Index(content, "# Plugin: aws") < strings.Index(content, "# Plugin: terraform (bundled)") {
+		t.Errorf("the bundled plugin must be written first:\n%s", content)
+	}
+
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	if err := fs.WriteFile(".tflint.hcl", got, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := tflint.LoadConfig(fs, ".tflint.hcl")
+	if err != nil {
+		t.Fatalf("the generated config must be parseable: %s", err)
+	}
+	if len(cfg.Rules) != 0 {
+		t.Errorf("all rules must be commented out, but got %d rules", len(cfg.Rules))
+	}
+
+	// Uncommenting rule blocks must also result in a valid config
+	uncommented := regexp.MustCompile(`(?m)^# (rule "|  enabled = |})`).ReplaceAll(got, []byte("$1"))
+	if err := fs.WriteFile(".tflint.hcl", uncommented, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = tflint.LoadConfig(fs, ".tflint.hcl")
+	if err != nil {
+		t.Fatalf("the uncommented config must be parseable: %s", err)
+	}
+	for _, name := range []string{"terraform_comment_syntax", "aws_instance_invalid_type"} {
+		rule, exists := cfg.Rules[name]
+		if !exists {
+			t.Errorf("%s is not found in the uncommented config", name)
+			continue
+		}
+		if !rule.Enabled {
+			t.Errorf("%s must be enabled since it is disabled by default", name)
+		}
+	}
+}
+
+func Test_generateConfigTemplate_error(t *testing.T) {
+	rulesets := []ruleSetRules{
+		&mockRuleSet{name: "aws", err: errors.New("unexpected error")},
+	}
+
+	_, err := generateConfigTemplate(rulesets)
+	if err == nil {
+		t.Fatal("an error is expected")
+	}
+	if err.Error() != "Failed to get rule names from `aws` plugin; unexpected error" {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
@@ -6,17 +6,16 @@ import (
 	"os"
 
 	"github.com/fatih/color"
-	"github.com/spf13/afero"
 	"github.com/terraform-linters/tflint/plugin"
 	"github.com/terraform-linters/tflint/tflint"
 )
 
 func (cli *CLI) init(opts Options) int {
 	if plugin.IsExperimentalModeEnabled() {
-		_, _ = color.New(color.FgYellow).Fprintln(cli.outStream, `Experimental mode is enabled. This behavior may change in future versions without notice`)
+		_, _ = cli.stdoutColor(color.FgYellow).Fprintln(cli.outStream, `Experimental mode is enabled. This behavior may change in future versions without notice`)
 	}
 
-	workingDirs, err := findWorkingDirs(opts)
+	workingDirs, _, err := findWorkingDirs(opts)
 	if err != nil {
 		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to find workspaces; %w", err), map[string][]byte{})
 		return ExitCodeError
@@ -25,7 +24,7 @@ func (cli *CLI) init(opts Options) int {
 	installed := false
 	for _, wd := range workingDirs {
 		err := cli.withinChangedDir(wd, func() error {
-			cfg, err := tflint.LoadConfig(afero.Afero{Fs: afero.NewOsFs()}, opts.Config)
+			cfg, err := loadConfig(opts)
 			if err != nil {
 				if opts.Recursive {
 					return fmt.Errorf("Failed to load TFLint config in %s; %w", wd, err)
@@ -53,7 +52,7 @@ func (cli *CLI) init(opts Options) int {
 					_, err = installCfg.Install()
 					if err != nil {
 						if errors.Is(err, plugin.ErrPluginNotVerified) {
-							_, _ = color.New(color.FgYellow).Fprintln(cli.outStream, `No signing key configured. Set "signing_key" to verify that the release is signed by the plugin developer`)
+							_, _ = cli.stdoutColor(color.FgYellow).Fprintln(cli.outStream, `No signing key configured. Set "signing_key" to verify that the release is signed by the plugin developer`)
 							err = nil
 						} else {
 							return fmt.Errorf("Failed to install a plugin; %w", err)
@@ -1,16 +1,25 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/bmatcuk/doublestar"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/spf13/afero"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint/formatter"
 	"github.com/terraform-linters/tflint/plugin"
 	"github.com/terraform-linters/tflint/terraform"
 	"github.com/terraform-linters/tflint/tflint"
@@ -19,85 +28,194 @@ import (
 )
 
 func (cli *CLI) inspect(opts Options) int {
+	start := time.Now()
 	issues := tflint.Issues{}
 	changes := map[string][]byte{}
+	scannedFiles := []string{}
+	var noFiles bool
+
+	err := cli.withinChangedDir(opts.chdir(), func() error {
+		// Issue filenames are prefixed with the directory, so patterns are also prefixed to match them
+		filters := make([]string, len(opts.Filter))
+		for i, pattern := range opts.Filter {
+			filters[i] = filepath.ToSlash(filepath.Join(opts.chdir(), pattern))
+		}
+		// Validate patterns before inspection. A pattern that does not match any files is not an error.
+		if _, err := (tflint.Issues{}).FilterByFile(filters); err != nil {
+			return fmt.Errorf("Failed to parse --filter options; %w", err)
+		}
 
-	err := cli.withinChangedDir(opts.Chdir, func() error {
-		filterFiles := []string{}
-		for _, pattern := range opts.Filter {
-			files, err := filepath.Glob(pattern)
+		if opts.changedOnly() {
+			changed, err := filterChangedFiles(opts)
 			if err != nil {
-				return fmt.Errorf("Failed to parse --filter options; %w", err)
+				return err
+			}
+			// Nothing to report, so skip loading configurations
+			if len(changed) == 0 {
+				log.Print("[INFO] No files are changed in the working directory")
+				noFiles = true
+				return nil
 			}
-			// Add the raw pattern to return an empty result if it doesn't match any files
-			if len(files) == 0 {
-				filterFiles = append(filterFiles, pattern)
+			// Changed files are already filtered by --filter, so they replace the patterns
+			filters = make([]string, len(changed))
+			for i, file := range changed {
+				filters[i] = escapeGlob(filepath.ToSlash(filepath.Join(opts.chdir(), file)))
 			}
-			filterFiles = append(filterFiles, files...)
 		}
-
-		// Join with the working directory to create the fullpath
-		for i, file := range filterFiles {
-			filterFiles[i] = filepath.Join(opts.Chdir, file)
+		if cli.fileList != nil {
+			// Listed files that match the current patterns replace them, so that the sets are intersected
+			filters = filterListedFiles(cli.fileList, filters, cli.originalWorkingDir)
+			if len(filters) == 0 {
+				log.Print("[INFO] No files in the file list are in the working directory")
+				noFiles = true
+				return nil
+			}
 		}
 
 		var err error
-		issues, changes, err = cli.inspectModule(opts, ".", filterFiles)
-		return err
+		dir := "."
+		if opts.stdin() {
+			// The directory of the file read from stdin is inspected as a module
+			dir = filepath.Dir(opts.stdinFilename())
+		}
+		issues, changes, err = cli.inspectModule(opts, dir, filters)
+		if err != nil {
+			return err
+		}
+		if !opts.NoDeduplicate {
+			issues = issues.Deduplicate()
+		}
+		scannedFiles = filterScannedFiles(cli.loader.ScannedFiles(), filters)
+		return nil
 	})
 	if err != nil {
 		sources := map[string][]byte{}
 		if cli.loader != nil {
 			sources = cli.loader.Sources()
 		}
+		cli.setStatistics(opts, formatter.Statistics{ModulesErrored: 1}, start)
 		cli.formatter.Print(tflint.Issues{}, err, sources)
 		return ExitCodeError
 	}
+	if noFiles {
+		if opts.ActAsWorker {
+			fmt.Fprint(cli.outStream, `{"issues":[],"scanned_files":[]}`)
+		} else {
+			cli.setStatistics(opts, formatter.Statistics{}, start)
+			cli.formatter.Print(tflint.Issues{}, nil, cli.sources)
+		}
+		return ExitCodeOK
+	}
+
+	if !opts.ActAsWorker {
+		if opts.GenerateBaseline {
+			return cli.generateBaseline(issues, opts)
+		}
+
+		issues, err = cli.applyBaseline(issues, opts)
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, err, cli.sources)
+			return ExitCodeError
+		}
+		// Hidden issues are neither reported nor affect the exit status
+		issues = filterByMinSeverity(issues, opts.MinSeverity)
+	}
+
+	var fixes *fixSummary
+	if opts.Fix && opts.Interactive && !opts.ActAsWorker {
+		if isTerminal(cli.outStream) {
+			var summary fixSummary
+			changes, summary, err = cli.confirmFixes(issues, changes)
+			if err != nil {
+				cli.formatter.Print(tflint.Issues{}, err, cli.sources)
+				return ExitCodeError
+			}
+			fixes = &summary
+		} else {
+			fmt.Fprintf(cli.errStream, "Warning: --interactive is ignored because stdout is not a terminal\n")
+		}
+	}
 
 	if opts.ActAsWorker {
 		// When acting as a recursive inspection worker, the formatter is ignored
 		// and the serialized issues are output.
-		out, err := json.Marshal(issues)
+		result := workerResult{Issues: issues, ScannedFiles: scannedFiles, Inspected: cli.evaluatedRules != nil, Rules: cli.evaluatedRules, Thresholds: ruleThresholds(cli.config), MinimumFailureSeverity: cli.config.MinimumFailureSeverity}
+		if opts.Fix {
+			result.Changes = cli.fileChanges(changes)
+		}
+		out, err := json.Marshal(result)
 		if err != nil {
 			fmt.Fprint(cli.errStream, err)
 			return ExitCodeError
 		}
 		fmt.Fprint(cli.outStream, string(out))
 	} else {
-		cli.formatter.Print(issues, nil, cli.sources)
+		cli.formatter.ScannedFiles = scannedFiles
+		if opts.Fix {
+			cli.formatter.Changes = cli.fileChanges(changes)
+		}
+		stats := formatter.Statistics{FilesInspected: len(scannedFiles), RulesEvaluated: len(cli.evaluatedRules)}
+		if cli.evaluatedRules != nil {
+			stats.ModulesInspected = 1
+		}
+		cli.setStatistics(opts, stats, start)
+		// All issues still affect the exit status even if they are not reported
+		var reported tflint.Issues
+		reported, cli.formatter.IssuesTruncated = truncateIssues(issues, opts.MaxIssues)
+		cli.formatter.Print(reported, nil, cli.sources)
+		if cli.formatter.Err() != nil {
+			return ExitCodeError
+		}
 	}
 
-	if opts.Fix {
+	if opts.Fix && !opts.DryRun {
 		if err := writeChanges(changes); err != nil {
 			cli.formatter.Print(tflint.Issues{}, err, cli.sources)
 			return ExitCodeError
 		}
+		if fixes != nil {
+			fmt.Fprintln(cli.outStream, fixes)
+		}
 	}
 
-	if len(issues) > 0 && !cli.config.Force && exceedsMinimumFailure(issues, opts.MinimumFailureSeverity) {
+	// Issues within thresholds of rules do not affect the exit status
+	issues = filterByThresholds(issues, ruleThresholds(cli.config))
+
+	if len(issues) > 0 && !cli.config.Force && exceedsMinimumFailure(issues, cli.failOnSeverity) {
 		return ExitCodeIssuesFound
 	}
 
 	return ExitCodeOK
 }
 
-func (cli *CLI) inspectModule(opts Options, dir string, filterFiles []string) (tflint.Issues, map[string][]byte, error) {
+func (cli *CLI) inspectModule(opts Options, dir string, filters []string) (tflint.Issues, map[string][]byte, error) {
 	issues := tflint.Issues{}
 	changes := map[string][]byte{}
 	var err error
+	cli.evaluatedRules = nil
+	cli.originals = map[string][]byte{}
 
 	// Setup config
-	cli.config, err = tflint.LoadConfig(afero.Afero{Fs: afero.NewOsFs()}, opts.Config)
+	cli.config, err = loadConfig(opts)
 	if err != nil {
 		return issues, changes, fmt.Errorf("Failed to load TFLint config; %w", err)
 	}
 	cli.config.Merge(opts.toConfig())
 
 	// Setup loader
-	cli.loader, err = terraform.NewLoader(afero.Afero{Fs: afero.NewOsFs()}, cli.originalWorkingDir)
+	//nolint:TODO // Use terraform.NewLoaderWithBaseDir with opts.chdir() once withinChangedDir is removed.
+	cli.loader, err = terraform.NewLoader(afero.Afero{Fs: afero.NewOsFs()}, cli.originalWorkingDir, cli.loaderCache)
 	if err != nil {
 		return issues, changes, fmt.Errorf("Failed to prepare loading; %w", err)
 	}
+	if cli.ignoredFile != nil {
+		cli.loader.IgnoreFiles(cli.ignoredFile)
+	}
+	if opts.stdin() {
+		if err := cli.loader.LoadFromReader(opts.stdinFilename(), bytes.NewReader(cli.stdinSource)); err != nil {
+			return issues, changes, fmt.Errorf("Failed to read configuration from stdin; %w", err)
+		}
+	}
 	if opts.ActAsWorker && !cli.loader.IsConfigDir(dir) {
 		// Ignore non-module directories in worker mode
 		return issues, changes, nil
@@ -108,12 +226,16 @@ func (cli *CLI) inspectModule(opts Options, dir string, filterFiles []string) (t
 	if err != nil {
 		return issues, changes, err
 	}
+	// Fixes are never applied in called modules, so only the root runner is limited
+	rootRunner.SetFixRules(opts.FixRules)
 
 	// Launch plugin processes
 	rulesetPlugin, err := launchPlugins(cli.config, opts.Fix)
 	if rulesetPlugin != nil {
 		defer rulesetPlugin.Clean()
-		go cli.registerShutdownHandler(func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go cli.registerShutdownHandler(ctx, func() {
 			rulesetPlugin.Clean()
 			os.Exit(ExitCodeError)
 		})
@@ -121,6 +243,16 @@ func (cli *CLI) inspectModule(opts Options, dir string, filterFiles []string) (t
 	if err != nil {
 		return issues, changes, err
 	}
+	evaluatedRules, err := getEvaluatedRules(cli.config, rulesetPlugin)
+	if err != nil {
+		return issues, changes, err
+	}
+	// Annotations can refer to disabled rules, so they are validated against all rules
+	ruleNames, err := getRuleNames(rulesetPlugin)
+	if err != nil {
+		return issues, changes, err
+	}
+	rootRunner.ValidateAnnotations(ruleNames)
 
 	// Check preconditions
 	sdkVersions := map[string]*version.Version{}
@@ -144,6 +276,10 @@ func (cli *CLI) inspectModule(opts Options, dir string, filterFiles []string) (t
 	//
 	// Repeat an inspection until there are no more changes or the limit is reached,
 	// in case an autofix introduces new issues.
+	warnedConflicts := map[string]bool{}
+	// fixesApplied records whether the fix of each issue is applied in the attempt where it is found,
+	// since a conflict is resolved again in each attempt and its fixes are skipped only in that attempt.
+	fixesApplied := map[*tflint.Issue]bool{}
 	for loop := 1; ; loop++ {
 		if loop > 10 {
 			return issues, changes, fmt.Errorf(`Reached the limit of autofix attempts, and the changes made by the autofix will not be applied. This may be due to the following reasons:
@@ -180,19 +316,47 @@ By setting TFLINT_LOG=trace, you can confirm the changes made by the autofix and
 			close(ch)
 		}
 
+		// Fixes of different rules that change the same lines may produce invalid HCL, so they are skipped
+		conflicts, diags := rootRunner.ResolveFixConflicts()
+		if diags.HasErrors() {
+			return issues, changes, fmt.Errorf("Failed to resolve conflicting fixes; %w", diags)
+		}
+		for _, conflict := range conflicts {
+			// The same conflict is found again in the next attempt if other fixes are applied
+			if !warnedConflicts[conflict.String()] {
+				fmt.Fprintf(cli.errStream, "Warning: %s\n", conflict)
+				warnedConflicts[conflict.String()] = true
+			}
+		}
+
 		changesInAttempt := map[string][]byte{}
 		for _, runner := range append(moduleRunners, rootRunner) {
-			for _, issue := range runner.LookupIssues(filterFiles...) {
+			filtered, err := runner.LookupIssues().FilterByFile(filters)
+			if err != nil {
+				return issues, changes, err
+			}
+			for _, issue := range filtered {
 				// On the second attempt, only fixable issues are appended to avoid duplicates.
-				if loop == 1 || issue.Fixable {
+				// Issues not fixed by --fix-rule or conflicts are found again in every attempt, so they are also excluded.
+				applied := fixApplied(rootRunner, issue)
+				if loop == 1 || applied {
 					issues = append(issues, issue)
+					fixesApplied[issue] = applied
 				}
 			}
 			runner.Issues = tflint.Issues{}
 
-			for path, source := range runner.LookupChanges(filterFiles...) {
+			for path, source := range runner.LookupChanges() {
+				if !matchFilters(filters, path) {
+					continue
+				}
 				changesInAttempt[path] = source
 				changes[path] = source
+				if original, exists := runner.LookupOriginal(path); exists {
+					if _, recorded := cli.originals[path]; !recorded {
+						cli.originals[path] = original
+					}
+				}
 			}
 			runner.ClearChanges()
 		}
@@ -202,14 +366,117 @@ By setting TFLINT_LOG=trace, you can confirm the changes made by the autofix and
 		}
 	}
 
+	if opts.Fix {
+		markFixed(issues, changes, fixesApplied)
+	}
+
 	// Set module sources to CLI
 	for path, source := range cli.loader.Sources() {
 		cli.sources[path] = source
 	}
+	cli.evaluatedRules = evaluatedRules
 
 	return issues, changes, nil
 }
 
+// markFixed marks issues whose fixes are applied to files. Fixes are never applied in called modules.
+func markFixed(issues tflint.Issues, changes map[string][]byte, fixesApplied map[*tflint.Issue]bool) {
+	for _, issue := range issues {
+		if _, exists := changes[issue.Range.Filename]; exists && fixesApplied[issue] {
+			issue.Fixed = true
+		}
+	}
+}
+
+// fixApplied returns true if the fix of the issue is applied by the runner.
+// Fixes are not applied if the rule is not passed to --fix-rule, or if they conflict with fixes of other rules.
+func fixApplied(runner *tflint.Runner, issue *tflint.Issue) bool {
+	return issue.Fixable && runner.FixAllowed(issue.Rule.Name()) && !runner.FixSkipped(issue.Rule.Name(), issue.Range)
+}
+
+// getEvaluatedRules returns names of rules provided by the plugins that are not disabled in the config.
+func getEvaluatedRules(cfg *tflint.Config, rulesetPlugin *plugin.Plugin) ([]string, error) {
+	rules := []string{}
+	for name, ruleset := range rulesetPlugin.RuleSets {
+		ruleNames, err := ruleset.RuleNames()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get rule names from `%s` plugin; %w", name, err)
+		}
+		for _, rule := range ruleNames {
+			if ruleStatus(cfg, rule) != ruleStatusDisabled {
+				rules = append(rules, rule)
+			}
+		}
+	}
+	slices.Sort(rules)
+	return rules, nil
+}
+
+// getRuleNames returns names of all rules provided by the plugins.
+func getRuleNames(rulesetPlugin *plugin.Plugin) ([]string, error) {
+	rules := []string{}
+	for name, ruleset := range rulesetPlugin.RuleSets {
+		ruleNames, err := ruleset.RuleNames()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get rule names from `%s` plugin; %w", name, err)
+		}
+		rules = append(rules, ruleNames...)
+	}
+	return rules, nil
+}
+
+// setStatistics sets the statistics of the inspection to the formatter if --statistics is passed.
+func (cli *CLI) setStatistics(opts Options, stats formatter.Statistics, start time.Time) {
+	if !opts.Statistics {
+		return
+	}
+	stats.Duration = time.Since(start)
+	cli.formatter.Statistics = &stats
+}
+
+// filterScannedFiles returns scanned files that match --filter. All files are returned if no filter is given.
+func filterScannedFiles(files []string, filters []string) []string {
+	if len(filters) == 0 {
+		return files
+	}
+
+	ret := []string{}
+	for _, file := range files {
+		if matchFilters(filters, file) {
+			ret = append(ret, file)
+		}
+	}
+	return ret
+}
+
+// matchFilters returns true if the path matches any of the patterns, or no pattern is given.
+// The patterns are the same as tflint.Issues.FilterByFile, and they must be validated in advance.
+func matchFilters(filters []string, path string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+
+	path = filepath.ToSlash(filepath.Clean(path))
+	for _, filter := range filters {
+		if matched, _ := doublestar.Match(filter, path); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// escapeGlob escapes meta characters in the path, so that it can be used as a pattern that matches only itself.
+func escapeGlob(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if strings.ContainsRune(`\*?[]{}`, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
 func (cli *CLI) setupRunners(opts Options, dir string) (*tflint.Runner, []*tflint.Runner, error) {
 	configs, diags := cli.loader.LoadConfig(dir, cli.config.CallModuleType)
 	if diags.HasErrors() {
@@ -254,10 +521,26 @@ func (cli *CLI) setupRunners(opts Options, dir string) (*tflint.Runner, []*tflin
 }
 
 func launchPlugins(config *tflint.Config, fix bool) (*plugin.Plugin, error) {
+	rulesetPlugin, rulesets, err := startPlugins(config, fix)
+	if err != nil {
+		return rulesetPlugin, err
+	}
+
+	// Validate config for plugins
+	if err := config.ValidateRules(rulesets...); err != nil {
+		return rulesetPlugin, fmt.Errorf("Failed to check rule config; %w", err)
+	}
+
+	return rulesetPlugin, nil
+}
+
+// startPlugins discovers plugins and applies the config to them without validating rule names.
+// The returned plugin must be cleaned up by the caller if it is not nil, even if an error is returned.
+func startPlugins(config *tflint.Config, fix bool) (*plugin.Plugin, []tflint.RuleSet, error) {
 	// Lookup plugins
 	rulesetPlugin, err := plugin.Discovery(config)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to initialize plugins; %w", err)
+		return nil, nil, fmt.Errorf("Failed to initialize plugins; %w", err)
 	}
 
 	rulesets := []tflint.RuleSet{}
@@ -270,44 +553,48 @@ func launchPlugins(config *tflint.Config, fix bool) (*plugin.Plugin, error) {
 		if err != nil {
 			if st, ok := status.FromError(err); ok && st.Code() == codes.Unimplemented {
 				// VersionConstraints endpoint is available in tflint-plugin-sdk v0.14+.
-				return rulesetPlugin, fmt.Errorf(`Plugin "%s" SDK version is incompatible. Compatible versions: %s`, name, plugin.SDKVersionConstraints)
+				return rulesetPlugin, nil, fmt.Errorf(`Plugin "%s" SDK version is incompatible. Compatible versions: %s`, name, plugin.SDKVersionConstraints)
 			} else {
-				return rulesetPlugin, fmt.Errorf(`Failed to get TFLint version constraints to "%s" plugin; %w`, name, err)
+				return rulesetPlugin, nil, fmt.Errorf(`Failed to get TFLint version constraints to "%s" plugin; %w`, name, err)
 			}
 		}
 		if !constraints.Check(tflint.Version) {
-			return rulesetPlugin, fmt.Errorf("Failed to satisfy version constraints; tflint-ruleset-%s requires %s, but TFLint version is %s", name, constraints, tflint.Version)
+			return rulesetPlugin, nil, fmt.Errorf("Failed to satisfy version constraints; tflint-ruleset-%s requires %s, but TFLint version is %s", name, constraints, tflint.Version)
 		}
 
 		if err := ruleset.ApplyGlobalConfig(pluginConf); err != nil {
-			return rulesetPlugin, fmt.Errorf(`Failed to apply global config to "%s" plugin; %w`, name, err)
+			return rulesetPlugin, nil, fmt.Errorf(`Failed to apply global config to "%s" plugin; %w`, name, err)
 		}
 		configSchema, err := ruleset.ConfigSchema()
 		if err != nil {
-			return rulesetPlugin, fmt.Errorf(`Failed to fetch config schema from "%s" plugin; %w`, name, err)
+			return rulesetPlugin, nil, fmt.Errorf(`Failed to fetch config schema from "%s" plugin; %w`, name, err)
 		}
 		content := &hclext.BodyContent{}
 		if plugin, exists := config.Plugins[name]; exists {
 			var diags hcl.Diagnostics
 			content, diags = plugin.Content(configSchema)
 			if diags.HasErrors() {
-				return rulesetPlugin, fmt.Errorf(`Failed to parse "%s" plugin config; %w`, name, diags)
+				return rulesetPlugin, nil, fmt.Errorf(`Failed to parse "%s" plugin config; %w`, name, diags)
 			}
 		}
 		err = ruleset.ApplyConfig(content, config.Sources())
 		if err != nil {
-			return rulesetPlugin, fmt.Errorf(`Failed to apply config to "%s" plugin; %w`, name, err)
+			return rulesetPlugin, nil, fmt.Errorf(`Failed to apply config to "%s" plugin; %w`, name, err)
 		}
 
 		rulesets = append(rulesets, ruleset)
 	}
 
-	// Validate config for plugins
-	if err := config.ValidateRules(rulesets...); err != nil {
-		return rulesetPlugin, fmt.Errorf("Failed to check rule config; %w", err)
-	}
+	return rulesetPlugin, rulesets, nil
+}
 
-	return rulesetPlugin, nil
+// fileChanges returns changes with the sources before autofixes, sorted by filename.
+func (cli *CLI) fileChanges(changes map[string][]byte) []tflint.FileChange {
+	ret := make([]tflint.FileChange, 0, len(changes))
+	for _, path := range slices.Sorted(maps.Keys(changes)) {
+		ret = append(ret, tflint.FileChange{Filename: path, Before: cli.originals[path], After: changes[path]})
+	}
+	return ret
 }
 
 func writeChanges(changes map[string][]byte) error {
@@ -332,6 +619,60 @@ func writeChanges(changes map[string][]byte) error {
 	return nil
 }
 
+// truncateIssues returns the first max issues in the sorted order, and true if some issues are dropped.
+// All issues are returned if max is 0.
+func truncateIssues(issues tflint.Issues, max int) (tflint.Issues, bool) {
+	if max <= 0 || len(issues) <= max {
+		return issues, false
+	}
+	return issues.Sort()[:max], true
+}
+
+// filterByMinSeverity returns issues with severities above or equal to the given minimum severity opt. All issues are returned if the opt is empty or invalid
+func filterByMinSeverity(issues tflint.Issues, minSeverityOpt string) tflint.Issues {
+	if minSeverityOpt == "" {
+		return issues
+	}
+	minSeverity, err := tflint.NewSeverity(minSeverityOpt)
+	if err != nil {
+		return issues
+	}
+	return issues.FilterBySeverity(minSeverity)
+}
+
+// ruleThresholds returns thresholds of rules set in the config. Rules without thresholds are not included.
+func ruleThresholds(config *tflint.Config) map[string]int {
+	ret := map[string]int{}
+	for name, rule := range config.Rules {
+		if rule.Threshold != nil {
+			ret[name] = *rule.Threshold
+		}
+	}
+	return ret
+}
+
+// filterByThresholds removes issues of rules whose number of issues does not exceed the threshold.
+// Issues are counted for each rule across all files, so the given issues must be all issues in the run.
+func filterByThresholds(issues tflint.Issues, thresholds map[string]int) tflint.Issues {
+	if len(thresholds) == 0 {
+		return issues
+	}
+
+	counts := map[string]int{}
+	for _, issue := range issues {
+		counts[issue.Rule.Name()]++
+	}
+
+	ret := tflint.Issues{}
+	for _, issue := range issues {
+		if threshold, exists := thresholds[issue.Rule.Name()]; exists && counts[issue.Rule.Name()] <= threshold {
+			continue
+		}
+		ret = append(ret, issue)
+	}
+	return ret
+}
+
 // Checks if the given issues contain severities above or equal to the given minimum failure opt. Defaults to true if an error occurs
 func exceedsMinimumFailure(issues tflint.Issues, minimumFailureOpt string) bool {
 	if minimumFailureOpt != "" {
@@ -11,9 +11,14 @@ import (
 	"os"
 	"os/exec"
 	"runtime"
+	"runtime/debug"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/terraform-linters/tflint/formatter"
 	"github.com/terraform-linters/tflint/tflint"
 )
 
@@ -25,16 +30,160 @@ type worker struct {
 	err    error
 }
 
+// workerError is an error occurred in a worker process.
+// The stderr of the worker is kept separately from the message, so that it can be output as details.
+type workerError struct {
+	dir    string
+	err    error
+	stderr []byte
+}
+
+func (e *workerError) Error() string {
+	return fmt.Sprintf("%s\n\n%s", e.Message(), e.stderr)
+}
+
+func (e *workerError) Unwrap() error {
+	return e.err
+}
+
+// Message returns the error message without the stderr
+func (e *workerError) Message() string {
+	return fmt.Sprintf("Failed to run in %s; %s", e.dir, e.err)
+}
+
+// Detail returns the stderr of the worker
+func (e *workerError) Detail() string {
+	return string(e.stderr)
+}
+
+// Dir returns the working directory of the worker
+func (e *workerError) Dir() string {
+	return e.dir
+}
+
+// errWorkerSkipped is the error of a directory that is not inspected because --max-issues is exceeded.
+var errWorkerSkipped = errors.New("skipped because the maximum number of issues is exceeded")
+
+// issueLimiter counts issues found by workers and tells not to start new workers once the count exceeds the limit.
+// Workers finish in parallel, so the count is atomic and the channel is closed only once.
+type issueLimiter struct {
+	max      int64
+	count    atomic.Int64
+	once     sync.Once
+	exceeded chan struct{}
+}
+
+// newIssueLimiter returns a limiter for the given limit. The limit is never exceeded if max is 0.
+func newIssueLimiter(max int) *issueLimiter {
+	return &issueLimiter{max: int64(max), exceeded: make(chan struct{})}
+}
+
+// add counts issues in the output of a worker. Outputs that cannot be parsed are not counted,
+// since they are reported as errors by the coordinator.
+func (l *issueLimiter) add(stdout []byte) {
+	if l.max <= 0 {
+		return
+	}
+	var result struct {
+		Issues []json.RawMessage `json:"issues"`
+	}
+	if err := json.Unmarshal(stdout, &result); err != nil {
+		return
+	}
+	if l.count.Add(int64(len(result.Issues))) > l.max {
+		l.once.Do(func() { close(l.exceeded) })
+	}
+}
+
+// workerResult is the output of a worker process
+type workerResult struct {
+	Issues       tflint.Issues `json:"issues"`
+	ScannedFiles []string      `json:"scanned_files"`
+	// Inspected is false if the directory is not a module or has no changed files.
+	Inspected bool `json:"inspected"`
+	// Rules are names of evaluated rules, so that the coordinator can count distinct rules in all directories.
+	Rules []string `json:"rules"`
+	// Thresholds are thresholds of rules in the config of the directory, so that the coordinator can
+	// count issues of each rule in all directories.
+	Thresholds map[string]int `json:"thresholds,omitempty"`
+	// MinimumFailureSeverity is minimum_failure_severity in the config of the directory, so that the coordinator
+	// can decide the exit status by issues in the directory.
+	MinimumFailureSeverity string `json:"minimum_failure_severity,omitempty"`
+	// Changes are files rewritten by --fix, so that the coordinator can print diffs of them.
+	Changes []tflint.FileChange `json:"changes,omitempty"`
+	// Panic is the message of a panic recovered in the worker, and Stack is the stack trace of it.
+	Panic string `json:"panic,omitempty"`
+	Stack string `json:"stack,omitempty"`
+}
+
+// runAsWorker runs the inspection as a worker process.
+// If the inspection panics, the panic is output as the result instead of crashing,
+// so that the coordinator can report it as an error of the directory and continue with others.
+func (cli *CLI) runAsWorker(inspect func() int) (status int) {
+	defer func() {
+		if r := recover(); r != nil {
+			out, err := json.Marshal(workerResult{Panic: fmt.Sprint(r), Stack: string(debug.Stack())})
+			if err != nil {
+				fmt.Fprint(cli.errStream, err)
+				status = ExitCodeError
+				return
+			}
+			fmt.Fprint(cli.outStream, string(out))
+			status = ExitCodeError
+		}
+	}()
+
+	return inspect()
+}
+
+// newWorkerPanicError returns an error for the panic in the worker.
+// The stack trace is only included in the details if debug logging is enabled.
+func newWorkerPanicError(dir string, result workerResult, stderr []byte) *workerError {
+	if debugLogEnabled() {
+		stderr = append(stderr, result.Stack...)
+	}
+	return &workerError{dir: dir, err: fmt.Errorf("panic: %s", result.Panic), stderr: stderr}
+}
+
+// debugLogEnabled returns true if TFLINT_LOG is DEBUG or more verbose
+func debugLogEnabled() bool {
+	level := strings.ToUpper(os.Getenv("TFLINT_LOG"))
+	return level == "DEBUG" || level == "TRACE"
+}
+
 func (cli *CLI) inspectParallel(opts Options) int {
-	workingDirs, err := findWorkingDirs(opts)
+	start := time.Now()
+	workingDirs, excluded, err := findWorkingDirs(opts)
 	if err != nil {
 		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to find workspaces; %w", err), map[string][]byte{})
 		return ExitCodeError
 	}
+	var skipped int
+	if opts.changedOnly() {
+		changedDirs, dependents, err := filterChangedDirs(opts, workingDirs)
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+			return ExitCodeError
+		}
+		skipped = len(workingDirs) - len(changedDirs)
+		workingDirs = changedDirs
+		opts.changedModuleDirs = dependents
+	}
+	cli.formatter.WorkingDirs = workingDirs
+	cli.formatter.ModuleDirs = workingDirs
+	if cli.fileList != nil {
+		path, err := writeFileList(cli.fileList)
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+			return ExitCodeError
+		}
+		defer os.Remove(path)
+		opts.FileList = path
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	go cli.registerShutdownHandler(cancel)
+	go cli.registerShutdownHandler(ctx, cancel)
 
 	workers, err := spawnWorkers(ctx, workingDirs, opts)
 	if err != nil {
@@ -43,16 +192,37 @@ func (cli *CLI) inspectParallel(opts Options) int {
 	}
 
 	issues := tflint.Issues{}
-	var canceled bool
+	scannedFiles := []string{}
+	evaluatedRules := []string{}
+	thresholds := map[string]int{}
+	// failOnSeverities are minimum failure severities in the config of the directory where each issue is found
+	failOnSeverities := map[*tflint.Issue]string{}
+	changes := []tflint.FileChange{}
+	stats := formatter.Statistics{ModulesSkipped: skipped, DirsExcluded: excluded}
+	var canceled, workerFailed, truncated bool
+	// streamed is the number of issues printed in streaming formats, which are limited by --max-issues as they are printed
+	var streamed int
+	// In streaming formats, results are printed as soon as each worker finishes.
+	// Baselines are generated from all issues, so results are not streamed in that case.
+	streaming := cli.formatter.IsStreaming() && !opts.GenerateBaseline
+	if !streaming || opts.Ordered {
+		// Otherwise, results are processed in the order of directories so that the output is deterministic.
+		// With --ordered, streaming formats also wait for all workers and then print results in that order.
+		workers = sortWorkers(workers)
+	}
 
 	for worker := range workers {
 		stdout, err := io.ReadAll(worker.stdout)
 		if err != nil {
+			workerFailed = true
+			stats.ModulesErrored++
 			cli.formatter.PrintErrorParallel(fmt.Errorf("Failed to read stdout in %s; %w", worker.dir, err), cli.sources)
 			continue
 		}
 		stderr, err := io.ReadAll(worker.stderr)
 		if err != nil {
+			workerFailed = true
+			stats.ModulesErrored++
 			cli.formatter.PrintErrorParallel(fmt.Errorf("Failed to read stderr in %s; %w", worker.dir, err), cli.sources)
 			continue
 		}
@@ -62,15 +232,64 @@ func (cli *CLI) inspectParallel(opts Options) int {
 				canceled = true
 				continue
 			}
+			if errors.Is(worker.err, errWorkerSkipped) {
+				truncated = true
+				continue
+			}
 
 			log.Printf("[DEBUG] Failed to run in %s; %s; stdout=%s", worker.dir, worker.err, stdout)
-			cli.formatter.PrintErrorParallel(fmt.Errorf("Failed to run in %s; %w\n\n%s", worker.dir, worker.err, stderr), cli.sources)
+			workerFailed = true
+			stats.ModulesErrored++
+			// If the worker panics, the panic is output as the result
+			var result workerResult
+			if err := json.Unmarshal(stdout, &result); err == nil && result.Panic != "" {
+				cli.formatter.PrintErrorParallel(newWorkerPanicError(worker.dir, result, stderr), cli.sources)
+				continue
+			}
+			cli.formatter.PrintErrorParallel(&workerError{dir: worker.dir, err: worker.err, stderr: stderr}, cli.sources)
 			continue
 		}
 
-		var workerIssues tflint.Issues
-		if err := json.Unmarshal(stdout, &workerIssues); err != nil {
-			panic(fmt.Errorf("failed to parse issues in %s; %s; stdout=%s; stderr=%s", worker.dir, err, stdout, stderr))
+		var result workerResult
+		if err := json.Unmarshal(stdout, &result); err != nil {
+			log.Printf("[DEBUG] Failed to parse the result in %s; %s; stdout=%s", worker.dir, err, stdout)
+			workerFailed = true
+			stats.ModulesErrored++
+			cli.formatter.PrintErrorParallel(&workerError{dir: worker.dir, err: fmt.Errorf("failed to parse the result; %w", err), stderr: stderr}, cli.sources)
+			continue
+		}
+		workerIssues := result.Issues
+		scannedFiles = append(scannedFiles, result.ScannedFiles...)
+		changes = append(changes, result.Changes...)
+		// If directories have different thresholds for the same rule, the lowest one is used
+		for name, threshold := range result.Thresholds {
+			if current, exists := thresholds[name]; !exists || threshold < current {
+				thresholds[name] = threshold
+			}
+		}
+		if result.Inspected {
+			stats.ModulesInspected++
+			evaluatedRules = append(evaluatedRules, result.Rules...)
+		}
+		if streaming {
+			workerIssues, err = cli.applyBaseline(workerIssues, opts)
+			if err != nil {
+				workerFailed = true
+				cli.formatter.PrintErrorParallel(err, cli.sources)
+				continue
+			}
+			printed := filterByMinSeverity(workerIssues, opts.MinSeverity)
+			if opts.MaxIssues > 0 {
+				if remaining := opts.MaxIssues - streamed; len(printed) > remaining {
+					printed = printed.Sort()[:remaining]
+					truncated = true
+				}
+				streamed += len(printed)
+			}
+			cli.formatter.PrintStream(printed)
+		}
+		for _, issue := range workerIssues {
+			failOnSeverities[issue] = result.MinimumFailureSeverity
 		}
 		issues = append(issues, workerIssues...)
 
@@ -85,22 +304,92 @@ func (cli *CLI) inspectParallel(opts Options) int {
 		return ExitCodeError
 	}
 
+	// Workers deduplicate issues in each directory, and this removes duplicates across directories
+	if !opts.NoDeduplicate {
+		issues = issues.Deduplicate()
+	}
+
 	var force bool
-	if opts.Force != nil {
-		force = *opts.Force
+	if exitZero := opts.exitZero(); exitZero != nil {
+		force = *exitZero
 	}
 
-	if err := cli.formatter.PrintParallel(issues, cli.sources); err != nil {
+	if opts.GenerateBaseline {
+		// Do not generate an incomplete baseline if some workers failed
+		if workerFailed {
+			cli.formatter.PrintParallel(tflint.Issues{}, cli.sources)
+			return ExitCodeError
+		}
+		return cli.generateBaseline(issues, opts)
+	}
+
+	if !streaming {
+		issues, err = cli.applyBaseline(issues, opts)
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, err, cli.sources)
+			return ExitCodeError
+		}
+	}
+	// Hidden issues are neither reported nor affect the exit status
+	issues = filterByMinSeverity(issues, opts.MinSeverity)
+
+	slices.Sort(scannedFiles)
+	cli.formatter.ScannedFiles = slices.Compact(scannedFiles)
+	slices.SortFunc(changes, func(a, b tflint.FileChange) int {
+		return strings.Compare(a.Filename, b.Filename)
+	})
+	cli.formatter.Changes = changes
+	slices.Sort(evaluatedRules)
+	stats.FilesInspected = len(cli.formatter.ScannedFiles)
+	stats.RulesEvaluated = len(slices.Compact(evaluatedRules))
+	cli.setStatistics(opts, stats, start)
+	// All issues still affect the exit status even if they are not reported
+	reported, truncatedOnPrint := truncateIssues(issues, opts.MaxIssues)
+	cli.formatter.IssuesTruncated = truncated || truncatedOnPrint
+	if err := cli.formatter.PrintParallel(reported, cli.sources); err != nil {
 		return ExitCodeError
 	}
 
-	if len(issues) > 0 && !force && exceedsMinimumFailure(issues, opts.MinimumFailureSeverity) {
+	// Issues within thresholds of rules do not affect the exit status
+	issues = filterByThresholds(issues, thresholds)
+
+	// The flag takes precedence over the config of each directory
+	exceeds := slices.ContainsFunc(issues, func(issue *tflint.Issue) bool {
+		failOnSeverity := cli.failOnSeverity
+		if failOnSeverity == "" {
+			failOnSeverity = failOnSeverities[issue]
+		}
+		return exceedsMinimumFailure(tflint.Issues{issue}, failOnSeverity)
+	})
+	if !force && exceeds {
 		return ExitCodeIssuesFound
 	}
 
 	return ExitCodeOK
 }
 
+// sortWorkers waits for all workers to finish and returns their results sorted by directory.
+// Errors in parallel workers are printed in the order they are received, so this makes them deterministic.
+func sortWorkers(workers <-chan worker) <-chan worker {
+	results := []worker{}
+	for worker := range workers {
+		results = append(results, worker)
+	}
+	slices.SortFunc(results, func(a, b worker) int {
+		return strings.Compare(a.dir, b.dir)
+	})
+
+	ch := make(chan worker, len(results))
+	for _, result := range results {
+		ch <- result
+	}
+	close(ch)
+	return ch
+}
+
+// workerWaitDelay is the time to wait for an interrupted worker to exit before killing it.
+const workerWaitDelay = 5 * time.Second
+
 // Spawn workers to run in parallel for each directory.
 // A worker is a process that runs itself as a child process.
 // The number of parallelism is controlled by --max-workers flag. The default is the number of CPUs.
@@ -119,6 +408,7 @@ func spawnWorkers(ctx context.Context, workingDirs []string, opts Options) (<-ch
 
 	ch := make(chan worker)
 	semaphore := make(chan struct{}, maxWorkers)
+	limiter := newIssueLimiter(opts.MaxIssues)
 
 	go func() {
 		defer close(ch)
@@ -128,7 +418,17 @@ func spawnWorkers(ctx context.Context, workingDirs []string, opts Options) (<-ch
 			wg.Add(1)
 			go func(wd string) {
 				defer wg.Done()
-				spawnWorker(ctx, self, wd, opts, ch, semaphore)
+				// Report the panic as an error of the directory so that other workers can continue
+				defer func() {
+					if r := recover(); r != nil {
+						stderr := new(bytes.Buffer)
+						if debugLogEnabled() {
+							stderr.Write(debug.Stack())
+						}
+						ch <- worker{dir: wd, stdout: new(bytes.Buffer), stderr: stderr, err: fmt.Errorf("panic: %v", r)}
+					}
+				}()
+				spawnWorker(ctx, self, wd, opts, ch, semaphore, limiter)
 			}(wd)
 		}
 		wg.Wait()
@@ -139,8 +439,9 @@ func spawnWorkers(ctx context.Context, workingDirs []string, opts Options) (<-ch
 
 // Spawn a worker process for the given directory.
 // When the process is complete, send the results to the given channel.
-// If the context is canceled, the started process will be interrupted.
-func spawnWorker(ctx context.Context, executable string, workingDir string, opts Options, ch chan<- worker, semaphore chan struct{}) {
+// If the context is canceled or the --dir-timeout is exceeded, the started process will be interrupted.
+// If the limiter is exceeded before the process is started, the directory is skipped. Started processes are not interrupted.
+func spawnWorker(ctx context.Context, executable string, workingDir string, opts Options, ch chan<- worker, semaphore chan struct{}, limiter *issueLimiter) {
 	// Blocks from exceeding the maximum number of workers
 	select {
 	case semaphore <- struct{}{}:
@@ -152,19 +453,41 @@ func spawnWorker(ctx context.Context, executable string, workingDir string, opts
 		ch <- worker{dir: workingDir, stdout: new(bytes.Buffer), stderr: new(bytes.Buffer), err: ctx.Err()}
 		return
 	}
+	select {
+	case <-limiter.exceeded:
+		log.Printf("[DEBUG] Worker in %s is skipped because the maximum number of issues is exceeded\n", workingDir)
+		ch <- worker{dir: workingDir, stdout: new(bytes.Buffer), stderr: new(bytes.Buffer), err: errWorkerSkipped}
+		return
+	default:
+	}
+
+	// The timeout starts when the worker is started, so waiting for other workers is not counted
+	workerCtx := ctx
+	if opts.DirTimeout != nil {
+		var cancel context.CancelFunc
+		workerCtx, cancel = context.WithTimeout(ctx, *opts.DirTimeout)
+		defer cancel()
+	}
 
 	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
-	cmd := exec.CommandContext(ctx, executable, opts.toWorkerCommands(workingDir)...)
+	cmd := exec.CommandContext(workerCtx, executable, opts.toWorkerCommands(workingDir)...)
 	cmd.Stdout, cmd.Stderr = stdout, stderr
+	// The worker stops plugin processes gracefully when interrupted.
+	// If it does not exit within the delay, it will be killed.
 	cmd.Cancel = func() error {
 		log.Printf("[DEBUG] Worker in %s is terminated\n", workingDir)
 		return cmd.Process.Signal(os.Interrupt)
 	}
-	cmd.WaitDelay = 3 * time.Second
+	cmd.WaitDelay = workerWaitDelay
 	err := cmd.Run()
 	if ctx.Err() != nil {
 		// If the context is canceled, return the context error instead of the command error.
 		err = ctx.Err()
+	} else if errors.Is(workerCtx.Err(), context.DeadlineExceeded) {
+		// Unlike cancellation, the timeout is reported as an error of the directory
+		err = fmt.Errorf("timed out after %s", *opts.DirTimeout)
+	} else if err == nil {
+		limiter.add(stdout.Bytes())
 	}
 
 	ch <- worker{dir: workingDir, stdout: stdout, stderr: stderr, err: err}
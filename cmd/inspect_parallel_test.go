@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/terraform-linters/tflint/formatter"
+)
+
+// runFakeWorker acts as a worker process of recursive inspection.
+// It panics in a directory named "panic" and reports main.tf as scanned in a directory named "ok".
+func runFakeWorker(args []string) int {
+	var dir string
+	for _, arg := range args {
+		if d, ok := strings.CutPrefix(arg, "--chdir="); ok {
+			dir = d
+		}
+	}
+
+	cli, err := NewCLI(os.Stdout, os.Stderr)
+	if err != nil {
+		fmt.Fprint(os.Stderr, err)
+		return ExitCodeError
+	}
+	return cli.runAsWorker(func() int {
+		if filepath.Base(dir) == "panic" {
+			panic("rule crashed")
+		}
+		result := workerResult{ScannedFiles: []string{}}
+		if filepath.Base(dir) == "ok" {
+			result = workerResult{ScannedFiles: []string{filepath.Join(dir, "main.tf")}, Inspected: true}
+		}
+		out, err := json.Marshal(result)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Fprint(cli.outStream, string(out))
+		return ExitCodeOK
+	})
+}
+
+func Test_inspectParallel_workerPanic(t *testing.T) {
+	t.Setenv("TFLINT_TEST_FAKE_WORKER", "1")
+
+	dir := t.TempDir()
+	for _, name := range []string{"ok", "panic"} {
+		if err := os.Mkdir(filepath.Join(dir, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		// Directories without configuration files are not inspected
+		if err := os.WriteFile(filepath.Join(dir, name, "main.tf"), []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		logLevel  string
+		withStack bool
+	}{
+		{
+			name:      "without debug logging",
+			logLevel:  "",
+			withStack: false,
+		},
+		{
+			name:      "with debug logging",
+			logLevel:  "debug",
+			withStack: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("TFLINT_LOG", test.logLevel)
+
+			stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+			cli, err := NewCLI(stdout, stderr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cli.formatter = &formatter.Formatter{Stdout: stdout, Stderr: stderr, Format: "json"}
+
+			status := cli.inspectParallel(Options{Recursive: true, Chdir: []string{dir}})
+			if status != ExitCodeError {
+				t.Errorf("expected exit status %d, but got %d", ExitCodeError, status)
+			}
+
+			var got formatter.JSONOutput
+			if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+				t.Fatalf("failed to parse the output: %s; stdout=%s", err, stdout)
+			}
+			// Results of other workers are output
+			if len(got.ScannedFiles) != 1 || got.ScannedFiles[0] != filepath.Join(dir, "ok", "main.tf") {
+				t.Errorf("expected main.tf in ok to be scanned, but got %v", got.ScannedFiles)
+			}
+			if len(got.Errors) != 1 {
+				t.Fatalf("expected an error, but got %d errors", len(got.Errors))
+			}
+			want := fmt.Sprintf("Failed to run in %s; panic: rule crashed", filepath.Join(dir, "panic"))
+			if got.Errors[0].Message != want {
+				t.Errorf("expected error message %q, but got %q", want, got.Errors[0].Message)
+			}
+			if withStack := strings.Contains(got.Errors[0].Detail, "runtime/debug.Stack"); withStack != test.withStack {
+				t.Errorf("expected the stack trace to be included: %t, but got detail %q", test.withStack, got.Errors[0].Detail)
+			}
+		})
+	}
+}
+
+func Test_issueLimiter(t *testing.T) {
+	output := func(n int) []byte {
+		issues := make([]string, n)
+		for i := range issues {
+			issues[i] = "{}"
+		}
+		return []byte(fmt.Sprintf(`{"issues":[%s]}`, strings.Join(issues, ",")))
+	}
+	exceeded := func(l *issueLimiter) bool {
+		select {
+		case <-l.exceeded:
+			return true
+		default:
+			return false
+		}
+	}
+
+	unlimited := newIssueLimiter(0)
+	unlimited.add(output(10))
+	if exceeded(unlimited) {
+		t.Fatal("the limit should never be exceeded if max is 0")
+	}
+
+	limiter := newIssueLimiter(10)
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			limiter.add(output(1))
+		}()
+	}
+	wg.Wait()
+	if exceeded(limiter) {
+		t.Fatal("the limit should not be exceeded by 10 issues")
+	}
+
+	limiter.add([]byte("invalid"))
+	if exceeded(limiter) {
+		t.Fatal("invalid outputs should not be counted")
+	}
+
+	// Closing the channel twice panics, so this also checks that it is closed only once
+	limiter.add(output(1))
+	limiter.add(output(1))
+	if !exceeded(limiter) {
+		t.Fatal("the limit should be exceeded by 12 issues")
+	}
+}
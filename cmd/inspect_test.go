@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+type testRule struct {
+	severity tflint.Severity
+}
+
+func (r *testRule) Name() string              { return "test_rule" }
+func (r *testRule) Enabled() bool             { return true }
+func (r *testRule) Severity() tflint.Severity { return r.severity }
+func (r *testRule) Link() string              { return "" }
+
+func Test_exceedsMinimumFailure(t *testing.T) {
+	tests := []struct {
+		severity  tflint.Severity
+		threshold string
+		want      bool
+	}{
+		{severity: sdk.ERROR, threshold: "", want: true},
+		{severity: sdk.ERROR, threshold: "error", want: true},
+		{severity: sdk.ERROR, threshold: "warning", want: true},
+		{severity: sdk.ERROR, threshold: "notice", want: true},
+		{severity: sdk.WARNING, threshold: "", want: true},
+		{severity: sdk.WARNING, threshold: "error", want: false},
+		{severity: sdk.WARNING, threshold: "warning", want: true},
+		{severity: sdk.WARNING, threshold: "notice", want: true},
+		{severity: sdk.NOTICE, threshold: "", want: true},
+		{severity: sdk.NOTICE, threshold: "error", want: false},
+		{severity: sdk.NOTICE, threshold: "warning", want: false},
+		{severity: sdk.NOTICE, threshold: "notice", want: true},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s issue with threshold %q", test.severity, test.threshold), func(t *testing.T) {
+			issues := tflint.Issues{{Rule: &testRule{severity: test.severity}, Message: "test"}}
+
+			got := exceedsMinimumFailure(issues, test.threshold)
+			if got != test.want {
+				t.Errorf("got %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_escapeGlob(t *testing.T) {
+	tests := []struct {
+		path  string
+		other string
+	}{
+		{path: "main.tf", other: "mainxtf"},
+		{path: "c*.tf", other: "cx.tf"},
+		{path: "c?.tf", other: "cx.tf"},
+		{path: "a[1].tf", other: "a1.tf"},
+		{path: "{b,c}.tf", other: "b.tf"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			filters := []string{escapeGlob(test.path)}
+
+			if !matchFilters(filters, test.path) {
+				t.Errorf("expected %s to match the escaped pattern %s", test.path, filters[0])
+			}
+			if matchFilters(filters, test.other) {
+				t.Errorf("expected %s not to match the escaped pattern %s", test.other, filters[0])
+			}
+		})
+	}
+}
+
+func Test_truncateIssues(t *testing.T) {
+	issues := tflint.Issues{
+		{Rule: &testRule{severity: sdk.ERROR}, Message: "b", Range: hcl.Range{Filename: "b.tf"}},
+		{Rule: &testRule{severity: sdk.ERROR}, Message: "a", Range: hcl.Range{Filename: "a.tf"}},
+	}
+
+	tests := []struct {
+		name      string
+		max       int
+		want      []string
+		truncated bool
+	}{
+		{name: "unlimited", max: 0, want: []string{"b", "a"}},
+		{name: "below the limit", max: 3, want: []string{"b", "a"}},
+		{name: "equal to the limit", max: 2, want: []string{"b", "a"}},
+		{name: "exceeds the limit", max: 1, want: []string{"a"}, truncated: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := make(tflint.Issues, len(issues))
+			copy(in, issues)
+
+			got, truncated := truncateIssues(in, test.max)
+			messages := make([]string, len(got))
+			for i, issue := range got {
+				messages[i] = issue.Message
+			}
+			if diff := cmp.Diff(test.want, messages); diff != "" {
+				t.Error(diff)
+			}
+			if truncated != test.truncated {
+				t.Errorf("got truncated=%t, want %t", truncated, test.truncated)
+			}
+		})
+	}
+}
+
+func Test_filterByThresholds(t *testing.T) {
+	issues := tflint.Issues{
+		{Rule: &testRule{severity: sdk.ERROR}, Message: "test", Range: hcl.Range{Filename: "a.tf"}},
+		{Rule: &testRule{severity: sdk.ERROR}, Message: "test", Range: hcl.Range{Filename: "b.tf"}},
+		{Rule: &testRule{severity: sdk.ERROR}, Message: "test", Range: hcl.Range{Filename: "c.tf"}},
+	}
+
+	tests := []struct {
+		name       string
+		thresholds map[string]int
+		want       int
+	}{
+		{name: "no thresholds", thresholds: map[string]int{}, want: 3},
+		{name: "below the threshold", thresholds: map[string]int{"test_rule": 4}, want: 0},
+		{name: "equal to the threshold", thresholds: map[string]int{"test_rule": 3}, want: 0},
+		{name: "exceeds the threshold", thresholds: map[string]int{"test_rule": 2}, want: 3},
+		{name: "zero threshold", thresholds: map[string]int{"test_rule": 0}, want: 3},
+		{name: "threshold of another rule", thresholds: map[string]int{"other_rule": 5}, want: 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := filterByThresholds(issues, test.thresholds)
+			if len(got) != test.want {
+				t.Errorf("got %d issues, want %d", len(got), test.want)
+			}
+		})
+	}
+}
+
+// namedRule is a test rule with the given name
+type namedRule struct {
+	testRule
+	name string
+}
+
+func (r *namedRule) Name() string { return r.name }
+
+func Test_markFixed_conflicts(t *testing.T) {
+	runner := tflint.TestRunner(t, map[string]string{"main.tf": "locals {\n  a = 1\n  c = 3\n  b = 2\n}\n"})
+	ruleA, ruleB := &namedRule{name: "rule_a"}, &namedRule{name: "rule_b"}
+	lineRange := func(line int) hcl.Range {
+		return hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: line}, End: hcl.Pos{Line: line}}
+	}
+
+	// rule_a fixes lines 2 and 4, and rule_b fixes line 4 again, which conflicts only with the second fix of rule_a
+	runner.EmitIssue(ruleA, "a", lineRange(2), true)
+	runner.EmitIssue(ruleA, "b", lineRange(4), true)
+	if diags := runner.ApplyChanges(map[string][]byte{"main.tf": []byte("locals {\n  a = 10\n  c = 3\n  b = 20\n}\n")}); diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	runner.EmitIssue(ruleB, "b", lineRange(4), true)
+	if diags := runner.ApplyChanges(map[string][]byte{"main.tf": []byte("locals {\n  a = 10\n  c = 3\n  b = 200\n}\n")}); diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	if _, diags := runner.ResolveFixConflicts(); diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	changes := runner.LookupChanges()
+	if diff := cmp.Diff("locals {\n  a = 10\n  c = 3\n  b = 2\n}\n", string(changes["main.tf"])); diff != "" {
+		t.Fatalf("the non-conflicting fix should be applied: %s", diff)
+	}
+
+	issues := runner.LookupIssues()
+	fixesApplied := map[*tflint.Issue]bool{}
+	for _, issue := range issues {
+		fixesApplied[issue] = fixApplied(runner, issue)
+	}
+	markFixed(issues, changes, fixesApplied)
+
+	got := map[string]bool{}
+	for _, issue := range issues {
+		got[issue.Rule.Name()+": "+issue.Message] = issue.Fixed
+	}
+	want := map[string]bool{"rule_a: a": true, "rule_a: b": false, "rule_b: b": false}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error(diff)
+	}
+}
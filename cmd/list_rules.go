@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/terraform-linters/tflint/plugin"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// Rule statuses printed by --list-rules.
+// Plugins do not expose whether each rule is enabled by default,
+// so rules that are not configured are printed as "default".
+const (
+	ruleStatusEnabled  = "enabled"
+	ruleStatusDisabled = "disabled"
+	ruleStatusDefault  = "default"
+)
+
+type listedRule struct {
+	Plugin string `json:"plugin"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// listRules prints rules provided by plugins enabled in the config.
+// Rules are printed as a table, or as a JSON array in the json format.
+func (cli *CLI) listRules(opts Options) int {
+	var rules []*listedRule
+	var format string
+	err := cli.withinChangedDir(opts.chdir(), func() error {
+		cfg, err := loadConfig(opts)
+		if err != nil {
+			return fmt.Errorf("Failed to load TFLint config; %w", err)
+		}
+		cfg.Merge(opts.toConfig())
+		format = cfg.Format
+
+		rules, err = getRules(cfg)
+		return err
+	})
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+		return ExitCodeError
+	}
+
+	if opts.EnabledOnly {
+		rules = slices.DeleteFunc(rules, func(rule *listedRule) bool {
+			return rule.Status == ruleStatusDisabled
+		})
+	}
+
+	if format == "json" {
+		out, err := json.Marshal(rules)
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to print rules; %w", err), map[string][]byte{})
+			return ExitCodeError
+		}
+		fmt.Fprintln(cli.outStream, string(out))
+		return ExitCodeOK
+	}
+
+	w := tabwriter.NewWriter(cli.outStream, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PLUGIN\tRULE\tSTATUS")
+	for _, rule := range rules {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", rule.Plugin, rule.Name, rule.Status)
+	}
+	if err := w.Flush(); err != nil {
+		log.Printf("[ERROR] Failed to print rules: %s", err)
+	}
+
+	return ExitCodeOK
+}
+
+func getRules(cfg *tflint.Config) ([]*listedRule, error) {
+	rulesetPlugin, err := plugin.Discovery(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to initialize plugins; %w", err)
+	}
+	defer rulesetPlugin.Clean()
+
+	rules := []*listedRule{}
+	for _, ruleset := range rulesetPlugin.RuleSets {
+		name, err := ruleset.RuleSetName()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get ruleset name; %w", err)
+		}
+		ruleNames, err := ruleset.RuleNames()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get rule names from `%s` plugin; %w", name, err)
+		}
+
+		for _, ruleName := range ruleNames {
+			rules = append(rules, &listedRule{Plugin: name, Name: ruleName, Status: ruleStatus(cfg, ruleName)})
+		}
+	}
+
+	slices.SortFunc(rules, func(a, b *listedRule) int {
+		if c := strings.Compare(a.Plugin, b.Plugin); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return rules, nil
+}
+
+// ruleStatus returns whether the rule is enabled according to the config.
+// The precedence is the same as that of plugins: --only, rule blocks, and disabled_by_default.
+func ruleStatus(cfg *tflint.Config, name string) string {
+	if len(cfg.Only) > 0 {
+		if slices.Contains(cfg.Only, name) {
+			return ruleStatusEnabled
+		}
+		return ruleStatusDisabled
+	}
+	if rule, exists := cfg.Rules[name]; exists {
+		if rule.Enabled {
+			return ruleStatusEnabled
+		}
+		return ruleStatusDisabled
+	}
+	if cfg.DisabledByDefault {
+		return ruleStatusDisabled
+	}
+	return ruleStatusDefault
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/go-version"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// migrateConfig rewrites the config file written for an older version of TFLint.
+// Without --from, all migrations are applied. Without --to, migrations up to the current version are applied.
+func (cli *CLI) migrateConfig(opts Options) int {
+	file := opts.Config
+	if file == "" {
+		file = ".tflint.hcl"
+	}
+
+	from, err := parseMigrationVersion("--from", opts.MigrateConfig.From, "0.0.0")
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+		return ExitCodeError
+	}
+	to, err := parseMigrationVersion("--to", opts.MigrateConfig.To, tflint.Version.String())
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+		return ExitCodeError
+	}
+
+	var warnings []string
+	var changed bool
+	err = cli.withinChangedDir(opts.chdir(), func() error {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("Failed to read the config file; %w", err)
+		}
+		var migrated []byte
+		migrated, warnings, err = tflint.MigrateConfig(src, file, from, to)
+		if err != nil {
+			return fmt.Errorf("Failed to migrate the config file; %w", err)
+		}
+		if bytes.Equal(src, migrated) {
+			return nil
+		}
+		changed = true
+
+		info, err := os.Stat(file)
+		if err != nil {
+			return fmt.Errorf("Failed to write the config file; %w", err)
+		}
+		if err := os.WriteFile(file, migrated, info.Mode().Perm()); err != nil {
+			return fmt.Errorf("Failed to write the config file; %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+		return ExitCodeError
+	}
+
+	if to.GreaterThan(tflint.Version) {
+		fmt.Fprintf(cli.errStream, "Warning: This version of TFLint only knows migrations up to v%s\n", tflint.Version)
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(cli.errStream, "Warning: %s\n", warning)
+	}
+	if changed {
+		fmt.Fprintf(cli.outStream, "Migrated %s\n", file)
+	} else {
+		fmt.Fprintf(cli.outStream, "%s is already up to date\n", file)
+	}
+	return ExitCodeOK
+}
+
+func parseMigrationVersion(flag string, value string, defaultValue string) (*version.Version, error) {
+	if value == "" {
+		value = defaultValue
+	}
+	v, err := version.NewVersion(value)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s: %s; %w", flag, value, err)
+	}
+	return v, nil
+}
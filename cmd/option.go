@@ -3,7 +3,9 @@ package cmd
 import (
 	"fmt"
 	"log"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/terraform-linters/tflint/terraform"
 	"github.com/terraform-linters/tflint/tflint"
@@ -11,31 +13,219 @@ import (
 
 // Options is an option specified by arguments.
 type Options struct {
-	Version                bool     `short:"v" long:"version" description:"Print TFLint version"`
-	Init                   bool     `long:"init" description:"Install plugins"`
-	Langserver             bool     `long:"langserver" description:"Start language server"`
-	Format                 string   `short:"f" long:"format" description:"Output format" choice:"default" choice:"json" choice:"checkstyle" choice:"junit" choice:"compact" choice:"sarif"`
-	Config                 string   `short:"c" long:"config" description:"Config file name (default: .tflint.hcl)" value-name:"FILE"`
-	IgnoreModules          []string `long:"ignore-module" description:"Ignore module sources" value-name:"SOURCE"`
-	EnableRules            []string `long:"enable-rule" description:"Enable rules from the command line" value-name:"RULE_NAME"`
-	DisableRules           []string `long:"disable-rule" description:"Disable rules from the command line" value-name:"RULE_NAME"`
-	Only                   []string `long:"only" description:"Enable only this rule, disabling all other defaults. Can be specified multiple times" value-name:"RULE_NAME"`
-	EnablePlugins          []string `long:"enable-plugin" description:"Enable plugins from the command line" value-name:"PLUGIN_NAME"`
-	Varfiles               []string `long:"var-file" description:"Terraform variable file name" value-name:"FILE"`
-	Variables              []string `long:"var" description:"Set a Terraform variable" value-name:"'foo=bar'"`
-	CallModuleType         *string  `long:"call-module-type" description:"Types of module to call (default: local)" choice:"all" choice:"local" choice:"none"`
-	Chdir                  string   `long:"chdir" description:"Switch to a different working directory before executing the command" value-name:"DIR"`
-	Recursive              bool     `long:"recursive" description:"Run command in each directory recursively"`
-	Filter                 []string `long:"filter" description:"Filter issues by file names or globs" value-name:"FILE"`
-	Force                  *bool    `long:"force" description:"Return zero exit status even if issues found"`
-	MinimumFailureSeverity string   `long:"minimum-failure-severity" description:"Sets minimum severity level for exiting with a non-zero error code" choice:"error" choice:"warning" choice:"notice"`
-	Color                  bool     `long:"color" description:"Enable colorized output"`
-	NoColor                bool     `long:"no-color" description:"Disable colorized output"`
-	Fix                    bool     `long:"fix" description:"Fix issues automatically"`
-	NoParallelRunners      bool     `long:"no-parallel-runners" description:"Disable per-runner parallelism"`
-	MaxWorkers             *int     `long:"max-workers" description:"Set maximum number of workers in recursive inspection (default: number of CPUs)" value-name:"N"`
-	ActAsBundledPlugin     bool     `long:"act-as-bundled-plugin" hidden:"true"`
-	ActAsWorker            bool     `long:"act-as-worker" hidden:"true"`
+	Version                bool           `short:"v" long:"version" description:"Print TFLint version. Use with --format=json for machine-readable output"`
+	Init                   bool           `long:"init" description:"Install plugins"`
+	Langserver             bool           `long:"langserver" description:"Start language server"`
+	PrintConfig            bool           `long:"print-config" description:"Print the effective config merged from the config file and CLI flags as JSON"`
+	ListRules              bool           `long:"list-rules" description:"Print rules provided by enabled plugins. Printed as JSON with --format=json"`
+	EnabledOnly            bool           `long:"enabled-only" description:"Print only rules that are not disabled with --list-rules"`
+	Format                 string         `short:"f" long:"format" description:"Output format" choice:"default" choice:"json" choice:"checkstyle" choice:"junit" choice:"compact" choice:"sarif" choice:"gitlab" choice:"github" choice:"rdjson" choice:"tap" choice:"csv" choice:"markdown" choice:"html" choice:"azure-devops" choice:"teamcity" choice:"sonarqube" choice:"template" choice:"jsonl" choice:"stream" choice:"unix"`
+	FormatTemplate         string         `long:"format-template" description:"Go template to output results in the template format" value-name:"TEMPLATE"`
+	FormatTemplateFile     string         `long:"format-template-file" description:"File of Go template to output results in the template format" value-name:"FILE"`
+	FormatOptions          []string       `long:"format-option" description:"Set an option of the output format. Can be specified multiple times" value-name:"key=value"`
+	SonarQubeSeverities    []string       `long:"sonarqube-severity" description:"Map a severity to SonarQube severity in the sonarqube format. Can be specified multiple times" value-name:"notice=INFO"`
+	OutputFile             string         `short:"o" long:"output-file" description:"Write the report to the file. Issues are also printed to stdout in the default format" value-name:"PATH"`
+	ReportFile             string         `long:"report-file" description:"Also write the report to the file in the format: json (default), sarif, or junit. Issues are still printed to stdout in the format given by --format" value-name:"PATH[,format=FORMAT]"`
+	Config                 string         `short:"c" long:"config" description:"Config file name (default: .tflint.hcl)" value-name:"FILE"`
+	NoConfigDiscovery      bool           `long:"no-config-discovery" description:"Do not search parent directories for .tflint.hcl"`
+	IgnoreModules          []string       `long:"ignore-module" description:"Ignore module sources" value-name:"SOURCE"`
+	EnableRules            []string       `long:"enable-rule" description:"Enable rules from the command line" value-name:"RULE_NAME"`
+	DisableRules           []string       `long:"disable-rule" description:"Disable rules from the command line" value-name:"RULE_NAME"`
+	Only                   []string       `long:"only" description:"Enable only this rule, disabling all other defaults. Can be specified multiple times" value-name:"RULE_NAME"`
+	Rules                  []string       `long:"rule" description:"Enable the rule. If no config file is found, all other rules are disabled. Can be specified multiple times" value-name:"RULE_NAME"`
+	DisabledByDefault      bool           `long:"disabled-by-default" description:"Disable all rules unless explicitly enabled in the config file or the command line"`
+	EnablePlugins          []string       `long:"enable-plugin" description:"Enable plugins from the command line" value-name:"PLUGIN_NAME"`
+	Varfiles               []string       `long:"var-file" description:"Terraform variable file name" value-name:"FILE"`
+	Variables              []string       `long:"var" description:"Set a Terraform variable" value-name:"'foo=bar'"`
+	CallModuleType         *string        `long:"call-module-type" description:"Types of module to call (default: local)" choice:"all" choice:"local" choice:"none"`
+	Chdir                  []string       `long:"chdir" description:"Switch to a different working directory before executing the command. Can be specified multiple times to inspect each directory and merge the results" value-name:"DIR"`
+	Recursive              bool           `long:"recursive" description:"Run command in each directory recursively"`
+	MaxDepth               *int           `long:"max-depth" description:"Set maximum depth of directories to inspect in recursive inspection (default: unlimited)" value-name:"N"`
+	FollowSymlinks         bool           `long:"follow-symlinks" description:"Follow symlinks to directories in recursive inspection. Directories reachable by multiple paths are inspected once"`
+	ExcludeDirs            []string       `long:"exclude-dir" description:"Skip directories matching the glob, relative to the base directory, in recursive inspection" value-name:"GLOB"`
+	NoIgnore               bool           `long:"no-ignore" description:"Do not read .tflintignore files. Patterns in TFLINT_IGNORE are still applied"`
+	NoTflintignore         bool           `long:"no-tflintignore" description:"Deprecated alias of --no-ignore"`
+	Ordered                bool           `long:"ordered" description:"Print results in the order of directories in recursive inspection, even in streaming formats"`
+	Filter                 []string       `long:"filter" description:"Filter issues by file names or globs" value-name:"FILE"`
+	FileList               string         `long:"file-list" description:"Filter issues by files listed in the file, one per line. Use - to read from stdin" value-name:"PATH"`
+	ChangedOnly            string         `long:"changed-only" description:"Report issues only in files changed from the default branch in the git repository. --changed-only=REF is the same as --changed-only --base-ref=REF" optional:"yes" optional-value:"HEAD" value-name:"REF"`
+	BaseRef                string         `long:"base-ref" description:"Report issues in files changed from the merge base with the ref instead of the default branch in --changed-only mode" value-name:"REF"`
+	Stdin                  bool           `long:"stdin" description:"Read the content of the file from stdin, e.g. an unsaved buffer in editors. Other files in the module are read from disk, and only issues in the file are reported"`
+	Filename               string         `long:"filename" description:"File name of the configuration read from stdin (default: stdin.tf)" value-name:"NAME"`
+	StdinFilename          string         `long:"stdin-filename" description:"Alias of --stdin --filename=NAME" value-name:"NAME"`
+	NoDeduplicate          bool           `long:"no-deduplicate" description:"Report all issues even if the same message is reported at the same position multiple times"`
+	MaxIssues              int            `long:"max-issues" description:"Report only the first N issues. In recursive inspection, no more directories are inspected once more than N issues are found (default: 0, unlimited)" value-name:"N"`
+	ExitZero               *bool          `long:"exit-zero" description:"Return zero exit status even if issues found. Errors still return a non-zero exit status"`
+	Force                  *bool          `long:"force" description:"Deprecated alias of --exit-zero"`
+	MinSeverity            string         `long:"min-severity" description:"Hide issues below this severity level (default: notice)" choice:"error" choice:"warning" choice:"notice"`
+	MinimumReportSeverity  string         `long:"minimum-report-severity" description:"Hide issues below this severity level in output. Unlike --min-severity, hidden issues still affect the exit status" choice:"error" choice:"warning" choice:"notice"`
+	MinimumFailureSeverity string         `long:"minimum-failure-severity" description:"Sets minimum severity level for exiting with a non-zero error code" choice:"error" choice:"warning" choice:"notice"`
+	FailOnSeverity         string         `long:"fail-on-severity" description:"Alias for --minimum-failure-severity. Takes precedence if both are set" choice:"error" choice:"warning" choice:"notice"`
+	Baseline               string         `long:"baseline" description:"Suppress issues recorded in the baseline file" value-name:"FILE"`
+	GenerateBaseline       bool           `long:"generate-baseline" description:"Write current issues to the baseline file (default: tflint-baseline.json) instead of reporting them"`
+	Color                  string         `long:"color" description:"Colorize output when the output is a terminal and TERM is not dumb (auto), always, or never. NO_COLOR disables colors in auto. --color without a value is the same as --color=always (default: auto)" choice:"auto" choice:"always" choice:"never" optional:"yes" optional-value:"always" value-name:"WHEN"`
+	NoColor                bool           `long:"no-color" description:"Alias of --color=never"`
+	NoSummary              bool           `long:"no-summary" description:"Hide the summary of issues at the end of the default format"`
+	GroupByFile            bool           `long:"group-by-file" description:"Group issues by file in the default format"`
+	CompactLinks           bool           `long:"compact-links" description:"Append rule links to issues in the compact format"`
+	CompactRanges          bool           `long:"compact-ranges" description:"Print the end line and column of issues in the compact format"`
+	QuietSuccess           bool           `long:"quiet-success" description:"Print nothing to stdout in the default and compact formats if no issues are reported and no errors occurred"`
+	Statistics             bool           `long:"statistics" description:"Output statistics of the inspection, such as the number of inspected files, in the default and json formats"`
+	PathMode               string         `long:"path-mode" description:"Print paths of issues relative to the current directory, relative to each inspected module, or as absolute paths (default: from-cwd)" choice:"from-cwd" choice:"relative" choice:"absolute"`
+	JSONVersion            string         `long:"json-version" description:"Pin the schema version of the json format (default: latest)" value-name:"VERSION"`
+	Context                int            `long:"context" description:"Print N lines of source before and after issue ranges in the default, compact, and json formats" value-name:"N"`
+	SnippetContext         int            `long:"snippet-context" description:"Deprecated alias of --context" value-name:"N"`
+	IncludeSource          bool           `long:"include-source" description:"Include the source of issue ranges as \"snippet\" in the json, jsonl, and stream formats"`
+	SourceMaxLength        *int           `long:"source-max-length" description:"Truncate snippets of --include-source to N bytes (default: 1000)" value-name:"N"`
+	Fix                    bool           `long:"fix" description:"Fix issues automatically"`
+	FixRules               []string       `long:"fix-rule" description:"With --fix, apply autofixes of only the rule. Issues of other rules are reported without fixes" value-name:"RULE_NAME"`
+	Interactive            bool           `long:"interactive" description:"With --fix, show the diff of each fix and confirm it before applying. Only works when stdout is a terminal"`
+	DryRun                 bool           `long:"dry-run" description:"With --fix, print a unified diff of fixes to stdout instead of rewriting files"`
+	DiffMaxLines           *int           `long:"diff-max-lines" description:"Truncate diffs of files rewritten by --fix to N lines in the default and json formats (default: 200)" value-name:"N"`
+	NoParallelRunners      bool           `long:"no-parallel-runners" description:"Disable per-runner parallelism"`
+	MaxWorkers             *int           `long:"max-workers" description:"Set maximum number of workers in recursive inspection (default: number of CPUs)" value-name:"N"`
+	DirTimeout             *time.Duration `long:"dir-timeout" description:"Set maximum time to inspect each directory in recursive inspection (default: unlimited)" value-name:"DURATION"`
+	Profile                string         `long:"profile" description:"Write CPU and heap profiles to PATH.cpu.pprof and PATH.mem.pprof. In recursive inspection, workers write profiles to PATH-<dir>" value-name:"PATH"`
+	Watch                  bool           `long:"watch" description:"Re-run inspection when .tf or .tfvars files are changed"`
+	WatchDebounce          *time.Duration `long:"watch-debounce" description:"Set time to wait for changes to settle in watch mode (default: 300ms)" value-name:"DURATION"`
+	ActAsBundledPlugin     bool           `long:"act-as-bundled-plugin" hidden:"true"`
+	ActAsWorker            bool           `long:"act-as-worker" hidden:"true"`
+	IgnoreBase             string         `long:"ignore-base" hidden:"true"`
+
+	GenerateConfig GenerateConfigOptions `command:"generate-config" description:"Generate a config file in which all rules are commented out"`
+	ValidateConfig ValidateConfigOptions `command:"validate-config" description:"Validate the config file and rule names"`
+	MigrateConfig  MigrateConfigOptions  `command:"migrate-config" description:"Rewrite the config file written for an older version of TFLint"`
+
+	// excludeDirs are patterns of --exclude-dir and exclude_dirs in the config file, which are merged by Run
+	excludeDirs []string
+	// skipDirs are patterns of skip_dirs in the config file, which are set by Run
+	skipDirs []string
+
+	// changedModuleDirs are working directories that are inspected in recursive --changed-only mode
+	// only because their local modules are changed. Issues in these directories are not filtered by changed files.
+	changedModuleDirs map[string]bool
+}
+
+// GenerateConfigOptions is an option of the generate-config subcommand.
+type GenerateConfigOptions struct {
+	Output    string `long:"output" description:"File name to write (default: .tflint.hcl)" value-name:"FILE"`
+	Overwrite bool   `long:"overwrite" description:"Overwrite the existing file"`
+}
+
+// ValidateConfigOptions is an option of the validate-config subcommand.
+type ValidateConfigOptions struct {
+	AllowUnknownRules bool `long:"allow-unknown-rules" description:"Report rules not provided by any plugin as warnings instead of errors"`
+}
+
+// MigrateConfigOptions is an option of the migrate-config subcommand.
+type MigrateConfigOptions struct {
+	From string `long:"from" description:"Version of TFLint the config file is written for (default: all migrations are applied)" value-name:"VERSION"`
+	To   string `long:"to" description:"Version of TFLint to migrate the config file to (default: current version)" value-name:"VERSION"`
+}
+
+// exitZero returns the value of --exit-zero, or --force if --exit-zero is not set.
+// It is nil if neither is set, so that the config file can set it.
+func (opts *Options) exitZero() *bool {
+	if opts.ExitZero != nil {
+		return opts.ExitZero
+	}
+	return opts.Force
+}
+
+// noIgnore returns true if --no-ignore or --no-tflintignore is set.
+func (opts *Options) noIgnore() bool {
+	return opts.NoIgnore || opts.NoTflintignore
+}
+
+// minimumFailureSeverity returns the value of --fail-on-severity, or --minimum-failure-severity if it is not set.
+func (opts *Options) minimumFailureSeverity() string {
+	if opts.FailOnSeverity != "" {
+		return opts.FailOnSeverity
+	}
+	return opts.MinimumFailureSeverity
+}
+
+// chdir returns the directory given by --chdir, or an empty string if not set.
+// If --chdir is given multiple times, it is also an empty string since each directory is inspected by a worker.
+func (opts *Options) chdir() string {
+	if len(opts.Chdir) == 1 {
+		return opts.Chdir[0]
+	}
+	return ""
+}
+
+// multipleChdirs returns true if --chdir is given multiple times.
+func (opts *Options) multipleChdirs() bool {
+	return len(opts.Chdir) > 1
+}
+
+// changedOnly returns true if --changed-only is set.
+func (opts *Options) changedOnly() bool {
+	return opts.ChangedOnly != ""
+}
+
+// baseRef returns the value of --base-ref, or the ref given by --changed-only=REF.
+// It is an empty string if changes are compared with the default branch.
+func (opts *Options) baseRef() string {
+	if opts.BaseRef != "" {
+		return opts.BaseRef
+	}
+	if opts.ChangedOnly != "HEAD" {
+		return opts.ChangedOnly
+	}
+	return ""
+}
+
+// stdin returns true if the configuration is read from stdin. --stdin-filename implies --stdin.
+func (opts *Options) stdin() bool {
+	return opts.Stdin || opts.StdinFilename != ""
+}
+
+// stdinFilename returns the name of the file read from stdin.
+func (opts *Options) stdinFilename() string {
+	switch {
+	case opts.StdinFilename != "":
+		return opts.StdinFilename
+	case opts.Filename != "":
+		return opts.Filename
+	default:
+		return "stdin.tf"
+	}
+}
+
+// stdinFlag returns the flag that reads configuration from stdin, or an empty string if not set.
+func (opts *Options) stdinFlag() string {
+	switch {
+	case opts.Stdin:
+		return "--stdin"
+	case opts.StdinFilename != "":
+		return "--stdin-filename"
+	default:
+		return ""
+	}
+}
+
+// reportFileFormats are formats that can be written by --report-file
+var reportFileFormats = []string{"json", "sarif", "junit"}
+
+// reportFile returns the path and format of --report-file. The format is json if not specified.
+// Only the last comma is a separator, so that paths can contain commas.
+func (opts *Options) reportFile() (string, string, error) {
+	path, format := opts.ReportFile, "json"
+	if i := strings.LastIndex(opts.ReportFile, ","); i >= 0 {
+		value, ok := strings.CutPrefix(opts.ReportFile[i+1:], "format=")
+		if !ok {
+			return "", "", fmt.Errorf(`Invalid --report-file "%s". The format must be specified as "PATH,format=FORMAT"`, opts.ReportFile)
+		}
+		path, format = opts.ReportFile[:i], value
+	}
+	if path == "" {
+		return "", "", fmt.Errorf(`Invalid --report-file "%s". The path must not be empty`, opts.ReportFile)
+	}
+	if !slices.Contains(reportFileFormats, format) {
+		return "", "", fmt.Errorf(`Report file format "%s" is not supported. Supported formats: %s`, format, strings.Join(reportFileFormats, ", "))
+	}
+	return path, format, nil
 }
 
 func (opts *Options) toConfig() *tflint.Config {
@@ -69,20 +259,30 @@ func (opts *Options) toConfig() *tflint.Config {
 	}
 
 	var force, forceSet bool
-	if opts.Force != nil {
-		force = *opts.Force
+	if exitZero := opts.exitZero(); exitZero != nil {
+		force = *exitZero
 		forceSet = true
 	}
 
+	minimumFailureSeverity := opts.minimumFailureSeverity()
+
+	// --only implies that all other rules are disabled
+	disabledByDefault := len(opts.Only) > 0 || opts.DisabledByDefault
+
 	log.Printf("[DEBUG] CLI Options")
 	log.Printf("[DEBUG]   CallModuleType: %s", callModuleType)
 	log.Printf("[DEBUG]   Force: %t", force)
 	log.Printf("[DEBUG]   Format: %s", opts.Format)
+	log.Printf("[DEBUG]   OutputFile: %s", opts.OutputFile)
+	log.Printf("[DEBUG]   ReportFile: %s", opts.ReportFile)
+	log.Printf("[DEBUG]   MinimumFailureSeverity: %s", minimumFailureSeverity)
 	log.Printf("[DEBUG]   Varfiles: %s", strings.Join(opts.Varfiles, ", "))
 	log.Printf("[DEBUG]   Variables: %s", strings.Join(opts.Variables, ", "))
 	log.Printf("[DEBUG]   EnableRules: %s", strings.Join(opts.EnableRules, ", "))
 	log.Printf("[DEBUG]   DisableRules: %s", strings.Join(opts.DisableRules, ", "))
 	log.Printf("[DEBUG]   Only: %s", strings.Join(opts.Only, ", "))
+	log.Printf("[DEBUG]   Rules: %s", strings.Join(opts.Rules, ", "))
+	log.Printf("[DEBUG]   DisabledByDefault: %t", opts.DisabledByDefault)
 	log.Printf("[DEBUG]   EnablePlugins: %s", strings.Join(opts.EnablePlugins, ", "))
 	log.Printf("[DEBUG]   IgnoreModules:")
 	for name, ignore := range ignoreModules {
@@ -90,7 +290,7 @@ func (opts *Options) toConfig() *tflint.Config {
 	}
 
 	rules := map[string]*tflint.RuleConfig{}
-	for _, rule := range append(opts.Only, opts.EnableRules...) {
+	for _, rule := range slices.Concat(opts.Only, opts.EnableRules, opts.Rules) {
 		rules[rule] = &tflint.RuleConfig{
 			Name:    rule,
 			Enabled: true,
@@ -128,8 +328,17 @@ func (opts *Options) toConfig() *tflint.Config {
 		Format:    opts.Format,
 		FormatSet: opts.Format != "",
 
-		DisabledByDefault:    len(opts.Only) > 0,
-		DisabledByDefaultSet: len(opts.Only) > 0,
+		OutputFile:    opts.OutputFile,
+		OutputFileSet: opts.OutputFile != "",
+
+		MinimumFailureSeverity:    minimumFailureSeverity,
+		MinimumFailureSeveritySet: minimumFailureSeverity != "",
+
+		DisabledByDefault:    disabledByDefault,
+		DisabledByDefaultSet: disabledByDefault,
+
+		// --rule acts as an allowlist if no config file is found
+		DisabledByDefaultWithoutFile: len(opts.Rules) > 0,
 
 		Varfiles:      varfiles,
 		Variables:     opts.Variables,
@@ -137,6 +346,8 @@ func (opts *Options) toConfig() *tflint.Config {
 		IgnoreModules: ignoreModules,
 		Rules:         rules,
 		Plugins:       plugins,
+
+		ExcludeDirs: opts.ExcludeDirs,
 	}
 }
 
@@ -147,16 +358,19 @@ func (opts *Options) toWorkerCommands(workingDir string) []string {
 	commands := []string{
 		"--act-as-worker",
 		"--chdir=" + workingDir,
-		"--force", // Exit status is always ignored
+		"--exit-zero", // Exit status is always ignored
 	}
 
-	// opts.Version, opts.Init, and opts.Langserver are not supported
+	// opts.Version, opts.Init, opts.Langserver, opts.PrintConfig, opts.ListRules, opts.EnabledOnly, opts.GenerateConfig, opts.ValidateConfig, and opts.MigrateConfig are not supported
 
-	// opt.Format is ignored because workers always output serialized issues
+	// opt.Format, opts.FormatOptions, opts.FormatTemplate, opts.FormatTemplateFile, opts.SonarQubeSeverities, opts.OutputFile, and opts.ReportFile are ignored because workers always output serialized issues
 
 	if opts.Config != "" {
 		commands = append(commands, fmt.Sprintf("--config=%s", opts.Config))
 	}
+	if opts.NoConfigDiscovery {
+		commands = append(commands, "--no-config-discovery")
+	}
 	for _, ignoreModule := range opts.IgnoreModules {
 		commands = append(commands, fmt.Sprintf("--ignore-module=%s", ignoreModule))
 	}
@@ -169,6 +383,12 @@ func (opts *Options) toWorkerCommands(workingDir string) []string {
 	for _, rule := range opts.Only {
 		commands = append(commands, fmt.Sprintf("--only=%s", rule))
 	}
+	for _, rule := range opts.Rules {
+		commands = append(commands, fmt.Sprintf("--rule=%s", rule))
+	}
+	if opts.DisabledByDefault {
+		commands = append(commands, "--disabled-by-default")
+	}
 	for _, plugin := range opts.EnablePlugins {
 		commands = append(commands, fmt.Sprintf("--enable-plugin=%s", plugin))
 	}
@@ -184,24 +404,72 @@ func (opts *Options) toWorkerCommands(workingDir string) []string {
 
 	// opts.Chdir should be ignored because it is given by the coordinator
 
-	// opts.Recursive is not supported
+	// opts.Recursive, opts.MaxDepth, opts.FollowSymlinks, opts.ExcludeDirs, and opts.Ordered are not supported
+
+	// Patterns in .tflintignore files and TFLINT_IGNORE are relative to the base directory of the walk.
+	// Each directory given by multiple --chdir is the base directory of its own, as in the non-recursive inspection.
+	baseDir := opts.chdir()
+	if opts.multipleChdirs() {
+		baseDir = workingDir
+	}
+	if baseDir == "" {
+		baseDir = "."
+	}
+	commands = append(commands, fmt.Sprintf("--ignore-base=%s", baseDir))
+	if opts.noIgnore() {
+		commands = append(commands, "--no-ignore")
+	}
 
 	for _, filter := range opts.Filter {
 		commands = append(commands, fmt.Sprintf("--filter=%s", filter))
 	}
+	if opts.FileList != "" {
+		commands = append(commands, fmt.Sprintf("--file-list=%s", opts.FileList))
+	}
+	if opts.changedOnly() && !opts.changedModuleDirs[workingDir] {
+		commands = append(commands, "--changed-only")
+		if ref := opts.baseRef(); ref != "" {
+			commands = append(commands, fmt.Sprintf("--base-ref=%s", ref))
+		}
+	}
+	if opts.NoDeduplicate {
+		commands = append(commands, "--no-deduplicate")
+	}
+
+	// opts.MaxIssues is ignored because the coordinator stops scheduling workers once the limit is exceeded
+
+	// opts.ExitZero, opts.Force, opts.MinimumFailureSeverity, and opts.FailOnSeverity are ignored because exit status is controlled by the coordinator
 
-	// opts.Force and opts.MinimumFailureSeverity are ignored because exit status is controlled by the coordinator
+	// opts.MinSeverity and opts.MinimumReportSeverity are ignored because the coordinator is responsible for filtering issues
 
-	// opts.Color and opts.NoColor are ignored because the coordinator is responsible for colorized output
+	// opts.Baseline and opts.GenerateBaseline are ignored because the coordinator is responsible for the baseline
+
+	// opts.Color, opts.NoColor, opts.NoSummary, opts.GroupByFile, opts.CompactLinks, opts.CompactRanges, opts.QuietSuccess, opts.Statistics, opts.Context, opts.SnippetContext, opts.IncludeSource, opts.SourceMaxLength, opts.DiffMaxLines, opts.PathMode, and opts.JSONVersion are ignored because the coordinator is responsible for the output
 
 	if opts.Fix {
 		commands = append(commands, "--fix")
 	}
+	for _, rule := range opts.FixRules {
+		commands = append(commands, fmt.Sprintf("--fix-rule=%s", rule))
+	}
+	// opts.Interactive is not supported
+	if opts.DryRun {
+		commands = append(commands, "--dry-run")
+	}
+
 	if opts.NoParallelRunners {
 		commands = append(commands, "--no-parallel-runners")
 	}
 
-	// opts.MaxWorkers is ignored because the coordinator is responsible for parallelism
+	// opts.MaxWorkers and opts.DirTimeout are ignored because the coordinator is responsible for parallelism
+
+	if opts.Profile != "" {
+		commands = append(commands, fmt.Sprintf("--profile=%s", workerProfilePath(opts.Profile, workingDir)))
+	}
+
+	// opts.Watch and opts.WatchDebounce are not supported
+
+	// opts.Stdin, opts.Filename, and opts.StdinFilename are not supported
 
 	// opts.ActAsBundledPlugin and opts.ActAsWorker are not supported
 
@@ -6,7 +6,6 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
-	flags "github.com/jessevdk/go-flags"
 	"github.com/terraform-linters/tflint/terraform"
 	"github.com/terraform-linters/tflint/tflint"
 )
@@ -52,6 +51,21 @@ func Test_toConfig(t *testing.T) {
 				Plugins:           map[string]*tflint.PluginConfig{},
 			},
 		},
+		{
+			Name:    "--exit-zero",
+			Command: "./tflint --exit-zero",
+			Expected: &tflint.Config{
+				CallModuleType:    terraform.CallLocalModule,
+				Force:             true,
+				ForceSet:          true,
+				IgnoreModules:     map[string]bool{},
+				Varfiles:          []string{},
+				Variables:         []string{},
+				DisabledByDefault: false,
+				Rules:             map[string]*tflint.RuleConfig{},
+				Plugins:           map[string]*tflint.PluginConfig{},
+			},
+		},
 		{
 			Name:    "--ignore-module",
 			Command: "./tflint --ignore-module module1,module2",
@@ -194,6 +208,53 @@ func Test_toConfig(t *testing.T) {
 				Plugins: map[string]*tflint.PluginConfig{},
 			},
 		},
+		{
+			Name:    "--disabled-by-default",
+			Command: "./tflint --disabled-by-default --enable-rule aws_instance_invalid_type",
+			Expected: &tflint.Config{
+				CallModuleType:       terraform.CallLocalModule,
+				Force:                false,
+				IgnoreModules:        map[string]bool{},
+				Varfiles:             []string{},
+				Variables:            []string{},
+				DisabledByDefault:    true,
+				DisabledByDefaultSet: true,
+				Rules: map[string]*tflint.RuleConfig{
+					"aws_instance_invalid_type": {
+						Name:    "aws_instance_invalid_type",
+						Enabled: true,
+						Body:    nil,
+					},
+				},
+				Plugins: map[string]*tflint.PluginConfig{},
+			},
+		},
+		{
+			Name:    "--rule",
+			Command: "./tflint --rule aws_instance_invalid_type --rule aws_instance_previous_type",
+			Expected: &tflint.Config{
+				CallModuleType:               terraform.CallLocalModule,
+				Force:                        false,
+				IgnoreModules:                map[string]bool{},
+				Varfiles:                     []string{},
+				Variables:                    []string{},
+				DisabledByDefault:            false,
+				DisabledByDefaultWithoutFile: true,
+				Rules: map[string]*tflint.RuleConfig{
+					"aws_instance_invalid_type": {
+						Name:    "aws_instance_invalid_type",
+						Enabled: true,
+						Body:    nil,
+					},
+					"aws_instance_previous_type": {
+						Name:    "aws_instance_previous_type",
+						Enabled: true,
+						Body:    nil,
+					},
+				},
+				Plugins: map[string]*tflint.PluginConfig{},
+			},
+		},
 		{
 			Name:    "--enable-plugin",
 			Command: "./tflint --enable-plugin test --enable-plugin another-test",
@@ -235,12 +296,75 @@ func Test_toConfig(t *testing.T) {
 				Plugins:           map[string]*tflint.PluginConfig{},
 			},
 		},
-	}
+		{
+			Name:    "--output-file",
+			Command: "./tflint --output-file result.json",
+			Expected: &tflint.Config{
+				CallModuleType:    terraform.CallLocalModule,
+				Force:             false,
+				IgnoreModules:     map[string]bool{},
+				Varfiles:          []string{},
+				Variables:         []string{},
+				DisabledByDefault: false,
+				OutputFile:        "result.json",
+				OutputFileSet:     true,
+				Rules:             map[string]*tflint.RuleConfig{},
+				Plugins:           map[string]*tflint.PluginConfig{},
+			},
+		},
+		{
+			Name:    "--minimum-failure-severity",
+			Command: "./tflint --minimum-failure-severity warning",
+			Expected: &tflint.Config{
+				CallModuleType:            terraform.CallLocalModule,
+				Force:                     false,
+				IgnoreModules:             map[string]bool{},
+				Varfiles:                  []string{},
+				Variables:                 []string{},
+				DisabledByDefault:         false,
+				MinimumFailureSeverity:    "warning",
+				MinimumFailureSeveritySet: true,
+				Rules:                     map[string]*tflint.RuleConfig{},
+				Plugins:                   map[string]*tflint.PluginConfig{},
+			},
+		},
+		{
+			Name:    "--fail-on-severity",
+			Command: "./tflint --fail-on-severity error",
+			Expected: &tflint.Config{
+				CallModuleType:            terraform.CallLocalModule,
+				Force:                     false,
+				IgnoreModules:             map[string]bool{},
+				Varfiles:                  []string{},
+				Variables:                 []string{},
+				DisabledByDefault:         false,
+				MinimumFailureSeverity:    "error",
+				MinimumFailureSeveritySet: true,
+				Rules:                     map[string]*tflint.RuleConfig{},
+				Plugins:                   map[string]*tflint.PluginConfig{},
+			},
+		},
+		{
+			Name:    "--fail-on-severity takes precedence over --minimum-failure-severity",
+			Command: "./tflint --minimum-failure-severity notice --fail-on-severity error",
+			Expected: &tflint.Config{
+				CallModuleType:            terraform.CallLocalModule,
+				Force:                     false,
+				IgnoreModules:             map[string]bool{},
+				Varfiles:                  []string{},
+				Variables:                 []string{},
+				DisabledByDefault:         false,
+				MinimumFailureSeverity:    "error",
+				MinimumFailureSeveritySet: true,
+				Rules:                     map[string]*tflint.RuleConfig{},
+				Plugins:                   map[string]*tflint.PluginConfig{},
+			},
+		}}
 
 	for _, tc := range cases {
 		t.Run(tc.Name, func(t *testing.T) {
 			var opts Options
-			parser := flags.NewParser(&opts, flags.HelpFlag)
+			parser := newOptionsParser(&opts)
 
 			_, err := parser.ParseArgs(strings.Split(tc.Command, " "))
 			if err != nil {
@@ -271,7 +395,7 @@ func Test_toWorkerCommands(t *testing.T) {
 			name:       "no args",
 			in:         []string{},
 			workingDir: "subdir",
-			want:       []string{"--act-as-worker", "--chdir=subdir", "--force"},
+			want:       []string{"--act-as-worker", "--chdir=subdir", "--exit-zero", "--ignore-base=."},
 		},
 		{
 			name: "all",
@@ -289,6 +413,8 @@ func Test_toWorkerCommands(t *testing.T) {
 				"--disable-rule=rule4",
 				"--only=rule5",
 				"--only=rule6",
+				"--rule=rule7",
+				"--disabled-by-default",
 				"--enable-plugin=plugin1",
 				"--enable-plugin=plugin2",
 				"--var-file=example1.tfvars",
@@ -298,15 +424,25 @@ func Test_toWorkerCommands(t *testing.T) {
 				"--call-module-type=all",
 				"--chdir=dir",
 				"--recursive",
+				"--no-tflintignore",
 				"--filter=main1.tf",
 				"--filter=main2.tf",
+				"--file-list=files.txt",
+				"--changed-only",
+				"--base-ref=main",
+				"--no-deduplicate",
+				"--exit-zero",
 				"--force",
 				"--minimum-failure-severity=warning",
 				"--color",
 				"--no-color",
 				"--fix",
+				"--fix-rule=rule8",
+				"--interactive",
+				"--dry-run",
 				"--no-parallel-runners",
 				"--max-workers=2",
+				"--profile=tflint",
 				"--act-as-bundled-plugin",
 				"--act-as-worker",
 			},
@@ -325,6 +461,8 @@ func Test_toWorkerCommands(t *testing.T) {
 				"--disable-rule=rule4",
 				"--only=rule5",
 				"--only=rule6",
+				"--rule=rule7",
+				"--disabled-by-default",
 				"--enable-plugin=plugin1",
 				"--enable-plugin=plugin2",
 				"--var-file=example1.tfvars",
@@ -334,25 +472,48 @@ func Test_toWorkerCommands(t *testing.T) {
 				"--call-module-type=all",
 				"--chdir=subdir", // "--chdir=dir",
 				// "--recursive",
+				"--ignore-base=dir",
+				"--no-ignore", // "--no-tflintignore",
 				"--filter=main1.tf",
 				"--filter=main2.tf",
-				"--force",
+				"--file-list=files.txt",
+				"--changed-only",
+				"--base-ref=main",
+				"--no-deduplicate",
+				"--exit-zero",
+				// "--force",
 				// "--minimum-failure-severity=warning",
 				// "--color",
 				// "--no-color",
 				"--fix",
+				"--fix-rule=rule8",
+				// "--interactive",
+				"--dry-run",
 				"--no-parallel-runners",
 				// "--max-workers=2",
+				"--profile=tflint-subdir", // "--profile=tflint",
 				// "--act-as-bundled-plugin",
 				"--act-as-worker",
 			},
 		},
+		{
+			name:       "changed-only with ref",
+			in:         []string{"--changed-only=main"},
+			workingDir: "subdir",
+			want:       []string{"--act-as-worker", "--chdir=subdir", "--exit-zero", "--ignore-base=.", "--changed-only", "--base-ref=main"},
+		},
+		{
+			name:       "multiple chdir",
+			in:         []string{"--chdir=envs/dev", "--chdir=envs/prod"},
+			workingDir: "envs/dev",
+			want:       []string{"--act-as-worker", "--chdir=envs/dev", "--exit-zero", "--ignore-base=envs/dev"},
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			var in Options
-			parser := flags.NewParser(&in, flags.HelpFlag)
+			parser := newOptionsParser(&in)
 			_, err := parser.ParseArgs(test.in)
 			if err != nil {
 				t.Fatal(err)
@@ -367,7 +528,7 @@ func Test_toWorkerCommands(t *testing.T) {
 
 			// Check if the output can be parsed
 			var out Options
-			parser = flags.NewParser(&out, flags.HelpFlag)
+			parser = newOptionsParser(&out)
 			_, err = parser.ParseArgs(got)
 			if err != nil {
 				t.Fatal(err)
@@ -375,3 +536,67 @@ func Test_toWorkerCommands(t *testing.T) {
 		})
 	}
 }
+
+func Test_reportFile(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     string
+		path   string
+		format string
+		err    string
+	}{
+		{
+			name:   "default format",
+			in:     "results.json",
+			path:   "results.json",
+			format: "json",
+		},
+		{
+			name:   "format",
+			in:     "results.sarif,format=sarif",
+			path:   "results.sarif",
+			format: "sarif",
+		},
+		{
+			name:   "path with commas",
+			in:     "a,b/results.xml,format=junit",
+			path:   "a,b/results.xml",
+			format: "junit",
+		},
+		{
+			name: "unknown key",
+			in:   "results.json,type=json",
+			err:  `Invalid --report-file "results.json,type=json". The format must be specified as "PATH,format=FORMAT"`,
+		},
+		{
+			name: "empty path",
+			in:   ",format=json",
+			err:  `Invalid --report-file ",format=json". The path must not be empty`,
+		},
+		{
+			name: "unsupported format",
+			in:   "results.txt,format=compact",
+			err:  `Report file format "compact" is not supported. Supported formats: json, sarif, junit`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := Options{ReportFile: test.in}
+
+			path, format, err := opts.reportFile()
+			if err != nil {
+				if err.Error() != test.err {
+					t.Fatalf("expected error %q, but got %q", test.err, err)
+				}
+				return
+			}
+			if test.err != "" {
+				t.Fatalf("expected error %q, but got nil", test.err)
+			}
+			if path != test.path || format != test.format {
+				t.Errorf("got path=%s format=%s, want path=%s format=%s", path, format, test.path, test.format)
+			}
+		})
+	}
+}
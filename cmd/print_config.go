@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/terraform-linters/tflint/tflint"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// maskedValue replaces values of sensitive plugin attributes
+const maskedValue = "***"
+
+// sensitiveAttributeKeywords are keywords in names of plugin attributes that may contain credentials
+var sensitiveAttributeKeywords = []string{"token", "secret", "password", "key"}
+
+type printedConfig struct {
+	Format                 string                          `json:"format"`
+	CallModuleType         string                          `json:"call_module_type"`
+	Force                  bool                            `json:"force"`
+	DisabledByDefault      bool                            `json:"disabled_by_default"`
+	PluginDir              string                          `json:"plugin_dir"`
+	OutputFile             string                          `json:"output_file"`
+	MinimumFailureSeverity string                          `json:"minimum_failure_severity"`
+	FormatOptions          map[string]map[string]string    `json:"format_options"`
+	Varfiles               []string                        `json:"varfiles"`
+	Variables              []string                        `json:"variables"`
+	Only                   []string                        `json:"only"`
+	IgnoreModules          []string                        `json:"ignore_modules"`
+	ExcludeDirs            []string                        `json:"exclude_dirs"`
+	SkipDirs               []string                        `json:"skip_dirs"`
+	EnabledRules           []string                        `json:"enabled_rules"`
+	DisabledRules          []string                        `json:"disabled_rules"`
+	Plugins                map[string]*printedPluginConfig `json:"plugins"`
+	Overrides              []*printedOverrideConfig        `json:"overrides"`
+}
+
+type printedPluginConfig struct {
+	Enabled    bool   `json:"enabled"`
+	Version    string `json:"version"`
+	Source     string `json:"source"`
+	SigningKey string `json:"signing_key"`
+	// Attributes are plugin-specific attributes. Nested blocks are not printed.
+	Attributes map[string]json.RawMessage `json:"attributes"`
+}
+
+type printedOverrideConfig struct {
+	Path          string   `json:"path"`
+	EnabledRules  []string `json:"enabled_rules"`
+	DisabledRules []string `json:"disabled_rules"`
+}
+
+// printConfig prints the effective config merged from the config file and CLI flags as JSON.
+// The config is loaded in the --chdir directory as inspection does.
+func (cli *CLI) printConfig(opts Options) int {
+	var cfg *tflint.Config
+	err := cli.withinChangedDir(opts.chdir(), func() error {
+		var err error
+		cfg, err = loadConfig(opts)
+		if err != nil {
+			return fmt.Errorf("Failed to load TFLint config; %w", err)
+		}
+		cfg.Merge(opts.toConfig())
+		return nil
+	})
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+		return ExitCodeError
+	}
+
+	out, err := json.MarshalIndent(toPrintedConfig(cfg), "", "  ")
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to print TFLint config; %w", err), map[string][]byte{})
+		return ExitCodeError
+	}
+	fmt.Fprintln(cli.outStream, string(out))
+
+	return ExitCodeOK
+}
+
+func toPrintedConfig(cfg *tflint.Config) *printedConfig {
+	ret := &printedConfig{
+		Format:                 cfg.Format,
+		CallModuleType:         cfg.CallModuleType.String(),
+		Force:                  cfg.Force,
+		DisabledByDefault:      cfg.DisabledByDefault,
+		PluginDir:              cfg.PluginDir,
+		OutputFile:             cfg.OutputFile,
+		MinimumFailureSeverity: cfg.MinimumFailureSeverity,
+		FormatOptions:          map[string]map[string]string{},
+		Varfiles:               nonNil(cfg.Varfiles),
+		Variables:              nonNil(cfg.Variables),
+		Only:                   nonNil(cfg.Only),
+		IgnoreModules:          []string{},
+		ExcludeDirs:            nonNil(cfg.ExcludeDirs),
+		SkipDirs:               nonNil(cfg.SkipDirs),
+		Plugins:                map[string]*printedPluginConfig{},
+		Overrides:              []*printedOverrideConfig{},
+	}
+	if ret.Format == "" {
+		ret.Format = "default"
+	}
+
+	maps.Copy(ret.FormatOptions, cfg.FormatOptions)
+
+	for _, source := range slices.Sorted(maps.Keys(cfg.IgnoreModules)) {
+		if cfg.IgnoreModules[source] {
+			ret.IgnoreModules = append(ret.IgnoreModules, source)
+		}
+	}
+	ret.EnabledRules, ret.DisabledRules = splitRulesByEnabled(cfg.Rules)
+
+	for name, plugin := range cfg.Plugins {
+		ret.Plugins[name] = &printedPluginConfig{
+			Enabled:    plugin.Enabled,
+			Version:    plugin.Version,
+			Source:     plugin.Source,
+			SigningKey: plugin.SigningKey,
+			Attributes: printedPluginAttributes(plugin),
+		}
+	}
+
+	for _, override := range cfg.Overrides {
+		enabled, disabled := splitRulesByEnabled(override.Rules)
+		ret.Overrides = append(ret.Overrides, &printedOverrideConfig{
+			Path:          override.Path,
+			EnabledRules:  enabled,
+			DisabledRules: disabled,
+		})
+	}
+
+	return ret
+}
+
+func splitRulesByEnabled(rules map[string]*tflint.RuleConfig) ([]string, []string) {
+	enabled, disabled := []string{}, []string{}
+	for _, name := range slices.Sorted(maps.Keys(rules)) {
+		if rules[name].Enabled {
+			enabled = append(enabled, name)
+		} else {
+			disabled = append(disabled, name)
+		}
+	}
+	return enabled, disabled
+}
+
+// printedPluginAttributes returns plugin-specific attributes as JSON values.
+// Attributes that may contain credentials are masked, and values that cannot be evaluated
+// without the evaluation context (e.g. function calls) are printed as null.
+func printedPluginAttributes(plugin *tflint.PluginConfig) map[string]json.RawMessage {
+	ret := map[string]json.RawMessage{}
+	// HACK: If you enable the plugin through the CLI instead of the file, its hcl.Body will be nil.
+	if plugin.Body == nil {
+		return ret
+	}
+
+	// Diagnostics about nested blocks are ignored because only attributes are printed
+	attrs, _ := plugin.Body.JustAttributes()
+	for name, attr := range attrs {
+		if isSensitiveAttribute(name) {
+			ret[name] = json.RawMessage(`"` + maskedValue + `"`)
+			continue
+		}
+
+		val, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			ret[name] = json.RawMessage("null")
+			continue
+		}
+		out, err := ctyjson.Marshal(val, val.Type())
+		if err != nil {
+			ret[name] = json.RawMessage("null")
+			continue
+		}
+		ret[name] = out
+	}
+	return ret
+}
+
+func isSensitiveAttribute(name string) bool {
+	name = strings.ToLower(name)
+	for _, keyword := range sensitiveAttributeKeywords {
+		if strings.Contains(name, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
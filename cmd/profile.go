@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+)
+
+// profiler writes CPU and heap profiles of the process with --profile.
+// Profiles are written to temporary files and renamed when stopped,
+// so that incomplete profiles are never left at the paths.
+type profiler struct {
+	path string
+	cpu  *outputFile
+}
+
+// startProfiling starts CPU profiling to PATH.cpu.pprof.
+func startProfiling(path string) (*profiler, error) {
+	cpu, err := newOutputFile(path + ".cpu.pprof")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to start profiling; %w", err)
+	}
+	if err := pprof.StartCPUProfile(cpu); err != nil {
+		cpu.discard()
+		return nil, fmt.Errorf("Failed to start profiling; %w", err)
+	}
+	return &profiler{path: path, cpu: cpu}, nil
+}
+
+// stop stops CPU profiling and writes the heap profile to PATH.mem.pprof.
+func (p *profiler) stop() error {
+	pprof.StopCPUProfile()
+	if err := p.cpu.commit(); err != nil {
+		return fmt.Errorf("Failed to write the CPU profile; %w", err)
+	}
+
+	mem, err := newOutputFile(p.path + ".mem.pprof")
+	if err != nil {
+		return fmt.Errorf("Failed to write the heap profile; %w", err)
+	}
+	defer mem.discard()
+	// Get up-to-date statistics of allocations
+	runtime.GC()
+	if err := errors.Join(pprof.WriteHeapProfile(mem), mem.commit()); err != nil {
+		return fmt.Errorf("Failed to write the heap profile; %w", err)
+	}
+	return nil
+}
+
+// workerProfilePath returns the path of profiles of the worker in the directory.
+// Each worker writes profiles to PATH-<dir>, e.g. PATH-modules_vpc.cpu.pprof.
+func workerProfilePath(path string, workingDir string) string {
+	name := filepath.ToSlash(filepath.Clean(workingDir))
+	if name == "." {
+		name = "root"
+	}
+	return fmt.Sprintf("%s-%s", path, strings.NewReplacer("/", "_", ":", "_").Replace(name))
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestCLIRun_profile(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.WriteFile("main.tf", []byte(`resource "aws_instance" "main" {}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Disable the bundled plugin so that no plugin processes are launched
+	if err := os.WriteFile(".tflint.hcl", []byte("plugin \"terraform\" {\n  enabled = false\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	cli, err := NewCLI(stdout, stderr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := cli.Run([]string{"tflint", "--profile=tflint"}); status != ExitCodeOK {
+		t.Fatalf("expected exit status %d, but got %d; stdout=%s, stderr=%s", ExitCodeOK, status, stdout, stderr)
+	}
+
+	for _, name := range []string{"tflint.cpu.pprof", "tflint.mem.pprof"} {
+		t.Run(name, func(t *testing.T) {
+			assertProfile(t, filepath.Join(dir, name))
+		})
+	}
+	// Temporary files are renamed
+	assertDirEntries(t, dir, 4)
+}
+
+func Test_workerProfilePath(t *testing.T) {
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{dir: ".", want: "tflint-root"},
+		{dir: "subdir", want: "tflint-subdir"},
+		{dir: filepath.Join("modules", "vpc"), want: "tflint-modules_vpc"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.dir, func(t *testing.T) {
+			if got := workerProfilePath("tflint", test.dir); got != test.want {
+				t.Errorf("want=%s, got=%s", test.want, got)
+			}
+		})
+	}
+}
+
+// assertProfile checks that the file is a non-empty profile in the pprof format, a gzipped protocol buffer.
+func assertProfile(t *testing.T, path string) {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	r, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) == 0 {
+		t.Fatal("profile is empty")
+	}
+	for len(b) > 0 {
+		_, _, n := protowire.ConsumeField(b)
+		if n < 0 {
+			t.Fatalf("failed to parse the profile: %s", protowire.ParseError(n))
+		}
+		b = b[n:]
+	}
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// validateConfig checks the config without inspecting modules.
+// The config is loaded and applied to plugins, so that syntax errors, unknown attributes,
+// and invalid plugin configs are reported. Rules not provided by any plugin are also reported.
+func (cli *CLI) validateConfig(opts Options) int {
+	var unknown []string
+	err := cli.withinChangedDir(opts.chdir(), func() error {
+		cfg, err := loadConfig(opts)
+		if err != nil {
+			return fmt.Errorf("Failed to load TFLint config; %w", err)
+		}
+		cfg.Merge(opts.toConfig())
+
+		rulesetPlugin, rulesets, err := startPlugins(cfg, false)
+		if rulesetPlugin != nil {
+			defer rulesetPlugin.Clean()
+		}
+		if err != nil {
+			return err
+		}
+
+		unknown, err = cfg.UnknownRules(rulesets...)
+		if err != nil {
+			return fmt.Errorf("Failed to check rule config; %w", err)
+		}
+		if len(unknown) > 0 && !opts.ValidateConfig.AllowUnknownRules {
+			return fmt.Errorf("Failed to check rule config; Rule not found: %s", strings.Join(unknown, ", "))
+		}
+		if err := cfg.ValidateOverrides(); err != nil {
+			return fmt.Errorf("Failed to check rule config; %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+		return ExitCodeError
+	}
+
+	for _, name := range unknown {
+		fmt.Fprintf(cli.errStream, "Warning: Rule not found: %s\n", name)
+	}
+	fmt.Fprintln(cli.outStream, "The config is valid")
+	return ExitCodeOK
+}
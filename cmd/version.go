@@ -1,23 +1,52 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"runtime"
+	"slices"
+	"strings"
 
-	"github.com/spf13/afero"
 	"github.com/terraform-linters/tflint/plugin"
 	"github.com/terraform-linters/tflint/tflint"
 )
 
-func (cli *CLI) printVersion(opts Options) int {
-	fmt.Fprintf(cli.outStream, "TFLint version %s\n", tflint.Version)
+// VersionInfo is the structure printed by `--version --format=json`.
+// Field names are stable, so that scripts can parse the output without depending on the text format.
+type VersionInfo struct {
+	// Version is the TFLint version, e.g. "0.57.0".
+	Version string `json:"version"`
+	// GoVersion is the Go version used to build TFLint, e.g. "go1.24.0".
+	GoVersion string `json:"go_version"`
+	// OS and Arch are the platform TFLint was built for, e.g. "linux" and "amd64".
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+	// Plugins are versions of plugins loaded for the working directories.
+	Plugins []PluginVersion `json:"plugins"`
+}
+
+// PluginVersion is the version of a loaded plugin in VersionInfo.
+type PluginVersion struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// WorkingDir is the directory where the plugin is loaded. It is set only in recursive mode.
+	WorkingDir string `json:"working_dir,omitempty"`
+}
 
-	workingDirs, err := findWorkingDirs(opts)
+func (cli *CLI) printVersion(opts Options) int {
+	workingDirs, _, err := findWorkingDirs(opts)
 	if err != nil {
 		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to find workspaces; %w", err), map[string][]byte{})
 		return ExitCodeError
 	}
 
+	if opts.Format == "json" {
+		return cli.printVersionJSON(opts, workingDirs)
+	}
+
+	fmt.Fprintf(cli.outStream, "TFLint version %s\n", tflint.Version)
+
 	if opts.Recursive {
 		fmt.Fprint(cli.outStream, "\n")
 	}
@@ -32,7 +61,7 @@ func (cli *CLI) printVersion(opts Options) int {
 			versions := getPluginVersions(opts)
 
 			for _, version := range versions {
-				fmt.Fprint(cli.outStream, version)
+				fmt.Fprintf(cli.outStream, "+ ruleset.%s (%s)\n", version.Name, version.Version)
 			}
 			if len(versions) == 0 && opts.Recursive {
 				fmt.Fprint(cli.outStream, "No plugins\n")
@@ -47,23 +76,58 @@ func (cli *CLI) printVersion(opts Options) int {
 	return ExitCodeOK
 }
 
-func getPluginVersions(opts Options) []string {
+func (cli *CLI) printVersionJSON(opts Options, workingDirs []string) int {
+	info := VersionInfo{
+		Version:   tflint.Version.String(),
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		Plugins:   []PluginVersion{},
+	}
+
+	for _, wd := range workingDirs {
+		err := cli.withinChangedDir(wd, func() error {
+			for _, version := range getPluginVersions(opts) {
+				if opts.Recursive {
+					version.WorkingDir = wd
+				}
+				info.Plugins = append(info.Plugins, version)
+			}
+			return nil
+		})
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+			return ExitCodeError
+		}
+	}
+
+	out, err := json.Marshal(info)
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to marshal version info; %w", err), map[string][]byte{})
+		return ExitCodeError
+	}
+	fmt.Fprintln(cli.outStream, string(out))
+
+	return ExitCodeOK
+}
+
+func getPluginVersions(opts Options) []PluginVersion {
 	// Load configuration files to print plugin versions
-	cfg, err := tflint.LoadConfig(afero.Afero{Fs: afero.NewOsFs()}, opts.Config)
+	cfg, err := loadConfig(opts)
 	if err != nil {
 		log.Printf("[ERROR] Failed to load TFLint config: %s", err)
-		return []string{}
+		return []PluginVersion{}
 	}
 	cfg.Merge(opts.toConfig())
 
 	rulesetPlugin, err := plugin.Discovery(cfg)
 	if err != nil {
 		log.Printf("[ERROR] Failed to initialize plugins: %s", err)
-		return []string{}
+		return []PluginVersion{}
 	}
 	defer rulesetPlugin.Clean()
 
-	versions := []string{}
+	versions := []PluginVersion{}
 	for _, ruleset := range rulesetPlugin.RuleSets {
 		name, err := ruleset.RuleSetName()
 		if err != nil {
@@ -76,8 +140,10 @@ func getPluginVersions(opts Options) []string {
 			continue
 		}
 
-		versions = append(versions, fmt.Sprintf("+ ruleset.%s (%s)\n", name, version))
+		versions = append(versions, PluginVersion{Name: name, Version: version})
 	}
+	// Plugins are stored in a map, so sort them to print in a stable order
+	slices.SortFunc(versions, func(a, b PluginVersion) int { return strings.Compare(a.Name, b.Name) })
 
 	return versions
 }
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"runtime"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func TestCLIRun_versionJSON(t *testing.T) {
+	// Disable the bundled plugin because the `os.Executable()` is go(1) in the tests
+	tflint.DisableBundledPlugin = true
+	defer func() {
+		tflint.DisableBundledPlugin = false
+	}()
+	t.Chdir(t.TempDir())
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cli, err := NewCLI(stdout, stderr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if status := cli.Run([]string{"tflint", "--version", "--format=json"}); status != ExitCodeOK {
+		t.Fatalf("expected status is %d, but got %d; stderr=%s", ExitCodeOK, status, stderr.String())
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("output is not valid JSON: %s; stdout=%s", err, stdout.String())
+	}
+	for _, key := range []string{"version", "go_version", "os", "arch", "plugins"} {
+		if _, exists := got[key]; !exists {
+			t.Errorf("%s is not found in %s", key, stdout.String())
+		}
+	}
+
+	var info VersionInfo
+	if err := json.Unmarshal(stdout.Bytes(), &info); err != nil {
+		t.Fatal(err)
+	}
+	want := VersionInfo{
+		Version:   tflint.Version.String(),
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		Plugins:   []PluginVersion{},
+	}
+	// Plugins are an empty list instead of null if no plugins are loaded
+	if diff := cmp.Diff(want, info); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+const defaultWatchDebounce = 300 * time.Millisecond
+
+// watchTargetSuffixes are suffixes of files that trigger re-inspection in watch mode
+var watchTargetSuffixes = []string{".tf", ".tf.json", ".tfvars", ".tfvars.json"}
+
+// watch inspects the working directory and re-inspects it each time files are changed.
+// The process continues until it receives SIGINT or SIGTERM.
+func (cli *CLI) watch(opts Options) int {
+	dir := opts.chdir()
+	if dir == "" {
+		dir = "."
+	}
+
+	interval := defaultWatchDebounce
+	if opts.WatchDebounce != nil {
+		interval = *opts.WatchDebounce
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to start watching files; %w", err), map[string][]byte{})
+		return ExitCodeError
+	}
+	defer watcher.Close()
+
+	// fsnotify does not watch subdirectories, so add them to watch local modules
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		// hidden directories are skipped
+		if path != dir && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		return watcher.Add(path)
+	})
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to start watching files; %w", err), map[string][]byte{})
+		return ExitCodeError
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go cli.registerShutdownHandler(ctx, cancel)
+
+	changes := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Has(fsnotify.Create) {
+					if info, err := os.Stat(event.Name); err == nil && info.IsDir() && !strings.HasPrefix(info.Name(), ".") {
+						if err := watcher.Add(event.Name); err != nil {
+							log.Printf("[ERROR] Failed to watch %s; %s", event.Name, err)
+						}
+					}
+				}
+				if !isWatchTarget(event.Name) || event.Op == fsnotify.Chmod {
+					continue
+				}
+				log.Printf("[DEBUG] File changed: %s", event)
+
+				select {
+				case changes <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("[ERROR] Failed to watch files; %s", err)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	cli.watchInspect(opts)
+	for range debounce(ctx, changes, interval) {
+		cli.watchInspect(opts)
+	}
+
+	return ExitCodeOK
+}
+
+// watchInspect clears the previous results and runs an inspection.
+// The exit status is ignored as the results are reported continuously.
+func (cli *CLI) watchInspect(opts Options) {
+	if isTerminal(cli.outStream) {
+		fmt.Fprint(cli.outStream, "\033[H\033[2J")
+	}
+	cli.inspect(opts)
+}
+
+// debounce returns a channel that receives a value when no events are received for the interval.
+// The channel is closed when the context is canceled or the input channel is closed.
+func debounce(ctx context.Context, events <-chan struct{}, interval time.Duration) <-chan struct{} {
+	out := make(chan struct{})
+
+	go func() {
+		defer close(out)
+
+		var timer <-chan time.Time
+		for {
+			select {
+			case _, ok := <-events:
+				if !ok {
+					return
+				}
+				timer = time.After(interval)
+			case <-timer:
+				timer = nil
+				select {
+				case out <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+func isWatchTarget(path string) bool {
+	for _, suffix := range watchTargetSuffixes {
+		if strings.HasSuffix(path, suffix) {
+			return true
+		}
+	}
+	return false
+}
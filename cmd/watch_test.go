@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Watch mode is not covered by integration tests because it runs until interrupted.
+// To test it manually:
+//
+//  1. Run `tflint --watch` in integrationtest/inspection/basic
+//  2. Edit and save template.tf, and check that the results are printed again after 300ms
+//  3. Save multiple files at once, and check that the results are printed only once
+//  4. Press Ctrl+C, and check that the process exits with "Received interrupt, shutting down..."
+//
+// Interrupts after repeated inspections are covered by Test_watch_interrupt.
+
+// syncBuffer is a buffer that can be written by the watch and read by the test concurrently
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func Test_watch_interrupt(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("interrupts cannot be sent to the process on Windows")
+	}
+
+	dir := t.TempDir()
+	config := `
+plugin "terraform" {
+  enabled = false
+}`
+	if err := os.WriteFile(filepath.Join(dir, ".tflint.hcl"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`variable "foo" {}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	outStream, errStream := new(syncBuffer), new(syncBuffer)
+	cli, err := NewCLI(outStream, errStream)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status := make(chan int)
+	go func() {
+		status <- cli.Run([]string{"tflint", "--watch", "--watch-debounce=10ms", "--format=json", "--chdir=" + dir})
+	}()
+
+	// waitInspections waits until the results of n inspections are printed
+	waitInspections := func(n int) {
+		t.Helper()
+		deadline := time.After(10 * time.Second)
+		for strings.Count(outStream.String(), `"issues"`) < n {
+			select {
+			case <-deadline:
+				t.Fatalf("expected %d inspections, but got: stdout=%s, stderr=%s", n, outStream.String(), errStream.String())
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}
+
+	waitInspections(1)
+	if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`variable "bar" {}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	waitInspections(2)
+
+	// Shutdown handlers of finished inspections must be unregistered, or they exit the process before the watch
+	process, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := process.Signal(os.Interrupt); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case got := <-status:
+		if got != ExitCodeOK {
+			t.Errorf("expected exit status is %d, but got %d: stderr=%s", ExitCodeOK, got, errStream.String())
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("the watch did not exit on interrupt")
+	}
+	if got := strings.Count(errStream.String(), "Received interrupt, shutting down..."); got != 1 {
+		t.Errorf("expected the interrupt to be handled once, but got %d times: stderr=%s", got, errStream.String())
+	}
+}
+
+func Test_debounce(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []time.Duration
+		want   int
+	}{
+		{
+			name:   "no events",
+			events: []time.Duration{},
+			want:   0,
+		},
+		{
+			name:   "single event",
+			events: []time.Duration{0},
+			want:   1,
+		},
+		{
+			name:   "burst events",
+			events: []time.Duration{0, 10 * time.Millisecond, 10 * time.Millisecond, 10 * time.Millisecond},
+			want:   1,
+		},
+		{
+			name:   "separated events",
+			events: []time.Duration{0, 200 * time.Millisecond},
+			want:   2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			events := make(chan struct{})
+			out := debounce(ctx, events, 50*time.Millisecond)
+
+			got := 0
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for range out {
+					got++
+				}
+			}()
+
+			for _, wait := range test.events {
+				time.Sleep(wait)
+				events <- struct{}{}
+			}
+			// Wait for the last event to settle
+			time.Sleep(150 * time.Millisecond)
+			cancel()
+			<-done
+
+			if got != test.want {
+				t.Errorf("expected %d, but got %d", test.want, got)
+			}
+		})
+	}
+}
+
+func Test_debounce_closed(t *testing.T) {
+	events := make(chan struct{})
+	out := debounce(context.Background(), events, time.Millisecond)
+
+	close(events)
+
+	select {
+	case _, ok := <-out:
+		if ok {
+			t.Fatal("should not receive a value")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the channel should be closed")
+	}
+}
+
+func Test_isWatchTarget(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "main.tf", want: true},
+		{path: "main.tf.json", want: true},
+		{path: "terraform.tfvars", want: true},
+		{path: "terraform.tfvars.json", want: true},
+		{path: ".tflint.hcl", want: false},
+		{path: "main.tf.swp", want: false},
+		{path: "README.md", want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			if got := isWatchTarget(test.path); got != test.want {
+				t.Errorf("expected %t, but got %t", test.want, got)
+			}
+		})
+	}
+}
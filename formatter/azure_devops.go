@@ -0,0 +1,122 @@
+package formatter
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// https://learn.microsoft.com/en-us/azure/devops/pipelines/scripts/logging-commands
+
+func (f *Formatter) azureDevOpsPrint(issues tflint.Issues, appErr error) {
+	failed := false
+	for _, issue := range issues {
+		issueType := toAzureDevOpsType(issue.Rule.Severity())
+		if issueType == "error" {
+			failed = true
+		}
+
+		fmt.Fprintf(
+			f.Stdout,
+			"##vso[task.logissue type=%s;sourcepath=%s;linenumber=%d;columnnumber=%d;code=%s]%s\n",
+			issueType,
+			azureDevOpsEscapeProperty(azureDevOpsPath(issue.Range.Filename)),
+			issue.Range.Start.Line,
+			issue.Range.Start.Column,
+			azureDevOpsEscapeProperty(issue.Rule.Name()),
+			azureDevOpsEscapeData(issue.Message),
+		)
+	}
+
+	if appErr != nil {
+		failed = true
+		f.azureDevOpsPrintErrors(appErr)
+	}
+
+	switch {
+	case failed:
+		fmt.Fprint(f.Stdout, "##vso[task.complete result=Failed;]\n")
+	case len(issues) > 0:
+		fmt.Fprint(f.Stdout, "##vso[task.complete result=SucceededWithIssues;]\n")
+	}
+}
+
+func (f *Formatter) azureDevOpsPrintErrors(err error) {
+	// errors.Join
+	if errs, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, err := range errs.Unwrap() {
+			f.azureDevOpsPrintErrors(err)
+		}
+		return
+	}
+
+	// hcl.Diagnostics
+	var diags hcl.Diagnostics
+	if errors.As(err, &diags) {
+		for _, diag := range diags {
+			issueType := "error"
+			if diag.Severity == hcl.DiagWarning {
+				issueType = "warning"
+			}
+			message := diag.Summary
+			if diag.Detail != "" {
+				message = fmt.Sprintf("%s; %s", diag.Summary, diag.Detail)
+			}
+
+			if diag.Subject == nil {
+				fmt.Fprintf(f.Stdout, "##vso[task.logissue type=%s]%s\n", issueType, azureDevOpsEscapeData(message))
+				continue
+			}
+			fmt.Fprintf(
+				f.Stdout,
+				"##vso[task.logissue type=%s;sourcepath=%s;linenumber=%d;columnnumber=%d]%s\n",
+				issueType,
+				azureDevOpsEscapeProperty(azureDevOpsPath(diag.Subject.Filename)),
+				diag.Subject.Start.Line,
+				diag.Subject.Start.Column,
+				azureDevOpsEscapeData(message),
+			)
+		}
+		return
+	}
+
+	fmt.Fprintf(f.Stdout, "##vso[task.logissue type=error]%s\n", azureDevOpsEscapeData(err.Error()))
+}
+
+// azureDevOpsPath returns the path relative to the repository root with forward slashes,
+// so that the same annotations are generated on Windows and Linux agents.
+func azureDevOpsPath(filename string) string {
+	return rootRelativePath(filename, os.Getenv("BUILD_SOURCESDIRECTORY"))
+}
+
+func azureDevOpsEscapeData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%AZP25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	s = strings.ReplaceAll(s, "\n", "%0A")
+	return s
+}
+
+func azureDevOpsEscapeProperty(s string) string {
+	s = azureDevOpsEscapeData(s)
+	s = strings.ReplaceAll(s, ";", "%3B")
+	s = strings.ReplaceAll(s, "]", "%5D")
+	return s
+}
+
+// toAzureDevOpsType maps severities to issue types.
+// Azure Pipelines only supports errors and warnings, so notices are reported as warnings.
+func toAzureDevOpsType(severity tflint.Severity) string {
+	switch severity {
+	case sdk.ERROR:
+		return "error"
+	case sdk.WARNING, sdk.NOTICE:
+		return "warning"
+	default:
+		panic(fmt.Errorf("Unexpected lint type: %s", severity))
+	}
+}
@@ -0,0 +1,132 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func Test_azureDevOpsPrint(t *testing.T) {
+	cases := []struct {
+		Name            string
+		Issues          tflint.Issues
+		Error           error
+		SourceDirectory string
+		Stdout          string
+	}{
+		{
+			Name:   "no issues",
+			Issues: tflint.Issues{},
+			Stdout: "",
+		},
+		{
+			Name: "issues",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: filepath.Join("subdir", "test.tf"),
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+				{
+					Rule:    &testRuleWithoutLink{},
+					Message: "100% [done];\nnext",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 10},
+						End:      hcl.Pos{Line: 2, Column: 6, Byte: 13},
+					},
+				},
+			},
+			Stdout: `##vso[task.logissue type=error;sourcepath=subdir/test.tf;linenumber=1;columnnumber=1;code=test_rule]test
+##vso[task.logissue type=warning;sourcepath=test.tf;linenumber=2;columnnumber=3;code=test_rule_without_link]100%AZP25 [done];%0Anext
+##vso[task.complete result=Failed;]
+`,
+		},
+		{
+			Name: "warnings only",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRuleWithoutLink{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+			},
+			Stdout: `##vso[task.logissue type=warning;sourcepath=test.tf;linenumber=1;columnnumber=1;code=test_rule_without_link]test
+##vso[task.complete result=SucceededWithIssues;]
+`,
+		},
+		{
+			Name: "sources directory",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+			},
+			SourceDirectory: "..",
+			Stdout: `##vso[task.logissue type=error;sourcepath=formatter/test.tf;linenumber=1;columnnumber=1;code=test_rule]test
+##vso[task.complete result=Failed;]
+`,
+		},
+		{
+			Name: "errors",
+			Error: errors.Join(
+				errors.New("an error occurred"),
+				hclDiags(`resource "foo" "bar" {`),
+			),
+			Stdout: `##vso[task.logissue type=error]an error occurred
+##vso[task.logissue type=error;sourcepath=main.tf;linenumber=1;columnnumber=22]Unclosed configuration block; There is no closing brace for this block before the end of the file. This may be caused by incorrect brace nesting elsewhere in this file.
+##vso[task.complete result=Failed;]
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			sourceDirectory := tc.SourceDirectory
+			if sourceDirectory != "" {
+				abs, err := filepath.Abs(sourceDirectory)
+				if err != nil {
+					t.Fatal(err)
+				}
+				sourceDirectory = abs
+			}
+			t.Setenv("BUILD_SOURCESDIRECTORY", sourceDirectory)
+
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: stderr}
+
+			formatter.azureDevOpsPrint(tc.Issues, tc.Error)
+
+			if diff := cmp.Diff(tc.Stdout, stdout.String()); diff != "" {
+				t.Errorf("stdout: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_azureDevOpsEscapeProperty(t *testing.T) {
+	got := azureDevOpsEscapeProperty("a;b]c%d\r\ne")
+	if want := "a%3Bb%5Dc%AZP25d%0D%0Ae"; got != want {
+		t.Errorf("expected %s, but got %s", want, got)
+	}
+}
@@ -5,25 +5,43 @@ import (
 	"fmt"
 
 	hcl "github.com/hashicorp/hcl/v2"
+	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
 	"github.com/terraform-linters/tflint/tflint"
 )
 
 func (f *Formatter) compactPrint(issues tflint.Issues, appErr error, sources map[string][]byte) {
+	if f.CompactSeverityPrefix {
+		f.compactPrefixedPrint(issues, appErr, sources)
+		return
+	}
+
 	if len(issues) > 0 {
 		fmt.Fprintf(f.Stdout, "%d issue(s) found:\n\n", len(issues))
 	}
 
 	for _, issue := range issues {
+		suffix := ""
+		if f.CompactLinks && issue.Rule.Link() != "" {
+			suffix = fmt.Sprintf(" (%s)", issue.Rule.Link())
+		}
+		if issue.Fixable && !f.Fix {
+			suffix += " [fix available]"
+		}
 		fmt.Fprintf(
 			f.Stdout,
-			"%s:%d:%d: %s - %s (%s)\n",
+			"%s:%s: %s - %s (%s)%s\n",
 			issue.Range.Filename,
-			issue.Range.Start.Line,
-			issue.Range.Start.Column,
+			f.compactPosition(issue.Range, issueSource(issue, sources)),
 			issue.Rule.Severity(),
 			issue.Message,
 			issue.Rule.Name(),
+			suffix,
 		)
+		if f.SnippetContext > 0 {
+			if src := issueSource(issue, sources); src != nil {
+				f.prettyPrintSource(src, issue.Range.Filename, []hcl.Range{issue.Range})
+			}
+		}
 	}
 
 	f.compactPrintErrors(appErr, sources)
@@ -48,10 +66,9 @@ func (f *Formatter) compactPrintErrors(err error, sources map[string][]byte) {
 		for _, diag := range diags {
 			fmt.Fprintf(
 				f.Stdout,
-				"%s:%d:%d: %s - %s. %s\n",
+				"%s:%s: %s - %s. %s\n",
 				diag.Subject.Filename,
-				diag.Subject.Start.Line,
-				diag.Subject.Start.Column,
+				f.compactPosition(*diag.Subject, sources[diag.Subject.Filename]),
 				fromHclSeverity(diag.Severity),
 				diag.Summary,
 				diag.Detail,
@@ -62,3 +79,101 @@ func (f *Formatter) compactPrintErrors(err error, sources map[string][]byte) {
 
 	f.prettyPrintErrors(err, sources, false)
 }
+
+// compactPrefixedPrint outputs issues as "<file>:<line>:<column>: E: [<rule>] <message>".
+// No header is printed, so that every line of stdout is an issue.
+func (f *Formatter) compactPrefixedPrint(issues tflint.Issues, appErr error, sources map[string][]byte) {
+	for _, issue := range issues {
+		suffix := ""
+		if f.CompactLinks && issue.Rule.Link() != "" {
+			suffix = fmt.Sprintf(" (%s)", issue.Rule.Link())
+		}
+		if issue.Fixable && !f.Fix {
+			suffix += " [fix available]"
+		}
+		fmt.Fprintf(
+			f.Stdout,
+			"%s:%s: %s: [%s] %s%s\n",
+			issue.Range.Filename,
+			f.compactPosition(issue.Range, issueSource(issue, sources)),
+			severityInitial(issue.Rule.Severity()),
+			issue.Rule.Name(),
+			issue.Message,
+			suffix,
+		)
+		if f.SnippetContext > 0 {
+			if src := issueSource(issue, sources); src != nil {
+				f.prettyPrintSource(src, issue.Range.Filename, []hcl.Range{issue.Range})
+			}
+		}
+	}
+
+	f.compactPrefixedPrintErrors(appErr, sources)
+}
+
+// compactPrefixedPrintErrors outputs errors to stderr in the same style as issues.
+// Errors without locations are printed as "E: <message>".
+func (f *Formatter) compactPrefixedPrintErrors(err error, sources map[string][]byte) {
+	if err == nil {
+		return
+	}
+
+	// errors.Join
+	if errs, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, err := range errs.Unwrap() {
+			f.compactPrefixedPrintErrors(err, sources)
+		}
+		return
+	}
+
+	// hcl.Diagnostics
+	var diags hcl.Diagnostics
+	if errors.As(err, &diags) {
+		for _, diag := range diags {
+			initial := "E"
+			if diag.Severity == hcl.DiagWarning {
+				initial = "W"
+			}
+			if diag.Subject == nil {
+				fmt.Fprintf(f.Stderr, "%s: %s. %s\n", initial, diag.Summary, diag.Detail)
+				continue
+			}
+			fmt.Fprintf(
+				f.Stderr,
+				"%s:%s: %s: %s. %s\n",
+				diag.Subject.Filename,
+				f.compactPosition(*diag.Subject, sources[diag.Subject.Filename]),
+				initial,
+				diag.Summary,
+				diag.Detail,
+			)
+		}
+		return
+	}
+
+	fmt.Fprintf(f.Stderr, "E: %s\n", err)
+}
+
+// severityInitial returns the first letter of the severity, e.g. "E" for errors.
+func severityInitial(severity tflint.Severity) string {
+	switch severity {
+	case sdk.ERROR:
+		return "E"
+	case sdk.WARNING:
+		return "W"
+	case sdk.NOTICE:
+		return "N"
+	default:
+		panic(fmt.Errorf("Unexpected lint type: %s", severity))
+	}
+}
+
+// compactPosition returns the "line:column" of the start of the range. Columns are counted in runes.
+// If CompactRanges is set, the end is appended as "line:column-line:column".
+func (f *Formatter) compactPosition(rng hcl.Range, src []byte) string {
+	pos := fmt.Sprintf("%d:%d", rng.Start.Line, runeColumn(rng.Start, src))
+	if f.CompactRanges {
+		pos += fmt.Sprintf("-%d:%d", rng.End.Line, runeColumn(rng.End, src))
+	}
+	return pos
+}
@@ -12,11 +12,17 @@ import (
 
 func Test_compactPrint(t *testing.T) {
 	cases := []struct {
-		Name   string
-		Issues tflint.Issues
-		Error  error
-		Stdout string
-		Stderr string
+		Name    string
+		Issues  tflint.Issues
+		Error   error
+		Context int
+		Fix     bool
+		Links   bool
+		Ranges  bool
+		Prefix  bool
+		Sources map[string][]byte
+		Stdout  string
+		Stderr  string
 	}{
 		{
 			Name:   "no issues",
@@ -41,6 +47,144 @@ func Test_compactPrint(t *testing.T) {
 test.tf:1:1: Error - test (test_rule)
 `,
 		},
+		{
+			Name: "fixable issues",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+					Fixable: true,
+				},
+			},
+			Stdout: `1 issue(s) found:
+
+test.tf:1:1: Error - test (test_rule) [fix available]
+`,
+		},
+		{
+			Name: "fixable issues with --fix",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+					Fixable: true,
+				},
+			},
+			Fix: true,
+			Stdout: `1 issue(s) found:
+
+test.tf:1:1: Error - test (test_rule)
+`,
+		},
+		{
+			Name: "issues with context",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 25},
+						End:      hcl.Pos{Line: 2, Column: 6, Byte: 28},
+					},
+				},
+			},
+			Context: 1,
+			Sources: map[string][]byte{"test.tf": []byte("resource \"foo\" \"bar\" {\n  ami = \"ami\"\n}\n")},
+			Stdout: `1 issue(s) found:
+
+test.tf:2:3: Error - test (test_rule)
+   1: resource "foo" "bar" {
+   2:   ami = "ami"
+   3: }
+`,
+		},
+		{
+			Name: "issues with links",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+					Fixable: true,
+				},
+				{
+					Rule:    &testRuleWithoutLink{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 25},
+						End:      hcl.Pos{Line: 2, Column: 6, Byte: 28},
+					},
+				},
+			},
+			Links: true,
+			Stdout: `2 issue(s) found:
+
+test.tf:1:1: Error - test (test_rule) (https://github.com) [fix available]
+test.tf:2:3: Warning - test (test_rule_without_link)
+`,
+		},
+		{
+			Name: "issues with ranges",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 3, Column: 2, Byte: 40},
+					},
+				},
+			},
+			Ranges: true,
+			Stdout: `1 issue(s) found:
+
+test.tf:1:1-3:2: Error - test (test_rule)
+`,
+		},
+		{
+			// Columns in HCL are counted in grapheme clusters, and the emoji with a skin tone modifier is one cluster of two runes
+			Name: "issues after multibyte characters",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 19, Byte: 25},
+						End:      hcl.Pos{Line: 1, Column: 28, Byte: 40},
+					},
+				},
+			},
+			Ranges:  true,
+			Sources: map[string][]byte{"test.tf": []byte(`locals { a = "👍🏽", b = "日本語" }`)},
+			Stdout: `1 issue(s) found:
+
+test.tf:1:20-1:29: Error - test (test_rule)
+`,
+		},
+		{
+			Name:   "diagnostics with ranges",
+			Error:  hclDiags(`resource "foo" "bar" {`),
+			Ranges: true,
+			Stdout: "main.tf:1:22-1:23: error - Unclosed configuration block. There is no closing brace for this block before the end of the file. This may be caused by incorrect brace nesting elsewhere in this file.\n",
+		},
 		{
 			Name:   "error",
 			Error:  errors.New("an error occurred"),
@@ -61,14 +205,65 @@ test.tf:1:1: Error - test (test_rule)
 			Stdout: "main.tf:1:22: error - Unclosed configuration block. There is no closing brace for this block before the end of the file. This may be caused by incorrect brace nesting elsewhere in this file.\n",
 			Stderr: "an error occurred\nfailed\n",
 		},
+		{
+			Name: "issues with severity prefixes",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+					Fixable: true,
+				},
+				{
+					Rule:    &testRuleWithoutLink{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 7},
+						End:      hcl.Pos{Line: 2, Column: 6, Byte: 10},
+					},
+				},
+			},
+			Prefix: true,
+			Stdout: `test.tf:1:1: E: [test_rule] test [fix available]
+test.tf:2:3: W: [test_rule_without_link] test
+`,
+		},
+		{
+			Name:   "no issues with severity prefixes",
+			Issues: tflint.Issues{},
+			Prefix: true,
+			Stdout: "",
+		},
+		{
+			Name: "joined errors with severity prefixes",
+			Error: errors.Join(
+				errors.New("an error occurred"),
+				hclDiags(`resource "foo" "bar" {`),
+				hcl.Diagnostics{{Severity: hcl.DiagWarning, Summary: "summary", Detail: "detail"}},
+			),
+			Prefix: true,
+			Stderr: `E: an error occurred
+main.tf:1:22: E: Unclosed configuration block. There is no closing brace for this block before the end of the file. This may be caused by incorrect brace nesting elsewhere in this file.
+W: summary. detail
+`,
+		},
 	}
 
 	for _, tc := range cases {
 		stdout := &bytes.Buffer{}
 		stderr := &bytes.Buffer{}
-		formatter := &Formatter{Stdout: stdout, Stderr: stderr}
+		formatter := &Formatter{Stdout: stdout, Stderr: stderr, SnippetContext: tc.Context, Fix: tc.Fix, CompactLinks: tc.Links, CompactRanges: tc.Ranges, CompactSeverityPrefix: tc.Prefix}
 
-		formatter.compactPrint(tc.Issues, tc.Error, map[string][]byte{})
+		sources := tc.Sources
+		if sources == nil {
+			sources = map[string][]byte{}
+		}
+		formatter.compactPrint(tc.Issues, tc.Error, sources)
 
 		if stdout.String() != tc.Stdout {
 			t.Errorf("Failed %s test: expected=%s, stdout=%s", tc.Name, tc.Stdout, stdout.String())
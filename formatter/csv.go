@@ -0,0 +1,41 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+var csvHeader = []string{"rule", "severity", "message", "file", "start_line", "start_col", "end_line", "end_col", "link"}
+
+// csvPrint outputs issues as RFC 4180 CSV.
+// Filenames are relative to the directory where TFLint was invoked, even in recursive inspection.
+func (f *Formatter) csvPrint(issues tflint.Issues, appErr error, sources map[string][]byte) {
+	w := csv.NewWriter(f.Stdout)
+
+	records := [][]string{csvHeader}
+	for _, issue := range issues.Sort() {
+		records = append(records, []string{
+			issue.Rule.Name(),
+			toSeverity(issue.Rule.Severity()),
+			issue.Message,
+			issue.Range.Filename,
+			strconv.Itoa(issue.Range.Start.Line),
+			strconv.Itoa(issue.Range.Start.Column),
+			strconv.Itoa(issue.Range.End.Line),
+			strconv.Itoa(issue.Range.End.Column),
+			issue.Rule.Link(),
+		})
+	}
+
+	if err := w.WriteAll(records); err != nil {
+		fmt.Fprint(f.Stderr, err)
+	}
+
+	// Errors are not mixed into the rows so that the output can be imported as is
+	if appErr != nil {
+		f.prettyPrintErrors(appErr, sources, false)
+	}
+}
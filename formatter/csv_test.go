@@ -0,0 +1,96 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func Test_csvPrint(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Issues tflint.Issues
+		Error  error
+		Stdout string
+		Stderr string
+	}{
+		{
+			Name:   "no issues",
+			Issues: tflint.Issues{},
+			Stdout: "rule,severity,message,file,start_line,start_col,end_line,end_col,link\n",
+		},
+		{
+			Name: "issues",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRuleWithoutLink{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "subdir/test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 2, Column: 4, Byte: 8},
+					},
+				},
+			},
+			Stdout: `rule,severity,message,file,start_line,start_col,end_line,end_col,link
+test_rule_without_link,warning,test,subdir/test.tf,1,1,1,4,
+test_rule,error,test,test.tf,1,1,2,4,https://github.com
+`,
+		},
+		{
+			Name: "escaped message",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "a \"quoted\", multiline\nmessage",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+			},
+			Stdout: `rule,severity,message,file,start_line,start_col,end_line,end_col,link
+test_rule,error,"a ""quoted"", multiline
+message",test.tf,1,1,1,4,https://github.com
+`,
+		},
+		{
+			Name:   "error",
+			Issues: tflint.Issues{},
+			Error:  errors.New("Failed to check ruleset. An error occurred."),
+			Stdout: "rule,severity,message,file,start_line,start_col,end_line,end_col,link\n",
+			Stderr: "Failed to check ruleset. An error occurred.\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: stderr}
+
+			formatter.csvPrint(tc.Issues, tc.Error, map[string][]byte{})
+
+			if diff := cmp.Diff(tc.Stdout, stdout.String()); diff != "" {
+				t.Errorf("stdout: %s", diff)
+			}
+			if diff := cmp.Diff(tc.Stderr, stderr.String()); diff != "" {
+				t.Errorf("stderr: %s", diff)
+			}
+		})
+	}
+}
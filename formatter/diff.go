@@ -0,0 +1,78 @@
+package formatter
+
+import (
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// DefaultDiffMaxLines is the default maximum number of lines of each diff.
+const DefaultDiffMaxLines = 200
+
+// fileDiff is a unified diff of a file rewritten by --fix.
+type fileDiff struct {
+	// Filename is the path of the file, converted according to PathMode.
+	Filename string
+	// Diff is the unified diff of the file. If truncated, OmittedLines is the number of omitted lines.
+	Diff         string
+	OmittedLines int
+}
+
+// fileDiffs returns unified diffs of Changes truncated to DiffMaxLines lines.
+// Nothing is returned if --fix is not passed.
+func (f *Formatter) fileDiffs() []fileDiff {
+	if !f.Fix {
+		return []fileDiff{}
+	}
+	maxLines := f.DiffMaxLines
+	if maxLines == 0 {
+		maxLines = DefaultDiffMaxLines
+	}
+
+	ret := []fileDiff{}
+	for _, change := range f.Changes {
+		path := f.rewritePath(change.Filename)
+		diff := UnifiedDiff(path, path, change.Before, change.After)
+		// Fixes that do not change the content, e.g. formatting the same code, have no diff
+		if diff == "" {
+			continue
+		}
+
+		lines := strings.SplitAfter(strings.TrimSuffix(diff, "\n"), "\n")
+		if len(lines) > maxLines {
+			ret = append(ret, fileDiff{Filename: path, Diff: strings.Join(lines[:maxLines], ""), OmittedLines: len(lines) - maxLines})
+			continue
+		}
+		ret = append(ret, fileDiff{Filename: path, Diff: diff})
+	}
+	return ret
+}
+
+// UnifiedDiff returns the unified diff of the file with 3 lines of context.
+// fromFile and toFile are the names in the "---" and "+++" headers. It is empty if the sources are the same.
+func UnifiedDiff(fromFile string, toFile string, before []byte, after []byte) string {
+	// The error is ignored because writing to the string buffer never fails
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(before),
+		B:        splitLines(after),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+	return diff
+}
+
+// splitLines splits the source into lines with line endings.
+// Unlike difflib.SplitLines, an empty line is not added after the last line ending.
+func splitLines(source []byte) []string {
+	if len(source) == 0 {
+		return []string{}
+	}
+	lines := strings.SplitAfter(string(source), "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	// The last line without a line ending is terminated so that it does not join the next line of the diff
+	lines[len(lines)-1] += "\n"
+	return lines
+}
@@ -0,0 +1,81 @@
+package formatter
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func Test_fileDiffs(t *testing.T) {
+	wd, err := filepath.Abs(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		fix      bool
+		changes  []tflint.FileChange
+		maxLines int
+		pathMode string
+		want     []fileDiff
+	}{
+		{
+			name:    "without --fix",
+			changes: []tflint.FileChange{{Filename: "main.tf", Before: []byte("a = 1\n"), After: []byte("a = 2\n")}},
+			want:    []fileDiff{},
+		},
+		{
+			name: "changes",
+			fix:  true,
+			changes: []tflint.FileChange{
+				{Filename: "main.tf", Before: []byte("a = 1\nb = 2\n"), After: []byte("a = 1\nb = 3\n")},
+				{Filename: "outputs.tf", Before: []byte("c = 1"), After: []byte("c = 2")},
+			},
+			want: []fileDiff{
+				{Filename: "main.tf", Diff: "--- main.tf\n+++ main.tf\n@@ -1,2 +1,2 @@\n a = 1\n-b = 2\n+b = 3\n"},
+				{Filename: "outputs.tf", Diff: "--- outputs.tf\n+++ outputs.tf\n@@ -1 +1 @@\n-c = 1\n+c = 2\n"},
+			},
+		},
+		{
+			name:    "no differences",
+			fix:     true,
+			changes: []tflint.FileChange{{Filename: "main.tf", Before: []byte("a = 1\n"), After: []byte("a = 1\n")}},
+			want:    []fileDiff{},
+		},
+		{
+			name:     "truncated",
+			fix:      true,
+			changes:  []tflint.FileChange{{Filename: "main.tf", Before: []byte("a = 1\nb = 2\n"), After: []byte("a = 2\nb = 3\n")}},
+			maxLines: 4,
+			want: []fileDiff{
+				{Filename: "main.tf", Diff: "--- main.tf\n+++ main.tf\n@@ -1,2 +1,2 @@\n-a = 1\n", OmittedLines: 3},
+			},
+		},
+		{
+			name:     "absolute path mode",
+			fix:      true,
+			changes:  []tflint.FileChange{{Filename: "main.tf", Before: []byte("a = 1\n"), After: []byte("a = 2\n")}},
+			pathMode: "absolute",
+			want: []fileDiff{
+				{
+					Filename: filepath.Join(wd, "main.tf"),
+					Diff:     "--- " + filepath.Join(wd, "main.tf") + "\n+++ " + filepath.Join(wd, "main.tf") + "\n@@ -1 +1 @@\n-a = 1\n+a = 2\n",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			formatter := &Formatter{Fix: test.fix, Changes: test.changes, DiffMaxLines: test.maxLines, PathMode: test.pathMode}
+
+			got := formatter.fileDiffs()
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package formatter
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// FormatOption is an option accepted by a format. Options are set with `--format-option key=value`
+// or the "options" attribute of a "format" block in the config file.
+type FormatOption struct {
+	Name        string
+	Description string
+
+	// apply parses the value and sets it to the formatter
+	apply func(f *Formatter, value string) error
+}
+
+// formatOptions is the registry of options for each format.
+// Formats not in the registry do not accept any options.
+var formatOptions = map[string][]FormatOption{
+	"default": {
+		contextOption,
+		boolOption("group_by_file", "Group issues by filename", func(f *Formatter) *bool { return &f.GroupByFile }),
+		boolOption("no_summary", "Hide the summary of issues", func(f *Formatter) *bool { return &f.NoSummary }),
+		quietSuccessOption,
+		diffMaxLinesOption,
+	},
+	"compact": {
+		contextOption,
+		boolOption("links", "Print rule links of issues", func(f *Formatter) *bool { return &f.CompactLinks }),
+		boolOption("ranges", "Print the end line and column of issues", func(f *Formatter) *bool { return &f.CompactRanges }),
+		boolOption("severity_prefix", "Print issues as \"<file>:<line>:<column>: E: [<rule>] <message>\" and errors to stderr in the same style", func(f *Formatter) *bool { return &f.CompactSeverityPrefix }),
+		quietSuccessOption,
+	},
+	"json": {
+		contextOption,
+		includeSourceOption,
+		sourceMaxLengthOption,
+		stringOption("version", "Pin the schema version", func(f *Formatter) *string { return &f.JSONVersion }),
+		diffMaxLinesOption,
+		boolOption("group_by_dir", "Add results of each inspected directory as \"runs\"", func(f *Formatter) *bool { return &f.GroupByDir }),
+	},
+	"jsonl": {
+		includeSourceOption,
+		sourceMaxLengthOption,
+	},
+	"stream": {
+		includeSourceOption,
+		sourceMaxLengthOption,
+	},
+	"html": {
+		contextOption,
+	},
+}
+
+// Options shared by multiple formats
+var (
+	contextOption         = intOption("context", "Print N lines of source before and after issue ranges", func(f *Formatter) *int { return &f.SnippetContext })
+	quietSuccessOption    = boolOption("quiet_success", "Print nothing if no issues are reported and no errors occurred", func(f *Formatter) *bool { return &f.QuietSuccess })
+	includeSourceOption   = boolOption("include_source", "Include the source of issue ranges as \"snippet\"", func(f *Formatter) *bool { return &f.IncludeSource })
+	sourceMaxLengthOption = intOption("source_max_length", "Truncate snippets to N bytes", func(f *Formatter) *int { return &f.SourceMaxLength })
+	diffMaxLinesOption    = intOption("diff_max_lines", "Truncate diffs of --fix to N lines", func(f *Formatter) *int { return &f.DiffMaxLines })
+)
+
+func boolOption(name string, description string, field func(*Formatter) *bool) FormatOption {
+	return FormatOption{Name: name, Description: description, apply: func(f *Formatter, value string) error {
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("must be a boolean, but got `%s`", value)
+		}
+		*field(f) = v
+		return nil
+	}}
+}
+
+func intOption(name string, description string, field func(*Formatter) *int) FormatOption {
+	return FormatOption{Name: name, Description: description, apply: func(f *Formatter, value string) error {
+		v, err := strconv.Atoi(value)
+		if err != nil || v < 0 {
+			return fmt.Errorf("must be a non-negative integer, but got `%s`", value)
+		}
+		*field(f) = v
+		return nil
+	}}
+}
+
+func stringOption(name string, description string, field func(*Formatter) *string) FormatOption {
+	return FormatOption{Name: name, Description: description, apply: func(f *Formatter, value string) error {
+		*field(f) = value
+		return nil
+	}}
+}
+
+// FormatOptions returns options accepted by the format in order of name.
+// The empty format is the same as "default".
+func FormatOptions(format string) []FormatOption {
+	if format == "" {
+		format = "default"
+	}
+	return slices.SortedFunc(slices.Values(formatOptions[format]), func(a, b FormatOption) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+// ParseFormatOptions parses options in the form of "key=value" (e.g. "context=2").
+// If the same key is passed multiple times, the last one takes precedence.
+func ParseFormatOptions(options []string) (map[string]string, error) {
+	ret := map[string]string{}
+
+	for _, option := range options {
+		key, value, found := strings.Cut(option, "=")
+		if !found || key == "" {
+			return ret, fmt.Errorf("`%s` is invalid. The format option must be in the form of `key=value`", option)
+		}
+		ret[key] = value
+	}
+
+	return ret, nil
+}
+
+// ApplyFormatOptions validates options against the options accepted by the format and sets them.
+// Unknown options are errors, so that typos are detected before inspection.
+func (f *Formatter) ApplyFormatOptions(format string, options map[string]string) error {
+	if format == "" {
+		format = "default"
+	}
+	accepted := FormatOptions(format)
+
+	// Apply in order of keys so that the first error is deterministic
+	for _, key := range slices.Sorted(maps.Keys(options)) {
+		idx := slices.IndexFunc(accepted, func(option FormatOption) bool { return option.Name == key })
+		if idx < 0 {
+			if len(accepted) == 0 {
+				return fmt.Errorf("%s is not a valid option for the %s format. The %s format does not accept any options", key, format, format)
+			}
+			names := make([]string, len(accepted))
+			for i, option := range accepted {
+				names[i] = option.Name
+			}
+			return fmt.Errorf("%s is not a valid option for the %s format. Valid options are %s", key, format, strings.Join(names, ", "))
+		}
+		if err := accepted[idx].apply(f, options[key]); err != nil {
+			return fmt.Errorf("%s option of the %s format %w", key, format, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package formatter
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestParseFormatOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []string
+		want    map[string]string
+		err     string
+	}{
+		{
+			name:    "empty",
+			options: []string{},
+			want:    map[string]string{},
+		},
+		{
+			name:    "multiple options",
+			options: []string{"links=true", "context=2", "links=false"},
+			want:    map[string]string{"links": "false", "context": "2"},
+		},
+		{
+			name:    "value with equal signs",
+			options: []string{"version=a=b"},
+			want:    map[string]string{"version": "a=b"},
+		},
+		{
+			name:    "without value",
+			options: []string{"links"},
+			err:     "`links` is invalid. The format option must be in the form of `key=value`",
+		},
+		{
+			name:    "without key",
+			options: []string{"=true"},
+			err:     "`=true` is invalid. The format option must be in the form of `key=value`",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseFormatOptions(test.options)
+			if err != nil {
+				if test.err == "" || err.Error() != test.err {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if test.err != "" {
+				t.Fatalf("expected error %q, but got nil", test.err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestApplyFormatOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		options map[string]string
+		want    *Formatter
+		err     string
+	}{
+		{
+			name:    "no options",
+			format:  "sarif",
+			options: map[string]string{},
+			want:    &Formatter{},
+		},
+		{
+			name:    "compact",
+			format:  "compact",
+			options: map[string]string{"links": "true", "ranges": "1", "context": "2"},
+			want:    &Formatter{CompactLinks: true, CompactRanges: true, SnippetContext: 2},
+		},
+		{
+			name:    "empty format is default",
+			format:  "",
+			options: map[string]string{"group_by_file": "true", "no_summary": "true", "quiet_success": "true"},
+			want:    &Formatter{GroupByFile: true, NoSummary: true, QuietSuccess: true},
+		},
+		{
+			name:    "json",
+			format:  "json",
+			options: map[string]string{"include_source": "true", "source_max_length": "10", "version": "1.0"},
+			want:    &Formatter{IncludeSource: true, SourceMaxLength: 10, JSONVersion: "1.0"},
+		},
+		{
+			name:    "unknown option",
+			format:  "compact",
+			options: map[string]string{"link": "true"},
+			err:     "link is not a valid option for the compact format. Valid options are context, links, quiet_success, ranges, severity_prefix",
+		},
+		{
+			name:    "format without options",
+			format:  "sarif",
+			options: map[string]string{"include_rule_metadata": "true"},
+			err:     "include_rule_metadata is not a valid option for the sarif format. The sarif format does not accept any options",
+		},
+		{
+			name:    "invalid boolean",
+			format:  "compact",
+			options: map[string]string{"links": "yes"},
+			err:     "links option of the compact format must be a boolean, but got `yes`",
+		},
+		{
+			name:    "negative integer",
+			format:  "json",
+			options: map[string]string{"context": "-1"},
+			err:     "context option of the json format must be a non-negative integer, but got `-1`",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := &Formatter{}
+			err := got.ApplyFormatOptions(test.format, test.options)
+			if err != nil {
+				if test.err == "" || err.Error() != test.err {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if test.err != "" {
+				t.Fatalf("expected error %q, but got nil", test.err)
+			}
+			if diff := cmp.Diff(test.want, got, cmpopts.IgnoreUnexported(Formatter{})); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestFormatOptions(t *testing.T) {
+	// Every option must have a description to be listed in the documentation
+	for format, options := range formatOptions {
+		if len(options) == 0 {
+			t.Errorf("%s format is registered without options", format)
+		}
+		for _, option := range options {
+			if option.Name == "" || option.Description == "" || option.apply == nil {
+				t.Errorf("%s format has an incomplete option: %+v", format, option)
+			}
+		}
+	}
+
+	if diff := cmp.Diff(FormatOptions(""), FormatOptions("default"), cmpopts.IgnoreFields(FormatOption{}, "apply")); diff != "" {
+		t.Error(diff)
+	}
+}
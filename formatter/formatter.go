@@ -1,10 +1,18 @@
 package formatter
 
 import (
+	"bufio"
+	"bytes"
+	"cmp"
 	"errors"
 	"fmt"
 	"io"
+	"path/filepath"
 	"slices"
+	"strings"
+	"sync"
+	"text/template"
+	"unicode/utf8"
 
 	hcl "github.com/hashicorp/hcl/v2"
 	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
@@ -13,35 +21,311 @@ import (
 
 // Formatter outputs appropriate results to stdout and stderr depending on the format
 type Formatter struct {
-	Stdout  io.Writer
-	Stderr  io.Writer
-	Format  string
-	Fix     bool
-	NoColor bool
+	Stdout io.Writer
+	Stderr io.Writer
+	Format string
+	Fix    bool
+
+	// Color enables colorized output to Stdout, and ColorStderr to Stderr.
+	// They are decided for each Formatter, so the global color.NoColor is not used.
+	Color       bool
+	ColorStderr bool
+
+	// NoSummary hides the summary of issues at the end of the default format.
+	NoSummary bool
+
+	// GroupByFile groups issues by filename in the default format.
+	GroupByFile bool
+
+	// CompactLinks appends rule links to issues in the compact format, and
+	// CompactRanges adds the end of issue ranges. They are opt-in so as not to break existing parsers.
+	CompactLinks  bool
+	CompactRanges bool
+
+	// CompactSeverityPrefix prints issues as "<file>:<line>:<column>: E: [<rule>] <message>" in the compact format,
+	// with initials of severities for quick eye-scanning. Errors are printed to Stderr in the same style.
+	CompactSeverityPrefix bool
+
+	// QuietSuccess prints nothing to stdout in the default and compact formats
+	// if no issues are reported and no errors occurred, not even the number of hidden issues.
+	QuietSuccess bool
+
+	// SnippetContext is the number of lines printed before and after issue ranges
+	// in the default and compact formats. In the json format, the lines are added as "context".
+	SnippetContext int
+
+	// IncludeSource adds the source of issue ranges as "snippet" in the json format.
+	IncludeSource bool
+
+	// SourceMaxLength is the maximum length of snippets in bytes. If zero, DefaultSourceMaxLength is used.
+	SourceMaxLength int
+
+	// JSONVersion is the schema version of the json format. The latest version is used if empty.
+	JSONVersion string
+
+	// MinimumReportSeverity hides issues below the severity in output,
+	// but callers still count hidden issues for the exit status. The number of hidden issues is printed in summaries.
+	MinimumReportSeverity string
+
+	// SonarQubeSeverities overrides the SonarQube severity for each severity in the sonarqube format.
+	SonarQubeSeverities map[tflint.Severity]string
+
+	// Template is the parsed template for the template format.
+	Template *template.Template
+
+	// SummaryStdout is an additional output where issues are printed in the default format.
+	// It is set when the report is written to a file, so that results are still readable in the terminal.
+	SummaryStdout io.Writer
+
+	// SummaryColor enables colorized output to SummaryStdout.
+	SummaryColor bool
+
+	// ReportStdout is a secondary output where the report is written in ReportFormat, e.g. a file archived in CI
+	// while results are printed to Stdout for humans. A failure to write it is printed to Stderr as a warning.
+	ReportStdout io.Writer
+	ReportFormat string
+
+	// WorkingDirs are the directories inspected in recursive mode.
+	// Formats that report results per directory use them.
+	WorkingDirs []string
+
+	// PathMode controls how paths of issues are printed. The following values are valid:
+	//   - from-cwd: relative to the current directory (default)
+	//   - relative: relative to the inspected module directory in ModuleDirs
+	//   - absolute: absolute paths
+	PathMode string
+
+	// ScannedFiles are the configuration files loaded for inspection. They are output in the json format.
+	ScannedFiles []string
+
+	// Statistics are counts of the inspection. If set, they are output in the json format
+	// and printed as a line in the default format.
+	Statistics *Statistics
+
+	// Changes are files rewritten by --fix. Their diffs are printed in the default and json formats if Fix is true.
+	Changes []tflint.FileChange
+
+	// DryRun prints a patch of Changes to Stdout instead of the results, so that fixes can be previewed or applied later.
+	// Errors are still printed to Stderr. In the sarif format, the results are printed with fixes instead of the patch.
+	DryRun bool
+
+	// DiffMaxLines is the maximum number of lines of each diff in Changes. If zero, DefaultDiffMaxLines is used.
+	DiffMaxLines int
+
+	// ModuleDirs are the directories of inspected modules relative to the current directory.
+	// They are used to print paths relative to modules.
+	ModuleDirs []string
+
+	// GroupByDir adds results of each directory in ModuleDirs to the json format.
+	GroupByDir bool
+
+	// IssuesTruncated is true if issues are dropped by --max-issues, and MaxIssues is the limit.
+	// A notice is printed in the default format, and "issues_truncated" is set in the json format.
+	IssuesTruncated bool
+	MaxIssues       int
 
 	// Errors occurred in parallel workers.
 	// Some formats do not output immediately, so they are saved here.
 	errInParallel error
+
+	// An error occurred while printing results, e.g. failed to execute the template.
+	printErr error
+
+	// The number of issues hidden by MinimumReportSeverity in the last Print.
+	hiddenIssues int
+
+	// State shared by printing goroutines. It is created on first use by shared, and is not copied by withStdout.
+	state *formatterState
+}
+
+// formatterState is the state of a Formatter that is shared by goroutines printing results in parallel.
+type formatterState struct {
+	// streamMu prevents lines in streaming formats from being interleaved
+	streamMu sync.Mutex
+
+	// The encoder of the stream format, created on first use
+	streamEncoderOnce sync.Once
+	streamEnc         *StreamEncoder
+
+	// Aggregate counts of results printed in the stream format. It is protected by streamMu.
+	streamSummary *JSONSummary
+
+	// Fingerprints of issues whose paths are rewritten, computed from the original paths
+	// so that they do not depend on PathMode. It is protected by fingerprintsMu.
+	fingerprints   map[*tflint.Issue]string
+	fingerprintsMu sync.Mutex
+
+	// Original issues of issues whose paths are rewritten. It is also protected by fingerprintsMu.
+	originals map[*tflint.Issue]*tflint.Issue
+}
+
+// formatterStateMu protects the creation of formatterState, because Formatters are created as literals.
+var formatterStateMu sync.Mutex
+
+// shared returns the state shared by goroutines printing results, creating it on first use.
+func (f *Formatter) shared() *formatterState {
+	formatterStateMu.Lock()
+	defer formatterStateMu.Unlock()
+	if f.state == nil {
+		f.state = &formatterState{}
+	}
+	return f.state
 }
 
+// bufferedFormats are formats that print errors in parallel workers
+// together with issues at the end, instead of in real time.
+var bufferedFormats = []string{"json", "checkstyle", "junit", "compact", "sarif", "gitlab", "github", "rdjson", "tap", "csv", "markdown", "html", "azure-devops", "teamcity", "sonarqube", "template", "unix"}
+
+// streamingFormats are formats that print issues and errors in parallel workers
+// as soon as each worker finishes, instead of at the end.
+var streamingFormats = []string{"jsonl", "stream"}
+
 // Print outputs the given issues and errors according to configured format
 func (f *Formatter) Print(issues tflint.Issues, err error, sources map[string][]byte) {
+	f.printReport(issues, err, sources)
+	if f.printsPatch() {
+		f.patchPrint(err, sources)
+		return
+	}
+	issues, sources = f.prepare(issues, sources)
+
 	switch f.Format {
 	case "default":
 		f.prettyPrint(issues, err, sources)
 	case "json":
-		f.jsonPrint(issues, err)
+		f.jsonPrint(issues, err, sources)
 	case "checkstyle":
 		f.checkstylePrint(issues, err, sources)
 	case "junit":
 		f.junitPrint(issues, err, sources)
 	case "compact":
 		f.compactPrint(issues, err, sources)
+	case "unix":
+		f.unixPrint(issues, err, sources)
 	case "sarif":
-		f.sarifPrint(issues, err)
+		f.sarifPrint(issues, err, sources)
+	case "gitlab":
+		f.gitlabPrint(issues, err, sources)
+	case "github":
+		f.githubPrint(issues, err)
+	case "rdjson":
+		f.rdjsonPrint(issues, err, sources)
+	case "tap":
+		f.tapPrint(issues, err, sources)
+	case "csv":
+		f.csvPrint(issues, err, sources)
+	case "markdown":
+		f.markdownPrint(issues, err)
+	case "html":
+		f.htmlPrint(issues, err, sources)
+	case "azure-devops":
+		f.azureDevOpsPrint(issues, err)
+	case "teamcity":
+		f.teamcityPrint(issues, err)
+	case "sonarqube":
+		f.sonarQubePrint(issues, err, sources)
+	case "template":
+		f.templatePrint(issues, err, sources)
+	case "jsonl":
+		f.jsonlPrint(issues, err, sources)
+	case "stream":
+		f.streamPrint(issues, err, sources)
 	default:
 		f.prettyPrint(issues, err, sources)
 	}
+
+	f.printSummary(issues, sources, f.hiddenIssues)
+}
+
+// Render returns the report that Print would write to Stdout, so that callers embedding TFLint
+// can render results without printing them. Output to Stderr, e.g. errors in the default format,
+// is still written to Stderr, and SummaryStdout and ReportStdout are not used.
+// The returned error is an error occurred while rendering, e.g. failed to execute the template.
+func (f *Formatter) Render(issues tflint.Issues, err error, sources map[string][]byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	renderer := f.withStdout(buf)
+	renderer.SummaryStdout = nil
+	renderer.ReportStdout = nil
+	renderer.Print(issues, err, sources)
+	return buf.Bytes(), renderer.printErr
+}
+
+// prepare returns issues and sources to be printed. Issues below MinimumReportSeverity are hidden,
+// the number of hidden issues is saved, and paths are converted according to PathMode.
+func (f *Formatter) prepare(issues tflint.Issues, sources map[string][]byte) (tflint.Issues, map[string][]byte) {
+	issues, f.hiddenIssues = f.filterByMinimumReportSeverity(issues)
+	return f.rewritePaths(issues, sources)
+}
+
+// withStdout returns a new Formatter with the same configuration that writes to the given stdout.
+// Results of the last print, such as errors in parallel workers, and the shared state are not copied.
+func (f *Formatter) withStdout(stdout io.Writer) *Formatter {
+	c := *f
+	c.Stdout = stdout
+	c.errInParallel = nil
+	c.printErr = nil
+	c.state = nil
+	return &c
+}
+
+// printSummary outputs issues to SummaryStdout in the default format.
+// Errors are not printed because they are included in the report or already printed to stderr.
+func (f *Formatter) printSummary(issues tflint.Issues, sources map[string][]byte, hidden int) {
+	if f.SummaryStdout == nil {
+		return
+	}
+	summary := f.withStdout(f.SummaryStdout)
+	summary.Format = "default"
+	summary.Color = f.SummaryColor
+	summary.SummaryStdout = nil
+	summary.hiddenIssues = hidden
+	summary.prettyPrint(issues, nil, sources)
+}
+
+// printsPatch returns true if the patch of Changes is printed instead of the results.
+// The sarif format can represent fixes, so the results are printed with them.
+func (f *Formatter) printsPatch() bool {
+	return f.DryRun && f.Format != "sarif"
+}
+
+// printReport outputs issues and errors to ReportStdout in ReportFormat.
+// Errors are not printed to Stderr because they are already printed by the primary output.
+// The report is rendered before writing, so that a failure to render it does not leave a partial report.
+func (f *Formatter) printReport(issues tflint.Issues, err error, sources map[string][]byte) {
+	if f.ReportStdout == nil {
+		return
+	}
+	report := f.withStdout(f.ReportStdout)
+	report.Stderr = io.Discard
+	report.Format = f.ReportFormat
+	report.Color = false
+	report.DryRun = false
+
+	out, renderErr := report.Render(issues, err, sources)
+	if renderErr != nil {
+		fmt.Fprintf(f.Stderr, "Warning: Failed to render the report file; %s\n", renderErr)
+		return
+	}
+	if _, err := f.ReportStdout.Write(out); err != nil {
+		fmt.Fprintf(f.Stderr, "Warning: Failed to write the report file; %s\n", err)
+	}
+}
+
+// filterByMinimumReportSeverity hides issues below MinimumReportSeverity and returns the number of hidden issues
+func (f *Formatter) filterByMinimumReportSeverity(issues tflint.Issues) (tflint.Issues, int) {
+	if f.MinimumReportSeverity != "" {
+		if minSeverity, err := tflint.NewSeverity(f.MinimumReportSeverity); err == nil {
+			reported := issues.FilterBySeverity(minSeverity)
+			return reported, len(issues) - len(reported)
+		}
+	}
+	return issues, 0
+}
+
+// Err returns an error occurred while printing results.
+// The exit status should be an error if this is not nil.
+func (f *Formatter) Err() error {
+	return f.printErr
 }
 
 // PrintErrorParallel outputs an error occurred in parallel workers.
@@ -54,10 +338,18 @@ func (f *Formatter) PrintErrorParallel(err error, sources map[string][]byte) {
 		f.errInParallel = errors.Join(f.errInParallel, err)
 	}
 
-	if slices.Contains([]string{"json", "checkstyle", "junit", "compact", "sarif"}, f.Format) {
+	if slices.Contains(bufferedFormats, f.Format) {
 		// These formats require errors to be printed at the end, so do nothing here
 		return
 	}
+	if f.Format == "stream" {
+		f.streamPrintResults(tflint.Issues{}, err, sources, 0)
+		return
+	}
+	if slices.Contains(streamingFormats, f.Format) {
+		f.jsonlPrintErrors(err, sources)
+		return
+	}
 
 	// Print errors immediately for other formats
 	f.prettyPrintErrors(err, sources, true)
@@ -67,18 +359,304 @@ func (f *Formatter) PrintErrorParallel(err error, sources map[string][]byte) {
 // Errors stored with PrintErrorParallel are output,
 // but in the default format they are output in real time, so they are ignored.
 func (f *Formatter) PrintParallel(issues tflint.Issues, sources map[string][]byte) error {
-	if slices.Contains([]string{"json", "checkstyle", "junit", "compact", "sarif"}, f.Format) {
+	if f.printsPatch() {
+		// Errors are already printed in real time
+		f.printReport(issues, f.errInParallel, sources)
+		f.patchPrint(nil, sources)
+		if f.errInParallel != nil {
+			return f.errInParallel
+		}
+		return f.printErr
+	}
+
+	if slices.Contains(bufferedFormats, f.Format) {
 		f.Print(issues, f.errInParallel, sources)
-		return f.errInParallel
+		if f.errInParallel != nil {
+			return f.errInParallel
+		}
+		return f.printErr
+	}
+
+	if slices.Contains(streamingFormats, f.Format) {
+		// Issues and errors are already printed by PrintStream and PrintErrorParallel, but the report needs all of them
+		f.printReport(issues, f.errInParallel, sources)
+		if f.Format == "stream" {
+			f.streamPrintSummary()
+		}
+		if f.errInParallel != nil {
+			return f.errInParallel
+		}
+		return f.printErr
 	}
 
 	if f.errInParallel != nil {
-		// Do not print the errors since they are already printed in real time
+		// Do not print the errors since they are already printed in real time.
+		// Statistics are still printed, so that failed directories are counted.
+		f.printReport(issues, f.errInParallel, sources)
+		if f.Statistics != nil && (f.Format == "" || f.Format == "default") {
+			fmt.Fprintf(f.Stdout, "%s\n\n", f.prettyStatistics())
+		}
 		return f.errInParallel
 	}
 
 	f.Print(issues, nil, sources)
-	return nil
+	return f.printErr
+}
+
+// IsStreaming returns true if the format prints results of parallel workers in real time.
+// In that case, results of each worker must be printed by PrintStream.
+func (f *Formatter) IsStreaming() bool {
+	return slices.Contains(streamingFormats, f.Format)
+}
+
+// PrintStream outputs issues found by a parallel worker immediately.
+// It is safe to call from multiple goroutines.
+func (f *Formatter) PrintStream(issues tflint.Issues) {
+	issues, hidden := f.filterByMinimumReportSeverity(issues)
+	issues, _ = f.rewritePaths(issues, map[string][]byte{})
+	if f.Format == "stream" {
+		f.streamPrintResults(issues, nil, map[string][]byte{}, hidden)
+	} else {
+		f.jsonlPrint(issues, nil, map[string][]byte{})
+	}
+
+	state := f.shared()
+	state.streamMu.Lock()
+	defer state.streamMu.Unlock()
+	f.printSummary(issues, map[string][]byte{}, hidden)
+}
+
+// issueSnippet returns the source code of the range where the issue was found.
+// If the source code is not available, it returns nil.
+func issueSnippet(issue *tflint.Issue, sources map[string][]byte) []byte {
+	src := issueSource(issue, sources)
+	if src == nil || issue.Range.Empty() || issue.Range.End.Byte > len(src) {
+		return nil
+	}
+	return issue.Range.SliceBytes(src)
+}
+
+// issueContext returns lines of the issue range and n lines before and after it, clamped to the start and end of the file.
+// If the source code is not available, it returns an empty string.
+func issueContext(issue *tflint.Issue, sources map[string][]byte, n int) string {
+	src := issueSource(issue, sources)
+	if src == nil {
+		return ""
+	}
+
+	lines := []string{}
+	sc := hcl.NewRangeScanner(src, issue.Range.Filename, bufio.ScanLines)
+	for sc.Scan() {
+		line := sc.Range().Start.Line
+		if line >= issue.Range.Start.Line-n && line <= issue.Range.End.Line+n {
+			lines = append(lines, string(sc.Bytes()))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// issueSource returns the source code of the file where the issue was found.
+// The source attached to the issue takes precedence as it reflects autofixes.
+func issueSource(issue *tflint.Issue, sources map[string][]byte) []byte {
+	if issue.Source != nil {
+		return issue.Source
+	}
+	return sources[issue.Range.Filename]
+}
+
+// runeColumn returns the 1-based column of the position counted in runes.
+// HCL counts columns in grapheme clusters, which differ from runes for emoji sequences and combining characters.
+// If the source is not available or does not match the position, the column in the position is returned instead.
+func runeColumn(pos hcl.Pos, src []byte) int {
+	if src == nil || pos.Line < 1 || pos.Byte > len(src) {
+		return pos.Column
+	}
+	if bytes.Count(src[:pos.Byte], []byte{'\n'})+1 != pos.Line {
+		return pos.Column
+	}
+	lineStart := bytes.LastIndexByte(src[:pos.Byte], '\n') + 1
+	return utf8.RuneCount(src[lineStart:pos.Byte]) + 1
+}
+
+// issueFingerprint returns the fingerprint of the issue. If the issue does not have the source, it is taken from sources.
+func issueFingerprint(issue *tflint.Issue, sources map[string][]byte) string {
+	if issue.Source == nil {
+		withSource := *issue
+		withSource.Source = sources[issue.Range.Filename]
+		return withSource.Fingerprint()
+	}
+	return issue.Fingerprint()
+}
+
+// fingerprint returns the fingerprint of the issue to be printed.
+// Paths of issues may have been rewritten, so the fingerprint of the original issue is returned in that case.
+func (f *Formatter) fingerprint(issue *tflint.Issue, sources map[string][]byte) string {
+	state := f.shared()
+	state.fingerprintsMu.Lock()
+	defer state.fingerprintsMu.Unlock()
+	if fingerprint, exists := state.fingerprints[issue]; exists {
+		return fingerprint
+	}
+	return issueFingerprint(issue, sources)
+}
+
+// sortedIssues returns issues in the same order as Issues.Sort,
+// but issues on the same location are also sorted by message and rule name to make the output deterministic.
+func sortedIssues(issues tflint.Issues) tflint.Issues {
+	sorted := slices.Clone(issues)
+	slices.SortFunc(sorted, func(a, b *tflint.Issue) int {
+		return cmp.Or(
+			cmp.Compare(a.Range.Filename, b.Range.Filename),
+			cmp.Compare(a.Range.Start.Line, b.Range.Start.Line),
+			cmp.Compare(a.Range.Start.Column, b.Range.Start.Column),
+			cmp.Compare(b.Range.End.Line, a.Range.End.Line),
+			cmp.Compare(b.Range.End.Column, a.Range.End.Column),
+			cmp.Compare(a.Message, b.Message),
+			cmp.Compare(a.Rule.Name(), b.Rule.Name()),
+		)
+	})
+	return sorted
+}
+
+// rewritePaths returns copies of issues and sources whose paths are converted according to PathMode.
+// Issues are not modified because they may be used after printing, e.g. for baselines.
+func (f *Formatter) rewritePaths(issues tflint.Issues, sources map[string][]byte) (tflint.Issues, map[string][]byte) {
+	if f.PathMode == "" || f.PathMode == "from-cwd" {
+		return issues, sources
+	}
+
+	state := f.shared()
+	state.fingerprintsMu.Lock()
+	defer state.fingerprintsMu.Unlock()
+	if state.fingerprints == nil {
+		state.fingerprints = map[*tflint.Issue]string{}
+		state.originals = map[*tflint.Issue]*tflint.Issue{}
+	}
+
+	ret := make(tflint.Issues, len(issues))
+	for idx, issue := range issues {
+		rewritten := *issue
+		// Rewritten paths can conflict in the sources (e.g. main.tf of each module), so keep the source in the issue
+		rewritten.Source = issueSource(issue, sources)
+		state.fingerprints[&rewritten] = issueFingerprint(issue, sources)
+		state.originals[&rewritten] = issue
+		rewritten.Range.Filename = f.rewritePath(issue.Range.Filename)
+		if issue.Callers != nil {
+			rewritten.Callers = make([]hcl.Range, len(issue.Callers))
+			for i, caller := range issue.Callers {
+				caller.Filename = f.rewritePath(caller.Filename)
+				rewritten.Callers[i] = caller
+			}
+		}
+		ret[idx] = &rewritten
+	}
+
+	retSources := make(map[string][]byte, len(sources))
+	for filename, src := range sources {
+		retSources[f.rewritePath(filename)] = src
+	}
+	return ret, retSources
+}
+
+// originalIssue returns the issue before its paths are rewritten, or the issue itself if it is not rewritten.
+func (f *Formatter) originalIssue(issue *tflint.Issue) *tflint.Issue {
+	state := f.shared()
+	state.fingerprintsMu.Lock()
+	defer state.fingerprintsMu.Unlock()
+	if original, exists := state.originals[issue]; exists {
+		return original
+	}
+	return issue
+}
+
+// rewritePath converts the path relative to the current directory according to PathMode.
+// If the path cannot be converted, it is returned as is.
+func (f *Formatter) rewritePath(filename string) string {
+	if filename == "" {
+		return filename
+	}
+
+	switch f.PathMode {
+	case "absolute":
+		abs, err := filepath.Abs(filename)
+		if err != nil {
+			return filename
+		}
+		return abs
+	case "relative":
+		moduleDir := f.moduleDir(filename)
+		if moduleDir == "" {
+			return filename
+		}
+
+		rel, err := filepath.Rel(moduleDir, filename)
+		if err != nil {
+			return filename
+		}
+		return rel
+	default:
+		return filename
+	}
+}
+
+// moduleDir returns the innermost directory in ModuleDirs containing the file.
+// Files outside modules (e.g. called modules) belong to the module only if it is unique.
+// If no directory is found, it returns an empty string.
+func (f *Formatter) moduleDir(filename string) string {
+	var moduleDir string
+	for _, dir := range f.ModuleDirs {
+		rel, err := filepath.Rel(dir, filename)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if len(dir) >= len(moduleDir) {
+			moduleDir = dir
+		}
+	}
+	if moduleDir == "" && len(f.ModuleDirs) == 1 {
+		moduleDir = f.ModuleDirs[0]
+	}
+	return moduleDir
+}
+
+// rootRelativePath returns the path relative to the given root directory with forward slashes.
+// If the root is empty or the file is outside the root, the path is returned as is.
+// The root may be relative to the current directory, and symlinks are resolved if the file is not found under it,
+// e.g. when the root is output by git with symlinks resolved.
+func rootRelativePath(filename string, root string) string {
+	if root == "" {
+		return filepath.ToSlash(filename)
+	}
+
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return filepath.ToSlash(filename)
+	}
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return filepath.ToSlash(filename)
+	}
+	rel, err := filepath.Rel(absRoot, abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		resolved, resolvedRoot := evalSymlinks(abs), evalSymlinks(absRoot)
+		rel, err = filepath.Rel(resolvedRoot, resolved)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(filename)
+		}
+	}
+	return filepath.ToSlash(rel)
+}
+
+// evalSymlinks returns the path with symlinks resolved, or the path as is if it cannot be resolved.
+// Only the directory is resolved if the file does not exist, e.g. for issues in sources read from stdin.
+func evalSymlinks(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	if dir, err := filepath.EvalSymlinks(filepath.Dir(path)); err == nil {
+		return filepath.Join(dir, filepath.Base(path))
+	}
+	return path
 }
 
 func toSeverity(lintType tflint.Severity) string {
@@ -2,10 +2,13 @@ package formatter
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
-	"github.com/fatih/color"
 	"github.com/google/go-cmp/cmp"
 	hcl "github.com/hashicorp/hcl/v2"
 	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
@@ -30,10 +33,25 @@ func (r *testRule) Link() string {
 	return "https://github.com"
 }
 
-func TestPrintErrorParallel(t *testing.T) {
-	// Disable color
-	color.NoColor = true
+type testRuleWithoutLink struct{}
+
+func (r *testRuleWithoutLink) Name() string {
+	return "test_rule_without_link"
+}
+
+func (r *testRuleWithoutLink) Enabled() bool {
+	return true
+}
+
+func (r *testRuleWithoutLink) Severity() tflint.Severity {
+	return sdk.WARNING
+}
 
+func (r *testRuleWithoutLink) Link() string {
+	return ""
+}
+
+func TestPrintErrorParallel(t *testing.T) {
 	tests := []struct {
 		name   string
 		format string
@@ -108,6 +126,12 @@ Error: test (test_rule)
 
 Reference: https://github.com
 
+Summary: 1 issue(s) in 1 file(s)
+  error: 1, warning: 0, notice: 0
+  Rules:
+       1  test_rule
+1 problem (1 error, 0 warnings, 0 notices)
+
 `,
 		},
 		{
@@ -117,14 +141,14 @@ Reference: https://github.com
 				f.PrintErrorParallel(errors.New("an error occurred"), map[string][]byte{})
 				f.PrintErrorParallel(errors.New("failed"), map[string][]byte{})
 			},
-			stdout: `{"issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"test.tf","start":{"line":1,"column":1},"end":{"line":1,"column":4}},"callers":[]}],"errors":[{"message":"an error occurred","severity":"error"},{"message":"failed","severity":"error"}]}`,
+			stdout: `{"format_version":"1.1","issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"test.tf","start":{"line":1,"column":1,"byte":0},"end":{"line":1,"column":4,"byte":3}},"callers":[],"fixable":false,"fingerprint":"a193a9a1f316b3b23c962f88d7a154a4800ed3c28f2bb3031b0ea60513304739"}],"errors":[{"message":"an error occurred","severity":"error"},{"message":"failed","severity":"error"}],"summary":{"issue_count":1,"error_count":2,"by_severity":{"error":1,"info":0,"warning":0},"by_rule":{"test_rule":1}},"scanned_files":[]}`,
 			error:  true,
 		},
 		{
 			name:   "JSON without errors",
 			format: "json",
 			before: func(f *Formatter) {},
-			stdout: `{"issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"test.tf","start":{"line":1,"column":1},"end":{"line":1,"column":4}},"callers":[]}],"errors":[]}`,
+			stdout: `{"format_version":"1.1","issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"test.tf","start":{"line":1,"column":1,"byte":0},"end":{"line":1,"column":4,"byte":3}},"callers":[],"fixable":false,"fingerprint":"a193a9a1f316b3b23c962f88d7a154a4800ed3c28f2bb3031b0ea60513304739"}],"errors":[],"summary":{"issue_count":1,"error_count":0,"by_severity":{"error":1,"info":0,"warning":0},"by_rule":{"test_rule":1}},"scanned_files":[]}`,
 		},
 	}
 
@@ -170,3 +194,560 @@ Reference: https://github.com
 		})
 	}
 }
+
+func TestPrintSummary(t *testing.T) {
+	issues := tflint.Issues{
+		{
+			Rule:    &testRule{},
+			Message: "test",
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+			},
+		},
+	}
+
+	stdout, summary, stderr := new(bytes.Buffer), new(bytes.Buffer), new(bytes.Buffer)
+	formatter := &Formatter{Stdout: stdout, SummaryStdout: summary, Stderr: stderr, Format: "compact"}
+
+	formatter.Print(issues, errors.New("an error occurred"), map[string][]byte{})
+
+	if diff := cmp.Diff("1 issue(s) found:\n\ntest.tf:1:1: Error - test (test_rule)\n", stdout.String()); diff != "" {
+		t.Errorf("stdout: %s", diff)
+	}
+	// Only issues are printed in the summary
+	want := `1 issue(s) found:
+
+Error: test (test_rule)
+
+  on test.tf line 1:
+   (source code not available)
+
+Reference: https://github.com
+
+Summary: 1 issue(s) in 1 file(s)
+  error: 1, warning: 0, notice: 0
+  Rules:
+       1  test_rule
+1 problem (1 error, 0 warnings, 0 notices)
+
+`
+	if diff := cmp.Diff(want, summary.String()); diff != "" {
+		t.Errorf("summary: %s", diff)
+	}
+	// Errors are printed only once
+	if diff := cmp.Diff("an error occurred\n", stderr.String()); diff != "" {
+		t.Errorf("stderr: %s", diff)
+	}
+}
+
+func TestPrintSummary_issuesTruncated(t *testing.T) {
+	issues := tflint.Issues{{Rule: &testRule{}, Message: "test", Range: hcl.Range{Filename: "test.tf"}}}
+
+	stdout, summary := new(bytes.Buffer), new(bytes.Buffer)
+	formatter := &Formatter{Stdout: stdout, SummaryStdout: summary, Stderr: new(bytes.Buffer), Format: "json", IssuesTruncated: true, MaxIssues: 1}
+
+	formatter.Print(issues, nil, map[string][]byte{})
+
+	if !strings.Contains(stdout.String(), `"issues_truncated":true`) {
+		t.Errorf("issues_truncated should be set in the report, but got %s", stdout.String())
+	}
+	if !strings.Contains(summary.String(), "Issues are truncated by --max-issues=1.") {
+		t.Errorf("the truncation notice should be printed in the summary, but got %s", summary.String())
+	}
+}
+
+type failingWriter struct{}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("no space left on device")
+}
+
+func TestPrintReport(t *testing.T) {
+	issues := tflint.Issues{
+		{
+			Rule:    &testRule{},
+			Message: "test",
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+			},
+		},
+	}
+
+	stdout, report, stderr := new(bytes.Buffer), new(bytes.Buffer), new(bytes.Buffer)
+	formatter := &Formatter{Stdout: stdout, ReportStdout: report, ReportFormat: "json", Stderr: stderr, Format: "compact"}
+
+	formatter.Print(issues, errors.New("an error occurred"), map[string][]byte{})
+
+	if diff := cmp.Diff("1 issue(s) found:\n\ntest.tf:1:1: Error - test (test_rule)\n", stdout.String()); diff != "" {
+		t.Errorf("stdout: %s", diff)
+	}
+	want := `{"format_version":"1.1","issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"test.tf","start":{"line":1,"column":1,"byte":0},"end":{"line":1,"column":4,"byte":3}},"callers":[],"fixable":false,"fingerprint":"a193a9a1f316b3b23c962f88d7a154a4800ed3c28f2bb3031b0ea60513304739"}],"errors":[{"message":"an error occurred","severity":"error"}],"summary":{"issue_count":1,"error_count":1,"by_severity":{"error":1,"info":0,"warning":0},"by_rule":{"test_rule":1}},"scanned_files":[]}`
+	if diff := cmp.Diff(want, report.String()); diff != "" {
+		t.Errorf("report: %s", diff)
+	}
+	// Errors are printed only once
+	if diff := cmp.Diff("an error occurred\n", stderr.String()); diff != "" {
+		t.Errorf("stderr: %s", diff)
+	}
+}
+
+func TestPrintReport_writeError(t *testing.T) {
+	issues := tflint.Issues{{Rule: &testRule{}, Message: "test", Range: hcl.Range{Filename: "test.tf"}}}
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	formatter := &Formatter{Stdout: stdout, ReportStdout: &failingWriter{}, ReportFormat: "sarif", Stderr: stderr, Format: "compact"}
+
+	formatter.Print(issues, nil, map[string][]byte{})
+
+	if !strings.Contains(stdout.String(), "test.tf:0:0: Error - test (test_rule)") {
+		t.Errorf("issues should be printed even if the report cannot be written, but got %s", stdout.String())
+	}
+	if diff := cmp.Diff("Warning: Failed to write the report file; no space left on device\n", stderr.String()); diff != "" {
+		t.Errorf("stderr: %s", diff)
+	}
+	if formatter.Err() != nil {
+		t.Errorf("a failure to write the report should not be an error, but got %s", formatter.Err())
+	}
+}
+
+func TestPrintReport_streaming(t *testing.T) {
+	issues := tflint.Issues{{Rule: &testRule{}, Message: "test", Range: hcl.Range{Filename: "test.tf"}}}
+
+	stdout, report := new(bytes.Buffer), new(bytes.Buffer)
+	formatter := &Formatter{Stdout: stdout, ReportStdout: report, ReportFormat: "json", Stderr: new(bytes.Buffer), Format: "jsonl"}
+
+	formatter.PrintStream(issues)
+	if report.Len() != 0 {
+		t.Fatalf("the report should not be written until all results are printed, but got %s", report.String())
+	}
+	if err := formatter.PrintParallel(issues, map[string][]byte{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var output JSONOutput
+	if err := json.Unmarshal(report.Bytes(), &output); err != nil {
+		t.Fatal(err)
+	}
+	if len(output.Issues) != 1 {
+		t.Errorf("the report should have all issues, but got %s", report.String())
+	}
+}
+
+func TestPrint_minimumReportSeverity(t *testing.T) {
+	errorIssue := &tflint.Issue{Rule: &testRule{}, Message: "test", Range: hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 1, Column: 1}, End: hcl.Pos{Line: 1, Column: 4}}}
+	warningIssue := &tflint.Issue{Rule: &testRuleWithoutLink{}, Message: "test", Range: hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 2, Column: 1}, End: hcl.Pos{Line: 2, Column: 4}}}
+
+	cases := []struct {
+		Name   string
+		Format string
+		Issues tflint.Issues
+		Stdout string
+	}{
+		{
+			Name:   "default",
+			Format: "default",
+			Issues: tflint.Issues{errorIssue, warningIssue},
+			Stdout: `1 issue(s) found:
+
+Error: test (test_rule)
+
+  on test.tf line 1:
+   (source code not available)
+
+Reference: https://github.com
+
+Summary: 1 issue(s) in 1 file(s)
+  error: 1, warning: 0, notice: 0
+  Rules:
+       1  test_rule
+  1 issue(s) below error hidden
+1 problem (1 error, 0 warnings, 0 notices)
+
+`,
+		},
+		{
+			Name:   "all issues hidden",
+			Format: "default",
+			Issues: tflint.Issues{warningIssue},
+			Stdout: "1 issue(s) below error hidden\n\n",
+		},
+		{
+			Name:   "json",
+			Format: "json",
+			Issues: tflint.Issues{errorIssue, warningIssue},
+			Stdout: `{"format_version":"1.1","issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"test.tf","start":{"line":1,"column":1,"byte":0},"end":{"line":1,"column":4,"byte":0}},"callers":[],"fixable":false,"fingerprint":"a193a9a1f316b3b23c962f88d7a154a4800ed3c28f2bb3031b0ea60513304739"}],"errors":[],"summary":{"issue_count":1,"error_count":0,"by_severity":{"error":1,"info":0,"warning":0},"by_rule":{"test_rule":1},"hidden_count":1},"scanned_files":[]}`,
+		},
+		{
+			Name:   "compact",
+			Format: "compact",
+			Issues: tflint.Issues{errorIssue, warningIssue},
+			Stdout: "1 issue(s) found:\n\ntest.tf:1:1: Error - test (test_rule)\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: &bytes.Buffer{}, Format: tc.Format, MinimumReportSeverity: "error"}
+
+			formatter.Print(tc.Issues, nil, map[string][]byte{})
+
+			if diff := cmp.Diff(tc.Stdout, stdout.String()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestPrint_quietSuccess(t *testing.T) {
+	warningIssue := &tflint.Issue{Rule: &testRuleWithoutLink{}, Message: "test", Range: hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 2, Column: 1}, End: hcl.Pos{Line: 2, Column: 4}}}
+
+	cases := []struct {
+		Name   string
+		Format string
+		Issues tflint.Issues
+		Error  error
+		Stdout string
+		Stderr string
+	}{
+		{
+			Name:   "default",
+			Format: "default",
+			Issues: tflint.Issues{},
+			Stdout: "",
+		},
+		{
+			Name:   "all issues hidden",
+			Format: "default",
+			Issues: tflint.Issues{warningIssue},
+			Stdout: "",
+		},
+		{
+			Name:   "all issues hidden with error",
+			Format: "default",
+			Issues: tflint.Issues{warningIssue},
+			Error:  errors.New("an error occurred"),
+			Stdout: "1 issue(s) below error hidden\n\n",
+			Stderr: "an error occurred\n",
+		},
+		{
+			Name:   "compact",
+			Format: "compact",
+			Issues: tflint.Issues{warningIssue},
+			Stdout: "",
+		},
+		{
+			Name:   "json",
+			Format: "json",
+			Issues: tflint.Issues{},
+			Stdout: `{"format_version":"1.1","issues":[],"errors":[],"summary":{"issue_count":0,"error_count":0,"by_severity":{"error":0,"info":0,"warning":0},"by_rule":{}},"scanned_files":[]}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: stderr, Format: tc.Format, MinimumReportSeverity: "error", QuietSuccess: true}
+
+			formatter.Print(tc.Issues, tc.Error, map[string][]byte{})
+
+			if diff := cmp.Diff(tc.Stdout, stdout.String()); diff != "" {
+				t.Errorf("stdout: %s", diff)
+			}
+			if diff := cmp.Diff(tc.Stderr, stderr.String()); diff != "" {
+				t.Errorf("stderr: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	issues := tflint.Issues{
+		{Rule: &testRule{}, Message: "test", Range: hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 1, Column: 1}, End: hcl.Pos{Line: 1, Column: 4}}},
+		{Rule: &testRuleWithoutLink{}, Message: "test", Range: hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 2, Column: 1}, End: hcl.Pos{Line: 2, Column: 4}}},
+	}
+
+	for _, format := range []string{"default", "json", "compact", "sarif", "checkstyle"} {
+		t.Run(format, func(t *testing.T) {
+			printed := &bytes.Buffer{}
+			printer := &Formatter{Stdout: printed, Stderr: &bytes.Buffer{}, Format: format, MinimumReportSeverity: "error"}
+			printer.Print(issues, nil, map[string][]byte{})
+
+			stdout, summary := &bytes.Buffer{}, &bytes.Buffer{}
+			renderer := &Formatter{Stdout: stdout, Stderr: &bytes.Buffer{}, Format: format, MinimumReportSeverity: "error", SummaryStdout: summary}
+			got, err := renderer.Render(issues, nil, map[string][]byte{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Render returns the same report as Print without writing it
+			if diff := cmp.Diff(printed.String(), string(got)); diff != "" {
+				t.Error(diff)
+			}
+			if stdout.String() != "" {
+				t.Errorf("stdout should be empty, but got %s", stdout.String())
+			}
+			if summary.String() != "" {
+				t.Errorf("summary should be empty, but got %s", summary.String())
+			}
+		})
+	}
+}
+
+func TestRender_error(t *testing.T) {
+	tmpl, err := ParseTemplate(`{{.Unknown}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	formatter := &Formatter{Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}, Format: "template", Template: tmpl}
+
+	_, err = formatter.Render(tflint.Issues{}, nil, map[string][]byte{})
+	if err == nil {
+		t.Fatal("expected an error, but got nil")
+	}
+	// Errors while rendering do not affect the formatter
+	if formatter.Err() != nil {
+		t.Errorf("unexpected error: %s", formatter.Err())
+	}
+}
+
+func TestRender_dryRun(t *testing.T) {
+	issues := tflint.Issues{{Rule: &testRule{}, Message: "test", Range: hcl.Range{Filename: "main.tf"}, Fixable: true, Fixed: true}}
+	changes := []tflint.FileChange{{Filename: "main.tf", Before: []byte("a = 1\n"), After: []byte("a = 2\n")}}
+
+	printed := &bytes.Buffer{}
+	printer := &Formatter{Stdout: printed, Stderr: &bytes.Buffer{}, Format: "default", Fix: true, DryRun: true, Changes: changes}
+	printer.Print(issues, nil, map[string][]byte{})
+
+	renderer := &Formatter{Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}, Format: "default", Fix: true, DryRun: true, Changes: changes}
+	got, err := renderer.Render(issues, nil, map[string][]byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The patch is rendered instead of the results
+	if !strings.HasPrefix(string(got), "--- a/main.tf\n") {
+		t.Errorf("the patch is not rendered: %s", got)
+	}
+	if diff := cmp.Diff(printed.String(), string(got)); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestBuildJSON(t *testing.T) {
+	issues := tflint.Issues{
+		{Rule: &testRule{}, Message: "test", Range: hcl.Range{Filename: "dir/test.tf", Start: hcl.Pos{Line: 1, Column: 1}, End: hcl.Pos{Line: 1, Column: 4}}},
+		{Rule: &testRuleWithoutLink{}, Message: "test", Range: hcl.Range{Filename: "dir/test.tf", Start: hcl.Pos{Line: 2, Column: 1}, End: hcl.Pos{Line: 2, Column: 4}}},
+	}
+	formatter := &Formatter{Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}, JSONVersion: "1.0", MinimumReportSeverity: "error", PathMode: "relative", ModuleDirs: []string{"dir"}}
+
+	got := formatter.BuildJSON(issues, nil, map[string][]byte{})
+
+	// Issues are filtered and paths are rewritten as in Print, but the latest schema is returned
+	if got.FormatVersion != JSONFormatVersion {
+		t.Errorf("expected format version %s, but got %s", JSONFormatVersion, got.FormatVersion)
+	}
+	if len(got.Issues) != 1 || got.Issues[0].Range.Filename != "test.tf" {
+		t.Errorf("unexpected issues: %+v", got.Issues)
+	}
+	if got.Summary.HiddenCount != 1 {
+		t.Errorf("expected 1 hidden issue, but got %d", got.Summary.HiddenCount)
+	}
+}
+
+func Test_rewritePaths(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issue := func(filename string, callers ...string) *tflint.Issue {
+		ret := &tflint.Issue{Rule: &testRule{}, Message: "test", Range: hcl.Range{Filename: filename}}
+		for _, caller := range callers {
+			ret.Callers = append(ret.Callers, hcl.Range{Filename: caller})
+		}
+		return ret
+	}
+
+	cases := []struct {
+		Name       string
+		PathMode   string
+		ModuleDirs []string
+		Filename   string
+		Callers    []string
+		Want       string
+		WantCaller []string
+	}{
+		{
+			Name:       "from-cwd",
+			PathMode:   "from-cwd",
+			ModuleDirs: []string{"envs/prod"},
+			Filename:   filepath.Join("envs", "prod", "main.tf"),
+			Want:       filepath.Join("envs", "prod", "main.tf"),
+		},
+		{
+			Name:       "relative",
+			PathMode:   "relative",
+			ModuleDirs: []string{"envs/prod"},
+			Filename:   filepath.Join("envs", "prod", "main.tf"),
+			Want:       "main.tf",
+		},
+		{
+			Name:       "relative in called module",
+			PathMode:   "relative",
+			ModuleDirs: []string{"envs/prod"},
+			Filename:   filepath.Join("modules", "vpc", "main.tf"),
+			Callers:    []string{filepath.Join("envs", "prod", "main.tf")},
+			Want:       filepath.Join("..", "..", "modules", "vpc", "main.tf"),
+			WantCaller: []string{"main.tf"},
+		},
+		{
+			Name:       "relative to innermost module",
+			PathMode:   "relative",
+			ModuleDirs: []string{".", "envs", filepath.Join("envs", "prod")},
+			Filename:   filepath.Join("envs", "prod", "main.tf"),
+			Want:       "main.tf",
+		},
+		{
+			Name:       "relative outside multiple modules",
+			PathMode:   "relative",
+			ModuleDirs: []string{"envs/dev", "envs/prod"},
+			Filename:   filepath.Join("modules", "vpc", "main.tf"),
+			Want:       filepath.Join("modules", "vpc", "main.tf"),
+		},
+		{
+			Name:       "absolute",
+			PathMode:   "absolute",
+			ModuleDirs: []string{"envs/prod"},
+			Filename:   filepath.Join("envs", "prod", "main.tf"),
+			Callers:    []string{"main.tf"},
+			Want:       filepath.Join(wd, "envs", "prod", "main.tf"),
+			WantCaller: []string{filepath.Join(wd, "main.tf")},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			formatter := &Formatter{PathMode: tc.PathMode, ModuleDirs: tc.ModuleDirs}
+			original := issue(tc.Filename, tc.Callers...)
+			sources := map[string][]byte{tc.Filename: []byte("foo = 1")}
+
+			issues, gotSources := formatter.rewritePaths(tflint.Issues{original}, sources)
+
+			if issues[0].Range.Filename != tc.Want {
+				t.Errorf("expected filename is %s, but got %s", tc.Want, issues[0].Range.Filename)
+			}
+			for i, caller := range tc.WantCaller {
+				if issues[0].Callers[i].Filename != caller {
+					t.Errorf("expected caller is %s, but got %s", caller, issues[0].Callers[i].Filename)
+				}
+			}
+			if string(gotSources[tc.Want]) != "foo = 1" {
+				t.Errorf("source is not found by the rewritten path: %v", gotSources)
+			}
+			if string(issueSource(issues[0], gotSources)) != "foo = 1" {
+				t.Error("source is not found for the rewritten issue")
+			}
+			if got, want := formatter.fingerprint(issues[0], gotSources), issueFingerprint(original, sources); got != want {
+				t.Errorf("the fingerprint should not depend on the path mode, expected %s, but got %s", want, got)
+			}
+			if original.Range.Filename != tc.Filename {
+				t.Errorf("the original issue should not be modified, but got %s", original.Range.Filename)
+			}
+		})
+	}
+}
+
+func Test_issueContext(t *testing.T) {
+	src := []byte(`resource "aws_instance" "web" {
+  ami           = "ami-12345678"
+  instance_type = "t1.2xlarge"
+
+  tags = {
+    Name = "web"
+  }
+}
+`)
+	issue := func(start, end int) *tflint.Issue {
+		return &tflint.Issue{
+			Rule:    &testRule{},
+			Message: "test",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: start, Column: 1},
+				End:      hcl.Pos{Line: end, Column: 1},
+			},
+		}
+	}
+
+	cases := []struct {
+		Name    string
+		Issue   *tflint.Issue
+		Sources map[string][]byte
+		N       int
+		Want    string
+	}{
+		{
+			Name:    "no context",
+			Issue:   issue(3, 3),
+			Sources: map[string][]byte{"main.tf": src},
+			N:       0,
+			Want:    `  instance_type = "t1.2xlarge"`,
+		},
+		{
+			Name:    "middle of file",
+			Issue:   issue(3, 3),
+			Sources: map[string][]byte{"main.tf": src},
+			N:       1,
+			Want:    "  ami           = \"ami-12345678\"\n  instance_type = \"t1.2xlarge\"\n",
+		},
+		{
+			Name:    "start of file",
+			Issue:   issue(1, 1),
+			Sources: map[string][]byte{"main.tf": src},
+			N:       2,
+			Want:    "resource \"aws_instance\" \"web\" {\n  ami           = \"ami-12345678\"\n  instance_type = \"t1.2xlarge\"",
+		},
+		{
+			Name:    "end of file",
+			Issue:   issue(8, 8),
+			Sources: map[string][]byte{"main.tf": src},
+			N:       2,
+			Want:    "    Name = \"web\"\n  }\n}",
+		},
+		{
+			Name:    "multi-line range",
+			Issue:   issue(5, 7),
+			Sources: map[string][]byte{"main.tf": src},
+			N:       1,
+			Want:    "\n  tags = {\n    Name = \"web\"\n  }\n}",
+		},
+		{
+			Name:    "larger than file",
+			Issue:   issue(3, 3),
+			Sources: map[string][]byte{"main.tf": src},
+			N:       100,
+			Want:    string(bytes.TrimSuffix(src, []byte("\n"))),
+		},
+		{
+			Name:    "source not available",
+			Issue:   issue(3, 3),
+			Sources: map[string][]byte{},
+			N:       1,
+			Want:    "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			got := issueContext(tc.Issue, tc.Sources, tc.N)
+			if diff := cmp.Diff(tc.Want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package formatter
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// https://docs.github.com/en/actions/reference/workflow-commands-for-github-actions
+
+func (f *Formatter) githubPrint(issues tflint.Issues, appErr error) {
+	for _, issue := range issues {
+		fmt.Fprintf(
+			f.Stdout,
+			"::%s file=%s,line=%d,endLine=%d,col=%d,title=%s::%s\n",
+			toGitHubCommand(issue.Rule.Severity()),
+			githubEscapeProperty(githubPath(issue.Range.Filename)),
+			issue.Range.Start.Line,
+			issue.Range.End.Line,
+			issue.Range.Start.Column,
+			githubEscapeProperty(issue.Rule.Name()),
+			githubEscapeData(issue.Message),
+		)
+	}
+
+	f.githubPrintErrors(appErr)
+
+	// Workflow commands are hard to read in the raw log, so print a summary to stderr
+	if len(issues) > 0 {
+		fmt.Fprintf(f.Stderr, "%d issue(s) found\n", len(issues))
+	}
+}
+
+func (f *Formatter) githubPrintErrors(err error) {
+	if err == nil {
+		return
+	}
+
+	// errors.Join
+	if errs, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, err := range errs.Unwrap() {
+			f.githubPrintErrors(err)
+		}
+		return
+	}
+
+	// hcl.Diagnostics
+	var diags hcl.Diagnostics
+	if errors.As(err, &diags) {
+		for _, diag := range diags {
+			command := "error"
+			if diag.Severity == hcl.DiagWarning {
+				command = "warning"
+			}
+			message := diag.Summary
+			if diag.Detail != "" {
+				message = fmt.Sprintf("%s; %s", diag.Summary, diag.Detail)
+			}
+
+			if diag.Subject == nil {
+				fmt.Fprintf(f.Stdout, "::%s::%s\n", command, githubEscapeData(message))
+				continue
+			}
+			fmt.Fprintf(
+				f.Stdout,
+				"::%s file=%s,line=%d,endLine=%d,col=%d::%s\n",
+				command,
+				githubEscapeProperty(githubPath(diag.Subject.Filename)),
+				diag.Subject.Start.Line,
+				diag.Subject.End.Line,
+				diag.Subject.Start.Column,
+				githubEscapeData(message),
+			)
+		}
+		return
+	}
+
+	fmt.Fprintf(f.Stdout, "::error::%s\n", githubEscapeData(err.Error()))
+}
+
+// githubPath returns the path relative to the workspace root.
+// Filenames are relative to the directory where TFLint was invoked,
+// which may differ from GITHUB_WORKSPACE depending on the job's working-directory.
+func githubPath(filename string) string {
+	return rootRelativePath(filename, os.Getenv("GITHUB_WORKSPACE"))
+}
+
+func githubEscapeData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	s = strings.ReplaceAll(s, "\n", "%0A")
+	return s
+}
+
+func githubEscapeProperty(s string) string {
+	s = githubEscapeData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+	s = strings.ReplaceAll(s, ",", "%2C")
+	return s
+}
+
+func toGitHubCommand(severity tflint.Severity) string {
+	switch severity {
+	case sdk.ERROR:
+		return "error"
+	case sdk.WARNING:
+		return "warning"
+	case sdk.NOTICE:
+		return "notice"
+	default:
+		panic(fmt.Errorf("Unexpected lint type: %s", severity))
+	}
+}
@@ -0,0 +1,115 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func Test_githubPrint(t *testing.T) {
+	cases := []struct {
+		Name      string
+		Issues    tflint.Issues
+		Error     error
+		Workspace string
+		Stdout    string
+		Stderr    string
+	}{
+		{
+			Name:   "no issues",
+			Issues: tflint.Issues{},
+			Stdout: "",
+		},
+		{
+			Name: "issues",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+				{
+					Rule:    &testRuleWithoutLink{},
+					Message: "50% of\nlines",
+					Range: hcl.Range{
+						Filename: "dir,1/test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 0},
+						End:      hcl.Pos{Line: 3, Column: 4, Byte: 3},
+					},
+				},
+			},
+			Stdout: `::error file=test.tf,line=1,endLine=1,col=1,title=test_rule::test
+::warning file=dir%2C1/test.tf,line=2,endLine=3,col=3,title=test_rule_without_link::50%25 of%0Alines
+`,
+			Stderr: "2 issue(s) found\n",
+		},
+		{
+			Name: "workspace",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+			},
+			Workspace: "..",
+			Stdout:    "::error file=formatter/test.tf,line=1,endLine=1,col=1,title=test_rule::test\n",
+			Stderr:    "1 issue(s) found\n",
+		},
+		{
+			Name:   "error",
+			Error:  errors.New("an error occurred"),
+			Stdout: "::error::an error occurred\n",
+		},
+		{
+			Name: "joined errors",
+			Error: errors.Join(
+				errors.New("an error occurred"),
+				hclDiags(`resource "foo" "bar" {`),
+			),
+			Stdout: `::error::an error occurred
+::error file=main.tf,line=1,endLine=1,col=22::Unclosed configuration block; There is no closing brace for this block before the end of the file. This may be caused by incorrect brace nesting elsewhere in this file.
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			workspace := tc.Workspace
+			if workspace != "" {
+				abs, err := filepath.Abs(workspace)
+				if err != nil {
+					t.Fatal(err)
+				}
+				workspace = abs
+			}
+			t.Setenv("GITHUB_WORKSPACE", workspace)
+
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: stderr}
+
+			formatter.githubPrint(tc.Issues, tc.Error)
+
+			if diff := cmp.Diff(tc.Stdout, stdout.String()); diff != "" {
+				t.Errorf("stdout: %s", diff)
+			}
+			if diff := cmp.Diff(tc.Stderr, stderr.String()); diff != "" {
+				t.Errorf("stderr: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package formatter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// https://docs.gitlab.com/ci/testing/code_quality/#code-quality-report-format
+
+type gitlabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Categories  []string       `json:"categories"`
+	Location    gitlabLocation `json:"location"`
+}
+
+type gitlabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitlabLines `json:"lines"`
+}
+
+type gitlabLines struct {
+	Begin int `json:"begin"`
+}
+
+func (f *Formatter) gitlabPrint(issues tflint.Issues, appErr error, sources map[string][]byte) {
+	ret := make([]gitlabIssue, len(issues))
+	root := gitlabProjectDir()
+	occurrences := map[string]int{}
+
+	for idx, issue := range issues.Sort() {
+		path := rootRelativePath(issue.Range.Filename, root)
+
+		// GitLab drops issues with the same fingerprint, so identical code in the same file
+		// is distinguished by the occurrence count. The first occurrence has the canonical fingerprint.
+		fingerprint := f.fingerprint(issue, sources)
+		occurrences[fingerprint]++
+		if occurrences[fingerprint] > 1 {
+			fingerprint = fmt.Sprintf("%s:%d", fingerprint, occurrences[fingerprint])
+		}
+
+		ret[idx] = gitlabIssue{
+			Description: issue.Message,
+			CheckName:   issue.Rule.Name(),
+			Fingerprint: fingerprint,
+			Severity:    toGitLabSeverity(issue.Rule.Severity()),
+			Categories:  []string{"Style"},
+			Location: gitlabLocation{
+				Path:  path,
+				Lines: gitlabLines{Begin: issue.Range.Start.Line},
+			},
+		}
+	}
+
+	ret = append(ret, f.gitlabErrors(appErr, root, sources)...)
+
+	out, err := json.Marshal(ret)
+	if err != nil {
+		fmt.Fprint(f.Stderr, err)
+	}
+	fmt.Fprint(f.Stdout, string(out))
+}
+
+// gitlabErrors converts diagnostics into blocker entries.
+// Errors without a source location cannot be reported to GitLab, so they are output to stderr.
+func (f *Formatter) gitlabErrors(err error, root string, sources map[string][]byte) []gitlabIssue {
+	if err == nil {
+		return []gitlabIssue{}
+	}
+
+	// errors.Join
+	if errs, ok := err.(interface{ Unwrap() []error }); ok {
+		ret := []gitlabIssue{}
+		for _, err := range errs.Unwrap() {
+			ret = append(ret, f.gitlabErrors(err, root, sources)...)
+		}
+		return ret
+	}
+
+	// hcl.Diagnostics
+	var diags hcl.Diagnostics
+	if errors.As(err, &diags) {
+		ret := []gitlabIssue{}
+		for _, diag := range diags {
+			if diag.Subject == nil {
+				f.prettyPrintErrors(hcl.Diagnostics{diag}, sources, false)
+				continue
+			}
+			path := rootRelativePath(diag.Subject.Filename, root)
+
+			h := sha256.New()
+			fmt.Fprintf(h, "%s\x00%s\x00%d\x00%d", diag.Summary, path, diag.Subject.Start.Line, diag.Subject.Start.Column)
+
+			ret = append(ret, gitlabIssue{
+				Description: fmt.Sprintf("%s; %s", diag.Summary, diag.Detail),
+				CheckName:   "tflint",
+				Fingerprint: hex.EncodeToString(h.Sum(nil)),
+				Severity:    "blocker",
+				Categories:  []string{"Bug Risk"},
+				Location: gitlabLocation{
+					Path:  path,
+					Lines: gitlabLines{Begin: diag.Subject.Start.Line},
+				},
+			})
+		}
+		return ret
+	}
+
+	f.prettyPrintErrors(err, sources, false)
+	return []gitlabIssue{}
+}
+
+// gitlabProjectDir returns the directory that paths in the report are relative to.
+// GitLab resolves paths from the repository root, which may differ from the directory where TFLint was invoked,
+// e.g. with --chdir. CI_PROJECT_DIR is used in GitLab CI, and the top-level directory of the git repository otherwise.
+// If neither is available, an empty string is returned and paths are printed as is.
+func gitlabProjectDir() string {
+	root := os.Getenv("CI_PROJECT_DIR")
+	if root == "" {
+		out, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+		if err != nil {
+			log.Printf("[DEBUG] Failed to get the top-level directory of the git repository; %s", err)
+			return ""
+		}
+		root = strings.TrimSpace(string(out))
+	}
+	return root
+}
+
+func toGitLabSeverity(severity tflint.Severity) string {
+	switch severity {
+	case sdk.ERROR:
+		return "critical"
+	case sdk.WARNING:
+		return "major"
+	case sdk.NOTICE:
+		return "minor"
+	default:
+		panic(fmt.Errorf("Unexpected lint type: %s", severity))
+	}
+}
@@ -0,0 +1,188 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func Test_gitlabPrint(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Issues  tflint.Issues
+		Error   error
+		Sources map[string][]byte
+		// ProjectDir is CI_PROJECT_DIR. It is the current directory by default.
+		ProjectDir string
+		Stdout     string
+		Stderr     string
+	}{
+		{
+			Name:   "no issues",
+			Issues: tflint.Issues{},
+			Stdout: "[]",
+		},
+		{
+			Name: "issues",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+				{
+					Rule:    &testRuleWithoutLink{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1, Byte: 4},
+						End:      hcl.Pos{Line: 2, Column: 4, Byte: 7},
+					},
+				},
+			},
+			Sources: map[string][]byte{"test.tf": []byte("foo\nbar\n")},
+			Stdout:  `[{"description":"test","check_name":"test_rule","fingerprint":"d322a78272f430ef480153e47258729a2e91e7829b1e1a40b77e4a6fc94cafb5","severity":"critical","categories":["Style"],"location":{"path":"test.tf","lines":{"begin":1}}},{"description":"test","check_name":"test_rule_without_link","fingerprint":"c8630d6f9fd3d48cba02ff80a51d71282740b47bd33bdbcf009a94963ed80d78","severity":"major","categories":["Style"],"location":{"path":"test.tf","lines":{"begin":2}}}]`,
+		},
+		{
+			Name: "moved issues",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 4, Column: 1, Byte: 3},
+						End:      hcl.Pos{Line: 4, Column: 4, Byte: 6},
+					},
+				},
+			},
+			// The fingerprint is the same as the first issue in the "issues" case
+			Sources: map[string][]byte{"test.tf": []byte("\n\n\nfoo\n")},
+			Stdout:  `[{"description":"test","check_name":"test_rule","fingerprint":"d322a78272f430ef480153e47258729a2e91e7829b1e1a40b77e4a6fc94cafb5","severity":"critical","categories":["Style"],"location":{"path":"test.tf","lines":{"begin":4}}}]`,
+		},
+		{
+			Name: "project dir",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+			},
+			Sources:    map[string][]byte{"test.tf": []byte("foo\n")},
+			ProjectDir: "..",
+			// The fingerprint is the same as other formats, so it does not depend on the project dir
+			Stdout: `[{"description":"test","check_name":"test_rule","fingerprint":"d322a78272f430ef480153e47258729a2e91e7829b1e1a40b77e4a6fc94cafb5","severity":"critical","categories":["Style"],"location":{"path":"formatter/test.tf","lines":{"begin":1}}}]`,
+		},
+		{
+			Name: "identical issues",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1, Byte: 4},
+						End:      hcl.Pos{Line: 2, Column: 4, Byte: 7},
+					},
+				},
+			},
+			// Fingerprints of identical code are distinguished by the occurrence count
+			Sources: map[string][]byte{"test.tf": []byte("foo\nfoo\n")},
+			Stdout:  `[{"description":"test","check_name":"test_rule","fingerprint":"d322a78272f430ef480153e47258729a2e91e7829b1e1a40b77e4a6fc94cafb5","severity":"critical","categories":["Style"],"location":{"path":"test.tf","lines":{"begin":1}}},{"description":"test","check_name":"test_rule","fingerprint":"d322a78272f430ef480153e47258729a2e91e7829b1e1a40b77e4a6fc94cafb5:2","severity":"critical","categories":["Style"],"location":{"path":"test.tf","lines":{"begin":2}}}]`,
+		},
+		{
+			Name:   "error",
+			Error:  errors.New("an error occurred"),
+			Stdout: "[]",
+			Stderr: "an error occurred\n",
+		},
+		{
+			Name: "joined errors",
+			Error: errors.Join(
+				errors.New("an error occurred"),
+				hclDiags(`resource "foo" "bar" {`),
+			),
+			Stdout: `[{"description":"Unclosed configuration block; There is no closing brace for this block before the end of the file. This may be caused by incorrect brace nesting elsewhere in this file.","check_name":"tflint","fingerprint":"82236d89ff750bf38e6213b5ae281bf745785091c82eb822fab372377dcae240","severity":"blocker","categories":["Bug Risk"],"location":{"path":"main.tf","lines":{"begin":1}}}]`,
+			Stderr: "an error occurred\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			projectDir := tc.ProjectDir
+			if projectDir == "" {
+				projectDir = "."
+			}
+			t.Setenv("CI_PROJECT_DIR", projectDir)
+
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: stderr}
+
+			formatter.gitlabPrint(tc.Issues, tc.Error, tc.Sources)
+
+			if diff := cmp.Diff(tc.Stdout, stdout.String()); diff != "" {
+				t.Errorf("stdout: %s", diff)
+			}
+			if diff := cmp.Diff(tc.Stderr, stderr.String()); diff != "" {
+				t.Errorf("stderr: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_gitlabProjectDir(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "init", dir).CombinedOutput(); err != nil {
+		t.Fatalf("Failed to run git init: %s; %s", err, out)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "module"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(filepath.Join(dir, "module"))
+
+	t.Run("CI_PROJECT_DIR", func(t *testing.T) {
+		t.Setenv("CI_PROJECT_DIR", dir)
+
+		if got := rootRelativePath("main.tf", gitlabProjectDir()); got != "module/main.tf" {
+			t.Errorf("expected module/main.tf, but got %s", got)
+		}
+	})
+
+	t.Run("git top-level directory", func(t *testing.T) {
+		t.Setenv("CI_PROJECT_DIR", "")
+
+		if got := rootRelativePath("main.tf", gitlabProjectDir()); got != "module/main.tf" {
+			t.Errorf("expected module/main.tf, but got %s", got)
+		}
+	})
+}
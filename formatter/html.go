@@ -0,0 +1,197 @@
+package formatter
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+//go:embed html.tmpl
+var htmlTemplateText string
+
+var htmlTemplate = template.Must(template.New("html").Parse(htmlTemplateText))
+
+// htmlReport is the data passed to the HTML template
+type htmlReport struct {
+	Version string
+	Total   htmlCounts
+	Files   []*htmlFile
+	Errors  []string
+}
+
+type htmlCounts struct {
+	Errors   int
+	Warnings int
+	Notices  int
+}
+
+func (c *htmlCounts) add(severity tflint.Severity) {
+	switch severity {
+	case sdk.ERROR:
+		c.Errors++
+	case sdk.WARNING:
+		c.Warnings++
+	case sdk.NOTICE:
+		c.Notices++
+	}
+}
+
+type htmlFile struct {
+	Name string
+	// Anchor is the filename, e.g. "main.tf", and Href is the link to it.
+	Anchor string
+	Href   template.URL
+	Counts htmlCounts
+	Issues []*htmlIssue
+}
+
+type htmlIssue struct {
+	// Anchor is "<file>-L<line>", e.g. "main.tf-L42". Issues on the same line are suffixed with "-2", "-3", and so on.
+	Anchor   string
+	Href     template.URL
+	Severity string
+	Rule     string
+	Link     string
+	Message  string
+	Line     int
+	Column   int
+	Lines    []htmlLine
+}
+
+// htmlLine is a line of a code frame. Mark is the part of the issue range in the line.
+type htmlLine struct {
+	Number int
+	Before string
+	Mark   string
+	After  string
+}
+
+// htmlPrint outputs a standalone HTML page with inline styles and no external assets.
+// The output is deterministic so that reports can be compared and attached to tickets.
+func (f *Formatter) htmlPrint(issues tflint.Issues, appErr error, sources map[string][]byte) {
+	// Execute into a buffer so that partial results are not printed on errors
+	out := new(bytes.Buffer)
+	if err := htmlTemplate.Execute(out, f.htmlReport(issues, appErr, sources)); err != nil {
+		f.printErr = fmt.Errorf("Failed to render the HTML report; %w", err)
+		f.prettyPrintErrors(f.printErr, sources, false)
+		return
+	}
+	fmt.Fprint(f.Stdout, out.String())
+}
+
+func (f *Formatter) htmlReport(issues tflint.Issues, appErr error, sources map[string][]byte) *htmlReport {
+	report := &htmlReport{Version: tflint.Version.String(), Files: []*htmlFile{}, Errors: htmlErrors(appErr)}
+
+	files := map[string]*htmlFile{}
+	anchors := map[string]int{}
+	for _, issue := range sortedIssues(issues) {
+		name := filepath.ToSlash(issue.Range.Filename)
+		file, exists := files[name]
+		if !exists {
+			file = &htmlFile{Name: name, Anchor: name, Href: htmlHref(name)}
+			files[name] = file
+			report.Files = append(report.Files, file)
+		}
+
+		anchor := fmt.Sprintf("%s-L%d", name, issue.Range.Start.Line)
+		anchors[anchor]++
+		if count := anchors[anchor]; count > 1 {
+			anchor = fmt.Sprintf("%s-%d", anchor, count)
+		}
+
+		file.Issues = append(file.Issues, &htmlIssue{
+			Anchor:   anchor,
+			Href:     htmlHref(anchor),
+			Severity: strings.ToLower(issue.Rule.Severity().String()),
+			Rule:     issue.Rule.Name(),
+			Link:     issue.Rule.Link(),
+			Message:  issue.Message,
+			Line:     issue.Range.Start.Line,
+			Column:   issue.Range.Start.Column,
+			Lines:    htmlFrame(issue, sources, f.SnippetContext),
+		})
+		file.Counts.add(issue.Rule.Severity())
+		report.Total.add(issue.Rule.Severity())
+	}
+
+	return report
+}
+
+// htmlHref returns the link to the anchor. Filenames are escaped as URL fragments, but slashes are kept as is.
+func htmlHref(anchor string) template.URL {
+	return template.URL((&url.URL{Fragment: anchor}).String())
+}
+
+// htmlFrame returns lines of the issue range and n lines before and after it.
+// If the source code is not available, it returns nil.
+func htmlFrame(issue *tflint.Issue, sources map[string][]byte, n int) []htmlLine {
+	src := issueSource(issue, sources)
+	if src == nil || issue.Range.End.Byte > len(src) {
+		return nil
+	}
+
+	lines := []htmlLine{}
+	sc := hcl.NewRangeScanner(src, issue.Range.Filename, bufio.ScanLines)
+	for sc.Scan() {
+		lineRange := sc.Range()
+		if lineRange.Start.Line < issue.Range.Start.Line-n || lineRange.Start.Line > issue.Range.End.Line+n {
+			continue
+		}
+
+		line := htmlLine{Number: lineRange.Start.Line, Before: string(sc.Bytes())}
+		if lineRange.Overlaps(issue.Range) {
+			overlap := lineRange.Overlap(issue.Range)
+			if !overlap.Empty() {
+				line.Before = string(src[lineRange.Start.Byte:overlap.Start.Byte])
+				line.Mark = string(src[overlap.Start.Byte:overlap.End.Byte])
+				line.After = string(src[overlap.End.Byte:lineRange.End.Byte])
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func htmlErrors(err error) []string {
+	if err == nil {
+		return []string{}
+	}
+
+	// errors.Join
+	if errs, ok := err.(interface{ Unwrap() []error }); ok {
+		ret := []string{}
+		for _, err := range errs.Unwrap() {
+			ret = append(ret, htmlErrors(err)...)
+		}
+		return ret
+	}
+
+	// hcl.Diagnostics
+	var diags hcl.Diagnostics
+	if errors.As(err, &diags) {
+		ret := []string{}
+		for _, diag := range diags {
+			message := diag.Summary
+			if diag.Detail != "" {
+				message = fmt.Sprintf("%s; %s", diag.Summary, diag.Detail)
+			}
+			if diag.Subject != nil {
+				message = fmt.Sprintf("%s: %s", diag.Subject, message)
+			}
+			ret = append(ret, message)
+		}
+		return ret
+	}
+
+	return []string{err.Error()}
+}
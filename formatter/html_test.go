@@ -0,0 +1,237 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func Test_htmlPrint(t *testing.T) {
+	src := []byte("resource \"foo\" \"bar\" {\n  ami  = \"<ami>\"\n  type = \"t2.micro\"\n}\n")
+
+	cases := []struct {
+		Name    string
+		Issues  tflint.Issues
+		Error   error
+		Context int
+		Sources map[string][]byte
+		Body    string
+	}{
+		{
+			Name:   "no issues",
+			Issues: tflint.Issues{},
+			Body: `<h1>TFLint Report</h1>
+<p>No issues found.</p>
+<footer>Generated by TFLint v` + tflint.Version.String() + `</footer>`,
+		},
+		{
+			Name: "issues",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRuleWithoutLink{},
+					Message: "type is <deprecated>",
+					Range: hcl.Range{
+						Filename: "modules/ec2/main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 10, Byte: 49},
+						End:      hcl.Pos{Line: 3, Column: 20, Byte: 59},
+					},
+				},
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 10, Byte: 32},
+						End:      hcl.Pos{Line: 2, Column: 17, Byte: 39},
+					},
+				},
+				{
+					Rule:    &testRuleWithoutLink{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 25},
+						End:      hcl.Pos{Line: 3, Column: 7, Byte: 46},
+					},
+				},
+			},
+			Sources: map[string][]byte{"main.tf": src, "modules/ec2/main.tf": src},
+			Body: `<h1>TFLint Report</h1>
+<table class="summary">
+<thead>
+<tr><th>File</th><th class="count">Errors</th><th class="count">Warnings</th><th class="count">Notices</th></tr>
+</thead>
+<tbody>
+<tr><td><a href="#main.tf">main.tf</a></td><td class="count">1</td><td class="count">1</td><td class="count">0</td></tr>
+<tr><td><a href="#modules/ec2/main.tf">modules/ec2/main.tf</a></td><td class="count">0</td><td class="count">1</td><td class="count">0</td></tr>
+</tbody>
+<tfoot>
+<tr><th>Total</th><th class="count">1</th><th class="count">2</th><th class="count">0</th></tr>
+</tfoot>
+</table>
+<section id="main.tf">
+<h2>main.tf</h2>
+<div class="issue" id="main.tf-L2">
+<div><span class="badge warning">warning</span> <a class="location" href="#main.tf-L2">2:3</a> <span class="message">test</span> (test_rule_without_link)</div>
+<pre class="frame"><span class="number">2</span>  <mark>ami  = &#34;&lt;ami&gt;&#34;</mark>
+<span class="number">3</span><mark>  type</mark> = &#34;t2.micro&#34;
+</pre>
+</div>
+<div class="issue" id="main.tf-L2-2">
+<div><span class="badge error">error</span> <a class="location" href="#main.tf-L2-2">2:10</a> <span class="message">test</span> (<a href="https://github.com">test_rule</a>)</div>
+<pre class="frame"><span class="number">2</span>  ami  = <mark>&#34;&lt;ami&gt;&#34;</mark>
+</pre>
+</div>
+</section>
+<section id="modules/ec2/main.tf">
+<h2>modules/ec2/main.tf</h2>
+<div class="issue" id="modules/ec2/main.tf-L3">
+<div><span class="badge warning">warning</span> <a class="location" href="#modules/ec2/main.tf-L3">3:10</a> <span class="message">type is &lt;deprecated&gt;</span> (test_rule_without_link)</div>
+<pre class="frame"><span class="number">3</span>  type = <mark>&#34;t2.micro&#34;</mark>
+</pre>
+</div>
+</section>
+<footer>Generated by TFLint v` + tflint.Version.String() + `</footer>`,
+		},
+		{
+			Name: "issues with context",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 10, Byte: 32},
+						End:      hcl.Pos{Line: 2, Column: 17, Byte: 39},
+					},
+				},
+			},
+			Context: 1,
+			Sources: map[string][]byte{"main.tf": src},
+			Body: `<h1>TFLint Report</h1>
+<table class="summary">
+<thead>
+<tr><th>File</th><th class="count">Errors</th><th class="count">Warnings</th><th class="count">Notices</th></tr>
+</thead>
+<tbody>
+<tr><td><a href="#main.tf">main.tf</a></td><td class="count">1</td><td class="count">0</td><td class="count">0</td></tr>
+</tbody>
+<tfoot>
+<tr><th>Total</th><th class="count">1</th><th class="count">0</th><th class="count">0</th></tr>
+</tfoot>
+</table>
+<section id="main.tf">
+<h2>main.tf</h2>
+<div class="issue" id="main.tf-L2">
+<div><span class="badge error">error</span> <a class="location" href="#main.tf-L2">2:10</a> <span class="message">test</span> (<a href="https://github.com">test_rule</a>)</div>
+<pre class="frame"><span class="number">1</span>resource &#34;foo&#34; &#34;bar&#34; {
+<span class="number">2</span>  ami  = <mark>&#34;&lt;ami&gt;&#34;</mark>
+<span class="number">3</span>  type = &#34;t2.micro&#34;
+</pre>
+</div>
+</section>
+<footer>Generated by TFLint v` + tflint.Version.String() + `</footer>`,
+		},
+		{
+			Name: "source code not available",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 10, Byte: 32},
+						End:      hcl.Pos{Line: 2, Column: 17, Byte: 39},
+					},
+				},
+			},
+			Body: `<h1>TFLint Report</h1>
+<table class="summary">
+<thead>
+<tr><th>File</th><th class="count">Errors</th><th class="count">Warnings</th><th class="count">Notices</th></tr>
+</thead>
+<tbody>
+<tr><td><a href="#main.tf">main.tf</a></td><td class="count">1</td><td class="count">0</td><td class="count">0</td></tr>
+</tbody>
+<tfoot>
+<tr><th>Total</th><th class="count">1</th><th class="count">0</th><th class="count">0</th></tr>
+</tfoot>
+</table>
+<section id="main.tf">
+<h2>main.tf</h2>
+<div class="issue" id="main.tf-L2">
+<div><span class="badge error">error</span> <a class="location" href="#main.tf-L2">2:10</a> <span class="message">test</span> (<a href="https://github.com">test_rule</a>)</div>
+<p class="unavailable">(source code not available)</p>
+</div>
+</section>
+<footer>Generated by TFLint v` + tflint.Version.String() + `</footer>`,
+		},
+		{
+			Name: "errors",
+			Error: errors.Join(
+				errors.New("an <error> occurred"),
+				hclDiags(`resource "foo" "bar" {`),
+			),
+			Body: `<h1>TFLint Report</h1>
+<p>No issues found.</p>
+<section id="errors">
+<h2>Errors</h2>
+<ul>
+<li class="message">an &lt;error&gt; occurred</li>
+<li class="message">main.tf:1,22-23: Unclosed configuration block; There is no closing brace for this block before the end of the file. This may be caused by incorrect brace nesting elsewhere in this file.</li>
+</ul>
+</section>
+<footer>Generated by TFLint v` + tflint.Version.String() + `</footer>`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: &bytes.Buffer{}, SnippetContext: tc.Context}
+
+			sources := tc.Sources
+			if sources == nil {
+				sources = map[string][]byte{}
+			}
+			formatter.htmlPrint(tc.Issues, tc.Error, sources)
+
+			out := stdout.String()
+			if !strings.HasPrefix(out, "<!DOCTYPE html>\n") || !strings.HasSuffix(out, "</body>\n</html>\n") {
+				t.Fatalf("output is not a standalone page: %s", out)
+			}
+			start, end := strings.Index(out, "<body>\n"), strings.Index(out, "\n</body>")
+			if diff := cmp.Diff(tc.Body, out[start+len("<body>\n"):end]); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_htmlPrint_deterministic(t *testing.T) {
+	issues := tflint.Issues{
+		{Rule: &testRule{}, Message: "b", Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1, Column: 1}, End: hcl.Pos{Line: 1, Column: 4}}},
+		{Rule: &testRuleWithoutLink{}, Message: "a", Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1, Column: 1}, End: hcl.Pos{Line: 1, Column: 4}}},
+		{Rule: &testRule{}, Message: "c", Range: hcl.Range{Filename: "a.tf", Start: hcl.Pos{Line: 5, Column: 1}, End: hcl.Pos{Line: 5, Column: 4}}},
+	}
+	reversed := tflint.Issues{issues[2], issues[1], issues[0]}
+
+	want, got := &bytes.Buffer{}, &bytes.Buffer{}
+	(&Formatter{Stdout: want, Stderr: &bytes.Buffer{}}).htmlPrint(issues, nil, map[string][]byte{})
+	(&Formatter{Stdout: got, Stderr: &bytes.Buffer{}}).htmlPrint(reversed, nil, map[string][]byte{})
+
+	if diff := cmp.Diff(want.String(), got.String()); diff != "" {
+		t.Error(diff)
+	}
+	// External assets are not loaded
+	for _, asset := range []string{"<link", "<script", "src="} {
+		if strings.Contains(want.String(), asset) {
+			t.Errorf("output contains %s", asset)
+		}
+	}
+}
@@ -1,21 +1,59 @@
 package formatter
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"path/filepath"
+	"slices"
+	"unicode/utf8"
 
 	"github.com/hashicorp/hcl/v2"
 	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
 	"github.com/terraform-linters/tflint/tflint"
 )
 
+// JSONFormatVersion is the latest schema version of the json format.
+// Bump the minor version for additive changes and keep older versions available in JSONFormatVersions.
+const JSONFormatVersion = "1.1"
+
+// JSONFormatVersions are schema versions of the json format that can be requested.
+//   - 1.0: The schema before versioning, which has only "issues" and "errors"
+//   - 1.1: Adds fixes, source contexts, fingerprints, the summary, scanned files, statistics, changes,
+//     runs per directory, and byte offsets. Columns are counted in runes, and outputs of workers are moved
+//     from "message" to "detail" of errors
+var JSONFormatVersions = []string{"1.0", "1.1"}
+
+// DefaultSourceMaxLength is the default maximum length of snippets in bytes.
+const DefaultSourceMaxLength = 1000
+
 // JSONIssue is a temporary structure for converting TFLint issues to JSON.
 type JSONIssue struct {
 	Rule    JSONRule    `json:"rule"`
 	Message string      `json:"message"`
 	Range   JSONRange   `json:"range"`
 	Callers []JSONRange `json:"callers"`
+	// Fixable is true if the rule supplied an autofix for the issue.
+	Fixable bool `json:"fixable"`
+	// Fixed is set only when --fix is used. It is true if the file was rewritten by the autofix.
+	Fixed *bool `json:"fixed,omitempty"`
+	// Context is source lines around the issue range. It is set only when --context is used.
+	Context string `json:"context,omitempty"`
+	// Snippet is the source of the issue range. It is set only when --include-source is used.
+	Snippet *JSONSnippet `json:"snippet,omitempty"`
+	// Fingerprint is a stable ID of the issue. See tflint.Issue.Fingerprint for the algorithm.
+	Fingerprint string `json:"fingerprint,omitempty"`
+}
+
+// JSONSnippet is a temporary structure for converting the source of issue ranges to JSON.
+type JSONSnippet struct {
+	// Code is the source of the issue range.
+	Code string `json:"code"`
+	// Line is the whole line where the issue range starts.
+	Line string `json:"line"`
+	// Truncated is true if the code or the line is longer than the maximum length.
+	Truncated bool `json:"truncated,omitempty"`
 }
 
 // JSONRule is a temporary structure for converting TFLint rules to JSON.
@@ -23,6 +61,9 @@ type JSONRule struct {
 	Name     string `json:"name"`
 	Severity string `json:"severity"`
 	Link     string `json:"link"`
+	// Fixable is true if the rule supplied an autofix for the issue, so that integrations can offer a fix
+	// by looking at the rule metadata only. It is omitted otherwise.
+	Fixable bool `json:"fixable,omitempty"`
 }
 
 // JSONRange is a temporary structure for converting ranges to JSON.
@@ -33,60 +74,398 @@ type JSONRange struct {
 }
 
 // JSONPos is a temporary structure for converting positions to JSON.
+// Column is 1-based and counted in runes, and Byte is the 0-based byte offset in the file.
 type JSONPos struct {
-	Line   int `json:"line"`
-	Column int `json:"column"`
+	Line   int  `json:"line"`
+	Column int  `json:"column"`
+	Byte   *int `json:"byte,omitempty"` // pointer so omitempty works
 }
 
 // JSONError is a temporary structure for converting errors to JSON.
+// For HCL diagnostics, Message is the same as Detail for compatibility.
 type JSONError struct {
 	Summary  string     `json:"summary,omitempty"`
 	Message  string     `json:"message"`
+	Detail   string     `json:"detail,omitempty"`
 	Severity string     `json:"severity"`
+	Filename string     `json:"filename,omitempty"`
 	Range    *JSONRange `json:"range,omitempty"` // pointer so omitempty works
 }
 
+// detailedError is an error whose details are separated from the message, e.g. the stderr of a worker.
+// Error() should return the message followed by the details, which is printed in other formats.
+type detailedError interface {
+	error
+	Message() string
+	Detail() string
+}
+
+// dirError is an error occurred in an inspected directory, e.g. an error of a worker in recursive mode.
+// It is used to group errors without filenames by directory.
+type dirError interface {
+	error
+	Dir() string
+}
+
 // JSONOutput is a temporary structure for converting to JSON.
+// It is also passed to templates in the template format.
 type JSONOutput struct {
+	FormatVersion string      `json:"format_version"`
+	Issues        []JSONIssue `json:"issues"`
+	Errors        []JSONError `json:"errors"`
+	// FixedFiles is files rewritten by --fix. It is omitted if no files are rewritten.
+	FixedFiles []string    `json:"fixed_files,omitempty"`
+	Summary    JSONSummary `json:"summary"`
+	// ScannedFiles is configuration files loaded for inspection, so that files that are silently skipped can be noticed.
+	ScannedFiles []string `json:"scanned_files"`
+	// Statistics is counts of the inspection. It is set only when --statistics is used.
+	Statistics *JSONStatistics `json:"statistics,omitempty"`
+	// Changes is diffs of files rewritten by --fix. It is omitted if no files are rewritten.
+	Changes []JSONChange `json:"changes,omitempty"`
+	// Runs is results of each inspected directory. It is set only when the group_by_dir option is used.
+	Runs []JSONRun `json:"runs,omitempty"`
+	// IssuesTruncated is true if issues are dropped by --max-issues. It is omitted otherwise.
+	IssuesTruncated bool `json:"issues_truncated,omitempty"`
+}
+
+// JSONRun is a temporary structure for converting results of an inspected directory to JSON.
+// Issues and errors are also included in the top-level arrays.
+type JSONRun struct {
+	// Dir is the directory relative to the current directory, with forward slashes.
+	Dir    string      `json:"dir"`
 	Issues []JSONIssue `json:"issues"`
 	Errors []JSONError `json:"errors"`
 }
 
-func (f *Formatter) jsonPrint(issues tflint.Issues, appErr error) {
-	ret := &JSONOutput{Issues: make([]JSONIssue, len(issues)), Errors: f.jsonErrors(appErr)}
+// JSONChange is a temporary structure for converting a diff of a file rewritten by --fix to JSON.
+type JSONChange struct {
+	File        string `json:"file"`
+	UnifiedDiff string `json:"unified_diff"`
+	// Truncated is true if the diff is truncated to the max lines.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// JSONSummary is a temporary structure for converting aggregate counts of issues and errors to JSON.
+type JSONSummary struct {
+	IssueCount int `json:"issue_count"`
+	ErrorCount int `json:"error_count"`
+	// BySeverity always has all severities, even if there are no issues.
+	BySeverity map[string]int `json:"by_severity"`
+	ByRule     map[string]int `json:"by_rule"`
+	// HiddenCount is the number of issues hidden by --minimum-report-severity. They are not counted in other fields.
+	// It is omitted if no issues are hidden.
+	HiddenCount int `json:"hidden_count,omitempty"`
+}
+
+// JSONStatistics is a temporary structure for converting statistics of the inspection to JSON.
+type JSONStatistics struct {
+	FilesInspected   int `json:"files_inspected"`
+	ModulesInspected int `json:"modules_inspected"`
+	// ModulesErrored is the number of modules that failed to be inspected. They are not counted in ModulesInspected.
+	ModulesErrored int `json:"modules_errored"`
+	// ModulesSkipped is the number of modules skipped in recursive --changed-only mode. It is omitted if no modules are skipped.
+	ModulesSkipped int `json:"modules_skipped,omitempty"`
+	// DirsExcluded is the number of directories excluded from recursive inspection. It is omitted if no directories are excluded.
+	DirsExcluded   int   `json:"dirs_excluded,omitempty"`
+	RulesEvaluated int   `json:"rules_evaluated"`
+	DurationMS     int64 `json:"duration_ms"`
+}
+
+// jsonIssueV1_0 is an issue in the json format version 1.0.
+type jsonIssueV1_0 struct {
+	Rule    JSONRule    `json:"rule"`
+	Message string      `json:"message"`
+	Range   JSONRange   `json:"range"`
+	Callers []JSONRange `json:"callers"`
+}
+
+// jsonOutputV1_0 is the json format version 1.0.
+type jsonOutputV1_0 struct {
+	FormatVersion string          `json:"format_version"`
+	Issues        []jsonIssueV1_0 `json:"issues"`
+	Errors        []JSONError     `json:"errors"`
+}
+
+func (f *Formatter) jsonPrint(issues tflint.Issues, appErr error, sources map[string][]byte) {
+	var output any = f.jsonOutput(issues, appErr, sources)
+	if f.JSONVersion == "1.0" {
+		output = toJSONOutputV1_0(output.(*JSONOutput))
+	}
+
+	out, err := json.Marshal(output)
+	if err != nil {
+		fmt.Fprint(f.Stderr, err)
+	}
+	fmt.Fprint(f.Stdout, string(out))
+}
+
+// BuildJSON returns the output of the json format before serialization, so that callers can post-process it.
+// Issues are filtered and paths are converted in the same way as Print.
+// The latest schema is always returned regardless of JSONVersion.
+func (f *Formatter) BuildJSON(issues tflint.Issues, appErr error, sources map[string][]byte) *JSONOutput {
+	latest := f.withStdout(f.Stdout)
+	latest.JSONVersion = ""
+	issues, sources = latest.prepare(issues, sources)
+	return latest.jsonOutput(issues, appErr, sources)
+}
+
+func (f *Formatter) jsonOutput(issues tflint.Issues, appErr error, sources map[string][]byte) *JSONOutput {
+	ret := &JSONOutput{FormatVersion: JSONFormatVersion, Issues: make([]JSONIssue, len(issues)), Errors: f.jsonErrors(appErr, sources), ScannedFiles: []string{}}
+	for _, file := range f.ScannedFiles {
+		ret.ScannedFiles = append(ret.ScannedFiles, f.rewritePath(file))
+	}
+	slices.Sort(ret.ScannedFiles)
 
-	for idx, issue := range issues.Sort() {
+	sorted := issues.Sort()
+	for idx, issue := range sorted {
 		ret.Issues[idx] = JSONIssue{
 			Rule: JSONRule{
 				Name:     issue.Rule.Name(),
 				Severity: toSeverity(issue.Rule.Severity()),
 				Link:     issue.Rule.Link(),
+				Fixable:  issue.Fixable,
 			},
+			Message:     issue.Message,
+			Range:       f.toJSONRange(issue.Range, issueSource(issue, sources)),
+			Callers:     make([]JSONRange, len(issue.Callers)),
+			Fixable:     issue.Fixable,
+			Fingerprint: f.fingerprint(issue, sources),
+		}
+		if f.SnippetContext > 0 {
+			ret.Issues[idx].Context = issueContext(issue, sources, f.SnippetContext)
+		}
+		if f.IncludeSource {
+			ret.Issues[idx].Snippet = jsonSnippet(issue, sources, f.SourceMaxLength)
+		}
+		if f.Fix {
+			fixed := issue.Fixed
+			ret.Issues[idx].Fixed = &fixed
+			if fixed && !slices.Contains(ret.FixedFiles, issue.Range.Filename) {
+				ret.FixedFiles = append(ret.FixedFiles, issue.Range.Filename)
+			}
+		}
+		for i, caller := range issue.Callers {
+			ret.Issues[idx].Callers[i] = f.toJSONRange(caller, sources[caller.Filename])
+		}
+	}
+	slices.Sort(ret.FixedFiles)
+	ret.Summary = jsonSummary(ret)
+	ret.Summary.HiddenCount = f.hiddenIssues
+	if f.Statistics != nil {
+		ret.Statistics = &JSONStatistics{
+			FilesInspected:   f.Statistics.FilesInspected,
+			ModulesInspected: f.Statistics.ModulesInspected,
+			ModulesErrored:   f.Statistics.ModulesErrored,
+			ModulesSkipped:   f.Statistics.ModulesSkipped,
+			DirsExcluded:     f.Statistics.DirsExcluded,
+			RulesEvaluated:   f.Statistics.RulesEvaluated,
+			DurationMS:       f.Statistics.Duration.Milliseconds(),
+		}
+	}
+	for _, diff := range f.fileDiffs() {
+		ret.Changes = append(ret.Changes, JSONChange{File: diff.Filename, UnifiedDiff: diff.Diff, Truncated: diff.OmittedLines > 0})
+	}
+	if f.GroupByDir {
+		ret.Runs = f.jsonRuns(sorted, ret.Issues, appErr, sources)
+	}
+	ret.IssuesTruncated = f.IssuesTruncated
+
+	return ret
+}
+
+// jsonRuns groups issues and errors by the directory in ModuleDirs they belong to.
+// Issues in called modules belong to the directory of the root module. Errors that do not
+// belong to any directory, e.g. errors before inspection, are only in the top-level errors.
+func (f *Formatter) jsonRuns(issues tflint.Issues, jsonIssues []JSONIssue, appErr error, sources map[string][]byte) []JSONRun {
+	dirs := f.ModuleDirs
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+	ret := make([]JSONRun, len(dirs))
+	index := map[string]int{}
+	for idx, dir := range dirs {
+		ret[idx] = JSONRun{Dir: filepath.ToSlash(filepath.Clean(dir)), Issues: []JSONIssue{}, Errors: []JSONError{}}
+		index[dir] = idx
+	}
+	lookup := func(dir string) (int, bool) {
+		if idx, exists := index[dir]; exists {
+			return idx, true
+		}
+		// In non-recursive mode, all results belong to the only directory
+		return 0, len(ret) == 1
+	}
+
+	for idx, issue := range issues {
+		if i, exists := lookup(f.issueDir(issue)); exists {
+			ret[i].Issues = append(ret[i].Issues, jsonIssues[idx])
+		}
+	}
+	f.addRunErrors(ret, lookup, appErr, sources)
+
+	return ret
+}
+
+// issueDir returns the directory in ModuleDirs the issue belongs to.
+// Paths of issues may have been rewritten, so the original issue is used in that case.
+func (f *Formatter) issueDir(issue *tflint.Issue) string {
+	issue = f.originalIssue(issue)
+
+	filename := issue.Range.Filename
+	if len(issue.Callers) > 0 {
+		filename = issue.Callers[0].Filename
+	}
+	return f.moduleDir(filename)
+}
+
+// addRunErrors adds errors to runs of the directories they belong to.
+// Errors with filenames belong to the directory of the file, and others belong to the directory where they occurred.
+func (f *Formatter) addRunErrors(runs []JSONRun, lookup func(dir string) (int, bool), err error, sources map[string][]byte) {
+	if err == nil {
+		return
+	}
+
+	// errors.Join
+	if errs, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, err := range errs.Unwrap() {
+			f.addRunErrors(runs, lookup, err, sources)
+		}
+		return
+	}
+
+	var dirErr dirError
+	for _, jsonErr := range f.jsonErrors(err, sources) {
+		var dir string
+		if jsonErr.Filename != "" {
+			dir = f.moduleDir(jsonErr.Filename)
+		} else if errors.As(err, &dirErr) {
+			dir = dirErr.Dir()
+		}
+		if i, exists := lookup(dir); exists {
+			runs[i].Errors = append(runs[i].Errors, jsonErr)
+		}
+	}
+}
+
+// toJSONRange converts the range to JSON. Columns are recalculated in runes if the source is available,
+// except in version 1.0, where columns of the range are kept for compatibility.
+func (f *Formatter) toJSONRange(rng hcl.Range, src []byte) JSONRange {
+	return JSONRange{
+		Filename: rng.Filename,
+		Start:    f.toJSONPos(rng.Start, src),
+		End:      f.toJSONPos(rng.End, src),
+	}
+}
+
+func (f *Formatter) toJSONPos(pos hcl.Pos, src []byte) JSONPos {
+	offset := pos.Byte
+	column := pos.Column
+	if f.JSONVersion != "1.0" {
+		column = runeColumn(pos, src)
+	}
+	return JSONPos{Line: pos.Line, Column: column, Byte: &offset}
+}
+
+// jsonSnippet returns the source of the issue range and the whole line where it starts.
+// They are truncated to maxLength bytes (DefaultSourceMaxLength if zero) without splitting multi-byte characters,
+// so that pathological ranges like large heredocs do not bloat the output.
+// If the source code is not available, it returns nil.
+func jsonSnippet(issue *tflint.Issue, sources map[string][]byte, maxLength int) *JSONSnippet {
+	code := issueSnippet(issue, sources)
+	if code == nil {
+		return nil
+	}
+	if maxLength == 0 {
+		maxLength = DefaultSourceMaxLength
+	}
+
+	src := issueSource(issue, sources)
+	start := issue.Range.Start.Byte
+	lineStart := bytes.LastIndexByte(src[:start], '\n') + 1
+	lineEnd := len(src)
+	if i := bytes.IndexByte(src[start:], '\n'); i >= 0 {
+		lineEnd = start + i
+	}
+	line := bytes.TrimSuffix(src[lineStart:lineEnd], []byte("\r"))
+
+	ret := &JSONSnippet{}
+	var codeTruncated, lineTruncated bool
+	ret.Code, codeTruncated = truncateSource(code, maxLength)
+	ret.Line, lineTruncated = truncateSource(line, maxLength)
+	ret.Truncated = codeTruncated || lineTruncated
+	return ret
+}
+
+// truncateSource returns the source as a string truncated to maxLength bytes.
+// The end is moved back to the start of a character, so that the result stays valid UTF-8.
+func truncateSource(src []byte, maxLength int) (string, bool) {
+	if len(src) <= maxLength {
+		return string(src), false
+	}
+	n := maxLength
+	for n > 0 && !utf8.RuneStart(src[n]) {
+		n--
+	}
+	return string(src[:n]), true
+}
+
+// jsonSummary counts issues and errors in the output, so that the counts always match the arrays.
+func jsonSummary(output *JSONOutput) JSONSummary {
+	ret := JSONSummary{
+		IssueCount: len(output.Issues),
+		ErrorCount: len(output.Errors),
+		BySeverity: map[string]int{
+			toSeverity(sdk.ERROR):   0,
+			toSeverity(sdk.WARNING): 0,
+			toSeverity(sdk.NOTICE):  0,
+		},
+		ByRule: map[string]int{},
+	}
+	for _, issue := range output.Issues {
+		ret.BySeverity[issue.Rule.Severity]++
+		ret.ByRule[issue.Rule.Name]++
+	}
+	return ret
+}
+
+// toJSONOutputV1_0 converts the output to version 1.0, which has only fields printed before versioning.
+func toJSONOutputV1_0(output *JSONOutput) *jsonOutputV1_0 {
+	ret := &jsonOutputV1_0{FormatVersion: "1.0", Issues: make([]jsonIssueV1_0, len(output.Issues)), Errors: make([]JSONError, len(output.Errors))}
+	for idx, issue := range output.Issues {
+		ret.Issues[idx] = jsonIssueV1_0{
+			Rule:    JSONRule{Name: issue.Rule.Name, Severity: issue.Rule.Severity, Link: issue.Rule.Link},
 			Message: issue.Message,
-			Range: JSONRange{
-				Filename: issue.Range.Filename,
-				Start:    JSONPos{Line: issue.Range.Start.Line, Column: issue.Range.Start.Column},
-				End:      JSONPos{Line: issue.Range.End.Line, Column: issue.Range.End.Column},
-			},
+			Range:   toJSONRangeV1_0(issue.Range),
 			Callers: make([]JSONRange, len(issue.Callers)),
 		}
 		for i, caller := range issue.Callers {
-			ret.Issues[idx].Callers[i] = JSONRange{
-				Filename: caller.Filename,
-				Start:    JSONPos{Line: caller.Start.Line, Column: caller.Start.Column},
-				End:      JSONPos{Line: caller.End.Line, Column: caller.End.Column},
-			}
+			ret.Issues[idx].Callers[i] = toJSONRangeV1_0(caller)
 		}
 	}
-
-	out, err := json.Marshal(ret)
-	if err != nil {
-		fmt.Fprint(f.Stderr, err)
+	// Details are not separated from messages in version 1.0
+	for idx, err := range output.Errors {
+		if err.Summary == "" && err.Detail != "" {
+			err.Message = fmt.Sprintf("%s\n\n%s", err.Message, err.Detail)
+		}
+		err.Detail = ""
+		err.Filename = ""
+		if err.Range != nil {
+			rng := toJSONRangeV1_0(*err.Range)
+			err.Range = &rng
+		}
+		ret.Errors[idx] = err
 	}
-	fmt.Fprint(f.Stdout, string(out))
+	return ret
 }
 
-func (f *Formatter) jsonErrors(err error) []JSONError {
+// toJSONRangeV1_0 converts the range to version 1.0, where positions do not have byte offsets.
+// Columns are not converted here, because they are counted in the same way as the range by toJSONPos.
+func toJSONRangeV1_0(rng JSONRange) JSONRange {
+	rng.Start.Byte = nil
+	rng.End.Byte = nil
+	return rng
+}
+
+func (f *Formatter) jsonErrors(err error, sources map[string][]byte) []JSONError {
 	if err == nil {
 		return []JSONError{}
 	}
@@ -95,7 +474,7 @@ func (f *Formatter) jsonErrors(err error) []JSONError {
 	if errs, ok := err.(interface{ Unwrap() []error }); ok {
 		ret := []JSONError{}
 		for _, err := range errs.Unwrap() {
-			ret = append(ret, f.jsonErrors(err)...)
+			ret = append(ret, f.jsonErrors(err, sources)...)
 		}
 		return ret
 	}
@@ -105,20 +484,28 @@ func (f *Formatter) jsonErrors(err error) []JSONError {
 	if errors.As(err, &diags) {
 		ret := make([]JSONError, len(diags))
 		for idx, diag := range diags {
+			jsonRange := f.toJSONRange(*diag.Subject, sources[diag.Subject.Filename])
 			ret[idx] = JSONError{
 				Severity: fromHclSeverity(diag.Severity),
 				Summary:  diag.Summary,
 				Message:  diag.Detail,
-				Range: &JSONRange{
-					Filename: diag.Subject.Filename,
-					Start:    JSONPos{Line: diag.Subject.Start.Line, Column: diag.Subject.Start.Column},
-					End:      JSONPos{Line: diag.Subject.End.Line, Column: diag.Subject.End.Column},
-				},
+				Detail:   diag.Detail,
+				Filename: diag.Subject.Filename,
+				Range:    &jsonRange,
 			}
 		}
 		return ret
 	}
 
+	var detailed detailedError
+	if errors.As(err, &detailed) {
+		return []JSONError{{
+			Severity: toSeverity(sdk.ERROR),
+			Message:  detailed.Message(),
+			Detail:   detailed.Detail(),
+		}}
+	}
+
 	return []JSONError{{
 		Severity: toSeverity(sdk.ERROR),
 		Message:  err.Error(),
@@ -2,30 +2,282 @@ package formatter
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	hcl "github.com/hashicorp/hcl/v2"
 	"github.com/terraform-linters/tflint/tflint"
 )
 
 func Test_jsonPrint(t *testing.T) {
 	cases := []struct {
-		Name   string
-		Issues tflint.Issues
-		Error  error
-		Stdout string
+		Name      string
+		Issues    tflint.Issues
+		Error     error
+		Fix       bool
+		Context   int
+		Source    bool
+		MaxLen    int
+		Sources   map[string][]byte
+		Scanned   []string
+		Stats     *Statistics
+		Changes   []tflint.FileChange
+		Truncated bool
+		Version   string
+		Stdout    string
 	}{
 		{
 			Name:   "no issues",
 			Issues: tflint.Issues{},
-			Stdout: `{"issues":[],"errors":[]}`,
+			Stdout: `{"format_version":"1.1","issues":[],"errors":[],"summary":{"issue_count":0,"error_count":0,"by_severity":{"error":0,"info":0,"warning":0},"by_rule":{}},"scanned_files":[]}`,
+		},
+		{
+			Name: "fixable issues",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+					Fixable: true,
+				},
+			},
+			Stdout: `{"format_version":"1.1","issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com","fixable":true},"message":"test","range":{"filename":"test.tf","start":{"line":1,"column":1,"byte":0},"end":{"line":1,"column":4,"byte":3}},"callers":[],"fixable":true,"fingerprint":"a193a9a1f316b3b23c962f88d7a154a4800ed3c28f2bb3031b0ea60513304739"}],"errors":[],"summary":{"issue_count":1,"error_count":0,"by_severity":{"error":1,"info":0,"warning":0},"by_rule":{"test_rule":1}},"scanned_files":[]}`,
+		},
+		{
+			Name: "fixed issues",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "b.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+					Fixable: true,
+					Fixed:   true,
+				},
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "a.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+					Fixable: true,
+					Fixed:   true,
+				},
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "a.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1, Byte: 10},
+						End:      hcl.Pos{Line: 2, Column: 4, Byte: 13},
+					},
+				},
+			},
+			Fix:    true,
+			Stdout: `{"format_version":"1.1","issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com","fixable":true},"message":"test","range":{"filename":"a.tf","start":{"line":1,"column":1,"byte":0},"end":{"line":1,"column":4,"byte":3}},"callers":[],"fixable":true,"fixed":true,"fingerprint":"f7ce17cc5619a47d83ad393c266a8f1f99a75e8bdbe098bd0110bc37e6efddd7"},{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"a.tf","start":{"line":2,"column":1,"byte":10},"end":{"line":2,"column":4,"byte":13}},"callers":[],"fixable":false,"fixed":false,"fingerprint":"f7ce17cc5619a47d83ad393c266a8f1f99a75e8bdbe098bd0110bc37e6efddd7"},{"rule":{"name":"test_rule","severity":"error","link":"https://github.com","fixable":true},"message":"test","range":{"filename":"b.tf","start":{"line":1,"column":1,"byte":0},"end":{"line":1,"column":4,"byte":3}},"callers":[],"fixable":true,"fixed":true,"fingerprint":"b7d3ab9dfe6dc9188683ac867f003072cc433afbe2e4c33b04b3e03380cf7d6a"}],"errors":[],"fixed_files":["a.tf","b.tf"],"summary":{"issue_count":3,"error_count":0,"by_severity":{"error":3,"info":0,"warning":0},"by_rule":{"test_rule":3}},"scanned_files":[]}`,
+		},
+		{
+			Name: "format version 1.0",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+					Fixable: true,
+					Fixed:   true,
+				},
+			},
+			Fix:     true,
+			Version: "1.0",
+			Stdout:  `{"format_version":"1.0","issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"test.tf","start":{"line":1,"column":1},"end":{"line":1,"column":4}},"callers":[]}],"errors":[]}`,
+		},
+		{
+			Name: "fields added after format version 1.0",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 25},
+						End:      hcl.Pos{Line: 2, Column: 6, Byte: 28},
+					},
+					Callers: []hcl.Range{
+						{
+							Filename: "main.tf",
+							Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+							End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+						},
+					},
+				},
+			},
+			Context:   1,
+			Source:    true,
+			Sources:   map[string][]byte{"test.tf": []byte("resource \"foo\" \"bar\" {\n  ami = \"ami\"\n}\n")},
+			Scanned:   []string{"main.tf", "test.tf"},
+			Stats:     &Statistics{FilesInspected: 2, ModulesInspected: 1, RulesEvaluated: 3, Duration: time.Second},
+			Truncated: true,
+			Version:   "1.0",
+			Stdout:    `{"format_version":"1.0","issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"test.tf","start":{"line":2,"column":3},"end":{"line":2,"column":6}},"callers":[{"filename":"main.tf","start":{"line":1,"column":1},"end":{"line":1,"column":4}}]}],"errors":[]}`,
+		},
+		{
+			Name:    "detailed error in format version 1.0",
+			Error:   &testDetailedError{message: "Failed to run in subdir; exit status 1", detail: "Failed to load configurations"},
+			Version: "1.0",
+			Stdout:  `{"format_version":"1.0","issues":[],"errors":[{"message":"Failed to run in subdir; exit status 1\n\nFailed to load configurations","severity":"error"}]}`,
+		},
+		{
+			Name: "diagnostics in format version 1.0",
+			Error: hcl.Diagnostics{
+				&hcl.Diagnostic{
+					Severity: hcl.DiagWarning,
+					Summary:  "summary",
+					Detail:   "detail",
+					Subject: &hcl.Range{
+						Filename: "filename",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 5, Column: 1, Byte: 4},
+					},
+				},
+			},
+			Version: "1.0",
+			Stdout:  `{"format_version":"1.0","issues":[],"errors":[{"summary":"summary","message":"detail","severity":"warning","range":{"filename":"filename","start":{"line":1,"column":1},"end":{"line":5,"column":1}}}]}`,
+		},
+		{
+			Name: "context",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 25},
+						End:      hcl.Pos{Line: 2, Column: 6, Byte: 28},
+					},
+				},
+			},
+			Context: 1,
+			Sources: map[string][]byte{"test.tf": []byte("resource \"foo\" \"bar\" {\n  ami = \"ami\"\n}\n")},
+			Stdout:  `{"format_version":"1.1","issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"test.tf","start":{"line":2,"column":3,"byte":25},"end":{"line":2,"column":6,"byte":28}},"callers":[],"fixable":false,"context":"resource \"foo\" \"bar\" {\n  ami = \"ami\"\n}","fingerprint":"1ecb9ff870450e2d5eb8799360002ffca4f2dc40923e4b510245c1fdc96e2577"}],"errors":[],"summary":{"issue_count":1,"error_count":0,"by_severity":{"error":1,"info":0,"warning":0},"by_rule":{"test_rule":1}},"scanned_files":[]}`,
+		},
+		{
+			Name: "snippet",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 25},
+						End:      hcl.Pos{Line: 2, Column: 6, Byte: 28},
+					},
+				},
+			},
+			Source:  true,
+			Sources: map[string][]byte{"test.tf": []byte("resource \"foo\" \"bar\" {\n  ami = \"ami\"\n}\n")},
+			Stdout:  `{"format_version":"1.1","issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"test.tf","start":{"line":2,"column":3,"byte":25},"end":{"line":2,"column":6,"byte":28}},"callers":[],"fixable":false,"snippet":{"code":"ami","line":"  ami = \"ami\""},"fingerprint":"1ecb9ff870450e2d5eb8799360002ffca4f2dc40923e4b510245c1fdc96e2577"}],"errors":[],"summary":{"issue_count":1,"error_count":0,"by_severity":{"error":1,"info":0,"warning":0},"by_rule":{"test_rule":1}},"scanned_files":[]}`,
+		},
+		{
+			Name: "truncated snippet",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 7, Byte: 6},
+						End:      hcl.Pos{Line: 1, Column: 12, Byte: 14},
+					},
+				},
+			},
+			Source:  true,
+			MaxLen:  4,
+			Sources: map[string][]byte{"test.tf": []byte("tag = \"äöü\"\n")},
+			Stdout:  `{"format_version":"1.1","issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"test.tf","start":{"line":1,"column":7,"byte":6},"end":{"line":1,"column":12,"byte":14}},"callers":[],"fixable":false,"snippet":{"code":"\"ä","line":"tag ","truncated":true},"fingerprint":"64aa1b62fee47d7ab8ac38792885d9fa5cb25ca6f603bb9ffc5e4f972a49dc7d"}],"errors":[],"summary":{"issue_count":1,"error_count":0,"by_severity":{"error":1,"info":0,"warning":0},"by_rule":{"test_rule":1}},"scanned_files":[]}`,
+		},
+		{
+			Name: "snippet without sources",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 25},
+						End:      hcl.Pos{Line: 2, Column: 6, Byte: 28},
+					},
+				},
+			},
+			Source: true,
+			Stdout: `{"format_version":"1.1","issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"test.tf","start":{"line":2,"column":3,"byte":25},"end":{"line":2,"column":6,"byte":28}},"callers":[],"fixable":false,"fingerprint":"a193a9a1f316b3b23c962f88d7a154a4800ed3c28f2bb3031b0ea60513304739"}],"errors":[],"summary":{"issue_count":1,"error_count":0,"by_severity":{"error":1,"info":0,"warning":0},"by_rule":{"test_rule":1}},"scanned_files":[]}`,
+		},
+		{
+			Name: "summary",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+				{
+					Rule:    &testRuleWithoutLink{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 2, Column: 4, Byte: 3},
+					},
+				},
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 3, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 3, Column: 4, Byte: 3},
+					},
+				},
+			},
+			Error:  errors.New("an error occurred"),
+			Stdout: `{"format_version":"1.1","issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"test.tf","start":{"line":1,"column":1,"byte":0},"end":{"line":1,"column":4,"byte":3}},"callers":[],"fixable":false,"fingerprint":"a193a9a1f316b3b23c962f88d7a154a4800ed3c28f2bb3031b0ea60513304739"},{"rule":{"name":"test_rule_without_link","severity":"warning","link":""},"message":"test","range":{"filename":"test.tf","start":{"line":2,"column":1,"byte":0},"end":{"line":2,"column":4,"byte":3}},"callers":[],"fixable":false,"fingerprint":"12896b0d6ec76dc363bdab65870d5a1372ba30d3ec2de0ccc0b3fd06cd332595"},{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"test.tf","start":{"line":3,"column":1,"byte":0},"end":{"line":3,"column":4,"byte":3}},"callers":[],"fixable":false,"fingerprint":"a193a9a1f316b3b23c962f88d7a154a4800ed3c28f2bb3031b0ea60513304739"}],"errors":[{"message":"an error occurred","severity":"error"}],"summary":{"issue_count":3,"error_count":1,"by_severity":{"error":2,"info":0,"warning":1},"by_rule":{"test_rule":2,"test_rule_without_link":1}},"scanned_files":[]}`,
+		},
+		{
+			Name:    "scanned files",
+			Issues:  tflint.Issues{},
+			Scanned: []string{"main.tf", "empty.tf"},
+			Stdout:  `{"format_version":"1.1","issues":[],"errors":[],"summary":{"issue_count":0,"error_count":0,"by_severity":{"error":0,"info":0,"warning":0},"by_rule":{}},"scanned_files":["empty.tf","main.tf"]}`,
 		},
 		{
 			Name:   "error",
 			Error:  fmt.Errorf("Failed to work; %w", errors.New("I don't feel like working")),
-			Stdout: `{"issues":[],"errors":[{"message":"Failed to work; I don't feel like working","severity":"error"}]}`,
+			Stdout: `{"format_version":"1.1","issues":[],"errors":[{"message":"Failed to work; I don't feel like working","severity":"error"}],"summary":{"issue_count":0,"error_count":1,"by_severity":{"error":0,"info":0,"warning":0},"by_rule":{}},"scanned_files":[]}`,
+		},
+		{
+			Name:   "detailed error",
+			Error:  &testDetailedError{message: "Failed to run in subdir; exit status 1", detail: "Failed to load configurations"},
+			Stdout: `{"format_version":"1.1","issues":[],"errors":[{"message":"Failed to run in subdir; exit status 1","detail":"Failed to load configurations","severity":"error"}],"summary":{"issue_count":0,"error_count":1,"by_severity":{"error":0,"info":0,"warning":0},"by_rule":{}},"scanned_files":[]}`,
 		},
 		{
 			Name: "diagnostics",
@@ -44,7 +296,7 @@ func Test_jsonPrint(t *testing.T) {
 					},
 				},
 			),
-			Stdout: `{"issues":[],"errors":[{"summary":"summary","message":"detail","severity":"warning","range":{"filename":"filename","start":{"line":1,"column":1},"end":{"line":5,"column":1}}}]}`,
+			Stdout: `{"format_version":"1.1","issues":[],"errors":[{"summary":"summary","message":"detail","detail":"detail","severity":"warning","filename":"filename","range":{"filename":"filename","start":{"line":1,"column":1,"byte":0},"end":{"line":5,"column":1,"byte":4}}}],"summary":{"issue_count":0,"error_count":1,"by_severity":{"error":0,"info":0,"warning":0},"by_rule":{}},"scanned_files":[]}`,
 		},
 		{
 			Name: "joined errors",
@@ -64,19 +316,241 @@ func Test_jsonPrint(t *testing.T) {
 					},
 				},
 			),
-			Stdout: `{"issues":[],"errors":[{"message":"an error occurred","severity":"error"},{"message":"failed","severity":"error"},{"summary":"summary","message":"detail","severity":"warning","range":{"filename":"filename","start":{"line":1,"column":1},"end":{"line":5,"column":1}}}]}`,
+			Stdout: `{"format_version":"1.1","issues":[],"errors":[{"message":"an error occurred","severity":"error"},{"message":"failed","severity":"error"},{"summary":"summary","message":"detail","detail":"detail","severity":"warning","filename":"filename","range":{"filename":"filename","start":{"line":1,"column":1,"byte":0},"end":{"line":5,"column":1,"byte":4}}}],"summary":{"issue_count":0,"error_count":3,"by_severity":{"error":0,"info":0,"warning":0},"by_rule":{}},"scanned_files":[]}`,
+		},
+		{
+			Name:    "statistics",
+			Issues:  tflint.Issues{},
+			Scanned: []string{"main.tf"},
+			Stats:   &Statistics{FilesInspected: 1, ModulesInspected: 1, ModulesErrored: 1, RulesEvaluated: 3, Duration: 1500 * time.Microsecond},
+			Stdout:  `{"format_version":"1.1","issues":[],"errors":[],"summary":{"issue_count":0,"error_count":0,"by_severity":{"error":0,"info":0,"warning":0},"by_rule":{}},"scanned_files":["main.tf"],"statistics":{"files_inspected":1,"modules_inspected":1,"modules_errored":1,"rules_evaluated":3,"duration_ms":1}}`,
+		},
+		{
+			Name:   "skipped modules in statistics",
+			Issues: tflint.Issues{},
+			Stats:  &Statistics{FilesInspected: 1, ModulesInspected: 1, ModulesSkipped: 2, RulesEvaluated: 3, Duration: time.Second},
+			Stdout: `{"format_version":"1.1","issues":[],"errors":[],"summary":{"issue_count":0,"error_count":0,"by_severity":{"error":0,"info":0,"warning":0},"by_rule":{}},"scanned_files":[],"statistics":{"files_inspected":1,"modules_inspected":1,"modules_errored":0,"modules_skipped":2,"rules_evaluated":3,"duration_ms":1000}}`,
+		},
+		{
+			Name:   "excluded directories in statistics",
+			Issues: tflint.Issues{},
+			Stats:  &Statistics{FilesInspected: 1, ModulesInspected: 1, DirsExcluded: 2, RulesEvaluated: 3, Duration: time.Second},
+			Stdout: `{"format_version":"1.1","issues":[],"errors":[],"summary":{"issue_count":0,"error_count":0,"by_severity":{"error":0,"info":0,"warning":0},"by_rule":{}},"scanned_files":[],"statistics":{"files_inspected":1,"modules_inspected":1,"modules_errored":0,"dirs_excluded":2,"rules_evaluated":3,"duration_ms":1000}}`,
+		},
+		{
+			Name:    "changes",
+			Issues:  tflint.Issues{},
+			Fix:     true,
+			Changes: []tflint.FileChange{{Filename: "main.tf", Before: []byte("a = 1\n"), After: []byte("a = 2\n")}},
+			Stdout:  `{"format_version":"1.1","issues":[],"errors":[],"summary":{"issue_count":0,"error_count":0,"by_severity":{"error":0,"info":0,"warning":0},"by_rule":{}},"scanned_files":[],"changes":[{"file":"main.tf","unified_diff":"--- main.tf\n+++ main.tf\n@@ -1 +1 @@\n-a = 1\n+a = 2\n"}]}`,
+		},
+		{
+			// Columns in HCL are counted in grapheme clusters, and the emoji with a skin tone modifier is one cluster of two runes
+			Name: "issues after multibyte characters",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 19, Byte: 25},
+						End:      hcl.Pos{Line: 1, Column: 28, Byte: 40},
+					},
+				},
+			},
+			Sources: map[string][]byte{"test.tf": []byte(`locals { a = "👍🏽", b = "日本語" }`)},
+			Stdout:  `{"format_version":"1.1","issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"test.tf","start":{"line":1,"column":20,"byte":25},"end":{"line":1,"column":29,"byte":40}},"callers":[],"fixable":false,"fingerprint":"f6caca9a209d227c72ee06202437a08f400c3f40ed90e07e9b0728eace5fd265"}],"errors":[],"summary":{"issue_count":1,"error_count":0,"by_severity":{"error":1,"info":0,"warning":0},"by_rule":{"test_rule":1}},"scanned_files":[]}`,
+		},
+		{
+			// Columns are not recalculated in format version 1.0 for compatibility
+			Name: "issues after multibyte characters in format version 1.0",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 19, Byte: 25},
+						End:      hcl.Pos{Line: 1, Column: 28, Byte: 40},
+					},
+				},
+			},
+			Sources: map[string][]byte{"test.tf": []byte(`locals { a = "👍🏽", b = "日本語" }`)},
+			Version: "1.0",
+			Stdout:  `{"format_version":"1.0","issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"test.tf","start":{"line":1,"column":19},"end":{"line":1,"column":28}},"callers":[]}],"errors":[]}`,
+		},
+		{
+			Name:      "issues truncated",
+			Issues:    tflint.Issues{},
+			Truncated: true,
+			Stdout:    `{"format_version":"1.1","issues":[],"errors":[],"summary":{"issue_count":0,"error_count":0,"by_severity":{"error":0,"info":0,"warning":0},"by_rule":{}},"scanned_files":[],"issues_truncated":true}`,
 		},
 	}
 
 	for _, tc := range cases {
 		stdout := &bytes.Buffer{}
 		stderr := &bytes.Buffer{}
-		formatter := &Formatter{Stdout: stdout, Stderr: stderr, Format: "json"}
+		formatter := &Formatter{Stdout: stdout, Stderr: stderr, Format: "json", Fix: tc.Fix, SnippetContext: tc.Context, IncludeSource: tc.Source, SourceMaxLength: tc.MaxLen, ScannedFiles: tc.Scanned, Statistics: tc.Stats, Changes: tc.Changes, IssuesTruncated: tc.Truncated, JSONVersion: tc.Version}
 
-		formatter.Print(tc.Issues, tc.Error, map[string][]byte{})
+		sources := tc.Sources
+		if sources == nil {
+			sources = map[string][]byte{}
+		}
+		formatter.Print(tc.Issues, tc.Error, sources)
 
 		if stdout.String() != tc.Stdout {
 			t.Fatalf("Failed %s test: expected=%s, stdout=%s", tc.Name, tc.Stdout, stdout.String())
 		}
+
+		if tc.Version == "" {
+			var output JSONOutput
+			if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+				t.Fatalf("Failed %s test: %s", tc.Name, err)
+			}
+			if output.Summary.IssueCount != len(output.Issues) || output.Summary.ErrorCount != len(output.Errors) {
+				t.Fatalf("Failed %s test: summary does not match the issues and errors: %+v", tc.Name, output.Summary)
+			}
+		}
 	}
 }
+
+func Test_jsonRuns(t *testing.T) {
+	issue := func(filename string, callers ...string) *tflint.Issue {
+		ret := &tflint.Issue{Rule: &testRule{}, Message: "test", Range: hcl.Range{Filename: filename}}
+		for _, caller := range callers {
+			ret.Callers = append(ret.Callers, hcl.Range{Filename: caller})
+		}
+		return ret
+	}
+	diag := func(filename string) hcl.Diagnostics {
+		return hcl.Diagnostics{{Severity: hcl.DiagError, Summary: "summary", Detail: filename, Subject: &hcl.Range{Filename: filename}}}
+	}
+
+	// run is a summary of JSONRun with filenames of issues and messages of errors
+	type run struct {
+		Dir    string
+		Files  []string
+		Errors []string
+	}
+
+	cases := []struct {
+		Name       string
+		ModuleDirs []string
+		PathMode   string
+		Issues     tflint.Issues
+		Error      error
+		Version    string
+		Want       []run
+	}{
+		{
+			Name:       "recursive",
+			ModuleDirs: []string{"subdir1", "subdir2", "subdir3"},
+			Issues: tflint.Issues{
+				issue(filepath.Join("subdir1", "main.tf")),
+				issue(filepath.Join("modules", "vpc", "main.tf"), filepath.Join("subdir2", "main.tf")),
+				issue(filepath.Join("subdir2", "main.tf")),
+			},
+			Error: errors.Join(
+				&testDetailedError{message: "Failed to run in subdir1; exit status 1", dir: "subdir1"},
+				diag(filepath.Join("subdir2", "main.tf")),
+				errors.New("Failed to find workspaces"),
+			),
+			Want: []run{
+				{Dir: "subdir1", Files: []string{filepath.Join("subdir1", "main.tf")}, Errors: []string{"Failed to run in subdir1; exit status 1"}},
+				{Dir: "subdir2", Files: []string{filepath.Join("modules", "vpc", "main.tf"), filepath.Join("subdir2", "main.tf")}, Errors: []string{filepath.Join("subdir2", "main.tf")}},
+				{Dir: "subdir3", Files: []string{}, Errors: []string{}},
+			},
+		},
+		{
+			Name:       "nested directories",
+			ModuleDirs: []string{".", "subdir"},
+			Issues: tflint.Issues{
+				issue("main.tf"),
+				issue(filepath.Join("subdir", "main.tf")),
+			},
+			Want: []run{
+				{Dir: ".", Files: []string{"main.tf"}, Errors: []string{}},
+				{Dir: "subdir", Files: []string{filepath.Join("subdir", "main.tf")}, Errors: []string{}},
+			},
+		},
+		{
+			Name:       "path mode relative",
+			ModuleDirs: []string{"subdir1", "subdir2"},
+			PathMode:   "relative",
+			Issues: tflint.Issues{
+				issue(filepath.Join("subdir1", "main.tf")),
+				issue(filepath.Join("subdir2", "main.tf")),
+			},
+			Want: []run{
+				{Dir: "subdir1", Files: []string{"main.tf"}, Errors: []string{}},
+				{Dir: "subdir2", Files: []string{"main.tf"}, Errors: []string{}},
+			},
+		},
+		{
+			Name:       "non-recursive",
+			ModuleDirs: []string{"."},
+			Issues:     tflint.Issues{issue("main.tf")},
+			Error:      errors.New("Failed to load configurations"),
+			Want: []run{
+				{Dir: ".", Files: []string{"main.tf"}, Errors: []string{"Failed to load configurations"}},
+			},
+		},
+		{
+			Name:       "format version 1.0",
+			ModuleDirs: []string{"."},
+			Issues:     tflint.Issues{issue("main.tf")},
+			Version:    "1.0",
+			Want:       []run{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: &bytes.Buffer{}, Format: "json", GroupByDir: true, ModuleDirs: tc.ModuleDirs, PathMode: tc.PathMode, JSONVersion: tc.Version}
+
+			formatter.Print(tc.Issues, tc.Error, map[string][]byte{})
+
+			var output JSONOutput
+			if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+				t.Fatal(err)
+			}
+			got := []run{}
+			for _, r := range output.Runs {
+				summary := run{Dir: r.Dir, Files: []string{}, Errors: []string{}}
+				for _, issue := range r.Issues {
+					summary.Files = append(summary.Files, issue.Range.Filename)
+				}
+				for _, err := range r.Errors {
+					summary.Errors = append(summary.Errors, err.Message)
+				}
+				got = append(got, summary)
+			}
+			if diff := cmp.Diff(tc.Want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+type testDetailedError struct {
+	message string
+	detail  string
+	dir     string
+}
+
+func (e *testDetailedError) Error() string {
+	return e.message + "\n\n" + e.detail
+}
+
+func (e *testDetailedError) Message() string {
+	return e.message
+}
+
+func (e *testDetailedError) Detail() string {
+	return e.detail
+}
+
+func (e *testDetailedError) Dir() string {
+	return e.dir
+}
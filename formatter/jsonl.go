@@ -0,0 +1,68 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// jsonlIssue is an issue line in the jsonl format.
+// The type field distinguishes issues from errors.
+type jsonlIssue struct {
+	Type string `json:"type"`
+	JSONIssue
+}
+
+// jsonlError is an error line in the jsonl format.
+type jsonlError struct {
+	Type string `json:"type"`
+	JSONError
+}
+
+// jsonlPrint outputs each issue and error as a single JSON line.
+// Unlike the json format, it can be printed multiple times in recursive inspection.
+func (f *Formatter) jsonlPrint(issues tflint.Issues, appErr error, sources map[string][]byte) {
+	output := f.jsonOutput(issues, appErr, sources)
+
+	lines := make([]any, 0, len(output.Issues)+len(output.Errors))
+	for _, issue := range output.Issues {
+		lines = append(lines, jsonlIssue{Type: "issue", JSONIssue: issue})
+	}
+	for _, err := range output.Errors {
+		lines = append(lines, jsonlError{Type: "error", JSONError: err})
+	}
+	f.jsonlWrite(lines)
+}
+
+// jsonlPrintErrors outputs errors occurred in parallel workers as JSON lines.
+func (f *Formatter) jsonlPrintErrors(appErr error, sources map[string][]byte) {
+	errs := f.jsonErrors(appErr, sources)
+
+	lines := make([]any, len(errs))
+	for idx, err := range errs {
+		lines[idx] = jsonlError{Type: "error", JSONError: err}
+	}
+	f.jsonlWrite(lines)
+}
+
+// jsonlWrite writes lines at once under the lock,
+// so that lines printed from parallel workers are not interleaved.
+func (f *Formatter) jsonlWrite(lines []any) {
+	out := new(bytes.Buffer)
+	for _, line := range lines {
+		b, err := json.Marshal(line)
+		if err != nil {
+			fmt.Fprint(f.Stderr, err)
+			continue
+		}
+		out.Write(b)
+		out.WriteByte('\n')
+	}
+
+	state := f.shared()
+	state.streamMu.Lock()
+	defer state.streamMu.Unlock()
+	fmt.Fprint(f.Stdout, out.String())
+}
@@ -0,0 +1,125 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func Test_jsonlPrint(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Issues tflint.Issues
+		Error  error
+		Stdout string
+	}{
+		{
+			Name:   "no issues",
+			Issues: tflint.Issues{},
+			Stdout: "",
+		},
+		{
+			Name: "issues",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+				{
+					Rule:    &testRuleWithoutLink{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1, Byte: 10},
+						End:      hcl.Pos{Line: 2, Column: 4, Byte: 13},
+					},
+				},
+			},
+			Stdout: `{"type":"issue","rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"test.tf","start":{"line":1,"column":1,"byte":0},"end":{"line":1,"column":4,"byte":3}},"callers":[],"fixable":false,"fingerprint":"a193a9a1f316b3b23c962f88d7a154a4800ed3c28f2bb3031b0ea60513304739"}
+{"type":"issue","rule":{"name":"test_rule_without_link","severity":"warning","link":""},"message":"test","range":{"filename":"test.tf","start":{"line":2,"column":1,"byte":10},"end":{"line":2,"column":4,"byte":13}},"callers":[],"fixable":false,"fingerprint":"12896b0d6ec76dc363bdab65870d5a1372ba30d3ec2de0ccc0b3fd06cd332595"}
+`,
+		},
+		{
+			Name:   "error",
+			Issues: tflint.Issues{},
+			Error:  errors.New("Failed to work; I don't feel like working"),
+			Stdout: `{"type":"error","message":"Failed to work; I don't feel like working","severity":"error"}
+`,
+		},
+		{
+			Name:   "diagnostics",
+			Issues: tflint.Issues{},
+			Error:  hclDiags(`resource "foo" "bar" {`),
+			Stdout: `{"type":"error","summary":"Unclosed configuration block","message":"There is no closing brace for this block before the end of the file. This may be caused by incorrect brace nesting elsewhere in this file.","detail":"There is no closing brace for this block before the end of the file. This may be caused by incorrect brace nesting elsewhere in this file.","severity":"error","filename":"main.tf","range":{"filename":"main.tf","start":{"line":1,"column":22,"byte":21},"end":{"line":1,"column":23,"byte":22}}}
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: stderr}
+
+			formatter.jsonlPrint(tc.Issues, tc.Error, map[string][]byte{})
+
+			if diff := cmp.Diff(tc.Stdout, stdout.String()); diff != "" {
+				t.Error(diff)
+			}
+			if stderr.String() != "" {
+				t.Errorf("unexpected stderr: %s", stderr.String())
+			}
+		})
+	}
+}
+
+func TestPrintStream(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	formatter := &Formatter{Stdout: stdout, Stderr: stderr, Format: "jsonl", MinimumReportSeverity: "error"}
+
+	issue := func(rule tflint.Rule, filename string) *tflint.Issue {
+		return &tflint.Issue{
+			Rule:    rule,
+			Message: "test",
+			Range: hcl.Range{
+				Filename: filename,
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+			},
+		}
+	}
+
+	if !formatter.IsStreaming() {
+		t.Fatal("jsonl should be a streaming format")
+	}
+	// Results are printed as soon as each worker finishes
+	formatter.PrintStream(tflint.Issues{issue(&testRule{}, "subdir1/test.tf"), issue(&testRuleWithoutLink{}, "subdir1/test.tf")})
+	formatter.PrintErrorParallel(errors.New("Failed to run in subdir2"), map[string][]byte{})
+	formatter.PrintStream(tflint.Issues{issue(&testRule{}, "subdir3/test.tf")})
+	// Streamed results are not printed again
+	err := formatter.PrintParallel(tflint.Issues{issue(&testRule{}, "subdir1/test.tf"), issue(&testRule{}, "subdir3/test.tf")}, map[string][]byte{})
+	if err == nil {
+		t.Error("expected the error in parallel, but got nil")
+	}
+
+	want := `{"type":"issue","rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"subdir1/test.tf","start":{"line":1,"column":1,"byte":0},"end":{"line":1,"column":4,"byte":3}},"callers":[],"fixable":false,"fingerprint":"2acbf3f334648c6b743ad337f9af602576ca0a33a8816288c917d93cd8369fc2"}
+{"type":"error","message":"Failed to run in subdir2","severity":"error"}
+{"type":"issue","rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"subdir3/test.tf","start":{"line":1,"column":1,"byte":0},"end":{"line":1,"column":4,"byte":3}},"callers":[],"fixable":false,"fingerprint":"f58521aef91659f0f40ab0ac1d1a59cab6a0711d9b206edc65ff322300df4bc9"}
+`
+	if diff := cmp.Diff(want, stdout.String()); diff != "" {
+		t.Error(diff)
+	}
+	if stderr.String() != "" {
+		t.Errorf("unexpected stderr: %s", stderr.String())
+	}
+}
@@ -2,23 +2,73 @@ package formatter
 
 import (
 	"encoding/xml"
+	"errors"
 	"fmt"
 
-	"github.com/jstemmer/go-junit-report/formatter"
+	hcl "github.com/hashicorp/hcl/v2"
 	"github.com/terraform-linters/tflint/tflint"
 )
 
 // https://www.ibm.com/docs/en/developer-for-zos/14.1.0?topic=formats-junit-xml-format
 
+type junitTestSuites struct {
+	XMLName xml.Name          `xml:"testsuites"`
+	Suites  []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	XMLName   xml.Name         `xml:"testsuite"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	Errors    int              `xml:"errors,attr"`
+	Time      string           `xml:"time,attr"`
+	Name      string           `xml:"name,attr"`
+	TestCases []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	XMLName   xml.Name      `xml:"testcase"`
+	Classname string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Error     *junitFailure `xml:"error,omitempty"`
+}
+
+type junitFailure struct {
+	Message  string `xml:"message,attr"`
+	Type     string `xml:"type,attr"`
+	Contents string `xml:",chardata"`
+}
+
+// junitReport groups test cases into test suites per file while keeping the insertion order.
+type junitReport struct {
+	suites []*junitTestSuite
+	index  map[string]*junitTestSuite
+}
+
+func (r *junitReport) suite(filename string) *junitTestSuite {
+	if suite, exists := r.index[filename]; exists {
+		return suite
+	}
+	suite := &junitTestSuite{Time: "0", Name: filename, TestCases: []*junitTestCase{}}
+	r.index[filename] = suite
+	r.suites = append(r.suites, suite)
+	return suite
+}
+
 func (f *Formatter) junitPrint(issues tflint.Issues, appErr error, sources map[string][]byte) {
-	cases := make([]formatter.JUnitTestCase, len(issues))
+	report := &junitReport{index: map[string]*junitTestSuite{}}
 
-	for i, issue := range issues.Sort() {
-		cases[i] = formatter.JUnitTestCase{
-			Name:      issue.Rule.Name(),
-			Classname: issue.Range.Filename,
+	for _, issue := range issues.Sort() {
+		suite := report.suite(issue.Range.Filename)
+		suite.Tests++
+		suite.Failures++
+		suite.TestCases = append(suite.TestCases, &junitTestCase{
+			Name:      issue.Range.String(),
+			Classname: issue.Rule.Name(),
 			Time:      "0",
-			Failure: &formatter.JUnitFailure{
+			Failure: &junitFailure{
 				Message: fmt.Sprintf("%s: %s", issue.Range, issue.Message),
 				Type:    issue.Rule.Severity().String(),
 				Contents: fmt.Sprintf(
@@ -29,28 +79,75 @@ func (f *Formatter) junitPrint(issues tflint.Issues, appErr error, sources map[s
 					issue.Range,
 				),
 			},
-		}
+		})
 	}
 
-	suites := formatter.JUnitTestSuites{
-		Suites: []formatter.JUnitTestSuite{
-			{
-				Time:      "0",
-				Tests:     len(issues),
-				Failures:  len(issues),
-				TestCases: cases,
-			},
-		},
+	f.junitAddErrors(report, appErr)
+
+	// Always output at least one test suite, even if there are no issues
+	if len(report.suites) == 0 {
+		report.suite("")
 	}
 
-	out, err := xml.MarshalIndent(suites, "", "  ")
+	out, err := xml.MarshalIndent(junitTestSuites{Suites: report.suites}, "", "  ")
 	if err != nil {
 		fmt.Fprint(f.Stderr, err)
 	}
 	fmt.Fprint(f.Stdout, xml.Header)
 	fmt.Fprint(f.Stdout, string(out))
+}
+
+func (f *Formatter) junitAddErrors(report *junitReport, err error) {
+	if err == nil {
+		return
+	}
+
+	// errors.Join
+	if errs, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, err := range errs.Unwrap() {
+			f.junitAddErrors(report, err)
+		}
+		return
+	}
 
-	if appErr != nil {
-		f.prettyPrintErrors(appErr, sources, false)
+	// hcl.Diagnostics
+	var diags hcl.Diagnostics
+	if errors.As(err, &diags) {
+		for _, diag := range diags {
+			// Diagnostics without a range belong to the unnamed suite, like application errors
+			filename, name, message := "", diag.Summary, diag.Summary
+			if diag.Subject != nil {
+				filename, name, message = diag.Subject.Filename, diag.Subject.String(), fmt.Sprintf("%s: %s", diag.Subject, diag.Summary)
+			}
+
+			suite := report.suite(filename)
+			suite.Tests++
+			suite.Errors++
+			suite.TestCases = append(suite.TestCases, &junitTestCase{
+				Name:      name,
+				Classname: diag.Summary,
+				Time:      "0",
+				Error: &junitFailure{
+					Message:  message,
+					Type:     fromHclSeverity(diag.Severity),
+					Contents: diag.Detail,
+				},
+			})
+		}
+		return
 	}
+
+	suite := report.suite("")
+	suite.Tests++
+	suite.Errors++
+	suite.TestCases = append(suite.TestCases, &junitTestCase{
+		Name:      "application_error",
+		Classname: "tflint",
+		Time:      "0",
+		Error: &junitFailure{
+			Message:  err.Error(),
+			Type:     "error",
+			Contents: err.Error(),
+		},
+	})
 }
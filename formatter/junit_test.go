@@ -2,6 +2,7 @@ package formatter
 
 import (
 	"bytes"
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -21,9 +22,7 @@ func Test_junitPrint(t *testing.T) {
 			Issues: tflint.Issues{},
 			Stdout: `<?xml version="1.0" encoding="UTF-8"?>
 <testsuites>
-  <testsuite tests="0" failures="0" time="0" name="">
-    <properties></properties>
-  </testsuite>
+  <testsuite tests="0" failures="0" errors="0" time="0" name=""></testsuite>
 </testsuites>`,
 		},
 		{
@@ -41,12 +40,109 @@ func Test_junitPrint(t *testing.T) {
 			},
 			Stdout: `<?xml version="1.0" encoding="UTF-8"?>
 <testsuites>
-  <testsuite tests="1" failures="1" time="0" name="">
-    <properties></properties>
-    <testcase classname="test.tf" name="test_rule" time="0">
+  <testsuite tests="1" failures="1" errors="0" time="0" name="test.tf">
+    <testcase classname="test_rule" name="test.tf:1,1-4" time="0">
       <failure message="test.tf:1,1-4: issue message" type="Error">Error: issue message&#xA;Rule: test_rule&#xA;Range: test.tf:1,1-4</failure>
     </testcase>
   </testsuite>
+</testsuites>`,
+		},
+		{
+			Name: "issues in multiple files",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "issue message",
+					Range: hcl.Range{
+						Filename: "test2.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+				{
+					Rule:    &testRule{},
+					Message: "issue message",
+					Range: hcl.Range{
+						Filename: "test1.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 2, Column: 4, Byte: 3},
+					},
+				},
+				{
+					Rule:    &testRule{},
+					Message: "issue message",
+					Range: hcl.Range{
+						Filename: "test1.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+			},
+			Stdout: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite tests="2" failures="2" errors="0" time="0" name="test1.tf">
+    <testcase classname="test_rule" name="test1.tf:1,1-4" time="0">
+      <failure message="test1.tf:1,1-4: issue message" type="Error">Error: issue message&#xA;Rule: test_rule&#xA;Range: test1.tf:1,1-4</failure>
+    </testcase>
+    <testcase classname="test_rule" name="test1.tf:2,1-4" time="0">
+      <failure message="test1.tf:2,1-4: issue message" type="Error">Error: issue message&#xA;Rule: test_rule&#xA;Range: test1.tf:2,1-4</failure>
+    </testcase>
+  </testsuite>
+  <testsuite tests="1" failures="1" errors="0" time="0" name="test2.tf">
+    <testcase classname="test_rule" name="test2.tf:1,1-4" time="0">
+      <failure message="test2.tf:1,1-4: issue message" type="Error">Error: issue message&#xA;Rule: test_rule&#xA;Range: test2.tf:1,1-4</failure>
+    </testcase>
+  </testsuite>
+</testsuites>`,
+		},
+		{
+			Name:  "error",
+			Error: errors.New("an error occurred"),
+			Stdout: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite tests="1" failures="0" errors="1" time="0" name="">
+    <testcase classname="tflint" name="application_error" time="0">
+      <error message="an error occurred" type="error">an error occurred</error>
+    </testcase>
+  </testsuite>
+</testsuites>`,
+		},
+		{
+			Name: "joined errors",
+			Error: errors.Join(
+				errors.New("an error occurred"),
+				hclDiags(`resource "foo" "bar" {`),
+			),
+			Stdout: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite tests="1" failures="0" errors="1" time="0" name="">
+    <testcase classname="tflint" name="application_error" time="0">
+      <error message="an error occurred" type="error">an error occurred</error>
+    </testcase>
+  </testsuite>
+  <testsuite tests="1" failures="0" errors="1" time="0" name="main.tf">
+    <testcase classname="Unclosed configuration block" name="main.tf:1,22-23" time="0">
+      <error message="main.tf:1,22-23: Unclosed configuration block" type="error">There is no closing brace for this block before the end of the file. This may be caused by incorrect brace nesting elsewhere in this file.</error>
+    </testcase>
+  </testsuite>
+</testsuites>`,
+		},
+		{
+			Name: "diagnostics without subject",
+			Error: hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Failed to initialize plugins",
+					Detail:   "Plugin not found",
+				},
+			},
+			Stdout: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite tests="1" failures="0" errors="1" time="0" name="">
+    <testcase classname="Failed to initialize plugins" name="Failed to initialize plugins" time="0">
+      <error message="Failed to initialize plugins" type="error">Plugin not found</error>
+    </testcase>
+  </testsuite>
 </testsuites>`,
 		},
 	}
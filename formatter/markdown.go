@@ -0,0 +1,141 @@
+package formatter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// markdownCollapseThreshold is the number of issues in a file above which
+// the table is collapsed into a <details> section to keep comments short.
+const markdownCollapseThreshold = 10
+
+var markdownEscaper = strings.NewReplacer(
+	"|", `\|`,
+	"<", "&lt;",
+	">", "&gt;",
+	"\r\n", "<br>",
+	"\n", "<br>",
+)
+
+// markdownPrint outputs a summary for pull request comments.
+// The output is deterministic so that successive comments can be compared.
+func (f *Formatter) markdownPrint(issues tflint.Issues, appErr error) {
+	fmt.Fprint(f.Stdout, "## TFLint Results\n\n")
+
+	files := []string{}
+	fileIssues := map[string]tflint.Issues{}
+	for _, issue := range sortedIssues(issues) {
+		if _, exists := fileIssues[issue.Range.Filename]; !exists {
+			files = append(files, issue.Range.Filename)
+		}
+		fileIssues[issue.Range.Filename] = append(fileIssues[issue.Range.Filename], issue)
+	}
+
+	if len(issues) == 0 {
+		fmt.Fprint(f.Stdout, "No issues found.\n")
+	} else {
+		fmt.Fprintf(f.Stdout, "%s in %s\n", markdownTotals(issues), markdownPlural(len(files), "file"))
+	}
+
+	for _, file := range files {
+		issues := fileIssues[file]
+
+		if len(issues) > markdownCollapseThreshold {
+			fmt.Fprintf(f.Stdout, "\n<details>\n<summary>%s (%s)</summary>\n\n", markdownEscaper.Replace(file), markdownPlural(len(issues), "issue"))
+		} else {
+			fmt.Fprintf(f.Stdout, "\n### %s\n\n", markdownEscaper.Replace(file))
+		}
+
+		fmt.Fprint(f.Stdout, "| Rule | Severity | Location | Message |\n")
+		fmt.Fprint(f.Stdout, "| --- | --- | --- | --- |\n")
+		for _, issue := range issues {
+			rule := fmt.Sprintf("`%s`", issue.Rule.Name())
+			if issue.Rule.Link() != "" {
+				rule = fmt.Sprintf("[%s](%s)", rule, issue.Rule.Link())
+			}
+			fmt.Fprintf(
+				f.Stdout,
+				"| %s | %s | %s:%d | %s |\n",
+				rule,
+				toSeverity(issue.Rule.Severity()),
+				markdownEscaper.Replace(issue.Range.Filename),
+				issue.Range.Start.Line,
+				markdownEscaper.Replace(issue.Message),
+			)
+		}
+
+		if len(issues) > markdownCollapseThreshold {
+			fmt.Fprint(f.Stdout, "\n</details>\n")
+		}
+	}
+
+	if appErr != nil {
+		fmt.Fprint(f.Stdout, "\n### Errors\n\n")
+		f.markdownPrintErrors(appErr)
+	}
+}
+
+func (f *Formatter) markdownPrintErrors(err error) {
+	// errors.Join
+	if errs, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, err := range errs.Unwrap() {
+			f.markdownPrintErrors(err)
+		}
+		return
+	}
+
+	// hcl.Diagnostics
+	var diags hcl.Diagnostics
+	if errors.As(err, &diags) {
+		for _, diag := range diags {
+			message := diag.Summary
+			if diag.Detail != "" {
+				message = fmt.Sprintf("%s; %s", diag.Summary, diag.Detail)
+			}
+			if diag.Subject == nil {
+				fmt.Fprintf(f.Stdout, "- %s\n", markdownEscaper.Replace(message))
+				continue
+			}
+			fmt.Fprintf(f.Stdout, "- %s: %s\n", markdownEscaper.Replace(diag.Subject.String()), markdownEscaper.Replace(message))
+		}
+		return
+	}
+
+	fmt.Fprintf(f.Stdout, "- %s\n", markdownEscaper.Replace(err.Error()))
+}
+
+// markdownTotals returns the number of issues for each severity, e.g. "3 errors, 5 warnings".
+// Severities without issues are omitted.
+func markdownTotals(issues tflint.Issues) string {
+	counts := map[tflint.Severity]int{}
+	for _, issue := range issues {
+		counts[issue.Rule.Severity()]++
+	}
+
+	totals := []string{}
+	for _, severity := range []struct {
+		severity tflint.Severity
+		noun     string
+	}{
+		{sdk.ERROR, "error"},
+		{sdk.WARNING, "warning"},
+		{sdk.NOTICE, "notice"},
+	} {
+		if count := counts[severity.severity]; count > 0 {
+			totals = append(totals, markdownPlural(count, severity.noun))
+		}
+	}
+	return strings.Join(totals, ", ")
+}
+
+func markdownPlural(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
@@ -0,0 +1,179 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func Test_markdownPrint(t *testing.T) {
+	manyIssues := tflint.Issues{}
+	manyRows := ""
+	for i := 1; i <= markdownCollapseThreshold+1; i++ {
+		manyIssues = append(manyIssues, &tflint.Issue{
+			Rule:    &testRuleWithoutLink{},
+			Message: "test",
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: i, Column: 1},
+				End:      hcl.Pos{Line: i, Column: 4},
+			},
+		})
+		manyRows += fmt.Sprintf("| `test_rule_without_link` | warning | test.tf:%d | test |\n", i)
+	}
+
+	cases := []struct {
+		Name   string
+		Issues tflint.Issues
+		Error  error
+		Stdout string
+	}{
+		{
+			Name:   "no issues",
+			Issues: tflint.Issues{},
+			Stdout: `## TFLint Results
+
+No issues found.
+`,
+		},
+		{
+			Name: "issues",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRuleWithoutLink{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test2.tf",
+						Start:    hcl.Pos{Line: 3, Column: 1},
+						End:      hcl.Pos{Line: 3, Column: 4},
+					},
+				},
+				{
+					Rule:    &testRule{},
+					Message: "a | b\n<c>",
+					Range: hcl.Range{
+						Filename: "test1.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 4},
+					},
+				},
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test1.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 4},
+					},
+				},
+			},
+			Stdout: "## TFLint Results\n" +
+				"\n" +
+				"2 errors, 1 warning in 2 files\n" +
+				"\n" +
+				"### test1.tf\n" +
+				"\n" +
+				"| Rule | Severity | Location | Message |\n" +
+				"| --- | --- | --- | --- |\n" +
+				"| [`test_rule`](https://github.com) | error | test1.tf:1 | a \\| b<br>&lt;c&gt; |\n" +
+				"| [`test_rule`](https://github.com) | error | test1.tf:2 | test |\n" +
+				"\n" +
+				"### test2.tf\n" +
+				"\n" +
+				"| Rule | Severity | Location | Message |\n" +
+				"| --- | --- | --- | --- |\n" +
+				"| `test_rule_without_link` | warning | test2.tf:3 | test |\n",
+		},
+		{
+			Name:   "collapsed issues",
+			Issues: manyIssues,
+			Stdout: "## TFLint Results\n" +
+				"\n" +
+				"11 warnings in 1 file\n" +
+				"\n" +
+				"<details>\n" +
+				"<summary>test.tf (11 issues)</summary>\n" +
+				"\n" +
+				"| Rule | Severity | Location | Message |\n" +
+				"| --- | --- | --- | --- |\n" +
+				manyRows +
+				"\n" +
+				"</details>\n",
+		},
+		{
+			Name:   "errors",
+			Issues: tflint.Issues{},
+			Error: errors.Join(
+				errors.New("Failed to check ruleset. An error occurred."),
+				fmt.Errorf(
+					"babel fish confused; %w",
+					hcl.Diagnostics{
+						&hcl.Diagnostic{
+							Severity: hcl.DiagWarning,
+							Summary:  "summary",
+							Detail:   "detail",
+							Subject: &hcl.Range{
+								Filename: "filename",
+								Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+								End:      hcl.Pos{Line: 5, Column: 1, Byte: 4},
+							},
+						},
+					},
+				),
+			),
+			Stdout: `## TFLint Results
+
+No issues found.
+
+### Errors
+
+- Failed to check ruleset. An error occurred.
+- filename:1,1-5,1: summary; detail
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: stderr}
+
+			formatter.markdownPrint(tc.Issues, tc.Error)
+
+			if diff := cmp.Diff(tc.Stdout, stdout.String()); diff != "" {
+				t.Errorf("stdout: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_markdownPrint_deterministic(t *testing.T) {
+	issues := func() tflint.Issues {
+		return tflint.Issues{
+			{Rule: &testRuleWithoutLink{}, Message: "test", Range: hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 1}}},
+			{Rule: &testRule{}, Message: "test", Range: hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 1}}},
+		}
+	}
+
+	first := &bytes.Buffer{}
+	(&Formatter{Stdout: first}).markdownPrint(issues(), nil)
+
+	got := &bytes.Buffer{}
+	reversed := issues()
+	reversed[0], reversed[1] = reversed[1], reversed[0]
+	(&Formatter{Stdout: got}).markdownPrint(reversed, nil)
+
+	if diff := cmp.Diff(first.String(), got.String()); diff != "" {
+		t.Fatal(diff)
+	}
+	if !strings.Contains(first.String(), "| [`test_rule`](https://github.com) | error | test.tf:1 | test |\n| `test_rule_without_link`") {
+		t.Fatalf("issues on the same location should be sorted by rule name: %s", first.String())
+	}
+}
@@ -0,0 +1,21 @@
+package formatter
+
+import (
+	"path/filepath"
+)
+
+// patchPrint prints Changes as a patch with "a/" and "b/" prefixes, like git diff.
+// Unlike diffs printed with --fix, the patch is never truncated so that it can be applied with git apply or patch -p1.
+func (f *Formatter) patchPrint(err error, sources map[string][]byte) {
+	for _, change := range f.Changes {
+		path := filepath.ToSlash(change.Filename)
+		diff := UnifiedDiff("a/"+path, "b/"+path, change.Before, change.After)
+		// Fixes that do not change the content have no diff
+		if diff == "" {
+			continue
+		}
+		f.prettyPrintDiff(diff)
+	}
+
+	f.prettyPrintErrors(err, sources, false)
+}
@@ -0,0 +1,98 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func Test_patchPrint(t *testing.T) {
+	issues := tflint.Issues{{Rule: &testRule{}, Message: "test", Range: hcl.Range{Filename: "main.tf"}, Fixable: true, Fixed: true}}
+	changes := []tflint.FileChange{
+		{Filename: "main.tf", Before: []byte("a = 1\nb = 2\nc = 3\n"), After: []byte("a = 1\nb = 4\nc = 5\n")},
+		{Filename: "modules/child/main.tf", Before: []byte("d = 1\n"), After: []byte("d = 2\n")},
+		{Filename: "unchanged.tf", Before: []byte("e = 1\n"), After: []byte("e = 1\n")},
+	}
+
+	tests := []struct {
+		name  string
+		color bool
+		err   error
+		want  string
+	}{
+		{
+			name: "patch",
+			want: `--- a/main.tf
++++ b/main.tf
+@@ -1,3 +1,3 @@
+ a = 1
+-b = 2
+-c = 3
++b = 4
++c = 5
+--- a/modules/child/main.tf
++++ b/modules/child/main.tf
+@@ -1 +1 @@
+-d = 1
++d = 2
+`,
+		},
+		{
+			name:  "color",
+			color: true,
+			want: "\x1b[1m--- a/main.tf\x1b[22m\n" +
+				"\x1b[1m+++ b/main.tf\x1b[22m\n" +
+				"\x1b[36m@@ -1,3 +1,3 @@\x1b[0m\n" +
+				" a = 1\n" +
+				"\x1b[31m-b = 2\x1b[0m\n" +
+				"\x1b[31m-c = 3\x1b[0m\n" +
+				"\x1b[32m+b = 4\x1b[0m\n" +
+				"\x1b[32m+c = 5\x1b[0m\n" +
+				"\x1b[1m--- a/modules/child/main.tf\x1b[22m\n" +
+				"\x1b[1m+++ b/modules/child/main.tf\x1b[22m\n" +
+				"\x1b[36m@@ -1 +1 @@\x1b[0m\n" +
+				"\x1b[31m-d = 1\x1b[0m\n" +
+				"\x1b[32m+d = 2\x1b[0m\n",
+		},
+		{
+			name: "error",
+			err:  errors.New("Failed to fix"),
+			want: `--- a/main.tf
++++ b/main.tf
+@@ -1,3 +1,3 @@
+ a = 1
+-b = 2
+-c = 3
++b = 4
++c = 5
+--- a/modules/child/main.tf
++++ b/modules/child/main.tf
+@@ -1 +1 @@
+-d = 1
++d = 2
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			// The patch is never truncated by DiffMaxLines
+			formatter := &Formatter{Stdout: stdout, Stderr: stderr, Format: "default", Fix: true, DryRun: true, Changes: changes, DiffMaxLines: 2, Color: test.color}
+
+			formatter.Print(issues, test.err, map[string][]byte{})
+
+			if diff := cmp.Diff(test.want, stdout.String()); diff != "" {
+				t.Error(diff)
+			}
+			if test.err != nil && !strings.Contains(stderr.String(), test.err.Error()) {
+				t.Errorf("the error is not printed to stderr: %s", stderr)
+			}
+		})
+	}
+}
@@ -2,9 +2,12 @@ package formatter
 
 import (
 	"bufio"
+	"cmp"
 	"errors"
 	"fmt"
 	"log"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/fatih/color"
@@ -14,19 +17,58 @@ import (
 	"github.com/terraform-linters/tflint/tflint"
 )
 
-var colorBold = color.New(color.Bold).SprintfFunc()
-var colorHighlight = color.New(color.Bold).Add(color.Underline).SprintFunc()
-var colorError = color.New(color.FgRed).SprintFunc()
-var colorWarning = color.New(color.FgYellow).SprintFunc()
-var colorNotice = color.New(color.FgHiWhite).SprintFunc()
+// Styles of colorized output. Whether colors are enabled is decided by each Formatter
+// for each stream, instead of the global color.NoColor.
+var (
+	styleBold      = []color.Attribute{color.Bold}
+	styleHighlight = []color.Attribute{color.Bold, color.Underline}
+	styleError     = []color.Attribute{color.FgRed}
+	styleWarning   = []color.Attribute{color.FgYellow}
+	styleNotice    = []color.Attribute{color.FgHiWhite}
+
+	styleDiffAdded   = []color.Attribute{color.FgGreen}
+	styleDiffRemoved = []color.Attribute{color.FgRed}
+	styleDiffHunk    = []color.Attribute{color.FgCyan}
+)
+
+// colorize returns the text with the style if enabled is true
+func colorize(enabled bool, style []color.Attribute, text any) string {
+	c := color.New(style...)
+	if enabled {
+		c.EnableColor()
+	} else {
+		c.DisableColor()
+	}
+	return c.Sprint(text)
+}
 
 func (f *Formatter) prettyPrint(issues tflint.Issues, err error, sources map[string][]byte) {
 	if len(issues) > 0 {
 		fmt.Fprintf(f.Stdout, "%d issue(s) found:\n\n", len(issues))
 
-		for _, issue := range issues.Sort() {
-			f.prettyPrintIssueWithSource(issue, sources)
+		if f.GroupByFile {
+			f.prettyPrintGroupedByFile(issues.Sort(), sources)
+		} else {
+			for _, issue := range issues.Sort() {
+				f.prettyPrintIssueWithSource(issue, sources)
+			}
 		}
+
+		f.prettyPrintDiffs()
+
+		if !f.NoSummary {
+			f.prettyPrintSummary(issues)
+		}
+
+		if f.IssuesTruncated {
+			fmt.Fprintf(f.Stdout, "%s\n\n", colorize(f.Color, styleWarning, fmt.Sprintf("Issues are truncated by --max-issues=%d. Increase the limit to see more.", f.MaxIssues)))
+		}
+	} else if f.hiddenIssues > 0 && !f.NoSummary && !(f.QuietSuccess && err == nil) {
+		fmt.Fprintf(f.Stdout, "%s\n\n", f.prettyHiddenIssues())
+	}
+
+	if f.Statistics != nil && !(f.QuietSuccess && len(issues) == 0 && err == nil) {
+		fmt.Fprintf(f.Stdout, "%s\n\n", f.prettyStatistics())
 	}
 
 	if err != nil {
@@ -34,74 +76,286 @@ func (f *Formatter) prettyPrint(issues tflint.Issues, err error, sources map[str
 	}
 }
 
-func (f *Formatter) prettyPrintIssueWithSource(issue *tflint.Issue, sources map[string][]byte) {
+func prettyIssueMessage(issue *tflint.Issue, fix bool) string {
 	message := issue.Message
 	if issue.Fixable {
-		if f.Fix {
+		// Fixes may not be applied even with --fix, e.g. when skipped in interactive mode
+		if fix && issue.Fixed {
 			message = "[Fixed] " + message
 		} else {
 			message = "[Fixable] " + message
 		}
 	}
+	return message
+}
 
+func (f *Formatter) prettyPrintIssueWithSource(issue *tflint.Issue, sources map[string][]byte) {
 	fmt.Fprintf(
 		f.Stdout,
 		"%s: %s (%s)\n\n",
-		colorSeverity(issue.Rule.Severity()), colorBold(message), issue.Rule.Name(),
+		f.colorSeverity(issue.Rule.Severity()), colorize(f.Color, styleBold, prettyIssueMessage(issue, f.Fix)), issue.Rule.Name(),
 	)
 	fmt.Fprintf(f.Stdout, "  on %s line %d:\n", issue.Range.Filename, issue.Range.Start.Line)
 
-	var src []byte
-	if issue.Source != nil {
-		src = issue.Source
+	src := issueSource(issue, sources)
+	if src == nil {
+		fmt.Fprintf(f.Stdout, "   (source code not available)\n")
 	} else {
-		src = sources[issue.Range.Filename]
+		f.prettyPrintSource(src, issue.Range.Filename, []hcl.Range{issue.Range})
+	}
+
+	if len(issue.Callers) > 0 {
+		fmt.Fprint(f.Stdout, "\nCallers:\n")
+		for _, caller := range issue.Callers {
+			fmt.Fprintf(f.Stdout, "   %s\n", caller)
+		}
+	}
+
+	if issue.Rule.Link() != "" {
+		fmt.Fprintf(f.Stdout, "\nReference: %s\n", issue.Rule.Link())
 	}
 
+	fmt.Fprint(f.Stdout, "\n")
+}
+
+// prettyPrintGroupedByFile outputs issues grouped by filename. Each filename is printed once as a header,
+// and issues on contiguous lines share a single code frame. Issues must be sorted.
+func (f *Formatter) prettyPrintGroupedByFile(issues tflint.Issues, sources map[string][]byte) {
+	for start := 0; start < len(issues); {
+		end := start + 1
+		for end < len(issues) && issues[end].Range.Filename == issues[start].Range.Filename {
+			end++
+		}
+		f.prettyPrintFileIssues(issues[start:end], sources)
+		start = end
+	}
+}
+
+func (f *Formatter) prettyPrintFileIssues(issues tflint.Issues, sources map[string][]byte) {
+	fmt.Fprintf(f.Stdout, "%s\n", colorize(f.Color, styleBold, issues[0].Range.Filename))
+
+	width := 0
+	for _, issue := range issues {
+		width = max(width, len(prettyIssuePos(issue)))
+	}
+
+	for _, cluster := range clusterIssuesByLine(issues) {
+		for _, issue := range cluster {
+			fmt.Fprintf(
+				f.Stdout,
+				"  %-*s  %s %s (%s)\n",
+				width, prettyIssuePos(issue), f.colorSeverityGlyph(issue.Rule.Severity()), prettyIssueMessage(issue, f.Fix), issue.Rule.Name(),
+			)
+		}
+		fmt.Fprint(f.Stdout, "\n")
+		f.prettyPrintCodeFrame(cluster, sources)
+		fmt.Fprint(f.Stdout, "\n")
+	}
+}
+
+func prettyIssuePos(issue *tflint.Issue) string {
+	return fmt.Sprintf("%d:%d", issue.Range.Start.Line, issue.Range.Start.Column)
+}
+
+// clusterIssuesByLine splits sorted issues in a file into clusters of issues on contiguous or overlapping lines.
+func clusterIssuesByLine(issues tflint.Issues) []tflint.Issues {
+	clusters := []tflint.Issues{}
+	lastLine := 0
+	for _, issue := range issues {
+		if len(clusters) > 0 && issue.Range.Start.Line <= lastLine+1 {
+			clusters[len(clusters)-1] = append(clusters[len(clusters)-1], issue)
+		} else {
+			clusters = append(clusters, tflint.Issues{issue})
+			lastLine = 0
+		}
+		lastLine = max(lastLine, issue.Range.End.Line)
+	}
+	return clusters
+}
+
+// prettyPrintCodeFrame outputs lines covered by the cluster once, highlighting ranges of all issues in it.
+func (f *Formatter) prettyPrintCodeFrame(cluster tflint.Issues, sources map[string][]byte) {
+	src := issueSource(cluster[0], sources)
 	if src == nil {
 		fmt.Fprintf(f.Stdout, "   (source code not available)\n")
-	} else {
-		sc := hcl.NewRangeScanner(src, issue.Range.Filename, bufio.ScanLines)
+		return
+	}
+
+	ranges := make([]hcl.Range, len(cluster))
+	for idx, issue := range cluster {
+		ranges[idx] = issue.Range
+	}
+	f.prettyPrintSource(src, cluster[0].Range.Filename, ranges)
+}
 
-		for sc.Scan() {
-			lineRange := sc.Range()
-			if !lineRange.Overlaps(issue.Range) {
+// prettyPrintSource outputs lines overlapping the ranges with line numbers, highlighting the ranges.
+// If SnippetContext is set, the given number of lines before and after the ranges are also printed.
+func (f *Formatter) prettyPrintSource(src []byte, filename string, ranges []hcl.Range) {
+	firstLine, lastLine := ranges[0].Start.Line, ranges[0].End.Line
+	for _, rng := range ranges {
+		firstLine = min(firstLine, rng.Start.Line)
+		lastLine = max(lastLine, rng.End.Line)
+	}
+
+	sc := hcl.NewRangeScanner(src, filename, bufio.ScanLines)
+	for sc.Scan() {
+		lineRange := sc.Range()
+
+		// Byte offsets of highlighted parts in the line, merged if they overlap
+		highlights := [][2]int{}
+		overlapped := false
+		for _, rng := range ranges {
+			if !lineRange.Overlaps(rng) {
+				continue
+			}
+			overlapped = true
+			overlap := lineRange.Overlap(rng)
+			if overlap.Empty() {
+				continue
+			}
+			highlights = append(highlights, [2]int{overlap.Start.Byte, overlap.End.Byte})
+		}
+		if !overlapped {
+			inContext := f.SnippetContext > 0 && lineRange.Start.Line >= firstLine-f.SnippetContext && lineRange.Start.Line <= lastLine+f.SnippetContext
+			if !inContext {
 				continue
 			}
+		}
+		slices.SortFunc(highlights, func(a, b [2]int) int { return cmp.Compare(a[0], b[0]) })
 
-			beforeRange, highlightedRange, afterRange := lineRange.PartitionAround(issue.Range)
-			if highlightedRange.Empty() {
-				fmt.Fprintf(f.Stdout, "%4d: %s\n", lineRange.Start.Line, sc.Bytes())
-			} else {
-				before := beforeRange.SliceBytes(src)
-				highlighted := highlightedRange.SliceBytes(src)
-				after := afterRange.SliceBytes(src)
-				fmt.Fprintf(
-					f.Stdout,
-					"%4d: %s%s%s\n",
-					lineRange.Start.Line,
-					before,
-					colorHighlight(string(highlighted)),
-					after,
-				)
+		line := new(strings.Builder)
+		pos := lineRange.Start.Byte
+		for _, highlight := range highlights {
+			if highlight[1] <= pos {
+				continue
 			}
+			highlight[0] = max(highlight[0], pos)
+			line.Write(src[pos:highlight[0]])
+			line.WriteString(colorize(f.Color, styleHighlight, string(src[highlight[0]:highlight[1]])))
+			pos = highlight[1]
 		}
+		line.Write(src[pos:lineRange.End.Byte])
+
+		fmt.Fprintf(f.Stdout, "%4d: %s\n", lineRange.Start.Line, line.String())
 	}
+}
 
-	if len(issue.Callers) > 0 {
-		fmt.Fprint(f.Stdout, "\nCallers:\n")
-		for _, caller := range issue.Callers {
-			fmt.Fprintf(f.Stdout, "   %s\n", caller)
-		}
+// prettySummaryTopRules is the maximum number of rules listed in the summary
+const prettySummaryTopRules = 5
+
+// prettyPrintSummary outputs the total number of issues, counts per severity,
+// and the rules with the most issues, so that large results can be understood at a glance.
+func (f *Formatter) prettyPrintSummary(issues tflint.Issues) {
+	severities := map[tflint.Severity]int{}
+	rules := map[string]int{}
+	files := map[string]bool{}
+	for _, issue := range issues {
+		severities[issue.Rule.Severity()]++
+		rules[issue.Rule.Name()]++
+		files[issue.Range.Filename] = true
 	}
 
-	if issue.Rule.Link() != "" {
-		fmt.Fprintf(f.Stdout, "\nReference: %s\n", issue.Rule.Link())
+	fmt.Fprintf(f.Stdout, "%s %d issue(s) in %d file(s)\n", colorize(f.Color, styleBold, "Summary:"), len(issues), len(files))
+	fmt.Fprintf(
+		f.Stdout,
+		"  %s: %d, %s: %d, %s: %d\n",
+		colorize(f.Color, styleError, "error"), severities[sdk.ERROR],
+		colorize(f.Color, styleWarning, "warning"), severities[sdk.WARNING],
+		colorize(f.Color, styleNotice, "notice"), severities[sdk.NOTICE],
+	)
+
+	// Rules with the same count are sorted by name for deterministic output
+	names := slices.SortedFunc(maps.Keys(rules), func(a, b string) int {
+		if c := cmp.Compare(rules[b], rules[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+	if len(names) > prettySummaryTopRules {
+		fmt.Fprintf(f.Stdout, "  Top %d rules:\n", prettySummaryTopRules)
+		names = names[:prettySummaryTopRules]
+	} else {
+		fmt.Fprint(f.Stdout, "  Rules:\n")
 	}
+	for _, name := range names {
+		fmt.Fprintf(f.Stdout, "  %6d  %s\n", rules[name], name)
+	}
+	if f.hiddenIssues > 0 {
+		fmt.Fprintf(f.Stdout, "  %s\n", f.prettyHiddenIssues())
+	}
+	fmt.Fprintf(f.Stdout, "%s\n", f.prettyStatus(len(issues), severities))
 
 	fmt.Fprint(f.Stdout, "\n")
 }
 
+// prettyStatus returns the final status line colorized by the highest severity,
+// e.g. "3 problems (1 error, 2 warnings, 0 notices)", so that warning-only results can be told apart from errors.
+func (f *Formatter) prettyStatus(total int, severities map[tflint.Severity]int) string {
+	style := styleNotice
+	switch {
+	case severities[sdk.ERROR] > 0:
+		style = styleError
+	case severities[sdk.WARNING] > 0:
+		style = styleWarning
+	}
+
+	status := fmt.Sprintf(
+		"%s (%s, %s, %s)",
+		pluralize(total, "problem"),
+		pluralize(severities[sdk.ERROR], "error"),
+		pluralize(severities[sdk.WARNING], "warning"),
+		pluralize(severities[sdk.NOTICE], "notice"),
+	)
+	return colorize(f.Color, append([]color.Attribute{color.Bold}, style...), status)
+}
+
+func pluralize(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+// prettyPrintDiffs prints unified diffs of files rewritten by --fix after the issue list
+func (f *Formatter) prettyPrintDiffs() {
+	diffs := f.fileDiffs()
+	if len(diffs) == 0 {
+		return
+	}
+
+	fmt.Fprintf(f.Stdout, "%s fixed:\n\n", pluralize(len(diffs), "file"))
+	for _, diff := range diffs {
+		f.prettyPrintDiff(diff.Diff)
+		if diff.OmittedLines > 0 {
+			fmt.Fprintf(f.Stdout, "... %s omitted. Increase --diff-max-lines to see more.\n", pluralize(diff.OmittedLines, "line"))
+		}
+		fmt.Fprint(f.Stdout, "\n")
+	}
+}
+
+// prettyPrintDiff prints the unified diff with headers in bold, removed lines in red, and added lines in green
+func (f *Formatter) prettyPrintDiff(diff string) {
+	for _, line := range strings.SplitAfter(strings.TrimSuffix(diff, "\n"), "\n") {
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+			line = colorize(f.Color, styleBold, line)
+		case strings.HasPrefix(line, "@@"):
+			line = colorize(f.Color, styleDiffHunk, line)
+		case strings.HasPrefix(line, "-"):
+			line = colorize(f.Color, styleDiffRemoved, line)
+		case strings.HasPrefix(line, "+"):
+			line = colorize(f.Color, styleDiffAdded, line)
+		}
+		fmt.Fprintln(f.Stdout, line)
+	}
+}
+
+// prettyHiddenIssues returns a message about issues hidden by MinimumReportSeverity
+func (f *Formatter) prettyHiddenIssues() string {
+	return fmt.Sprintf("%d issue(s) below %s hidden", f.hiddenIssues, f.MinimumReportSeverity)
+}
+
 func (f *Formatter) prettyPrintErrors(err error, sources map[string][]byte, withIndent bool) {
 	if err == nil {
 		return
@@ -120,13 +374,13 @@ func (f *Formatter) prettyPrintErrors(err error, sources map[string][]byte, with
 	if errors.As(err, &diags) {
 		fmt.Fprintf(f.Stderr, "%s:\n\n", err)
 
-		writer := hcl.NewDiagnosticTextWriter(f.Stderr, parseSources(sources), 0, !f.NoColor)
+		writer := hcl.NewDiagnosticTextWriter(f.Stderr, parseSources(sources), 0, f.ColorStderr)
 		_ = writer.WriteDiagnostics(diags)
 		return
 	}
 
 	if withIndent {
-		fmt.Fprintf(f.Stderr, "%s %s\n", colorError("│"), err)
+		fmt.Fprintf(f.Stderr, "%s %s\n", colorize(f.ColorStderr, styleError, "│"), err)
 	} else {
 		fmt.Fprintf(f.Stderr, "%s\n", err)
 	}
@@ -134,7 +388,7 @@ func (f *Formatter) prettyPrintErrors(err error, sources map[string][]byte, with
 
 // PrettyPrintStderr outputs the given output to stderr with an indent.
 func (f *Formatter) PrettyPrintStderr(output string) {
-	fmt.Fprintf(f.Stderr, "%s %s\n", colorWarning("│"), output)
+	fmt.Fprintf(f.Stderr, "%s %s\n", colorize(f.ColorStderr, styleWarning, "│"), output)
 }
 
 func parseSources(sources map[string][]byte) map[string]*hcl.File {
@@ -159,14 +413,28 @@ func parseSources(sources map[string][]byte) map[string]*hcl.File {
 	return ret
 }
 
-func colorSeverity(severity tflint.Severity) string {
+// colorSeverityGlyph returns a colored glyph representing the severity
+func (f *Formatter) colorSeverityGlyph(severity tflint.Severity) string {
+	switch severity {
+	case sdk.ERROR:
+		return colorize(f.Color, styleError, "✖")
+	case sdk.WARNING:
+		return colorize(f.Color, styleWarning, "⚠")
+	case sdk.NOTICE:
+		return colorize(f.Color, styleNotice, "ℹ")
+	default:
+		panic("Unreachable")
+	}
+}
+
+func (f *Formatter) colorSeverity(severity tflint.Severity) string {
 	switch severity {
 	case sdk.ERROR:
-		return colorError(severity)
+		return colorize(f.Color, styleError, severity)
 	case sdk.WARNING:
-		return colorWarning(severity)
+		return colorize(f.Color, styleWarning, severity)
 	case sdk.NOTICE:
-		return colorNotice(severity)
+		return colorize(f.Color, styleNotice, severity)
 	default:
 		panic("Unreachable")
 	}
@@ -4,17 +4,17 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
-	"github.com/fatih/color"
+	"github.com/google/go-cmp/cmp"
 	hcl "github.com/hashicorp/hcl/v2"
+	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
 	"github.com/terraform-linters/tflint/tflint"
 )
 
 func Test_prettyPrint(t *testing.T) {
-	// Disable color
-	color.NoColor = true
-
 	warningColor := "\x1b[33m"
 	highlightColor := "\x1b[1;4m"
 	resetColor := "\x1b[0m"
@@ -74,6 +74,12 @@ Callers:
 
 Reference: https://github.com
 
+Summary: 1 issue(s) in 1 file(s)
+  error: 1, warning: 0, notice: 0
+  Rules:
+       1  test_rule
+1 problem (1 error, 0 warnings, 0 notices)
+
 `,
 		},
 		{
@@ -98,6 +104,12 @@ Error: test (test_rule)
 
 Reference: https://github.com
 
+Summary: 1 issue(s) in 1 file(s)
+  error: 1, warning: 0, notice: 0
+  Rules:
+       1  test_rule
+1 problem (1 error, 0 warnings, 0 notices)
+
 `,
 		},
 		{
@@ -126,6 +138,12 @@ Error: [Fixable] test (test_rule)
 
 Reference: https://github.com
 
+Summary: 1 issue(s) in 1 file(s)
+  error: 1, warning: 0, notice: 0
+  Rules:
+       1  test_rule
+1 problem (1 error, 0 warnings, 0 notices)
+
 `,
 		},
 		{
@@ -135,6 +153,7 @@ Reference: https://github.com
 					Rule:    &testRule{},
 					Message: "test",
 					Fixable: true,
+					Fixed:   true,
 					Range: hcl.Range{
 						Filename: "test.tf",
 						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
@@ -155,6 +174,12 @@ Error: [Fixed] test (test_rule)
 
 Reference: https://github.com
 
+Summary: 1 issue(s) in 1 file(s)
+  error: 1, warning: 0, notice: 0
+  Rules:
+       1  test_rule
+1 problem (1 error, 0 warnings, 0 notices)
+
 `,
 		},
 		{
@@ -183,6 +208,12 @@ Error: test (test_rule)
 
 Reference: https://github.com
 
+Summary: 1 issue(s) in 1 file(s)
+  error: 1, warning: 0, notice: 0
+  Rules:
+       1  test_rule
+1 problem (1 error, 0 warnings, 0 notices)
+
 `,
 		},
 		{
@@ -261,7 +292,7 @@ detail
 		t.Run(tc.Name, func(t *testing.T) {
 			stdout := &bytes.Buffer{}
 			stderr := &bytes.Buffer{}
-			formatter := &Formatter{Stdout: stdout, Stderr: stderr, Fix: tc.Fix}
+			formatter := &Formatter{Stdout: stdout, Stderr: stderr, Fix: tc.Fix, ColorStderr: true}
 
 			formatter.prettyPrint(tc.Issues, tc.Error, tc.Sources)
 
@@ -274,3 +305,436 @@ detail
 		})
 	}
 }
+
+func Test_prettyPrintSummary(t *testing.T) {
+	issue := func(rule tflint.Rule, filename string) *tflint.Issue {
+		return &tflint.Issue{Rule: rule, Message: "test", Range: hcl.Range{Filename: filename}}
+	}
+
+	cases := []struct {
+		Name   string
+		Issues tflint.Issues
+		Stdout string
+	}{
+		{
+			Name: "multiple severities",
+			Issues: tflint.Issues{
+				issue(&testRule{}, "a.tf"),
+				issue(&testRuleWithoutLink{}, "a.tf"),
+				issue(&testRuleWithoutLink{}, "b.tf"),
+			},
+			Stdout: `Summary: 3 issue(s) in 2 file(s)
+  error: 1, warning: 2, notice: 0
+  Rules:
+       2  test_rule_without_link
+       1  test_rule
+3 problems (1 error, 2 warnings, 0 notices)
+
+`,
+		},
+		{
+			Name: "top rules",
+			Issues: tflint.Issues{
+				issue(&namedTestRule{name: "rule_a"}, "a.tf"),
+				issue(&namedTestRule{name: "rule_b"}, "a.tf"),
+				issue(&namedTestRule{name: "rule_b"}, "a.tf"),
+				issue(&namedTestRule{name: "rule_c"}, "a.tf"),
+				issue(&namedTestRule{name: "rule_d"}, "a.tf"),
+				issue(&namedTestRule{name: "rule_e"}, "a.tf"),
+				issue(&namedTestRule{name: "rule_f"}, "a.tf"),
+			},
+			Stdout: `Summary: 7 issue(s) in 1 file(s)
+  error: 7, warning: 0, notice: 0
+  Top 5 rules:
+       2  rule_b
+       1  rule_a
+       1  rule_c
+       1  rule_d
+       1  rule_e
+7 problems (7 errors, 0 warnings, 0 notices)
+
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: &bytes.Buffer{}}
+
+			formatter.prettyPrintSummary(tc.Issues)
+
+			if stdout.String() != tc.Stdout {
+				t.Fatalf("expected=%s, stdout=%s", tc.Stdout, stdout.String())
+			}
+		})
+	}
+}
+
+func Test_prettyPrint_noSummary(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	formatter := &Formatter{Stdout: stdout, Stderr: &bytes.Buffer{}, NoSummary: true}
+
+	formatter.prettyPrint(tflint.Issues{{Rule: &testRule{}, Message: "test", Range: hcl.Range{Filename: "test.tf"}}}, nil, map[string][]byte{})
+
+	if strings.Contains(stdout.String(), "Summary:") {
+		t.Fatalf("summary should be hidden, but got %s", stdout.String())
+	}
+}
+
+func Test_prettyPrint_issuesTruncated(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	formatter := &Formatter{Stdout: stdout, Stderr: &bytes.Buffer{}, NoSummary: true, IssuesTruncated: true, MaxIssues: 1}
+
+	formatter.prettyPrint(tflint.Issues{{Rule: &testRule{}, Message: "test", Range: hcl.Range{Filename: "test.tf"}}}, nil, map[string][]byte{})
+
+	if !strings.Contains(stdout.String(), "Issues are truncated by --max-issues=1. Increase the limit to see more.") {
+		t.Fatalf("truncation notice should be printed, but got %s", stdout.String())
+	}
+}
+
+func Test_prettyStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		total      int
+		severities map[tflint.Severity]int
+		want       string
+		// escape is the escape sequence of bold and the color of the highest severity
+		escape string
+	}{
+		{
+			name:       "errors",
+			total:      3,
+			severities: map[tflint.Severity]int{sdk.ERROR: 1, sdk.WARNING: 2},
+			want:       "3 problems (1 error, 2 warnings, 0 notices)",
+			escape:     "\x1b[1;31m",
+		},
+		{
+			name:       "warnings only",
+			total:      2,
+			severities: map[tflint.Severity]int{sdk.WARNING: 1, sdk.NOTICE: 1},
+			want:       "2 problems (0 errors, 1 warning, 1 notice)",
+			escape:     "\x1b[1;33m",
+		},
+		{
+			name:       "notices only",
+			total:      1,
+			severities: map[tflint.Severity]int{sdk.NOTICE: 1},
+			want:       "1 problem (0 errors, 0 warnings, 1 notice)",
+			escape:     "\x1b[1;97m",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := (&Formatter{}).prettyStatus(test.total, test.severities); got != test.want {
+				t.Errorf("want=%q, got=%q", test.want, got)
+			}
+			// Colorized by the highest severity
+			if got := (&Formatter{Color: true}).prettyStatus(test.total, test.severities); !strings.HasPrefix(got, test.escape) {
+				t.Errorf("expected to start with %q, but got %q", test.escape, got)
+			}
+		})
+	}
+}
+
+func Test_prettyPrint_statistics(t *testing.T) {
+	stats := &Statistics{FilesInspected: 3, ModulesInspected: 2, RulesEvaluated: 20, Duration: 1234567 * time.Microsecond}
+
+	tests := []struct {
+		name         string
+		issues       tflint.Issues
+		err          error
+		stats        *Statistics
+		quietSuccess bool
+		want         string
+	}{
+		{
+			name:   "no issues",
+			issues: tflint.Issues{},
+			stats:  stats,
+			want:   "Inspected 3 file(s) in 2 module(s) with 20 rule(s) in 1.235s\n\n",
+		},
+		{
+			name:   "errored modules",
+			issues: tflint.Issues{},
+			err:    errors.New("failed"),
+			stats:  &Statistics{FilesInspected: 1, ModulesInspected: 1, ModulesErrored: 2, RulesEvaluated: 5, Duration: 10 * time.Millisecond},
+			want:   "Inspected 1 file(s) in 1 module(s) (2 failed) with 5 rule(s) in 10ms\n\n",
+		},
+		{
+			name:   "skipped modules",
+			issues: tflint.Issues{},
+			stats:  &Statistics{FilesInspected: 1, ModulesInspected: 1, ModulesErrored: 2, ModulesSkipped: 3, RulesEvaluated: 5, Duration: 10 * time.Millisecond},
+			want:   "Inspected 1 file(s) in 1 module(s) (2 failed, 3 skipped) with 5 rule(s) in 10ms\n\n",
+		},
+		{
+			name:   "excluded directories",
+			issues: tflint.Issues{},
+			stats:  &Statistics{FilesInspected: 1, ModulesInspected: 1, ModulesSkipped: 3, DirsExcluded: 4, RulesEvaluated: 5, Duration: 10 * time.Millisecond},
+			want:   "Inspected 1 file(s) in 1 module(s) (3 skipped, 4 excluded) with 5 rule(s) in 10ms\n\n",
+		},
+		{
+			name:   "issues",
+			issues: tflint.Issues{{Rule: &testRule{}, Message: "test", Range: hcl.Range{Filename: "test.tf"}}},
+			stats:  stats,
+			want:   "Inspected 3 file(s) in 2 module(s) with 20 rule(s) in 1.235s\n\n",
+		},
+		{
+			name:         "quiet success",
+			issues:       tflint.Issues{},
+			stats:        stats,
+			quietSuccess: true,
+			want:         "",
+		},
+		{
+			name:   "without statistics",
+			issues: tflint.Issues{},
+			want:   "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: &bytes.Buffer{}, Statistics: test.stats, QuietSuccess: test.quietSuccess}
+
+			formatter.prettyPrint(test.issues, test.err, map[string][]byte{})
+
+			got := stdout.String()
+			if idx := strings.Index(got, "Inspected"); idx > 0 {
+				// The statistics line is printed at the end of stdout
+				got = got[idx:]
+			} else if idx < 0 && test.want != "" {
+				t.Fatalf("statistics are not printed: %s", got)
+			} else if idx < 0 {
+				got = ""
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_prettyPrint_diffs(t *testing.T) {
+	issues := tflint.Issues{{Rule: &testRule{}, Message: "test", Range: hcl.Range{Filename: "main.tf"}, Fixable: true, Fixed: true}}
+	changes := []tflint.FileChange{{Filename: "main.tf", Before: []byte("a = 1\nb = 2\n"), After: []byte("a = 1\nb = 3\n")}}
+
+	tests := []struct {
+		name     string
+		fix      bool
+		maxLines int
+		want     string
+	}{
+		{
+			name: "fix",
+			fix:  true,
+			want: `1 file fixed:
+
+--- main.tf
++++ main.tf
+@@ -1,2 +1,2 @@
+ a = 1
+-b = 2
++b = 3
+
+`,
+		},
+		{
+			name:     "truncated",
+			fix:      true,
+			maxLines: 4,
+			want: `1 file fixed:
+
+--- main.tf
++++ main.tf
+@@ -1,2 +1,2 @@
+ a = 1
+... 2 lines omitted. Increase --diff-max-lines to see more.
+
+`,
+		},
+		{
+			name: "without --fix",
+			want: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: &bytes.Buffer{}, Fix: test.fix, Changes: changes, DiffMaxLines: test.maxLines, NoSummary: true}
+
+			formatter.prettyPrint(issues, nil, map[string][]byte{})
+
+			got := stdout.String()
+			// Diffs are printed after the issue list
+			if idx := strings.Index(got, "1 file fixed:"); idx >= 0 {
+				got = got[idx:]
+			} else {
+				got = ""
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_prettyPrint_colorPerStream(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	// e.g. stdout is redirected to a file, but stderr is a terminal
+	formatter := &Formatter{Stdout: stdout, Stderr: stderr, NoSummary: true, Color: false, ColorStderr: true}
+
+	issues := tflint.Issues{{Rule: &testRule{}, Message: "test", Range: hcl.Range{Filename: "test.tf"}}}
+	formatter.prettyPrint(issues, nil, map[string][]byte{})
+	formatter.PrettyPrintStderr("a warning")
+
+	if strings.Contains(stdout.String(), "\x1b[") {
+		t.Errorf("stdout should not be colorized, but got %q", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "\x1b[33m") {
+		t.Errorf("stderr should be colorized, but got %q", stderr.String())
+	}
+}
+
+func Test_prettyPrint_groupByFile(t *testing.T) {
+	bold := "\x1b[1m"
+	boldReset := "\x1b[22m"
+	errorColor := "\x1b[31m"
+	warningColor := "\x1b[33m"
+	highlightColor := "\x1b[1;4m"
+	highlightReset := "\x1b[22;24m"
+	resetColor := "\x1b[0m"
+
+	issue := func(rule tflint.Rule, filename string, start hcl.Pos, end hcl.Pos) *tflint.Issue {
+		return &tflint.Issue{Rule: rule, Message: "test", Range: hcl.Range{Filename: filename, Start: start, End: end}}
+	}
+	issues := tflint.Issues{
+		issue(&testRuleWithoutLink{}, "test.tf", hcl.Pos{Line: 4, Column: 1, Byte: 17}, hcl.Pos{Line: 4, Column: 4, Byte: 20}),
+		issue(&testRule{}, "test.tf", hcl.Pos{Line: 2, Column: 1, Byte: 8}, hcl.Pos{Line: 2, Column: 4, Byte: 11}),
+		issue(&testRule{}, "test.tf", hcl.Pos{Line: 1, Column: 7, Byte: 6}, hcl.Pos{Line: 1, Column: 8, Byte: 7}),
+		issue(&testRuleWithoutLink{}, "test.tf", hcl.Pos{Line: 1, Column: 1, Byte: 0}, hcl.Pos{Line: 1, Column: 4, Byte: 3}),
+		issue(&testRule{}, "other.tf", hcl.Pos{Line: 1, Column: 1, Byte: 0}, hcl.Pos{Line: 1, Column: 4, Byte: 3}),
+	}
+	sources := map[string][]byte{
+		"test.tf": []byte("foo = 1\nbar = 2\n\nbaz = 3\n"),
+	}
+
+	stdout := &bytes.Buffer{}
+	// Enable color to check highlighted ranges
+	formatter := &Formatter{Stdout: stdout, Stderr: &bytes.Buffer{}, NoSummary: true, GroupByFile: true, Color: true}
+
+	formatter.prettyPrint(issues, nil, sources)
+
+	want := fmt.Sprintf(`5 issue(s) found:
+
+%[1]sother.tf%[2]s
+  1:1  %[3]s✖%[7]s test (test_rule)
+
+   (source code not available)
+
+%[1]stest.tf%[2]s
+  1:1  %[4]s⚠%[7]s test (test_rule_without_link)
+  1:7  %[3]s✖%[7]s test (test_rule)
+  2:1  %[3]s✖%[7]s test (test_rule)
+
+   1: %[5]sfoo%[6]s = %[5]s1%[6]s
+   2: %[5]sbar%[6]s = 2
+
+  4:1  %[4]s⚠%[7]s test (test_rule_without_link)
+
+   4: %[5]sbaz%[6]s = 3
+
+`, bold, boldReset, errorColor, warningColor, highlightColor, highlightReset, resetColor)
+	if diff := cmp.Diff(want, stdout.String()); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func Test_prettyPrint_snippetContext(t *testing.T) {
+	src := []byte("a = 1\nb = 2\nc = <<EOT\nfoo\nEOT\nd = 4\n")
+	issues := tflint.Issues{
+		{
+			Rule:    &testRule{},
+			Message: "test",
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 3, Column: 5, Byte: 16},
+				End:      hcl.Pos{Line: 5, Column: 4, Byte: 29},
+			},
+		},
+	}
+
+	cases := []struct {
+		Name    string
+		Context int
+		Snippet string
+	}{
+		{
+			Name:    "no context",
+			Context: 0,
+			Snippet: `   3: c = <<EOT
+   4: foo
+   5: EOT
+`,
+		},
+		{
+			Name:    "context",
+			Context: 1,
+			Snippet: `   2: b = 2
+   3: c = <<EOT
+   4: foo
+   5: EOT
+   6: d = 4
+`,
+		},
+		{
+			Name:    "clamp at file boundaries",
+			Context: 10,
+			Snippet: `   1: a = 1
+   2: b = 2
+   3: c = <<EOT
+   4: foo
+   5: EOT
+   6: d = 4
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: &bytes.Buffer{}, NoSummary: true, SnippetContext: tc.Context}
+
+			formatter.prettyPrint(issues, nil, map[string][]byte{"test.tf": src})
+
+			want := "1 issue(s) found:\n\nError: test (test_rule)\n\n  on test.tf line 3:\n" + tc.Snippet + "\nReference: https://github.com\n\n"
+			if diff := cmp.Diff(want, stdout.String()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+type namedTestRule struct {
+	name string
+}
+
+func (r *namedTestRule) Name() string {
+	return r.name
+}
+
+func (r *namedTestRule) Enabled() bool {
+	return true
+}
+
+func (r *namedTestRule) Severity() tflint.Severity {
+	return sdk.ERROR
+}
+
+func (r *namedTestRule) Link() string {
+	return ""
+}
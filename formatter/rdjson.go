@@ -0,0 +1,159 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// https://github.com/reviewdog/reviewdog/tree/master/proto/rdf
+
+type rdjsonResult struct {
+	Source      rdjsonSource        `json:"source"`
+	Diagnostics []*rdjsonDiagnostic `json:"diagnostics"`
+}
+
+type rdjsonSource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type rdjsonDiagnostic struct {
+	Message  string         `json:"message"`
+	Location rdjsonLocation `json:"location"`
+	Severity string         `json:"severity"`
+	Code     *rdjsonCode    `json:"code,omitempty"`
+}
+
+type rdjsonLocation struct {
+	Path  string      `json:"path"`
+	Range rdjsonRange `json:"range"`
+}
+
+type rdjsonRange struct {
+	Start rdjsonPosition `json:"start"`
+	End   rdjsonPosition `json:"end"`
+}
+
+type rdjsonPosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type rdjsonCode struct {
+	Value string `json:"value"`
+	URL   string `json:"url,omitempty"`
+}
+
+func (f *Formatter) rdjsonPrint(issues tflint.Issues, appErr error, sources map[string][]byte) {
+	ret := &rdjsonResult{
+		Source:      rdjsonSource{Name: "tflint", URL: "https://github.com/terraform-linters/tflint"},
+		Diagnostics: make([]*rdjsonDiagnostic, len(issues)),
+	}
+
+	for idx, issue := range issues.Sort() {
+		src := issueSource(issue, sources)
+
+		ret.Diagnostics[idx] = &rdjsonDiagnostic{
+			Message: issue.Message,
+			Location: rdjsonLocation{
+				Path:  filepath.ToSlash(issue.Range.Filename),
+				Range: toRdjsonRange(issue.Range, src),
+			},
+			Severity: toRdjsonSeverity(issue.Rule.Severity()),
+			Code:     &rdjsonCode{Value: issue.Rule.Name(), URL: issue.Rule.Link()},
+		}
+	}
+
+	ret.Diagnostics = append(ret.Diagnostics, f.rdjsonErrors(appErr, sources)...)
+
+	out, err := json.Marshal(ret)
+	if err != nil {
+		fmt.Fprint(f.Stderr, err)
+	}
+	fmt.Fprint(f.Stdout, string(out))
+}
+
+// rdjsonErrors converts diagnostics into rdjson diagnostics.
+// Errors without a source location are output to stderr.
+func (f *Formatter) rdjsonErrors(err error, sources map[string][]byte) []*rdjsonDiagnostic {
+	if err == nil {
+		return []*rdjsonDiagnostic{}
+	}
+
+	// errors.Join
+	if errs, ok := err.(interface{ Unwrap() []error }); ok {
+		ret := []*rdjsonDiagnostic{}
+		for _, err := range errs.Unwrap() {
+			ret = append(ret, f.rdjsonErrors(err, sources)...)
+		}
+		return ret
+	}
+
+	// hcl.Diagnostics
+	var diags hcl.Diagnostics
+	if errors.As(err, &diags) {
+		ret := []*rdjsonDiagnostic{}
+		for _, diag := range diags {
+			if diag.Subject == nil {
+				f.prettyPrintErrors(hcl.Diagnostics{diag}, sources, false)
+				continue
+			}
+
+			severity := "ERROR"
+			if diag.Severity == hcl.DiagWarning {
+				severity = "WARNING"
+			}
+
+			ret = append(ret, &rdjsonDiagnostic{
+				Message: fmt.Sprintf("%s; %s", diag.Summary, diag.Detail),
+				Location: rdjsonLocation{
+					Path:  filepath.ToSlash(diag.Subject.Filename),
+					Range: toRdjsonRange(*diag.Subject, sources[diag.Subject.Filename]),
+				},
+				Severity: severity,
+			})
+		}
+		return ret
+	}
+
+	f.prettyPrintErrors(err, sources, false)
+	return []*rdjsonDiagnostic{}
+}
+
+// toRdjsonRange converts the range to rdjson positions.
+// Columns in rdjson are counted in UTF-8 bytes, while HCL counts them in characters,
+// so they are recalculated from byte offsets if the source is available.
+func toRdjsonRange(rng hcl.Range, src []byte) rdjsonRange {
+	return rdjsonRange{
+		Start: toRdjsonPosition(rng.Start, src),
+		End:   toRdjsonPosition(rng.End, src),
+	}
+}
+
+func toRdjsonPosition(pos hcl.Pos, src []byte) rdjsonPosition {
+	if src == nil || pos.Line < 1 || pos.Byte > len(src) {
+		return rdjsonPosition{Line: pos.Line, Column: pos.Column}
+	}
+	lineStart := bytes.LastIndexByte(src[:pos.Byte], '\n') + 1
+	return rdjsonPosition{Line: pos.Line, Column: pos.Byte - lineStart + 1}
+}
+
+func toRdjsonSeverity(severity tflint.Severity) string {
+	switch severity {
+	case sdk.ERROR:
+		return "ERROR"
+	case sdk.WARNING:
+		return "WARNING"
+	case sdk.NOTICE:
+		return "INFO"
+	default:
+		panic(fmt.Errorf("Unexpected lint type: %s", severity))
+	}
+}
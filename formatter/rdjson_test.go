@@ -0,0 +1,94 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func Test_rdjsonPrint(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Issues  tflint.Issues
+		Error   error
+		Sources map[string][]byte
+		Stdout  string
+		Stderr  string
+	}{
+		{
+			Name:   "no issues",
+			Issues: tflint.Issues{},
+			Stdout: `{"source":{"name":"tflint","url":"https://github.com/terraform-linters/tflint"},"diagnostics":[]}`,
+		},
+		{
+			Name: "issues",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+				{
+					Rule:    &testRuleWithoutLink{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1, Byte: 4},
+						End:      hcl.Pos{Line: 2, Column: 4, Byte: 7},
+					},
+				},
+			},
+			Stdout: `{"source":{"name":"tflint","url":"https://github.com/terraform-linters/tflint"},"diagnostics":[{"message":"test","location":{"path":"test.tf","range":{"start":{"line":1,"column":1},"end":{"line":1,"column":4}}},"severity":"ERROR","code":{"value":"test_rule","url":"https://github.com"}},{"message":"test","location":{"path":"test.tf","range":{"start":{"line":2,"column":1},"end":{"line":2,"column":4}}},"severity":"WARNING","code":{"value":"test_rule_without_link"}}]}`,
+		},
+		{
+			Name: "issues with multibyte characters",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 7, Byte: 23},
+						End:      hcl.Pos{Line: 2, Column: 10, Byte: 26},
+					},
+				},
+			},
+			Sources: map[string][]byte{"test.tf": []byte("# コメント\n\"あ\" = foo\n")},
+			Stdout:  `{"source":{"name":"tflint","url":"https://github.com/terraform-linters/tflint"},"diagnostics":[{"message":"test","location":{"path":"test.tf","range":{"start":{"line":2,"column":9},"end":{"line":2,"column":12}}},"severity":"ERROR","code":{"value":"test_rule","url":"https://github.com"}}]}`,
+		},
+		{
+			Name: "joined errors",
+			Error: errors.Join(
+				errors.New("an error occurred"),
+				hclDiags(`resource "foo" "bar" {`),
+			),
+			Stdout: `{"source":{"name":"tflint","url":"https://github.com/terraform-linters/tflint"},"diagnostics":[{"message":"Unclosed configuration block; There is no closing brace for this block before the end of the file. This may be caused by incorrect brace nesting elsewhere in this file.","location":{"path":"main.tf","range":{"start":{"line":1,"column":22},"end":{"line":1,"column":23}}},"severity":"ERROR"}]}`,
+			Stderr: "an error occurred\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: stderr}
+
+			formatter.rdjsonPrint(tc.Issues, tc.Error, tc.Sources)
+
+			if diff := cmp.Diff(tc.Stdout, stdout.String()); diff != "" {
+				t.Errorf("stdout: %s", diff)
+			}
+			if diff := cmp.Diff(tc.Stderr, stderr.String()); diff != "" {
+				t.Errorf("stderr: %s", diff)
+			}
+		})
+	}
+}
@@ -1,81 +1,283 @@
 package formatter
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"net/url"
 	"path/filepath"
+	"strings"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/owenrumney/go-sarif/v2/sarif"
+	"github.com/pmezard/go-difflib/difflib"
 	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
 	"github.com/terraform-linters/tflint/tflint"
 )
 
-func (f *Formatter) sarifPrint(issues tflint.Issues, appErr error) {
-	report, initErr := sarif.New(sarif.Version210)
-	if initErr != nil {
-		panic(initErr)
+const sarifColumnKind = "unicodeCodePoints"
+
+func (f *Formatter) sarifPrint(issues tflint.Issues, appErr error, sources map[string][]byte) {
+	report, err := f.sarifReport(issues, appErr, sources)
+	if err != nil {
+		panic(err)
+	}
+
+	stdoutErr := report.PrettyWrite(f.Stdout)
+	if stdoutErr != nil {
+		panic(stdoutErr)
+	}
+}
+
+// BuildSARIF returns the report of the sarif format before serialization, so that callers can post-process it.
+// Issues are filtered and paths are converted in the same way as Print.
+func (f *Formatter) BuildSARIF(issues tflint.Issues, appErr error, sources map[string][]byte) (*sarif.Report, error) {
+	issues, sources = f.prepare(issues, sources)
+	return f.sarifReport(issues, appErr, sources)
+}
+
+func (f *Formatter) sarifReport(issues tflint.Issues, appErr error, sources map[string][]byte) (*sarif.Report, error) {
+	report, err := sarif.New(sarif.Version210)
+	if err != nil {
+		return nil, err
 	}
 
 	run := sarif.NewRunWithInformationURI("tflint", "https://github.com/terraform-linters/tflint")
+	// HCL counts columns by characters, not by UTF-16 code units (the SARIF default)
+	run.WithColumnKind(sarifColumnKind)
 
 	version := tflint.Version.String()
 	run.Tool.Driver.Version = &version
 
 	report.AddRun(run)
 
-	for _, issue := range issues {
-		rule := run.AddRule(issue.Rule.Name()).
-			WithHelpURI(issue.Rule.Link()).
-			WithDescription("")
-
-		var level string
-		switch issue.Rule.Severity() {
-		case sdk.ERROR:
-			level = "error"
-		case sdk.NOTICE:
-			level = "note"
-		case sdk.WARNING:
-			level = "warning"
-		default:
-			panic(fmt.Errorf("Unexpected lint type: %s", issue.Rule.Severity()))
-		}
-
-		var location *sarif.PhysicalLocation
-		if issue.Range.Filename != "" {
-			location = sarif.NewPhysicalLocation().
-				WithArtifactLocation(sarif.NewSimpleArtifactLocation(filepath.ToSlash(issue.Range.Filename)))
-
-			if !issue.Range.Empty() {
-				location.WithRegion(
-					sarif.NewRegion().
-						WithStartLine(issue.Range.Start.Line).
-						WithStartColumn(issue.Range.Start.Column).
-						WithEndLine(issue.Range.End.Line).
-						WithEndColumn(issue.Range.End.Column),
-				)
-			}
+	fixes := map[*tflint.Issue]*sarif.Fix{}
+	if f.DryRun {
+		fixes = f.sarifFixes(issues)
+	}
+
+	descriptors := map[string]*sarif.ReportingDescriptor{}
+	occurrences := map[string]int{}
+
+	for _, issue := range issues.Sort() {
+		level := toSarifLevel(issue.Rule.Severity())
+
+		// The rule descriptor is built from the first issue of the rule.
+		// The result level may differ from the default level of the descriptor.
+		rule, exists := descriptors[issue.Rule.Name()]
+		if !exists {
+			rule = run.AddRule(issue.Rule.Name()).
+				WithShortDescription(sarif.NewMultiformatMessageString(issue.Rule.Name())).
+				WithFullDescription(sarif.NewMultiformatMessageString(sarifFullDescription(issue.Rule))).
+				WithDefaultConfiguration(sarif.NewReportingConfiguration().WithLevel(level)).
+				// The helpUri is always emitted, even if the rule has no link
+				WithHelpURI(issue.Rule.Link())
+			descriptors[issue.Rule.Name()] = rule
 		}
 
 		result := run.CreateResultForRule(rule.ID).
 			WithLevel(level).
 			WithMessage(sarif.NewTextMessage(issue.Message))
 
-		if location != nil {
+		if location := sarifPhysicalLocation(issue.Range); location != nil {
 			result.AddLocation(sarif.NewLocationWithPhysicalLocation(location))
 		}
+
+		// Issues in called modules have the chain of module calls from the root module.
+		// The last caller is the location in the called module where the issue was raised.
+		for idx, caller := range issue.Callers {
+			location := sarifPhysicalLocation(caller)
+			if location == nil {
+				continue
+			}
+			message := "Module call argument"
+			if idx == len(issue.Callers)-1 {
+				message = "Location in the called module"
+			}
+			result.AddRelatedLocation(
+				sarif.NewLocationWithPhysicalLocation(location).
+					WithId(idx).
+					WithMessage(sarif.NewTextMessage(message)),
+			)
+		}
+
+		fingerprints := map[string]interface{}{
+			"tflintFingerprint/v1": f.fingerprint(issue, sources),
+		}
+		if hash := sarifLineHash(issue, sources); hash != "" {
+			// Identical lines in the same file are distinguished by the occurrence count
+			key := issue.Range.Filename + "\x00" + hash
+			occurrences[key]++
+			fingerprints["primaryLocationLineHash"] = fmt.Sprintf("%s:%d", hash, occurrences[key])
+		}
+		result.WithPartialFingerPrints(fingerprints)
+
+		// Plugins do not send the contents of fixes unless --fix is passed,
+		// so only a hint that the issue can be fixed is emitted unless fixes are computed by --fix --dry-run.
+		if issue.Fixable {
+			props := sarif.NewPropertyBag()
+			props.AddBoolean("fixable", true)
+			result.AttachPropertyBag(props)
+		}
+		if fix, exists := fixes[f.originalIssue(issue)]; exists {
+			result.AddFix(fix)
+		}
 	}
 
 	errRun := sarif.NewRunWithInformationURI("tflint-errors", "https://github.com/terraform-linters/tflint")
+	errRun.WithColumnKind(sarifColumnKind)
 	errRun.Tool.Driver.Version = &version
 
 	report.AddRun(errRun)
 	f.sarifAddErrors(errRun, appErr)
 
-	stdoutErr := report.PrettyWrite(f.Stdout)
-	if stdoutErr != nil {
-		panic(stdoutErr)
+	return report, nil
+}
+
+// sarifPhysicalLocation returns the physical location of the range, or nil if the range has no filename.
+func sarifPhysicalLocation(rng hcl.Range) *sarif.PhysicalLocation {
+	if rng.Filename == "" {
+		return nil
+	}
+	location := sarif.NewPhysicalLocation().
+		WithArtifactLocation(sarif.NewSimpleArtifactLocation(sarifArtifactURI(rng.Filename)))
+
+	if !rng.Empty() {
+		location.WithRegion(
+			sarif.NewRegion().
+				WithStartLine(rng.Start.Line).
+				WithStartColumn(rng.Start.Column).
+				WithEndLine(rng.End.Line).
+				WithEndColumn(rng.End.Column).
+				WithByteOffset(rng.Start.Byte).
+				WithByteLength(rng.End.Byte - rng.Start.Byte),
+		)
+	}
+	return location
+}
+
+// sarifFixes returns fixes of the issues built from Changes, keyed by the original issues.
+// Plugins send the whole source after fixes instead of edits of each issue, so each changed hunk of a file
+// is attributed to the fixed issue in the file that starts nearest to it. Regions are byte ranges of the source before fixes.
+func (f *Formatter) sarifFixes(issues tflint.Issues) map[*tflint.Issue]*sarif.Fix {
+	fixes := map[*tflint.Issue]*sarif.Fix{}
+
+	for _, change := range f.Changes {
+		candidates := tflint.Issues{}
+		uri := ""
+		for _, issue := range issues {
+			if original := f.originalIssue(issue); original.Fixed && original.Range.Filename == change.Filename {
+				candidates = append(candidates, original)
+				uri = sarifArtifactURI(issue.Range.Filename)
+			}
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+
+		before, after := sarifSplitLines(change.Before), sarifSplitLines(change.After)
+		offsets := make([]int, len(before)+1)
+		for i, line := range before {
+			offsets[i+1] = offsets[i] + len(line)
+		}
+
+		changes := map[*tflint.Issue]*sarif.ArtifactChange{}
+		for _, op := range difflib.NewMatcher(before, after).GetOpCodes() {
+			if op.Tag == 'e' {
+				continue
+			}
+			owner := nearestIssue(candidates, op.I1+1, max(op.I2, op.I1+1))
+			if _, exists := changes[owner]; !exists {
+				changes[owner] = sarif.NewArtifactChange(sarif.NewSimpleArtifactLocation(uri))
+			}
+			changes[owner].WithReplacement(
+				sarif.NewReplacement(
+					sarif.NewRegion().
+						WithByteOffset(offsets[op.I1]).
+						WithByteLength(offsets[op.I2] - offsets[op.I1]),
+				).WithInsertedContent(sarif.NewArtifactContent().WithText(strings.Join(after[op.J1:op.J2], ""))),
+			)
+		}
+
+		for issue, artifactChange := range changes {
+			fixes[issue] = sarif.NewFix().
+				WithDescriptionText(fmt.Sprintf("Fix %s", issue.Rule.Name())).
+				WithArtifactChanges([]*sarif.ArtifactChange{artifactChange})
+		}
+	}
+
+	return fixes
+}
+
+// nearestIssue returns the issue that starts between the given lines, or the nearest one.
+// If several issues are equally near, the first one is returned.
+func nearestIssue(issues tflint.Issues, start int, end int) *tflint.Issue {
+	var ret *tflint.Issue
+	distance := 0
+	for _, issue := range issues {
+		line := issue.Range.Start.Line
+		d := 0
+		if line < start {
+			d = start - line
+		} else if line > end {
+			d = line - end
+		}
+		if ret == nil || d < distance {
+			ret, distance = issue, d
+		}
+	}
+	return ret
+}
+
+// sarifSplitLines splits the source into lines with line endings.
+// Unlike splitLines, the last line is kept as is so that byte offsets match the source.
+func sarifSplitLines(source []byte) []string {
+	lines := strings.SplitAfter(string(source), "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
 	}
+	return lines
+}
+
+func sarifFullDescription(rule tflint.Rule) string {
+	if rule.Link() == "" {
+		return rule.Name()
+	}
+	return fmt.Sprintf("%s. See %s for details.", rule.Name(), rule.Link())
+}
+
+func toSarifLevel(severity tflint.Severity) string {
+	switch severity {
+	case sdk.ERROR:
+		return "error"
+	case sdk.NOTICE:
+		return "note"
+	case sdk.WARNING:
+		return "warning"
+	default:
+		panic(fmt.Errorf("Unexpected lint type: %s", severity))
+	}
+}
+
+// sarifLineHash returns a hash of the rule name and the source line where the issue starts.
+// Leading and trailing whitespace is ignored so that re-indentation does not change the hash.
+// The path is not included so that the hash is the same regardless of where TFLint runs.
+func sarifLineHash(issue *tflint.Issue, sources map[string][]byte) string {
+	src := issueSource(issue, sources)
+	if src == nil || issue.Range.Start.Line < 1 {
+		return ""
+	}
+	lines := bytes.Split(src, []byte("\n"))
+	if issue.Range.Start.Line > len(lines) {
+		return ""
+	}
+	line := bytes.TrimSpace(lines[issue.Range.Start.Line-1])
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s", issue.Rule.Name(), line)
+	return hex.EncodeToString(h.Sum(nil))
 }
 
 func (f *Formatter) sarifAddErrors(errRun *sarif.Run, err error) {
@@ -95,8 +297,16 @@ func (f *Formatter) sarifAddErrors(errRun *sarif.Run, err error) {
 	var diags hcl.Diagnostics
 	if errors.As(err, &diags) {
 		for _, diag := range diags {
+			result := errRun.CreateResultForRule(diag.Summary).
+				WithLevel(fromHclSeverity(diag.Severity)).
+				WithMessage(sarif.NewTextMessage(diag.Detail))
+			// Diagnostics without a location, e.g. errors of plugin configs, are reported without it
+			if diag.Subject == nil {
+				continue
+			}
+
 			location := sarif.NewPhysicalLocation().
-				WithArtifactLocation(sarif.NewSimpleArtifactLocation(filepath.ToSlash(diag.Subject.Filename))).
+				WithArtifactLocation(sarif.NewSimpleArtifactLocation(sarifArtifactURI(diag.Subject.Filename))).
 				WithRegion(
 					sarif.NewRegion().
 						WithByteOffset(diag.Subject.Start.Byte).
@@ -107,10 +317,7 @@ func (f *Formatter) sarifAddErrors(errRun *sarif.Run, err error) {
 						WithEndColumn(diag.Subject.End.Column),
 				)
 
-			errRun.CreateResultForRule(diag.Summary).
-				WithLevel(fromHclSeverity(diag.Severity)).
-				WithMessage(sarif.NewTextMessage(diag.Detail)).
-				AddLocation(sarif.NewLocationWithPhysicalLocation(location))
+			result.AddLocation(sarif.NewLocationWithPhysicalLocation(location))
 		}
 		return
 	}
@@ -119,3 +326,16 @@ func (f *Formatter) sarifAddErrors(errRun *sarif.Run, err error) {
 		WithLevel("error").
 		WithMessage(sarif.NewTextMessage(err.Error()))
 }
+
+// sarifArtifactURI returns the URI of the file. Absolute paths are converted to file URIs.
+func sarifArtifactURI(filename string) string {
+	path := filepath.ToSlash(filename)
+	if !filepath.IsAbs(filename) {
+		return path
+	}
+	// Windows paths like C:/foo need a leading slash
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
@@ -0,0 +1,139 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// https://docs.sonarsource.com/sonarqube-server/latest/analyzing-source-code/importing-external-issues/generic-issue-import-format/
+
+type sonarQubeIssue struct {
+	EngineID        string            `json:"engineId"`
+	RuleID          string            `json:"ruleId"`
+	PrimaryLocation sonarQubeLocation `json:"primaryLocation"`
+	Severity        string            `json:"severity"`
+	Type            string            `json:"type"`
+}
+
+type sonarQubeLocation struct {
+	Message   string             `json:"message"`
+	FilePath  string             `json:"filePath"`
+	TextRange sonarQubeTextRange `json:"textRange"`
+}
+
+// sonarQubeTextRange is a range with 1-based lines and 0-based columns.
+// Optional fields are omitted if unknown.
+type sonarQubeTextRange struct {
+	StartLine   int  `json:"startLine"`
+	EndLine     *int `json:"endLine,omitempty"`
+	StartColumn *int `json:"startColumn,omitempty"`
+	EndColumn   *int `json:"endColumn,omitempty"`
+}
+
+type sonarQubeOutput struct {
+	Issues []sonarQubeIssue `json:"issues"`
+}
+
+// sonarQubeSeverityValues are valid severities in SonarQube
+var sonarQubeSeverityValues = []string{"BLOCKER", "CRITICAL", "MAJOR", "MINOR", "INFO"}
+
+// defaultSonarQubeSeverities are used for severities not set in Formatter.SonarQubeSeverities
+var defaultSonarQubeSeverities = map[tflint.Severity]string{
+	sdk.ERROR:   "CRITICAL",
+	sdk.WARNING: "MAJOR",
+	sdk.NOTICE:  "MINOR",
+}
+
+// ParseSonarQubeSeverities parses mappings in the form of "severity=SONARQUBE_SEVERITY" (e.g. "notice=INFO").
+func ParseSonarQubeSeverities(mappings []string) (map[tflint.Severity]string, error) {
+	ret := map[tflint.Severity]string{}
+
+	for _, mapping := range mappings {
+		from, to, found := strings.Cut(mapping, "=")
+		if !found {
+			return ret, fmt.Errorf("`%s` is invalid. The SonarQube severity must be in the form of `severity=SONARQUBE_SEVERITY`", mapping)
+		}
+		severity, err := tflint.NewSeverity(from)
+		if err != nil {
+			return ret, err
+		}
+		to = strings.ToUpper(to)
+		if !slices.Contains(sonarQubeSeverityValues, to) {
+			return ret, fmt.Errorf("%s is not a recognized SonarQube severity. Valid values are %s", to, strings.Join(sonarQubeSeverityValues, ", "))
+		}
+		ret[severity] = to
+	}
+
+	return ret, nil
+}
+
+// sonarQubePrint outputs issues in the generic issue import format.
+// Errors are output to stderr because the format cannot represent them.
+func (f *Formatter) sonarQubePrint(issues tflint.Issues, appErr error, sources map[string][]byte) {
+	ret := &sonarQubeOutput{Issues: make([]sonarQubeIssue, len(issues))}
+
+	for idx, issue := range issues.Sort() {
+		ret.Issues[idx] = sonarQubeIssue{
+			EngineID: "tflint",
+			RuleID:   issue.Rule.Name(),
+			PrimaryLocation: sonarQubeLocation{
+				Message:   issue.Message,
+				FilePath:  filepath.ToSlash(issue.Range.Filename),
+				TextRange: toSonarQubeTextRange(issue),
+			},
+			Severity: f.toSonarQubeSeverity(issue.Rule.Severity()),
+			Type:     "CODE_SMELL",
+		}
+	}
+
+	out, err := json.Marshal(ret)
+	if err != nil {
+		fmt.Fprint(f.Stderr, err)
+	}
+	fmt.Fprint(f.Stdout, string(out))
+
+	if appErr != nil {
+		f.prettyPrintErrors(appErr, sources, false)
+	}
+}
+
+func (f *Formatter) toSonarQubeSeverity(severity tflint.Severity) string {
+	if s, exists := f.SonarQubeSeverities[severity]; exists {
+		return s
+	}
+	if s, exists := defaultSonarQubeSeverities[severity]; exists {
+		return s
+	}
+	panic(fmt.Errorf("Unexpected lint type: %s", severity))
+}
+
+// toSonarQubeTextRange converts the issue range to a text range.
+// HCL columns are 1-based, while SonarQube columns are 0-based offsets.
+// SonarQube rejects ranges where the end is not after the start, so columns are omitted in that case.
+func toSonarQubeTextRange(issue *tflint.Issue) sonarQubeTextRange {
+	r := issue.Range
+	ret := sonarQubeTextRange{StartLine: max(r.Start.Line, 1)}
+
+	if r.End.Line < r.Start.Line || r.End.Line == 0 {
+		return ret
+	}
+	endLine := r.End.Line
+	ret.EndLine = &endLine
+
+	if r.Start.Column < 1 || r.End.Column < 1 {
+		return ret
+	}
+	if r.Start.Line == r.End.Line && r.End.Column <= r.Start.Column {
+		return ret
+	}
+	startColumn, endColumn := r.Start.Column-1, r.End.Column-1
+	ret.StartColumn, ret.EndColumn = &startColumn, &endColumn
+
+	return ret
+}
@@ -0,0 +1,200 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func Test_sonarQubePrint(t *testing.T) {
+	cases := []struct {
+		Name       string
+		Issues     tflint.Issues
+		Error      error
+		Severities map[tflint.Severity]string
+		Stdout     string
+		Stderr     string
+	}{
+		{
+			Name:   "no issues",
+			Issues: tflint.Issues{},
+			Stdout: `{"issues":[]}`,
+		},
+		{
+			Name: "issues",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: filepath.Join("subdir", "test.tf"),
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+				{
+					Rule:    &testRuleWithoutLink{},
+					Message: "multi-line",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 10},
+						End:      hcl.Pos{Line: 4, Column: 2, Byte: 30},
+					},
+				},
+			},
+			Stdout: `{"issues":[{"engineId":"tflint","ruleId":"test_rule","primaryLocation":{"message":"test","filePath":"subdir/test.tf","textRange":{"startLine":1,"endLine":1,"startColumn":0,"endColumn":3}},"severity":"CRITICAL","type":"CODE_SMELL"},{"engineId":"tflint","ruleId":"test_rule_without_link","primaryLocation":{"message":"multi-line","filePath":"test.tf","textRange":{"startLine":2,"endLine":4,"startColumn":2,"endColumn":1}},"severity":"MAJOR","type":"CODE_SMELL"}]}`,
+		},
+		{
+			Name: "severity mappings",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+				{
+					Rule:    &testRuleWithoutLink{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1, Byte: 10},
+						End:      hcl.Pos{Line: 2, Column: 4, Byte: 13},
+					},
+				},
+			},
+			Severities: map[tflint.Severity]string{sdk.ERROR: "BLOCKER"},
+			Stdout:     `{"issues":[{"engineId":"tflint","ruleId":"test_rule","primaryLocation":{"message":"test","filePath":"test.tf","textRange":{"startLine":1,"endLine":1,"startColumn":0,"endColumn":3}},"severity":"BLOCKER","type":"CODE_SMELL"},{"engineId":"tflint","ruleId":"test_rule_without_link","primaryLocation":{"message":"test","filePath":"test.tf","textRange":{"startLine":2,"endLine":2,"startColumn":0,"endColumn":3}},"severity":"MAJOR","type":"CODE_SMELL"}]}`,
+		},
+		{
+			Name:   "error",
+			Issues: tflint.Issues{},
+			Error:  errors.New("Failed to work; I don't feel like working"),
+			Stdout: `{"issues":[]}`,
+			Stderr: "Failed to work; I don't feel like working\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: stderr, SonarQubeSeverities: tc.Severities}
+
+			formatter.sonarQubePrint(tc.Issues, tc.Error, map[string][]byte{})
+
+			if diff := cmp.Diff(tc.Stdout, stdout.String()); diff != "" {
+				t.Errorf("stdout: %s", diff)
+			}
+			if diff := cmp.Diff(tc.Stderr, stderr.String()); diff != "" {
+				t.Errorf("stderr: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_toSonarQubeTextRange(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+
+	cases := []struct {
+		Name  string
+		Range hcl.Range
+		Want  sonarQubeTextRange
+	}{
+		{
+			Name: "single line",
+			Range: hcl.Range{
+				Start: hcl.Pos{Line: 1, Column: 5},
+				End:   hcl.Pos{Line: 1, Column: 10},
+			},
+			Want: sonarQubeTextRange{StartLine: 1, EndLine: intPtr(1), StartColumn: intPtr(4), EndColumn: intPtr(9)},
+		},
+		{
+			Name: "multiple lines",
+			Range: hcl.Range{
+				Start: hcl.Pos{Line: 1, Column: 5},
+				End:   hcl.Pos{Line: 3, Column: 1},
+			},
+			Want: sonarQubeTextRange{StartLine: 1, EndLine: intPtr(3), StartColumn: intPtr(4), EndColumn: intPtr(0)},
+		},
+		{
+			Name: "empty range",
+			Range: hcl.Range{
+				Start: hcl.Pos{Line: 1, Column: 5},
+				End:   hcl.Pos{Line: 1, Column: 5},
+			},
+			Want: sonarQubeTextRange{StartLine: 1, EndLine: intPtr(1)},
+		},
+		{
+			Name:  "no position",
+			Range: hcl.Range{},
+			Want:  sonarQubeTextRange{StartLine: 1},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			got := toSonarQubeTextRange(&tflint.Issue{Range: tc.Range})
+			if diff := cmp.Diff(tc.Want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestParseSonarQubeSeverities(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Mappings []string
+		Want     map[tflint.Severity]string
+		Err      string
+	}{
+		{
+			Name:     "valid",
+			Mappings: []string{"error=BLOCKER", "notice=info"},
+			Want:     map[tflint.Severity]string{sdk.ERROR: "BLOCKER", sdk.NOTICE: "INFO"},
+		},
+		{
+			Name:     "invalid form",
+			Mappings: []string{"error"},
+			Err:      "`error` is invalid. The SonarQube severity must be in the form of `severity=SONARQUBE_SEVERITY`",
+		},
+		{
+			Name:     "unknown severity",
+			Mappings: []string{"fatal=BLOCKER"},
+			Err:      "fatal is not a recognized severity",
+		},
+		{
+			Name:     "unknown SonarQube severity",
+			Mappings: []string{"error=FATAL"},
+			Err:      "FATAL is not a recognized SonarQube severity. Valid values are BLOCKER, CRITICAL, MAJOR, MINOR, INFO",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			got, err := ParseSonarQubeSeverities(tc.Mappings)
+			if tc.Err != "" {
+				if err == nil || err.Error() != tc.Err {
+					t.Fatalf("expected error %q, but got %v", tc.Err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.Want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
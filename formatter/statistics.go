@@ -0,0 +1,49 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Statistics are counts of the inspection, so that the coverage can be monitored
+// even if no issues are found.
+type Statistics struct {
+	// FilesInspected is the number of configuration files loaded for inspection.
+	FilesInspected int
+	// ModulesInspected is the number of working directories inspected successfully.
+	// In recursive mode, directories that failed to be inspected are counted in ModulesErrored instead.
+	ModulesInspected int
+	ModulesErrored   int
+	// ModulesSkipped is the number of working directories skipped in recursive --changed-only mode
+	// because no files are changed in them or their local modules.
+	ModulesSkipped int
+	// DirsExcluded is the number of directories pruned from the walk by --exclude-dir and exclude_dirs
+	// in recursive mode. Their descendants are not counted.
+	DirsExcluded int
+	// RulesEvaluated is the number of distinct rules not disabled in the config.
+	// Plugins do not expose rules they disable by default, e.g. by presets, so they are also counted.
+	RulesEvaluated int
+	Duration       time.Duration
+}
+
+// prettyStatistics returns the one-line version of the statistics, e.g.
+// "Inspected 3 file(s) in 2 module(s) with 20 rule(s) in 1.2s"
+func (f *Formatter) prettyStatistics() string {
+	s := f.Statistics
+	modules := fmt.Sprintf("%d module(s)", s.ModulesInspected)
+	notes := []string{}
+	if s.ModulesErrored > 0 {
+		notes = append(notes, fmt.Sprintf("%d failed", s.ModulesErrored))
+	}
+	if s.ModulesSkipped > 0 {
+		notes = append(notes, fmt.Sprintf("%d skipped", s.ModulesSkipped))
+	}
+	if s.DirsExcluded > 0 {
+		notes = append(notes, fmt.Sprintf("%d excluded", s.DirsExcluded))
+	}
+	if len(notes) > 0 {
+		modules = fmt.Sprintf("%s (%s)", modules, strings.Join(notes, ", "))
+	}
+	return fmt.Sprintf("Inspected %d file(s) in %s with %d rule(s) in %s", s.FilesInspected, modules, s.RulesEvaluated, s.Duration.Round(time.Millisecond))
+}
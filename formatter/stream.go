@@ -0,0 +1,111 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// StreamEncoder writes typed values as newline-delimited JSON in the stream format.
+// Each value is written as a single line like {"type":"issue","data":{...}}.
+// It is safe to call from multiple goroutines.
+type StreamEncoder struct {
+	w  io.Writer
+	mu sync.Mutex
+}
+
+// streamLine is a line in the stream format
+type streamLine struct {
+	Type string `json:"type"`
+	Data any    `json:"data"`
+}
+
+// NewStreamEncoder returns a new encoder that writes to w.
+func NewStreamEncoder(w io.Writer) *StreamEncoder {
+	return &StreamEncoder{w: w}
+}
+
+// Encode writes the data as a line of the given type.
+// The line is written at once, so that lines written from multiple goroutines are not interleaved.
+func (e *StreamEncoder) Encode(typ string, data any) error {
+	b, err := json.Marshal(streamLine{Type: typ, Data: data})
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, err = e.w.Write(b)
+	return err
+}
+
+// streamPrint outputs issues and errors as lines, followed by the summary that closes the stream.
+func (f *Formatter) streamPrint(issues tflint.Issues, appErr error, sources map[string][]byte) {
+	f.streamPrintResults(issues, appErr, sources, f.hiddenIssues)
+	f.streamPrintSummary()
+}
+
+// streamPrintResults outputs issues and errors as lines immediately, and adds them to the summary.
+// It is safe to call from multiple goroutines.
+func (f *Formatter) streamPrintResults(issues tflint.Issues, appErr error, sources map[string][]byte, hidden int) {
+	output := f.jsonOutput(issues, appErr, sources)
+
+	state := f.shared()
+	state.streamMu.Lock()
+	summary := state.streamSummaryLocked()
+	summary.IssueCount += output.Summary.IssueCount
+	summary.ErrorCount += output.Summary.ErrorCount
+	for severity, count := range output.Summary.BySeverity {
+		summary.BySeverity[severity] += count
+	}
+	for rule, count := range output.Summary.ByRule {
+		summary.ByRule[rule] += count
+	}
+	summary.HiddenCount += hidden
+	state.streamMu.Unlock()
+
+	enc := f.streamEncoder()
+	for _, issue := range output.Issues {
+		if err := enc.Encode("issue", issue); err != nil {
+			fmt.Fprint(f.Stderr, err)
+		}
+	}
+	for _, err := range output.Errors {
+		if err := enc.Encode("error", err); err != nil {
+			fmt.Fprint(f.Stderr, err)
+		}
+	}
+}
+
+// streamPrintSummary outputs the summary of all results printed in the stream format.
+func (f *Formatter) streamPrintSummary() {
+	state := f.shared()
+	state.streamMu.Lock()
+	summary := *state.streamSummaryLocked()
+	state.streamMu.Unlock()
+
+	if err := f.streamEncoder().Encode("summary", summary); err != nil {
+		fmt.Fprint(f.Stderr, err)
+	}
+}
+
+func (f *Formatter) streamEncoder() *StreamEncoder {
+	state := f.shared()
+	state.streamEncoderOnce.Do(func() {
+		state.streamEnc = NewStreamEncoder(f.Stdout)
+	})
+	return state.streamEnc
+}
+
+// streamSummaryLocked returns the summary of results printed so far. s.streamMu must be held.
+func (s *formatterState) streamSummaryLocked() *JSONSummary {
+	if s.streamSummary == nil {
+		summary := jsonSummary(&JSONOutput{})
+		s.streamSummary = &summary
+	}
+	return s.streamSummary
+}
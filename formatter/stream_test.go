@@ -0,0 +1,152 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func Test_streamPrint(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Issues tflint.Issues
+		Error  error
+		Stdout string
+	}{
+		{
+			Name:   "no issues",
+			Issues: tflint.Issues{},
+			Stdout: `{"type":"summary","data":{"issue_count":0,"error_count":0,"by_severity":{"error":0,"info":0,"warning":0},"by_rule":{}}}
+`,
+		},
+		{
+			Name: "issues",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+			},
+			Stdout: `{"type":"issue","data":{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"test.tf","start":{"line":1,"column":1,"byte":0},"end":{"line":1,"column":4,"byte":3}},"callers":[],"fixable":false,"fingerprint":"a193a9a1f316b3b23c962f88d7a154a4800ed3c28f2bb3031b0ea60513304739"}}
+{"type":"summary","data":{"issue_count":1,"error_count":0,"by_severity":{"error":1,"info":0,"warning":0},"by_rule":{"test_rule":1}}}
+`,
+		},
+		{
+			Name:   "error",
+			Issues: tflint.Issues{},
+			Error:  errors.New("an error occurred"),
+			Stdout: `{"type":"error","data":{"message":"an error occurred","severity":"error"}}
+{"type":"summary","data":{"issue_count":0,"error_count":1,"by_severity":{"error":0,"info":0,"warning":0},"by_rule":{}}}
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: stderr, Format: "stream"}
+
+			formatter.Print(tc.Issues, tc.Error, map[string][]byte{})
+
+			if diff := cmp.Diff(tc.Stdout, stdout.String()); diff != "" {
+				t.Error(diff)
+			}
+			if stderr.String() != "" {
+				t.Errorf("unexpected stderr: %s", stderr.String())
+			}
+		})
+	}
+}
+
+func TestPrintStream_stream(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	formatter := &Formatter{Stdout: stdout, Stderr: &bytes.Buffer{}, Format: "stream", MinimumReportSeverity: "error"}
+
+	issue := func(rule tflint.Rule, filename string) *tflint.Issue {
+		return &tflint.Issue{
+			Rule:    rule,
+			Message: "test",
+			Range: hcl.Range{
+				Filename: filename,
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+			},
+		}
+	}
+
+	if !formatter.IsStreaming() {
+		t.Fatal("stream should be a streaming format")
+	}
+
+	// Results are printed before the scan completes
+	formatter.PrintStream(tflint.Issues{issue(&testRule{}, "subdir1/test.tf"), issue(&testRuleWithoutLink{}, "subdir1/test.tf")})
+	want := `{"type":"issue","data":{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"subdir1/test.tf","start":{"line":1,"column":1,"byte":0},"end":{"line":1,"column":4,"byte":3}},"callers":[],"fixable":false,"fingerprint":"2acbf3f334648c6b743ad337f9af602576ca0a33a8816288c917d93cd8369fc2"}}
+`
+	if diff := cmp.Diff(want, stdout.String()); diff != "" {
+		t.Fatalf("results of the first worker: %s", diff)
+	}
+
+	formatter.PrintErrorParallel(errors.New("Failed to run in subdir2"), map[string][]byte{})
+	formatter.PrintStream(tflint.Issues{issue(&testRule{}, "subdir3/test.tf")})
+	// Streamed results are not printed again, and the summary closes the stream
+	err := formatter.PrintParallel(tflint.Issues{issue(&testRule{}, "subdir1/test.tf"), issue(&testRule{}, "subdir3/test.tf")}, map[string][]byte{})
+	if err == nil {
+		t.Error("expected the error in parallel, but got nil")
+	}
+
+	want += `{"type":"error","data":{"message":"Failed to run in subdir2","severity":"error"}}
+{"type":"issue","data":{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"subdir3/test.tf","start":{"line":1,"column":1,"byte":0},"end":{"line":1,"column":4,"byte":3}},"callers":[],"fixable":false,"fingerprint":"f58521aef91659f0f40ab0ac1d1a59cab6a0711d9b206edc65ff322300df4bc9"}}
+{"type":"summary","data":{"issue_count":2,"error_count":1,"by_severity":{"error":2,"info":0,"warning":0},"by_rule":{"test_rule":2},"hidden_count":1}}
+`
+	if diff := cmp.Diff(want, stdout.String()); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestStreamEncoder(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	enc := NewStreamEncoder(stdout)
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := enc.Encode("issue", map[string]int{"line": i}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+	if len(lines) != 10 {
+		t.Fatalf("expected 10 lines, but got %d: %s", len(lines), stdout.String())
+	}
+	// Lines written from multiple goroutines are not interleaved
+	for _, line := range lines {
+		var got struct {
+			Type string         `json:"type"`
+			Data map[string]int `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("Failed to parse line `%s`; %s", line, err)
+		}
+		if got.Type != "issue" {
+			t.Errorf("expected issue, but got %s", got.Type)
+		}
+	}
+}
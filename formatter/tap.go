@@ -0,0 +1,159 @@
+package formatter
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// https://testanything.org/tap-version-13-specification.html
+
+// tapPoint is a test point. It fails if any issues or diagnostics are found.
+type tapPoint struct {
+	name   string
+	issues tflint.Issues
+	diags  hcl.Diagnostics
+}
+
+func (f *Formatter) tapPrint(issues tflint.Issues, appErr error, sources map[string][]byte) {
+	points := f.tapPoints(issues.Sort(), tapDiagnostics(appErr), sources)
+
+	fmt.Fprintf(f.Stdout, "1..%d\n", len(points))
+	for idx, point := range points {
+		if len(point.issues) == 0 && len(point.diags) == 0 {
+			fmt.Fprintf(f.Stdout, "ok %d - %s\n", idx+1, point.name)
+			continue
+		}
+
+		fmt.Fprintf(f.Stdout, "not ok %d - %s\n", idx+1, point.name)
+		fmt.Fprint(f.Stdout, "  ---\n")
+		if len(point.issues) > 0 {
+			fmt.Fprint(f.Stdout, "  issues:\n")
+		}
+		for _, issue := range point.issues {
+			fmt.Fprintf(f.Stdout, "    - rule: %s\n", strconv.Quote(issue.Rule.Name()))
+			fmt.Fprintf(f.Stdout, "      severity: %s\n", toSeverity(issue.Rule.Severity()))
+			fmt.Fprintf(f.Stdout, "      message: %s\n", strconv.Quote(issue.Message))
+			fmt.Fprintf(f.Stdout, "      file: %s\n", strconv.Quote(filepath.ToSlash(issue.Range.Filename)))
+			fmt.Fprintf(f.Stdout, "      line: %d\n", issue.Range.Start.Line)
+			fmt.Fprintf(f.Stdout, "      column: %d\n", issue.Range.Start.Column)
+			if issue.Rule.Link() != "" {
+				fmt.Fprintf(f.Stdout, "      link: %s\n", strconv.Quote(issue.Rule.Link()))
+			}
+		}
+		if len(point.diags) > 0 {
+			fmt.Fprint(f.Stdout, "  errors:\n")
+		}
+		for _, diag := range point.diags {
+			fmt.Fprintf(f.Stdout, "    - summary: %s\n", strconv.Quote(diag.Summary))
+			fmt.Fprintf(f.Stdout, "      detail: %s\n", strconv.Quote(diag.Detail))
+			fmt.Fprintf(f.Stdout, "      severity: %s\n", fromHclSeverity(diag.Severity))
+			fmt.Fprintf(f.Stdout, "      file: %s\n", strconv.Quote(filepath.ToSlash(diag.Subject.Filename)))
+			fmt.Fprintf(f.Stdout, "      line: %d\n", diag.Subject.Start.Line)
+			fmt.Fprintf(f.Stdout, "      column: %d\n", diag.Subject.Start.Column)
+		}
+		fmt.Fprint(f.Stdout, "  ...\n")
+	}
+
+	if appErr != nil {
+		fmt.Fprintf(f.Stdout, "Bail out! %s\n", tapEscapeLine(appErr.Error()))
+	}
+}
+
+// tapPoints returns test points for each inspected working directory in recursive mode,
+// or for each loaded file otherwise. Issues and diagnostics are grouped into the test point they belong to.
+func (f *Formatter) tapPoints(issues tflint.Issues, diags hcl.Diagnostics, sources map[string][]byte) []*tapPoint {
+	points := []*tapPoint{}
+	index := map[string]*tapPoint{}
+	add := func(name string) *tapPoint {
+		if point, exists := index[name]; exists {
+			return point
+		}
+		point := &tapPoint{name: name, issues: tflint.Issues{}}
+		index[name] = point
+		points = append(points, point)
+		return point
+	}
+
+	if len(f.WorkingDirs) > 0 {
+		for _, dir := range f.WorkingDirs {
+			add(filepath.ToSlash(filepath.Clean(dir)))
+		}
+		// Issues in files outside the working directories (e.g. called modules)
+		// are reported in a test point for the directory of the file
+		for _, issue := range issues {
+			point := add(filepath.ToSlash(filepath.Dir(issue.Range.Filename)))
+			point.issues = append(point.issues, issue)
+		}
+		for _, diag := range diags {
+			point := add(filepath.ToSlash(filepath.Dir(diag.Subject.Filename)))
+			point.diags = append(point.diags, diag)
+		}
+		return points
+	}
+
+	filenames := []string{}
+	for filename := range sources {
+		filenames = append(filenames, filename)
+	}
+	slices.Sort(filenames)
+	for _, filename := range filenames {
+		add(filepath.ToSlash(filename))
+	}
+	for _, issue := range issues {
+		point := add(filepath.ToSlash(issue.Range.Filename))
+		point.issues = append(point.issues, issue)
+	}
+	for _, diag := range diags {
+		point := add(filepath.ToSlash(diag.Subject.Filename))
+		point.diags = append(point.diags, diag)
+	}
+	return points
+}
+
+// tapDiagnostics returns diagnostics with source locations contained in the error.
+func tapDiagnostics(err error) hcl.Diagnostics {
+	if err == nil {
+		return hcl.Diagnostics{}
+	}
+
+	// errors.Join
+	if errs, ok := err.(interface{ Unwrap() []error }); ok {
+		ret := hcl.Diagnostics{}
+		for _, err := range errs.Unwrap() {
+			ret = append(ret, tapDiagnostics(err)...)
+		}
+		return ret
+	}
+
+	// hcl.Diagnostics
+	var diags hcl.Diagnostics
+	if errors.As(err, &diags) {
+		ret := hcl.Diagnostics{}
+		for _, diag := range diags {
+			if diag.Subject != nil {
+				ret = append(ret, diag)
+			}
+		}
+		return ret
+	}
+
+	return hcl.Diagnostics{}
+}
+
+// tapEscapeLine joins lines of the message since each TAP directive must fit on one line.
+func tapEscapeLine(s string) string {
+	lines := []string{}
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "; ")
+}
@@ -0,0 +1,211 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func Test_tapPrint(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Issues      tflint.Issues
+		Error       error
+		Sources     map[string][]byte
+		WorkingDirs []string
+		Stdout      string
+	}{
+		{
+			Name:   "no issues",
+			Issues: tflint.Issues{},
+			Sources: map[string][]byte{
+				"main.tf":      {},
+				"variables.tf": {},
+			},
+			Stdout: `1..2
+ok 1 - main.tf
+ok 2 - variables.tf
+`,
+		},
+		{
+			Name:   "no files",
+			Issues: tflint.Issues{},
+			Stdout: "1..0\n",
+		},
+		{
+			Name: "issues",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRuleWithoutLink{},
+					Message: "second",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 10},
+						End:      hcl.Pos{Line: 2, Column: 6, Byte: 13},
+					},
+				},
+				{
+					Rule:    &testRule{},
+					Message: `first "quoted"`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+			},
+			Sources: map[string][]byte{
+				"main.tf":      {},
+				"variables.tf": {},
+			},
+			Stdout: `1..2
+not ok 1 - main.tf
+  ---
+  issues:
+    - rule: "test_rule"
+      severity: error
+      message: "first \"quoted\""
+      file: "main.tf"
+      line: 1
+      column: 1
+      link: "https://github.com"
+    - rule: "test_rule_without_link"
+      severity: warning
+      message: "second"
+      file: "main.tf"
+      line: 2
+      column: 3
+  ...
+ok 2 - variables.tf
+`,
+		},
+		{
+			Name: "multiple dirs",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "subdir1/main.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "modules/instance/main.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+			},
+			WorkingDirs: []string{".", "subdir1", "subdir2"},
+			Stdout: `1..4
+ok 1 - .
+not ok 2 - subdir1
+  ---
+  issues:
+    - rule: "test_rule"
+      severity: error
+      message: "test"
+      file: "subdir1/main.tf"
+      line: 1
+      column: 1
+      link: "https://github.com"
+  ...
+ok 3 - subdir2
+not ok 4 - modules/instance
+  ---
+  issues:
+    - rule: "test_rule"
+      severity: error
+      message: "test"
+      file: "modules/instance/main.tf"
+      line: 1
+      column: 1
+      link: "https://github.com"
+  ...
+`,
+		},
+		{
+			Name:   "error",
+			Issues: tflint.Issues{},
+			Error:  errors.New("Failed to load configurations\n\nmain.tf:1,9-10: Unclosed configuration block"),
+			Stdout: `1..0
+Bail out! Failed to load configurations; main.tf:1,9-10: Unclosed configuration block
+`,
+		},
+		{
+			Name:   "diagnostics",
+			Issues: tflint.Issues{},
+			Error: fmt.Errorf(
+				"Failed to load configurations; %w",
+				hcl.Diagnostics{
+					&hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "summary",
+						Detail:   "detail",
+						Subject: &hcl.Range{
+							Filename: "main.tf",
+							Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
+							End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
+						},
+					},
+				},
+			),
+			Sources: map[string][]byte{
+				"main.tf":      {},
+				"variables.tf": {},
+			},
+			Stdout: `1..2
+not ok 1 - main.tf
+  ---
+  errors:
+    - summary: "summary"
+      detail: "detail"
+      severity: error
+      file: "main.tf"
+      line: 1
+      column: 9
+  ...
+ok 2 - variables.tf
+Bail out! Failed to load configurations; main.tf:1,9-10: summary; detail
+`,
+		},
+		{
+			Name:   "errors in multiple dirs",
+			Issues: tflint.Issues{},
+			Error: errors.Join(
+				errors.New("Failed to run in subdir1; exit status 1"),
+				errors.New("Failed to run in subdir2; exit status 1"),
+			),
+			WorkingDirs: []string{"subdir1", "subdir2"},
+			Stdout: `1..2
+ok 1 - subdir1
+ok 2 - subdir2
+Bail out! Failed to run in subdir1; exit status 1; Failed to run in subdir2; exit status 1
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: stderr, WorkingDirs: tc.WorkingDirs}
+
+			formatter.tapPrint(tc.Issues, tc.Error, tc.Sources)
+
+			if diff := cmp.Diff(tc.Stdout, stdout.String()); diff != "" {
+				t.Errorf("stdout: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package formatter
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// https://www.jetbrains.com/help/teamcity/service-messages.html#Reporting+Inspections
+
+var teamcityEscaper = strings.NewReplacer(
+	"|", "||",
+	"'", "|'",
+	"[", "|[",
+	"]", "|]",
+	"\r", "|r",
+	"\n", "|n",
+)
+
+func (f *Formatter) teamcityPrint(issues tflint.Issues, appErr error) {
+	// Inspection types must be registered before inspections of the type are reported
+	registered := map[string]bool{}
+	for _, issue := range issues {
+		if registered[issue.Rule.Name()] {
+			continue
+		}
+		registered[issue.Rule.Name()] = true
+
+		description := issue.Rule.Link()
+		if description == "" {
+			description = issue.Rule.Name()
+		}
+		fmt.Fprintf(
+			f.Stdout,
+			"##teamcity[inspectionType id='%s' name='%s' description='%s' category='%s']\n",
+			teamcityEscaper.Replace(issue.Rule.Name()),
+			teamcityEscaper.Replace(issue.Rule.Name()),
+			teamcityEscaper.Replace(description),
+			toSeverity(issue.Rule.Severity()),
+		)
+	}
+
+	for _, issue := range issues {
+		fmt.Fprintf(
+			f.Stdout,
+			"##teamcity[inspection typeId='%s' message='%s' file='%s' line='%d' SEVERITY='%s']\n",
+			teamcityEscaper.Replace(issue.Rule.Name()),
+			teamcityEscaper.Replace(issue.Message),
+			teamcityEscaper.Replace(filepath.ToSlash(issue.Range.Filename)),
+			issue.Range.Start.Line,
+			toTeamCitySeverity(issue.Rule.Severity()),
+		)
+	}
+
+	if appErr != nil {
+		f.teamcityPrintErrors(appErr)
+	}
+}
+
+func (f *Formatter) teamcityPrintErrors(err error) {
+	// errors.Join
+	if errs, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, err := range errs.Unwrap() {
+			f.teamcityPrintErrors(err)
+		}
+		return
+	}
+
+	// hcl.Diagnostics
+	var diags hcl.Diagnostics
+	if errors.As(err, &diags) {
+		for _, diag := range diags {
+			status := "ERROR"
+			if diag.Severity == hcl.DiagWarning {
+				status = "WARNING"
+			}
+			message := diag.Summary
+			if diag.Detail != "" {
+				message = fmt.Sprintf("%s; %s", diag.Summary, diag.Detail)
+			}
+			if diag.Subject != nil {
+				message = fmt.Sprintf("%s: %s", diag.Subject.String(), message)
+			}
+
+			fmt.Fprintf(f.Stdout, "##teamcity[message text='%s' status='%s']\n", teamcityEscaper.Replace(message), status)
+		}
+		return
+	}
+
+	fmt.Fprintf(f.Stdout, "##teamcity[message text='%s' status='ERROR']\n", teamcityEscaper.Replace(err.Error()))
+}
+
+// toTeamCitySeverity maps severities to inspection severities.
+func toTeamCitySeverity(severity tflint.Severity) string {
+	switch severity {
+	case sdk.ERROR:
+		return "ERROR"
+	case sdk.WARNING:
+		return "WARNING"
+	case sdk.NOTICE:
+		return "INFO"
+	default:
+		panic(fmt.Errorf("Unexpected lint type: %s", severity))
+	}
+}
@@ -0,0 +1,89 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func Test_teamcityPrint(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Issues tflint.Issues
+		Error  error
+		Stdout string
+	}{
+		{
+			Name:   "no issues",
+			Issues: tflint.Issues{},
+			Stdout: "",
+		},
+		{
+			Name: "issues",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: filepath.Join("subdir", "test.tf"),
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+				{
+					Rule:    &testRuleWithoutLink{},
+					Message: "'quoted' [list] a|b\nnext",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 10},
+						End:      hcl.Pos{Line: 2, Column: 6, Byte: 13},
+					},
+				},
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 3, Column: 1, Byte: 20},
+						End:      hcl.Pos{Line: 3, Column: 4, Byte: 23},
+					},
+				},
+			},
+			Stdout: `##teamcity[inspectionType id='test_rule' name='test_rule' description='https://github.com' category='error']
+##teamcity[inspectionType id='test_rule_without_link' name='test_rule_without_link' description='test_rule_without_link' category='warning']
+##teamcity[inspection typeId='test_rule' message='test' file='subdir/test.tf' line='1' SEVERITY='ERROR']
+##teamcity[inspection typeId='test_rule_without_link' message='|'quoted|' |[list|] a||b|nnext' file='test.tf' line='2' SEVERITY='WARNING']
+##teamcity[inspection typeId='test_rule' message='test' file='test.tf' line='3' SEVERITY='ERROR']
+`,
+		},
+		{
+			Name: "errors",
+			Error: errors.Join(
+				errors.New("an error occurred"),
+				hclDiags(`resource "foo" "bar" {`),
+			),
+			Stdout: `##teamcity[message text='an error occurred' status='ERROR']
+##teamcity[message text='main.tf:1,22-23: Unclosed configuration block; There is no closing brace for this block before the end of the file. This may be caused by incorrect brace nesting elsewhere in this file.' status='ERROR']
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: stderr}
+
+			formatter.teamcityPrint(tc.Issues, tc.Error)
+
+			if diff := cmp.Diff(tc.Stdout, stdout.String()); diff != "" {
+				t.Errorf("stdout: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"text/template"
+
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// templateFuncs are helper functions available in templates
+var templateFuncs = template.FuncMap{
+	// severityColor colorizes the severity (e.g. "error") unless colorized output is disabled.
+	// It is replaced when executing the template so that the Formatter can decide whether to colorize.
+	"severityColor": templateSeverityColor(false),
+	// rel returns the path relative to the root with forward slashes, e.g. {{.Range.Filename | rel "/workspace"}}
+	"rel": func(root string, path string) (string, error) {
+		abs, err := filepath.Abs(root)
+		if err != nil {
+			return "", err
+		}
+		return rootRelativePath(path, abs), nil
+	},
+	// json returns the value as JSON, e.g. {{json .Message}}
+	"json": func(v any) (string, error) {
+		out, err := json.Marshal(v)
+		return string(out), err
+	},
+}
+
+func templateSeverityColor(enabled bool) func(severity string) string {
+	return func(severity string) string {
+		switch severity {
+		case "error":
+			return colorize(enabled, styleError, severity)
+		case "warning":
+			return colorize(enabled, styleWarning, severity)
+		case "info":
+			return colorize(enabled, styleNotice, severity)
+		default:
+			return severity
+		}
+	}
+}
+
+// ParseTemplate parses the template for the template format.
+// Templates are executed against JSONOutput.
+func ParseTemplate(text string) (*template.Template, error) {
+	return template.New("format").Funcs(templateFuncs).Parse(text)
+}
+
+func (f *Formatter) templatePrint(issues tflint.Issues, appErr error, sources map[string][]byte) {
+	// Errors before loading the template (e.g. invalid CLI options) are printed in the default format
+	if f.Template == nil {
+		f.prettyPrint(issues, appErr, sources)
+		return
+	}
+
+	// Clone the template so that the shared template is not changed by the Formatter's color setting
+	tmpl, err := f.Template.Clone()
+	if err != nil {
+		f.printErr = fmt.Errorf("Failed to execute the format template; %w", err)
+		f.prettyPrintErrors(f.printErr, sources, false)
+		return
+	}
+	tmpl.Funcs(template.FuncMap{"severityColor": templateSeverityColor(f.Color)})
+
+	// Execute into a buffer so that partial results are not printed on errors
+	out := new(bytes.Buffer)
+	if err := tmpl.Execute(out, f.jsonOutput(issues, appErr, sources)); err != nil {
+		f.printErr = fmt.Errorf("Failed to execute the format template; %w", err)
+		f.prettyPrintErrors(f.printErr, sources, false)
+		return
+	}
+	fmt.Fprint(f.Stdout, out.String())
+}
@@ -0,0 +1,97 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func Test_templatePrint(t *testing.T) {
+	issues := tflint.Issues{
+		{
+			Rule:    &testRule{},
+			Message: `test "quoted"`,
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+			},
+		},
+	}
+
+	cases := []struct {
+		Name     string
+		Template string
+		Issues   tflint.Issues
+		Error    error
+		Color    bool
+		Stdout   string
+		Stderr   string
+		Err      bool
+	}{
+		{
+			Name:     "issues",
+			Template: `{{range .Issues}}{{.Range.Filename | rel "."}}:{{.Range.Start.Line}} {{severityColor .Rule.Severity}} {{json .Message}} ({{.Rule.Name}}){{"\n"}}{{end}}`,
+			Issues:   issues,
+			Stdout:   "test.tf:1 error \"test \\\"quoted\\\"\" (test_rule)\n",
+		},
+		{
+			Name:     "issues with color",
+			Template: `{{range .Issues}}{{severityColor .Rule.Severity}}{{end}}`,
+			Issues:   issues,
+			Color:    true,
+			Stdout:   "\x1b[31merror\x1b[0m",
+		},
+		{
+			Name:     "errors",
+			Template: `{{range .Errors}}{{.Severity}}: {{.Message}}{{end}}`,
+			Issues:   tflint.Issues{},
+			Error:    errors.New("Failed to work; I don't feel like working"),
+			Stdout:   "error: Failed to work; I don't feel like working",
+		},
+		{
+			Name:     "execution error",
+			Template: `{{.Unknown}}`,
+			Issues:   issues,
+			Stderr:   "Failed to execute the format template; template: format:1:2: executing \"format\" at <.Unknown>: can't evaluate field Unknown in type *formatter.JSONOutput\n",
+			Err:      true,
+		},
+		{
+			Name:   "no template",
+			Issues: tflint.Issues{},
+			Error:  errors.New("Failed to parse CLI options"),
+			Stderr: "Failed to parse CLI options\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: stderr, Color: tc.Color}
+			if tc.Template != "" {
+				tmpl, err := ParseTemplate(tc.Template)
+				if err != nil {
+					t.Fatal(err)
+				}
+				formatter.Template = tmpl
+			}
+
+			formatter.templatePrint(tc.Issues, tc.Error, map[string][]byte{})
+
+			if diff := cmp.Diff(tc.Stdout, stdout.String()); diff != "" {
+				t.Errorf("stdout: %s", diff)
+			}
+			if diff := cmp.Diff(tc.Stderr, stderr.String()); diff != "" {
+				t.Errorf("stderr: %s", diff)
+			}
+			if (formatter.Err() != nil) != tc.Err {
+				t.Errorf("expected error is %t, but got %v", tc.Err, formatter.Err())
+			}
+		})
+	}
+}
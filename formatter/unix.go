@@ -0,0 +1,84 @@
+package formatter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// unixPrint outputs issues in the "file:line:col: severity: message [rule]" format,
+// which editors like Vim and Emacs can parse as compiler output without configuration.
+// Nothing is printed to stdout if there are no issues.
+func (f *Formatter) unixPrint(issues tflint.Issues, appErr error, sources map[string][]byte) {
+	for _, issue := range issues {
+		fmt.Fprintf(
+			f.Stdout,
+			"%s:%d:%d: %s: %s [%s]\n",
+			issue.Range.Filename,
+			issue.Range.Start.Line,
+			unixColumn(issue.Range, sources),
+			strings.ToLower(issue.Rule.Severity().String()),
+			unixMessage(issue.Message),
+			issue.Rule.Name(),
+		)
+	}
+
+	f.unixPrintErrors(appErr, sources)
+}
+
+func (f *Formatter) unixPrintErrors(err error, sources map[string][]byte) {
+	if err == nil {
+		return
+	}
+
+	// errors.Join
+	if errs, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, err := range errs.Unwrap() {
+			f.unixPrintErrors(err, sources)
+		}
+		return
+	}
+
+	// hcl.Diagnostics
+	var diags hcl.Diagnostics
+	if errors.As(err, &diags) {
+		for _, diag := range diags {
+			if diag.Subject == nil {
+				fmt.Fprintf(f.Stderr, "%s\n", unixMessage(diag.Summary+". "+diag.Detail))
+				continue
+			}
+			fmt.Fprintf(
+				f.Stdout,
+				"%s:%d:%d: %s: %s\n",
+				diag.Subject.Filename,
+				diag.Subject.Start.Line,
+				unixColumn(*diag.Subject, sources),
+				fromHclSeverity(diag.Severity),
+				unixMessage(diag.Summary+". "+diag.Detail),
+			)
+		}
+		return
+	}
+
+	fmt.Fprintf(f.Stderr, "%s\n", err)
+}
+
+// unixColumn returns the 1-based column of the start of the range counted in runes, as in the compact format.
+// If the source is not available, the column in the range is returned instead.
+func unixColumn(rng hcl.Range, sources map[string][]byte) int {
+	return runeColumn(rng.Start, sources[rng.Filename])
+}
+
+// unixMessage collapses a multi-line message into a single line
+func unixMessage(message string) string {
+	lines := []string{}
+	for _, line := range strings.Split(message, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, " ")
+}
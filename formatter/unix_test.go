@@ -0,0 +1,123 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func Test_unixPrint(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Issues  tflint.Issues
+		Error   error
+		Sources map[string][]byte
+		Stdout  string
+		Stderr  string
+	}{
+		{
+			Name:   "no issues",
+			Issues: tflint.Issues{},
+			Stdout: "",
+		},
+		{
+			Name: "issues",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+				{
+					Rule:    &testRuleWithoutLink{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 25},
+						End:      hcl.Pos{Line: 2, Column: 6, Byte: 28},
+					},
+				},
+			},
+			Stdout: `test.tf:1:1: error: test [test_rule]
+test.tf:2:3: warning: test [test_rule_without_link]
+`,
+		},
+		{
+			Name: "column after multibyte characters",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 14, Byte: 38},
+						End:      hcl.Pos{Line: 2, Column: 15, Byte: 39},
+					},
+				},
+			},
+			Sources: map[string][]byte{"test.tf": []byte("resource \"foo\" \"bar\" {\n  tag = \"äö\" + x\n}\n")},
+			Stdout:  "test.tf:2:14: error: test [test_rule]\n",
+		},
+		{
+			Name: "multi-line message",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "first line\n  second line\n\nthird line",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+			},
+			Stdout: "test.tf:1:1: error: first line second line third line [test_rule]\n",
+		},
+		{
+			Name:   "error",
+			Error:  errors.New("an error occurred"),
+			Stderr: "an error occurred\n",
+		},
+		{
+			Name:   "diagnostics",
+			Error:  hclDiags(`resource "foo" "bar" {`),
+			Stdout: "main.tf:1:22: error: Unclosed configuration block. There is no closing brace for this block before the end of the file. This may be caused by incorrect brace nesting elsewhere in this file.\n",
+		},
+		{
+			Name: "joined errors",
+			Error: errors.Join(
+				errors.New("an error occurred"),
+				errors.New("failed"),
+			),
+			Stderr: "an error occurred\nfailed\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: stderr}
+
+			sources := tc.Sources
+			if sources == nil {
+				sources = map[string][]byte{}
+			}
+			formatter.unixPrint(tc.Issues, tc.Error, sources)
+
+			if stdout.String() != tc.Stdout {
+				t.Errorf("expected=%s, stdout=%s", tc.Stdout, stdout.String())
+			}
+			if stderr.String() != tc.Stderr {
+				t.Errorf("expected=%s, stderr=%s", tc.Stderr, stderr.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+// openPty opens a pseudo-terminal pair. Input written to the master is read from the slave as terminal input.
+func openPty(t *testing.T) (*os.File, *os.File) {
+	t.Helper()
+
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("pseudo-terminals are not available: %s", err)
+	}
+	t.Cleanup(func() { master.Close() })
+
+	if err := unix.IoctlSetPointerInt(int(master.Fd()), unix.TIOCSPTLCK, 0); err != nil {
+		t.Fatal(err)
+	}
+	n, err := unix.IoctlGetUint32(int(master.Fd()), unix.TIOCGPTN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slave, err := os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("pseudo-terminals are not available: %s", err)
+	}
+	t.Cleanup(func() { slave.Close() })
+
+	return master, slave
+}
+
+// TestIntegrationInteractive runs the installed binary on a pseudo-terminal, since --interactive
+// reads answers from the controlling terminal and is only activated when stdout is a terminal.
+func TestIntegrationInteractive(t *testing.T) {
+	bin, err := exec.LookPath("tflint")
+	if err != nil {
+		t.Skipf("tflint is not installed: %s", err)
+	}
+
+	dir, _ := os.Getwd()
+	testDir := filepath.Join(dir, "interactive")
+	t.Chdir(testDir)
+
+	original, err := os.ReadFile("main.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := os.WriteFile(filepath.Join(testDir, "main.tf"), original, 0644); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	master, slave := openPty(t)
+	// Apply the fix of the first issue and skip the second one
+	if _, err := master.WriteString("y\nn\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := new(bytes.Buffer)
+	copied := make(chan error)
+	go func() {
+		_, err := io.Copy(out, master)
+		copied <- err
+	}()
+
+	stderr := new(bytes.Buffer)
+	cmd := exec.Command(bin, "--fix", "--interactive")
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = slave, slave, stderr
+	// The pseudo-terminal becomes the controlling terminal, so that /dev/tty is opened
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true, Setctty: true, Ctty: 0}
+	cmd.Env = append(os.Environ(), "TFLINT_LOG=")
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	// Close the slave in this process so that reading the master ends when the command exits
+	slave.Close()
+	if err := cmd.Wait(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			t.Fatal(err)
+		}
+	}
+	// Reading the master fails with EIO after all slaves are closed
+	if err := <-copied; err != nil && !errors.Is(err, syscall.EIO) {
+		t.Fatal(err)
+	}
+	output := strings.ReplaceAll(out.String(), "\r\n", "\n")
+
+	if prompts := strings.Count(output, "Apply this fix? [y/n/a/q] "); prompts != 2 {
+		t.Errorf("expected 2 prompts, but got %d: %s\nstderr: %s", prompts, output, stderr)
+	}
+	if !strings.Contains(output, "Applied 1 fix, skipped 1 fix") {
+		t.Errorf("the summary is not printed: %s\nstderr: %s", output, stderr)
+	}
+
+	got, err := os.ReadFile("main.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# autofixed\n\nvariable \"foo\" {}\n\n// autofixed\n"
+	if string(got) != want {
+		t.Errorf("expected %q, but got %q", want, string(got))
+	}
+}
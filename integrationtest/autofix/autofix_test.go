@@ -11,6 +11,7 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"text/template"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/terraform-linters/tflint/cmd"
@@ -80,6 +81,11 @@ func TestIntegration(t *testing.T) {
 			Command: "./tflint --format json --fix --filter=main.tf",
 			Dir:     "filter",
 		},
+		{
+			Name:    "--fix-rule",
+			Command: "./tflint --format json --fix --fix-rule=terraform_autofix_remove_local",
+			Dir:     "fix_rule",
+		},
 	}
 
 	// Disable the bundled plugin because the `os.Executable()` is go(1) in the tests
@@ -192,6 +198,120 @@ func TestIntegration(t *testing.T) {
 	}
 }
 
+func TestIntegrationDryRun(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Command string
+		Dir     string
+		// Result is the golden file of the output, rendered as a template with the TFLint version.
+		// If empty, dry_run.diff is used.
+		Result string
+	}{
+		{
+			Name:    "simple fix",
+			Command: "./tflint --fix --dry-run --no-color",
+			Dir:     "simple",
+		},
+		{
+			Name:    "fix in multiple files",
+			Command: "./tflint --fix --dry-run --no-color",
+			Dir:     "multiple_files",
+		},
+		{
+			Name:    "--chdir",
+			Command: "./tflint --chdir=dir --fix --dry-run --no-color",
+			Dir:     "chdir",
+		},
+		{
+			Name:    "--fix-rule",
+			Command: "./tflint --fix --dry-run --fix-rule=terraform_autofix_remove_local --no-color",
+			Dir:     "fix_rule",
+		},
+		{
+			Name:    "--format=sarif",
+			Command: "./tflint --fix --dry-run --format=sarif",
+			Dir:     "simple",
+			Result:  "dry_run.sarif.tmpl",
+		},
+	}
+
+	// Disable the bundled plugin because the `os.Executable()` is go(1) in the tests
+	tflint.DisableBundledPlugin = true
+	defer func() {
+		tflint.DisableBundledPlugin = false
+	}()
+
+	dir, _ := os.Getwd()
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			testDir := filepath.Join(dir, tc.Dir)
+			t.Chdir(testDir)
+
+			tfFiles := map[string][]byte{}
+			err := filepath.Walk(".", func(path string, info fs.FileInfo, err error) error {
+				if !info.IsDir() && strings.HasSuffix(path, ".tf") {
+					sources, err := os.ReadFile(path)
+					if err != nil {
+						return err
+					}
+					tfFiles[path] = sources
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() {
+				// restore original files in case they are rewritten
+				for path := range tfFiles {
+					if err := os.WriteFile(path, tfFiles[path], 0644); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}()
+
+			outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+			cli, err := cmd.NewCLI(outStream, errStream)
+			if err != nil {
+				t.Fatal(err)
+			}
+			args := strings.Split(tc.Command, " ")
+
+			status := cli.Run(args)
+			if status != cmd.ExitCodeIssuesFound {
+				t.Fatalf("expected exit status %d, but got %d: %s", cmd.ExitCodeIssuesFound, status, errStream)
+			}
+
+			result := tc.Result
+			if result == "" {
+				result = "dry_run.diff"
+			}
+			tmpl, err := template.ParseFiles(filepath.Join(testDir, result))
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := new(bytes.Buffer)
+			if err := tmpl.Execute(want, struct{ Version string }{Version: tflint.Version.String()}); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(outStream.String(), want.String()); diff != "" {
+				t.Fatal(diff)
+			}
+
+			// files should be unchanged
+			for path := range tfFiles {
+				got, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(string(got), string(tfFiles[path])); diff != "" {
+					t.Fatal(diff)
+				}
+			}
+		})
+	}
+}
+
 func IsWindowsResultExist() bool {
 	_, err := os.Stat("result_windows.json")
 	return !os.IsNotExist(err)
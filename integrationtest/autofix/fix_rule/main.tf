@@ -0,0 +1,4 @@
+locals {
+  foo = 1
+  autofix_removed = 2
+}
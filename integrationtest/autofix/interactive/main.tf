@@ -0,0 +1,5 @@
+// autofixed
+
+variable "foo" {}
+
+// autofixed
@@ -0,0 +1,5 @@
+# The issue is still suppressed after lines are added above it
+
+resource "aws_instance" "existing" {
+  instance_type = "t2.micro"
+}
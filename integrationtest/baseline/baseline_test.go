@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/terraform-linters/tflint/cmd"
+	"github.com/terraform-linters/tflint/formatter"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+func TestIntegration(t *testing.T) {
+	// Disable the default github format in GitHub Actions
+	t.Setenv("GITHUB_ACTIONS", "")
+
+	// Disable the bundled plugin because the `os.Executable()` is go(1) in the tests
+	tflint.DisableBundledPlugin = true
+	defer func() {
+		tflint.DisableBundledPlugin = false
+	}()
+
+	tests := []struct {
+		name    string
+		command string
+		dir     string
+		status  int
+		stderr  string
+		result  string
+	}{
+		{
+			name:    "without baseline",
+			command: "./tflint --format json",
+			dir:     "basic",
+			status:  cmd.ExitCodeIssuesFound,
+			result:  "result_before.json",
+		},
+		{
+			name:    "with baseline",
+			command: "./tflint --format json --baseline tflint-baseline.json",
+			dir:     "basic",
+			status:  cmd.ExitCodeIssuesFound,
+			stderr:  "1 issue(s) suppressed by the baseline",
+			result:  "result_after.json",
+		},
+		{
+			name:    "all issues in baseline",
+			command: "./tflint --format json --baseline tflint-baseline.json",
+			dir:     "all_suppressed",
+			status:  cmd.ExitCodeOK,
+			stderr:  "1 issue(s) suppressed by the baseline",
+		},
+		{
+			name:    "baseline not found",
+			command: "./tflint --format json --baseline not_found.json",
+			dir:     "basic",
+			status:  cmd.ExitCodeError,
+		},
+	}
+
+	dir, _ := os.Getwd()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testDir := filepath.Join(dir, test.dir)
+			t.Chdir(testDir)
+
+			outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+			cli, err := cmd.NewCLI(outStream, errStream)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := cli.Run(strings.Split(test.command, " "))
+
+			if got != test.status {
+				t.Errorf("expected status is %d, but got %d", test.status, got)
+			}
+			if !strings.Contains(errStream.String(), test.stderr) {
+				t.Errorf("stderr did not contain expected\n\texpected: %s\n\tgot: %s", test.stderr, errStream.String())
+			}
+			if test.result == "" {
+				return
+			}
+
+			b, err := os.ReadFile(filepath.Join(testDir, test.result))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var expected *formatter.JSONOutput
+			if err := json.Unmarshal(b, &expected); err != nil {
+				t.Fatal(err)
+			}
+			var output *formatter.JSONOutput
+			if err := json.Unmarshal(outStream.Bytes(), &output); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(expected, output); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestIntegrationGenerateBaseline(t *testing.T) {
+	// Disable the default github format in GitHub Actions
+	t.Setenv("GITHUB_ACTIONS", "")
+
+	// Disable the bundled plugin because the `os.Executable()` is go(1) in the tests
+	tflint.DisableBundledPlugin = true
+	defer func() {
+		tflint.DisableBundledPlugin = false
+	}()
+
+	// Generate the baseline in a temporary directory to avoid overwriting fixtures
+	dir := t.TempDir()
+	for _, file := range []string{".tflint.hcl", "main.tf"} {
+		content, err := os.ReadFile(filepath.Join("basic", file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, file), content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(dir)
+
+	outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+	cli, err := cmd.NewCLI(outStream, errStream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cli.Run([]string{"./tflint", "--generate-baseline"}); got != cmd.ExitCodeOK {
+		t.Fatalf("expected status is %d, but got %d: %s", cmd.ExitCodeOK, got, errStream.String())
+	}
+	if diff := cmp.Diff("Baseline with 2 issue(s) written to tflint-baseline.json\n", outStream.String()); diff != "" {
+		t.Error(diff)
+	}
+
+	// All current issues are suppressed by the generated baseline
+	outStream, errStream = new(bytes.Buffer), new(bytes.Buffer)
+	cli, err = cmd.NewCLI(outStream, errStream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cli.Run([]string{"./tflint", "--format", "json", "--baseline", "tflint-baseline.json"}); got != cmd.ExitCodeOK {
+		t.Fatalf("expected status is %d, but got %d: %s", cmd.ExitCodeOK, got, errStream.String())
+	}
+	if diff := cmp.Diff(`{"format_version":"1.1","issues":[],"errors":[],"summary":{"issue_count":0,"error_count":0,"by_severity":{"error":0,"info":0,"warning":0},"by_rule":{}},"scanned_files":["main.tf"]}`, outStream.String()); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff("2 issue(s) suppressed by the baseline\n", errStream.String()); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,7 @@
+resource "aws_instance" "existing" {
+  instance_type = "t2.micro"
+}
+
+resource "aws_instance" "new" {
+  instance_type = "t3.micro"
+}
@@ -46,11 +46,21 @@ func TestIntegration(t *testing.T) {
 			command: "tflint --format json --force",
 			dir:     "disabled_by_default",
 		},
+		{
+			name:    "disabled_by_default by CLI",
+			command: "tflint --format json --force --disabled-by-default",
+			dir:     "disabled_by_default_cli",
+		},
 		{
 			name:    "only",
 			command: "tflint --format json --force --only terraform_unused_declarations",
 			dir:     "only",
 		},
+		{
+			name:    "rule without config",
+			command: "tflint --format json --force --rule terraform_unused_declarations",
+			dir:     "rule",
+		},
 	}
 
 	dir, _ := os.Getwd()
@@ -0,0 +1,3 @@
+rule "terraform_unused_declarations" {
+  enabled = true
+}
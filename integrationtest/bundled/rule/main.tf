@@ -0,0 +1,6 @@
+variable "unused" {}
+variable "used" {}
+
+resource "aws_instance" "main" {
+  instance_type = "${var.used}"
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/terraform-linters/tflint/cmd"
+	"github.com/terraform-linters/tflint/formatter"
+)
+
+func TestIntegration(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	// Changes the instance type in the file, which is reported as an issue in the changed file
+	change := func(t *testing.T, path string) {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, bytes.ReplaceAll(content, []byte("t2.micro"), []byte("t3.micro")), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		command string
+		setup   func(t *testing.T, dir string)
+		status  int
+		want    []string
+		// skipped is the number of skipped modules in the statistics. It is checked only with --statistics.
+		// "modules" has no configuration files, so it is not a module.
+		skipped int
+		stderr  string
+	}{
+		{
+			name:    "uncommitted changes",
+			command: "tflint --format json --changed-only",
+			setup: func(t *testing.T, dir string) {
+				change(t, filepath.Join(dir, "main.tf"))
+			},
+			status: cmd.ExitCodeIssuesFound,
+			want:   []string{"main.tf: instance type is t3.micro"},
+		},
+		{
+			name:    "committed and uncommitted changes from the default branch",
+			command: "tflint --format json --changed-only",
+			setup: func(t *testing.T, dir string) {
+				git(t, dir, "checkout", "-b", "feature")
+				change(t, filepath.Join(dir, "other.tf"))
+				git(t, dir, "commit", "-am", "change other.tf")
+				change(t, filepath.Join(dir, "main.tf"))
+			},
+			status: cmd.ExitCodeIssuesFound,
+			want:   []string{"main.tf: instance type is t3.micro", "other.tf: instance type is t3.micro"},
+		},
+		{
+			name:    "remote HEAD as the default branch",
+			command: "tflint --format json --changed-only",
+			setup: func(t *testing.T, dir string) {
+				git(t, dir, "update-ref", "refs/remotes/origin/trunk", "HEAD")
+				git(t, dir, "symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/trunk")
+				git(t, dir, "checkout", "-b", "feature")
+				git(t, dir, "branch", "-D", "main")
+				change(t, filepath.Join(dir, "other.tf"))
+				git(t, dir, "commit", "-am", "change other.tf")
+			},
+			status: cmd.ExitCodeIssuesFound,
+			want:   []string{"other.tf: instance type is t3.micro"},
+		},
+		{
+			name:    "no default branch",
+			command: "tflint --format json --changed-only",
+			setup: func(t *testing.T, dir string) {
+				git(t, dir, "branch", "-m", "trunk")
+			},
+			status: cmd.ExitCodeError,
+			stderr: "Failed to find the default branch",
+		},
+		{
+			name:    "untracked files",
+			command: "tflint --format json --changed-only",
+			setup: func(t *testing.T, dir string) {
+				// Non-ASCII names are quoted by git unless paths are separated by NUL
+				if err := os.WriteFile(filepath.Join(dir, "ünträcked.tf"), []byte(`resource "aws_instance" "untracked" {
+  instance_type = "t3.micro"
+}
+`), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			status: cmd.ExitCodeIssuesFound,
+			want:   []string{"ünträcked.tf: instance type is t3.micro"},
+		},
+		{
+			name:    "no changes",
+			command: "tflint --format json --changed-only",
+			status:  cmd.ExitCodeOK,
+			want:    []string{},
+		},
+		{
+			name:    "changes outside the working directory",
+			command: "tflint --chdir=subdir1 --format json --changed-only",
+			setup: func(t *testing.T, dir string) {
+				change(t, filepath.Join(dir, "main.tf"))
+			},
+			status: cmd.ExitCodeOK,
+			want:   []string{},
+		},
+		{
+			name:    "changed-only + filter",
+			command: "tflint --format json --changed-only --filter=other.tf",
+			setup: func(t *testing.T, dir string) {
+				change(t, filepath.Join(dir, "main.tf"))
+				change(t, filepath.Join(dir, "other.tf"))
+			},
+			status: cmd.ExitCodeIssuesFound,
+			want:   []string{"other.tf: instance type is t3.micro"},
+		},
+		{
+			name:    "base ref",
+			command: "tflint --format json --changed-only --base-ref=main",
+			setup: func(t *testing.T, dir string) {
+				git(t, dir, "checkout", "-b", "feature")
+				change(t, filepath.Join(dir, "other.tf"))
+				git(t, dir, "commit", "-am", "change other.tf")
+				// Uncommitted changes are not compared with the base ref
+				change(t, filepath.Join(dir, "main.tf"))
+			},
+			status: cmd.ExitCodeIssuesFound,
+			want:   []string{"other.tf: instance type is t3.micro"},
+		},
+		{
+			name:    "changed-only with ref",
+			command: "tflint --format json --changed-only=main",
+			setup: func(t *testing.T, dir string) {
+				git(t, dir, "checkout", "-b", "feature")
+				change(t, filepath.Join(dir, "other.tf"))
+				git(t, dir, "commit", "-am", "change other.tf")
+				change(t, filepath.Join(dir, "main.tf"))
+			},
+			status: cmd.ExitCodeIssuesFound,
+			want:   []string{"other.tf: instance type is t3.micro"},
+		},
+		{
+			name:    "changed-only with ref + base ref",
+			command: "tflint --format json --changed-only=main --base-ref=main",
+			status:  cmd.ExitCodeError,
+			stderr:  "--base-ref cannot be used with --changed-only=REF",
+		},
+		{
+			name:    "recursive",
+			command: "tflint --recursive --format json --changed-only",
+			setup: func(t *testing.T, dir string) {
+				change(t, filepath.Join(dir, "subdir1", "main.tf"))
+			},
+			status: cmd.ExitCodeIssuesFound,
+			want:   []string{"subdir1/main.tf: instance type is t3.micro"},
+		},
+		{
+			name:    "recursive + chdir",
+			command: "tflint --chdir=subdir1 --recursive --format json --changed-only",
+			setup: func(t *testing.T, dir string) {
+				change(t, filepath.Join(dir, "main.tf"))
+				change(t, filepath.Join(dir, "subdir1", "main.tf"))
+				change(t, filepath.Join(dir, "subdir2", "main.tf"))
+			},
+			status: cmd.ExitCodeIssuesFound,
+			want:   []string{"subdir1/main.tf: instance type is t3.micro"},
+		},
+		{
+			name:    "recursive + changed local module",
+			command: "tflint --recursive --format json --changed-only --statistics",
+			setup: func(t *testing.T, dir string) {
+				change(t, filepath.Join(dir, "modules", "instance", "main.tf"))
+			},
+			status: cmd.ExitCodeIssuesFound,
+			// Issues in all files are reported in subdir3 since it is inspected only because the module is changed
+			want:    []string{"modules/instance/main.tf: instance type is t3.micro", "subdir3/main.tf: instance type is t2.micro"},
+			skipped: 3,
+		},
+		{
+			name:    "recursive + statistics",
+			command: "tflint --recursive --format json --changed-only --statistics",
+			setup: func(t *testing.T, dir string) {
+				change(t, filepath.Join(dir, "subdir1", "main.tf"))
+			},
+			status:  cmd.ExitCodeIssuesFound,
+			want:    []string{"subdir1/main.tf: instance type is t3.micro"},
+			skipped: 4,
+		},
+		{
+			name:    "not a git repository",
+			command: "tflint --format json --changed-only",
+			setup: func(t *testing.T, dir string) {
+				if err := os.RemoveAll(filepath.Join(dir, ".git")); err != nil {
+					t.Fatal(err)
+				}
+			},
+			status: cmd.ExitCodeError,
+			stderr: "--changed-only requires a git repository",
+		},
+	}
+
+	src, _ := os.Getwd()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.CopyFS(dir, os.DirFS(filepath.Join(src, "repo"))); err != nil {
+				t.Fatal(err)
+			}
+			git(t, dir, "init", "--initial-branch=main")
+			git(t, dir, "add", ".")
+			git(t, dir, "commit", "-m", "initial commit")
+			if test.setup != nil {
+				test.setup(t, dir)
+			}
+			t.Chdir(dir)
+
+			args := strings.Split(test.command, " ")
+			var command *exec.Cmd
+			if runtime.GOOS == "windows" {
+				command = exec.Command("tflint.exe", args[1:]...)
+			} else {
+				command = exec.Command("tflint", args[1:]...)
+			}
+			outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+			command.Stdout = outStream
+			command.Stderr = errStream
+
+			status := 0
+			if err := command.Run(); err != nil {
+				exitErr, ok := err.(*exec.ExitError)
+				if !ok {
+					t.Fatalf("Failed to exec command: %s", err)
+				}
+				status = exitErr.ExitCode()
+			}
+			if status != test.status {
+				t.Errorf("expected status is %d, but got %d: stderr=%s", test.status, status, errStream.String())
+			}
+
+			var output *formatter.JSONOutput
+			if err := json.Unmarshal(outStream.Bytes(), &output); err != nil {
+				t.Fatal(err)
+			}
+			if test.stderr != "" {
+				// Errors are printed in JSON with the json format
+				if len(output.Errors) == 0 || !strings.Contains(output.Errors[0].Message, test.stderr) {
+					t.Errorf("expected error containing %q, but got %+v", test.stderr, output.Errors)
+				}
+				return
+			}
+
+			if strings.Contains(test.command, "--statistics") {
+				if output.Statistics == nil {
+					t.Fatal("statistics are not found")
+				}
+				if output.Statistics.ModulesSkipped != test.skipped {
+					t.Errorf("expected skipped modules are %d, but got %d", test.skipped, output.Statistics.ModulesSkipped)
+				}
+			}
+
+			got := []string{}
+			for _, issue := range output.Issues {
+				got = append(got, filepath.ToSlash(issue.Range.Filename)+": "+issue.Message)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	command := exec.Command("git", args...)
+	command.Dir = dir
+	command.Env = append(
+		os.Environ(),
+		"GIT_AUTHOR_NAME=tflint",
+		"GIT_AUTHOR_EMAIL=tflint@example.com",
+		"GIT_COMMITTER_NAME=tflint",
+		"GIT_COMMITTER_EMAIL=tflint@example.com",
+	)
+	if out, err := command.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to run git %s: %s; %s", strings.Join(args, " "), err, out)
+	}
+}
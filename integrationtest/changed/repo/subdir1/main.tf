@@ -0,0 +1,3 @@
+resource "aws_instance" "subdir1" {
+  instance_type = "t2.micro"
+}
@@ -0,0 +1,7 @@
+plugin "terraform" {
+  enabled = false
+}
+
+plugin "testing" {
+  enabled = true
+}
@@ -0,0 +1,3 @@
+resource "aws_instance" "subdir2" {
+  instance_type = "t2.micro"
+}
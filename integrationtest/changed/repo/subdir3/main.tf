@@ -0,0 +1,7 @@
+module "instance" {
+  source = "../modules/instance"
+}
+
+resource "aws_instance" "subdir3" {
+  instance_type = "t2.micro"
+}
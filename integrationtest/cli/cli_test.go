@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -14,6 +16,11 @@ import (
 )
 
 func TestIntegration(t *testing.T) {
+	// Disable the default github format in GitHub Actions
+	t.Setenv("GITHUB_ACTIONS", "")
+	// Do not use ignore patterns set in the environment running the tests
+	t.Setenv("TFLINT_IGNORE", "")
+
 	// Disable the bundled plugin because the `os.Executable()` is go(1) in the tests
 	tflint.DisableBundledPlugin = true
 	defer func() {
@@ -35,6 +42,13 @@ func TestIntegration(t *testing.T) {
 			status:  cmd.ExitCodeOK,
 			stdout:  fmt.Sprintf("TFLint version %s", tflint.Version),
 		},
+		{
+			name:    "print version in JSON",
+			command: "./tflint --version --format json",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeOK,
+			stdout:  fmt.Sprintf(`{"version":"%s","go_version":"%s","os":"%s","arch":"%s","plugins":[{"name":"testing","version":"0.1.0"}]}`, tflint.Version, runtime.Version(), runtime.GOOS, runtime.GOARCH),
+		},
 		{
 			name:    "print help",
 			command: "./tflint --help",
@@ -54,7 +68,48 @@ func TestIntegration(t *testing.T) {
 			command: "./tflint --format json",
 			dir:     "no_issues",
 			status:  cmd.ExitCodeOK,
-			stdout:  `{"issues":[],"errors":[]}`,
+			stdout:  `{"format_version":"1.1","issues":[],"errors":[],"summary":{"issue_count":0,"error_count":0,"by_severity":{"error":0,"info":0,"warning":0},"by_rule":{}},"scanned_files":[]}`,
+		},
+		{
+			name:    "compact format with links and ranges",
+			command: "./tflint --format compact --compact-links --compact-ranges",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  "main.tf:2:19-2:29: Error - instance type is t2.micro (aws_instance_example_type)\n",
+		},
+		{
+			name:    "html format",
+			command: "./tflint --format html",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout: `<div class="issue" id="main.tf-L2">
+<div><span class="badge error">error</span> <a class="location" href="#main.tf-L2">2:19</a> <span class="message">instance type is t2.micro</span> (aws_instance_example_type)</div>
+<pre class="frame"><span class="number">2</span>  instance_type = <mark>&#34;t2.micro&#34;</mark>
+</pre>`,
+		},
+		{
+			name:    "format config with context",
+			command: "./tflint --context=1",
+			dir:     "format_config",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout: `main.tf:2:19: Error - instance type is t2.micro (aws_instance_example_type)
+   1: resource "aws_instance" "main" {
+   2:   instance_type = "t2.micro"
+   3: }`,
+		},
+		{
+			name:    "pin JSON format version",
+			command: "./tflint --format json --json-version 1.0",
+			dir:     "format_config",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  `{"format_version":"1.0","issues":[{"rule":{"name":"aws_instance_example_type","severity":"error","link":""},"message":"instance type is t2.micro","range":{"filename":"main.tf","start":{"line":2,"column":19},"end":{"line":2,"column":29}},"callers":[]}],"errors":[]}`,
+		},
+		{
+			name:    "unsupported JSON format version",
+			command: "./tflint --format json --json-version 2.0",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stdout:  `JSON format version \"2.0\" is not supported. Supported versions: 1.0, 1.1`,
 		},
 		{
 			name:    "format config",
@@ -68,7 +123,49 @@ func TestIntegration(t *testing.T) {
 			command: "./tflint --format json",
 			dir:     "format_config",
 			status:  cmd.ExitCodeIssuesFound,
-			stdout:  `{"issues":[{"rule":{"name":"aws_instance_example_type","severity":"error","link":""},"message":"instance type is t2.micro","range":{"filename":"main.tf","start":{"line":2,"column":19},"end":{"line":2,"column":29}},"callers":[]}],"errors":[]}`,
+			stdout:  `{"format_version":"1.1","issues":[{"rule":{"name":"aws_instance_example_type","severity":"error","link":""},"message":"instance type is t2.micro","range":{"filename":"main.tf","start":{"line":2,"column":19,"byte":51},"end":{"line":2,"column":29,"byte":61}},"callers":[],"fixable":false,"fingerprint":"902b3241be6b85d566605deba6aacf71013e04171bc26139ea6806cceb1a10af"}],"errors":[],"summary":{"issue_count":1,"error_count":0,"by_severity":{"error":1,"info":0,"warning":0},"by_rule":{"aws_instance_example_type":1}},"scanned_files":["main.tf"]}`,
+		},
+		{
+			name:    "format options in config",
+			command: "./tflint",
+			dir:     "format_options",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  "main.tf:2:19-2:29: Error - instance type is t2.micro (aws_instance_example_type)\n",
+		},
+		{
+			name:    "files ignored by .tflintignore",
+			command: "./tflint --format compact",
+			dir:     "tflintignore",
+			status:  cmd.ExitCodeOK,
+			stdout:  "",
+		},
+		{
+			name:    "--no-ignore",
+			command: "./tflint --format compact --no-ignore",
+			dir:     "tflintignore",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  "generated.tf:2:19: Error - instance type is t2.micro (aws_instance_example_type)\n",
+		},
+		{
+			name:    "format option flag",
+			command: "./tflint --format compact --format-option ranges=true",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  "main.tf:2:19-2:29: Error - instance type is t2.micro (aws_instance_example_type)\n",
+		},
+		{
+			name:    "format option flag overrides config",
+			command: "./tflint --format-option ranges=false",
+			dir:     "format_options",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  "main.tf:2:19: Error - instance type is t2.micro (aws_instance_example_type)\n",
+		},
+		{
+			name:    "unknown format option",
+			command: "./tflint --format compact --format-option rangess=true",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeError,
+			stderr:  "rangess is not a valid option for the compact format. Valid options are context, links, quiet_success, ranges, severity_prefix",
 		},
 		{
 			name:    "`--force` option with no issues",
@@ -224,6 +321,27 @@ func TestIntegration(t *testing.T) {
 			status:  cmd.ExitCodeOK,
 			stdout:  fmt.Sprintf("%s (aws_instance_example_type)", color.New(color.Bold).Sprint("instance type is t2.micro")),
 		},
+		{
+			name:    "--exit-zero option with issues",
+			command: "./tflint --exit-zero",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeOK,
+			stdout:  fmt.Sprintf("%s (aws_instance_example_type)", color.New(color.Bold).Sprint("instance type is t2.micro")),
+		},
+		{
+			name:    "--exit-zero option with loading errors",
+			command: "./tflint --exit-zero",
+			dir:     "load_errors",
+			status:  cmd.ExitCodeError,
+			stderr:  "Failed to load configurations;",
+		},
+		{
+			name:    "--exit-zero option with plugin errors",
+			command: "./tflint --exit-zero",
+			dir:     "check_errors",
+			status:  cmd.ExitCodeError,
+			stderr:  `failed to check "aws_cloudformation_stack_error" rule: an error occurred in Check`,
+		},
 		{
 			name:    "--minimum-failure-severity option with warning issues and minimum-failure-severity notice",
 			command: "./tflint --minimum-failure-severity=notice",
@@ -238,6 +356,167 @@ func TestIntegration(t *testing.T) {
 			status:  cmd.ExitCodeIssuesFound,
 			stdout:  fmt.Sprintf("%s (aws_s3_bucket_with_config_example)", color.New(color.Bold).Sprint("bucket name is test, config=bucket")),
 		},
+		{
+			name:    "--fail-on-severity option with warning issues and fail-on-severity warning",
+			command: "./tflint --fail-on-severity=warning",
+			dir:     "warnings_found",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  fmt.Sprintf("%s (aws_s3_bucket_with_config_example)", color.New(color.Bold).Sprint("bucket name is test, config=bucket")),
+		},
+		{
+			name:    "--fail-on-severity option with warning issues and fail-on-severity error",
+			command: "./tflint --fail-on-severity=error",
+			dir:     "warnings_found",
+			status:  cmd.ExitCodeOK,
+			stdout:  fmt.Sprintf("%s (aws_s3_bucket_with_config_example)", color.New(color.Bold).Sprint("bucket name is test, config=bucket")),
+		},
+		{
+			name:    "--minimum-failure-severity option with warning issues and minimum-failure-severity warning with --force",
+			command: "./tflint --minimum-failure-severity=warning --force",
+			dir:     "warnings_found",
+			status:  cmd.ExitCodeOK,
+			stdout:  fmt.Sprintf("%s (aws_s3_bucket_with_config_example)", color.New(color.Bold).Sprint("bucket name is test, config=bucket")),
+		},
+		{
+			name:    "minimum_failure_severity in config with warning issues",
+			command: "./tflint",
+			dir:     "failure_severity_config",
+			status:  cmd.ExitCodeOK,
+			stdout:  fmt.Sprintf("%s (aws_s3_bucket_with_config_example)", color.New(color.Bold).Sprint("bucket name is test, config=bucket")),
+		},
+		{
+			name:    "--minimum-failure-severity option overrides minimum_failure_severity in config",
+			command: "./tflint --minimum-failure-severity=warning",
+			dir:     "failure_severity_config",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  fmt.Sprintf("%s (aws_s3_bucket_with_config_example)", color.New(color.Bold).Sprint("bucket name is test, config=bucket")),
+		},
+		{
+			name:    "issues within the rule threshold",
+			command: "./tflint --format compact",
+			dir:     "rule_threshold",
+			status:  cmd.ExitCodeOK,
+			stdout:  "6 issue(s) found:",
+		},
+		{
+			name:    "issues exceed the rule threshold",
+			command: "./tflint --format compact --config threshold_5.hcl",
+			dir:     "rule_threshold",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  "6 issue(s) found:",
+		},
+		{
+			name:    "--max-issues option",
+			command: "./tflint --format json --max-issues=1",
+			dir:     "max_issues",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  `"summary":{"issue_count":1,"error_count":0,"by_severity":{"error":1,"info":0,"warning":0},"by_rule":{"aws_instance_example_type":1}},"scanned_files":["main.tf"],"issues_truncated":true}`,
+		},
+		{
+			name:    "--max-issues option in pretty format",
+			command: "./tflint --max-issues=1",
+			dir:     "max_issues",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  "Issues are truncated by --max-issues=1. Increase the limit to see more.",
+		},
+		{
+			name:    "--max-issues option with issues under the limit",
+			command: "./tflint --format json --max-issues=2",
+			dir:     "max_issues",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  `"scanned_files":["main.tf"]}`,
+		},
+		{
+			name:    "unsupported --report-file format",
+			command: "./tflint --report-file=result.xml,format=checkstyle",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `Report file format "checkstyle" is not supported. Supported formats: json, sarif, junit`,
+		},
+		{
+			name:    "--report-file with --watch",
+			command: "./tflint --report-file=result.json --watch",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  "--report-file cannot be used with --watch",
+		},
+		{
+			name:    "negative --max-issues option",
+			command: "./tflint --max-issues=-1",
+			dir:     "max_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  "Max issues should be greater than or equal to 0",
+		},
+		{
+			name:    "--max-issues with --generate-baseline",
+			command: "./tflint --max-issues=1 --generate-baseline",
+			dir:     "max_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  "--generate-baseline cannot be used with --max-issues",
+		},
+		{
+			name:    "--min-severity option with warning issues and min-severity warning",
+			command: "./tflint --min-severity=warning",
+			dir:     "warnings_found",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  fmt.Sprintf("%s (aws_s3_bucket_with_config_example)", color.New(color.Bold).Sprint("bucket name is test, config=bucket")),
+		},
+		{
+			name:    "--min-severity option with warning issues and min-severity error",
+			command: "./tflint --min-severity=error",
+			dir:     "warnings_found",
+			status:  cmd.ExitCodeOK,
+			stdout:  "",
+		},
+		{
+			name:    "--min-severity option with warning issues and min-severity error in JSON",
+			command: "./tflint --min-severity=error --format=json",
+			dir:     "warnings_found",
+			status:  cmd.ExitCodeOK,
+			stdout:  `{"format_version":"1.1","issues":[],"errors":[],"summary":{"issue_count":0,"error_count":0,"by_severity":{"error":0,"info":0,"warning":0},"by_rule":{}},"scanned_files":["main.tf"]}`,
+		},
+		{
+			name:    "--minimum-report-severity option with warning issues and minimum-report-severity error",
+			command: "./tflint --minimum-report-severity=error",
+			dir:     "warnings_found",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  "1 issue(s) below error hidden",
+		},
+		{
+			name:    "--minimum-report-severity option with warning issues and minimum-report-severity error in JSON",
+			command: "./tflint --minimum-report-severity=error --format=json",
+			dir:     "warnings_found",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  `{"format_version":"1.1","issues":[],"errors":[],"summary":{"issue_count":0,"error_count":0,"by_severity":{"error":0,"info":0,"warning":0},"by_rule":{},"hidden_count":1},"scanned_files":["main.tf"]}`,
+		},
+		{
+			name:    "--minimum-report-severity option with warning issues and minimum-failure-severity error",
+			command: "./tflint --minimum-report-severity=error --minimum-failure-severity=error",
+			dir:     "warnings_found",
+			status:  cmd.ExitCodeOK,
+			stdout:  "1 issue(s) below error hidden",
+		},
+		{
+			name:    "--quiet-success option with warning issues and minimum-report-severity error",
+			command: "./tflint --quiet-success --minimum-report-severity=error --minimum-failure-severity=error",
+			dir:     "warnings_found",
+			status:  cmd.ExitCodeOK,
+			stdout:  "",
+		},
+		{
+			name:    "--quiet-success option with warning issues and minimum-report-severity error in JSON",
+			command: "./tflint --quiet-success --minimum-report-severity=error --minimum-failure-severity=error --format=json",
+			dir:     "warnings_found",
+			status:  cmd.ExitCodeOK,
+			stdout:  `{"format_version":"1.1","issues":[],"errors":[],"summary":{"issue_count":0,"error_count":0,"by_severity":{"error":0,"info":0,"warning":0},"by_rule":{},"hidden_count":1},"scanned_files":["main.tf"]}`,
+		},
+		{
+			name:    "--quiet-success option with warning issues",
+			command: "./tflint --quiet-success",
+			dir:     "warnings_found",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  fmt.Sprintf("%s (aws_s3_bucket_with_config_example)", color.New(color.Bold).Sprint("bucket name is test, config=bucket")),
+		},
 		{
 			name:    "--minimum-failure-severity option with warning issues and minimum-failure-severity error",
 			command: "./tflint --minimum-failure-severity=error",
@@ -252,6 +531,41 @@ func TestIntegration(t *testing.T) {
 			status:  cmd.ExitCodeIssuesFound,
 			stdout:  "instance type is t2.micro (aws_instance_example_type)",
 		},
+		{
+			name:    "--color option",
+			command: "./tflint --color",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  "\x1b[1minstance type is t2.micro\x1b[22m (aws_instance_example_type)",
+		},
+		{
+			name:    "--color=always option",
+			command: "./tflint --color=always",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  "\x1b[1minstance type is t2.micro\x1b[22m (aws_instance_example_type)",
+		},
+		{
+			name:    "--color=never option",
+			command: "./tflint --color=never",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  "instance type is t2.micro (aws_instance_example_type)",
+		},
+		{
+			name:    "--no-color option takes precedence over --color",
+			command: "./tflint --color=always --no-color",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  "instance type is t2.micro (aws_instance_example_type)",
+		},
+		{
+			name:    "invalid --color option",
+			command: "./tflint --color=sometimes",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  "Invalid value `sometimes' for option `--color'",
+		},
 		{
 			name:    "checking errors are occurred",
 			command: "./tflint",
@@ -351,6 +665,21 @@ func TestIntegration(t *testing.T) {
 			status:  cmd.ExitCodeOK,
 			stdout:  "",
 		},
+		{
+			name:    "scanned files in JSON",
+			command: "./tflint --format json",
+			dir:     "multiple_files",
+			status:  cmd.ExitCodeIssuesFound,
+			// empty.tf has no issues, but it is scanned
+			stdout: `"scanned_files":["empty.tf","main.tf"]`,
+		},
+		{
+			name:    "scanned files in JSON with --filter",
+			command: "./tflint --format json --filter=empty.tf",
+			dir:     "multiple_files",
+			status:  cmd.ExitCodeOK,
+			stdout:  `"scanned_files":["empty.tf"]`,
+		},
 		{
 			name:    "--chdir",
 			command: "./tflint --chdir=subdir",
@@ -400,6 +729,308 @@ func TestIntegration(t *testing.T) {
 			status:  cmd.ExitCodeError,
 			stderr:  `Max workers should be greater than 0`,
 		},
+		{
+			name:    "negative context",
+			command: "./tflint --context=-1",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `Context should be greater than or equal to 0`,
+		},
+		{
+			name:    "negative snippet context",
+			command: "./tflint --snippet-context=-1",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `Snippet context should be greater than or equal to 0`,
+		},
+		{
+			name:    "--include-source option",
+			command: "./tflint --format json --include-source",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  `"snippet":{"code":"\"t2.micro\"","line":"  instance_type = \"t2.micro\""}`,
+		},
+		{
+			name:    "--source-max-length option",
+			command: "./tflint --format json --include-source --source-max-length=8",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  `"snippet":{"code":"\"t2.micr","line":"  instan","truncated":true}`,
+		},
+		{
+			name:    "invalid source max length",
+			command: "./tflint --include-source --source-max-length=0",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `Source max length should be greater than 0`,
+		},
+		{
+			name:    "stream with fix",
+			command: "./tflint --format stream --fix",
+			dir:     "multiple_files",
+			status:  cmd.ExitCodeError,
+			stdout:  `{"type":"error","data":{"message":"--fix cannot be used with --format=stream","severity":"error"}}`,
+		},
+		{
+			name:    "watch with recursive",
+			command: "./tflint --watch --recursive",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `--watch cannot be used with --recursive`,
+		},
+		{
+			name:    "watch with fix",
+			command: "./tflint --watch --fix",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `--watch cannot be used with --fix`,
+		},
+		{
+			name:    "fix-rule without fix",
+			command: "./tflint --fix-rule=terraform_comment_syntax",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `--fix-rule can only be used with --fix`,
+		},
+		{
+			name:    "interactive without fix",
+			command: "./tflint --interactive",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `--interactive can only be used with --fix`,
+		},
+		{
+			name:    "interactive with recursive",
+			command: "./tflint --fix --interactive --recursive",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `--interactive cannot be used with --recursive or multiple --chdir`,
+		},
+		{
+			name:    "dry-run without fix",
+			command: "./tflint --dry-run",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `--dry-run can only be used with --fix`,
+		},
+		{
+			name:    "dry-run with interactive",
+			command: "./tflint --fix --dry-run --interactive",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `--dry-run cannot be used with --interactive`,
+		},
+		{
+			name:    "dry-run with format",
+			command: "./tflint --fix --dry-run --format=json",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `--dry-run cannot be used with --format=json`,
+		},
+		{
+			name:    "invalid watch debounce",
+			command: "./tflint --watch --watch-debounce=0s",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `Watch debounce should be greater than 0`,
+		},
+		{
+			name:    "invalid dir timeout",
+			command: "./tflint --recursive --dir-timeout=0s",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `Directory timeout should be greater than 0`,
+		},
+		{
+			name:    "invalid sonarqube severity",
+			command: "./tflint --sonarqube-severity=error=FATAL",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `Failed to parse --sonarqube-severity options; FATAL is not a recognized SonarQube severity. Valid values are BLOCKER, CRITICAL, MAJOR, MINOR, INFO`,
+		},
+		{
+			name:    "template format without template",
+			command: "./tflint --format template",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `--format-template or --format-template-file is required for the template format`,
+		},
+		{
+			name:    "both template and template file",
+			command: "./tflint --format template --format-template={{.Issues}} --format-template-file=format.tmpl",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `--format-template and --format-template-file cannot be used together`,
+		},
+		{
+			name:    "template file not found",
+			command: "./tflint --format template --format-template-file=not_found.tmpl",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `Failed to read the format template file; open not_found.tmpl: `,
+		},
+		{
+			name:    "invalid template",
+			command: "./tflint --format template --format-template={{",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `Failed to parse the format template; template: format:1: unclosed action`,
+		},
+		{
+			name:    "template execution error",
+			command: "./tflint --format template --format-template={{.Unknown}}",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `Failed to execute the format template; template: format:1:2: executing "format" at <.Unknown>: can't evaluate field Unknown in type *formatter.JSONOutput`,
+		},
+		{
+			name:    "generate baseline with changed-only",
+			command: "./tflint --generate-baseline --changed-only",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `--generate-baseline cannot be used with --changed-only`,
+		},
+		{
+			name:    "print config with recursive",
+			command: "./tflint --print-config --recursive",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `--print-config cannot be used with --recursive`,
+		},
+		{
+			name:    "list rules with recursive",
+			command: "./tflint --list-rules --recursive",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `--list-rules cannot be used with --recursive`,
+		},
+		{
+			name:    "multiple chdir with recursive",
+			command: "./tflint --chdir=a --chdir=b --recursive",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `Multiple --chdir cannot be used with --recursive`,
+		},
+		{
+			name:    "multiple chdir with print config",
+			command: "./tflint --chdir=a --chdir=b --print-config",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `Multiple --chdir can only be used in inspection`,
+		},
+		{
+			name:    "enabled only without list rules",
+			command: "./tflint --enabled-only",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `--enabled-only can only be used with --list-rules`,
+		},
+		{
+			name:    "print config with non-existent config file",
+			command: "./tflint --print-config --config=not_found.hcl",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `Failed to load TFLint config; failed to load file: open not_found.hcl:`,
+		},
+		{
+			name:    "base ref without changed-only",
+			command: "./tflint --base-ref=main",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `--base-ref can only be used with --changed-only`,
+		},
+		{
+			name:    "validate valid config",
+			command: "./tflint validate-config",
+			dir:     "validate_config",
+			status:  cmd.ExitCodeOK,
+			stdout:  "The config is valid",
+		},
+		{
+			name:    "validate config with unknown rule",
+			command: "./tflint validate-config",
+			dir:     "validate_config/unknown_rule",
+			status:  cmd.ExitCodeError,
+			stderr:  "Failed to check rule config; Rule not found: nosuchrule",
+		},
+		{
+			name:    "validate config with unknown rule allowed",
+			command: "./tflint validate-config --allow-unknown-rules",
+			dir:     "validate_config/unknown_rule",
+			status:  cmd.ExitCodeOK,
+			stdout:  "The config is valid",
+			stderr:  "Warning: Rule not found: nosuchrule",
+		},
+		{
+			name:    "validate config with invalid syntax",
+			command: "./tflint validate-config",
+			dir:     "validate_config/invalid_syntax",
+			status:  cmd.ExitCodeError,
+			stderr:  "Failed to load TFLint config; .tflint.hcl:1,18-19: Unclosed configuration block",
+		},
+		{
+			name:    "validate config with unknown attribute",
+			command: "./tflint validate-config",
+			dir:     "validate_config/unknown_attribute",
+			status:  cmd.ExitCodeError,
+			stderr:  `An argument named "no_such_attribute" is not expected here`,
+		},
+		{
+			name:    "validate config enabling disabled rules in override blocks",
+			command: "./tflint validate-config",
+			dir:     "validate_config/override_enable_disabled",
+			status:  cmd.ExitCodeError,
+			stderr:  `Failed to check rule config; override "modules/**": rule "aws_instance_example_type": rules disabled in the global config cannot be enabled in override blocks`,
+		},
+		{
+			name:    "validate config with unsupported attributes in override blocks",
+			command: "./tflint validate-config",
+			dir:     "validate_config/override_unsupported_attribute",
+			status:  cmd.ExitCodeError,
+			stderr:  `Failed to load TFLint config; override "modules/**": rule "aws_instance_example_type": only "enabled" can be set in rule blocks in override blocks`,
+		},
+		{
+			name:    "validate config passed by --config",
+			command: "./tflint validate-config --config=unknown_rule/.tflint.hcl",
+			dir:     "validate_config",
+			status:  cmd.ExitCodeError,
+			stderr:  "Rule not found: nosuchrule",
+		},
+		{
+			name:    "output without generate-config",
+			command: "./tflint --output=.tflint.hcl generate-config",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `--output can only be used with generate-config`,
+		},
+		{
+			name:    "existing file in generate-config",
+			command: "./tflint generate-config",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `".tflint.hcl" already exists. Use --overwrite to overwrite it`,
+		},
+		{
+			name:    "allow unknown rules without validate-config",
+			command: "./tflint --allow-unknown-rules",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `--allow-unknown-rules can only be used with validate-config`,
+		},
+		{
+			name:    "migration versions without migrate-config",
+			command: "./tflint --from=v0.53",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `--from can only be used with migrate-config`,
+		},
+		{
+			name:    "invalid migration version",
+			command: "./tflint migrate-config --to=latest",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `Invalid --to: latest`,
+		},
 	}
 
 	dir, _ := os.Getwd()
@@ -434,3 +1065,230 @@ func TestIntegration(t *testing.T) {
 		})
 	}
 }
+
+func TestIntegrationOutputFile(t *testing.T) {
+	// Disable the default github format in GitHub Actions
+	t.Setenv("GITHUB_ACTIONS", "")
+
+	// Disable the bundled plugin because the `os.Executable()` is go(1) in the tests
+	tflint.DisableBundledPlugin = true
+	defer func() {
+		tflint.DisableBundledPlugin = false
+	}()
+
+	tests := []struct {
+		name    string
+		command string
+		dir     string
+		status  int
+		stdout  string
+		stderr  string
+		result  string
+	}{
+		{
+			name:    "no issues",
+			command: "./tflint --format json --output-file %s",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeOK,
+			result:  `{"format_version":"1.1","issues":[],"errors":[],"summary":{"issue_count":0,"error_count":0,"by_severity":{"error":0,"info":0,"warning":0},"by_rule":{}},"scanned_files":[]}`,
+		},
+		{
+			name:    "issues found",
+			command: "./tflint --format compact -o %s",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  "1 issue(s) found:",
+			result:  "main.tf:2:19: Error - instance type is t2.micro (aws_instance_example_type)",
+		},
+		{
+			name:    "issues found in json",
+			command: "./tflint --format json --output-file %s",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  "Error: instance type is t2.micro (aws_instance_example_type)",
+			result:  `"message":"instance type is t2.micro"`,
+		},
+		{
+			name:    "parent directory does not exist",
+			command: "./tflint --output-file %s",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  "Failed to create the output file",
+		},
+		{
+			name:    "report file",
+			command: "./tflint --format compact --report-file %s",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  "main.tf:2:19: Error - instance type is t2.micro (aws_instance_example_type)",
+			result:  `"message":"instance type is t2.micro"`,
+		},
+		{
+			name:    "report file in sarif",
+			command: "./tflint --format compact --report-file %s,format=sarif",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  "main.tf:2:19: Error - instance type is t2.micro (aws_instance_example_type)",
+			result:  `"ruleId": "aws_instance_example_type"`,
+		},
+		{
+			name:    "parent directory of report file does not exist",
+			command: "./tflint --report-file %s",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  "Failed to create the report file",
+		},
+	}
+
+	dir, _ := os.Getwd()
+	defaultNoColor := color.NoColor
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testDir := filepath.Join(dir, test.dir)
+			t.Cleanup(func() {
+				color.NoColor = defaultNoColor
+			})
+			t.Chdir(testDir)
+
+			outputDir := t.TempDir()
+			outputFile := filepath.Join(outputDir, "result")
+			if test.result == "" {
+				outputFile = filepath.Join(outputDir, "not_found", "result")
+			} else if err := os.WriteFile(outputFile, []byte("previous result"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+			cli, err := cmd.NewCLI(outStream, errStream)
+			if err != nil {
+				t.Fatal(err)
+			}
+			args := strings.Split(fmt.Sprintf(test.command, outputFile), " ")
+
+			got := cli.Run(args)
+
+			if got != test.status {
+				t.Errorf("expected status is %d, but got %d", test.status, got)
+			}
+			// The report is written to the file, and human-readable results are printed to stdout
+			if !strings.Contains(outStream.String(), test.stdout) || (test.stdout == "" && outStream.String() != "") {
+				t.Errorf("stdout did not contain expected\n\texpected: %s\n\tgot: %s", test.stdout, outStream.String())
+			}
+			if !strings.Contains(errStream.String(), test.stderr) || (test.stderr == "" && errStream.String() != "") {
+				t.Errorf("stderr did not contain expected\n\texpected: %s\n\tgot: %s", test.stderr, errStream.String())
+			}
+			if test.result == "" {
+				return
+			}
+			result, err := os.ReadFile(outputFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(result), test.result) {
+				t.Errorf("output file did not contain expected\n\texpected: %s\n\tgot: %s", test.result, string(result))
+			}
+			// Temporary files must not be left
+			entries, err := os.ReadDir(outputDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != 1 {
+				t.Errorf("expected only the output file, but got %d entries", len(entries))
+			}
+		})
+	}
+}
+
+func TestIntegrationStdinFilename(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		stdin   string
+		status  int
+		stdout  string
+		stderr  string
+	}{
+		{
+			// The variable is declared in variables.tf on disk, and the issue in other.tf is not reported
+			name:    "override a file on disk",
+			command: "tflint --format json --stdin-filename=main.tf -",
+			stdin:   "resource \"aws_instance\" \"main\" {\n  instance_type = var.instance_type\n}\n",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  `"issues":[{"rule":{"name":"aws_instance_example_type","severity":"error","link":""},"message":"instance type is t2.micro","range":{"filename":"main.tf","start":{"line":2,"column":19,"byte":51}`,
+		},
+		{
+			name:    "new file",
+			command: "tflint --format json --stdin-filename=new.tf",
+			stdin:   "resource \"aws_instance\" \"new\" {\n  instance_type = var.instance_type\n}\n",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  `"summary":{"issue_count":1,"error_count":0,"by_severity":{"error":1,"info":0,"warning":0},"by_rule":{"aws_instance_example_type":1}},"scanned_files":["new.tf"]}`,
+		},
+		{
+			name:    "--stdin --filename",
+			command: "tflint --format json --stdin --filename=main.tf -",
+			stdin:   "resource \"aws_instance\" \"main\" {\n  instance_type = var.instance_type\n}\n",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  `"issues":[{"rule":{"name":"aws_instance_example_type","severity":"error","link":""},"message":"instance type is t2.micro","range":{"filename":"main.tf","start":{"line":2,"column":19,"byte":51}`,
+		},
+		{
+			name:    "stdin without --stdin-filename",
+			command: "tflint -",
+			status:  cmd.ExitCodeError,
+			stderr:  "--stdin or --stdin-filename is required to read configuration from stdin with -",
+		},
+		{
+			name:    "with --filename",
+			command: "tflint --filename=other.tf --stdin-filename=main.tf",
+			status:  cmd.ExitCodeError,
+			stderr:  "--filename cannot be used with --stdin-filename",
+		},
+		{
+			name:    "with --recursive",
+			command: "tflint --stdin-filename=main.tf --recursive",
+			status:  cmd.ExitCodeError,
+			stderr:  "--stdin-filename cannot be used with --recursive",
+		},
+	}
+
+	dir, _ := os.Getwd()
+	testDir := filepath.Join(dir, "stdin_filename")
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Chdir(testDir)
+			t.Setenv("TFLINT_IGNORE", "")
+			t.Setenv("GITHUB_ACTIONS", "")
+
+			args := strings.Split(test.command, " ")
+			var command *exec.Cmd
+			if runtime.GOOS == "windows" {
+				command = exec.Command("tflint.exe", args[1:]...)
+			} else {
+				command = exec.Command("tflint", args[1:]...)
+			}
+			outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+			command.Stdin = strings.NewReader(test.stdin)
+			command.Stdout = outStream
+			command.Stderr = errStream
+
+			status := 0
+			if err := command.Run(); err != nil {
+				exitErr, ok := err.(*exec.ExitError)
+				if !ok {
+					t.Fatalf("Failed to exec command: %s", err)
+				}
+				status = exitErr.ExitCode()
+			}
+			if status != test.status {
+				t.Errorf("expected status is %d, but got %d; stderr=%s", test.status, status, errStream.String())
+			}
+			if !strings.Contains(outStream.String(), test.stdout) {
+				t.Errorf("stdout did not contain expected\n\texpected: %s\n\tgot: %s", test.stdout, outStream.String())
+			}
+			if !strings.Contains(errStream.String(), test.stderr) {
+				t.Errorf("stderr did not contain expected\n\texpected: %s\n\tgot: %s", test.stderr, errStream.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+config {
+  minimum_failure_severity = "error"
+}
+
+plugin "testing" {
+  enabled = true
+}
+
+rule "aws_s3_bucket_with_config_example" {
+  enabled = true
+  name = "bucket"
+}
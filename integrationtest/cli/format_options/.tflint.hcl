@@ -0,0 +1,12 @@
+config {
+  format = "compact"
+}
+
+format {
+  name    = "compact"
+  options = { ranges = true }
+}
+
+plugin "testing" {
+  enabled = true
+}
@@ -0,0 +1,8 @@
+plugin "testing" {
+  enabled = true
+}
+
+rule "aws_instance_example_type" {
+  enabled   = true
+  threshold = 6
+}
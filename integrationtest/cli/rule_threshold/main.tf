@@ -0,0 +1,23 @@
+resource "aws_instance" "web1" {
+  instance_type = "t2.micro"
+}
+
+resource "aws_instance" "web2" {
+  instance_type = "t2.micro"
+}
+
+resource "aws_instance" "web3" {
+  instance_type = "t2.micro"
+}
+
+resource "aws_instance" "web4" {
+  instance_type = "t2.micro"
+}
+
+resource "aws_instance" "web5" {
+  instance_type = "t2.micro"
+}
+
+resource "aws_instance" "web6" {
+  instance_type = "t2.micro"
+}
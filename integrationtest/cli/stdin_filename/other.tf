@@ -0,0 +1,3 @@
+resource "aws_instance" "other" {
+  instance_type = "t3.nano"
+}
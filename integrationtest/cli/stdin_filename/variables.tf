@@ -0,0 +1,3 @@
+variable "instance_type" {
+  default = "t2.micro"
+}
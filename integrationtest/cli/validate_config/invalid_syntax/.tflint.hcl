@@ -0,0 +1,2 @@
+plugin "testing" {
+  enabled = true
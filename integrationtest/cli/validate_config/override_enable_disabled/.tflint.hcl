@@ -0,0 +1,15 @@
+plugin "testing" {
+  enabled = true
+}
+
+rule "aws_instance_example_type" {
+  enabled = false
+}
+
+override {
+  path = "modules/**"
+
+  rule "aws_instance_example_type" {
+    enabled = true
+  }
+}
@@ -0,0 +1,7 @@
+config {
+  no_such_attribute = true
+}
+
+plugin "testing" {
+  enabled = true
+}
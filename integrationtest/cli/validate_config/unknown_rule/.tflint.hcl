@@ -0,0 +1,7 @@
+plugin "testing" {
+  enabled = true
+}
+
+rule "nosuchrule" {
+  enabled = true
+}
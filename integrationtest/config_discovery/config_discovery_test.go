@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/terraform-linters/tflint/cmd"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func TestIntegration(t *testing.T) {
+	// Disable the default github format in GitHub Actions
+	t.Setenv("GITHUB_ACTIONS", "")
+
+	// Disable the bundled plugin because the `os.Executable()` is go(1) in the tests
+	tflint.DisableBundledPlugin = true
+	defer func() {
+		tflint.DisableBundledPlugin = false
+	}()
+
+	// The fixture is copied to a temporary directory, and .git directories are created there
+	// because they cannot be committed. The layout is as follows:
+	//
+	//   .git/
+	//   .tflint.hcl          (force = true)
+	//   modules/.tflint.hcl  (aws_instance_example_type is disabled)
+	//   modules/vpc/main.tf
+	//   services/app/main.tf
+	//   vendor_repo/.git/
+	//   vendor_repo/app/main.tf
+	tests := []struct {
+		name    string
+		command string
+		status  int
+		issues  bool
+	}{
+		{
+			name:    "nearest config in parent directories",
+			command: "./tflint --chdir=modules/vpc --enable-plugin=testing",
+			status:  cmd.ExitCodeOK,
+			issues:  false,
+		},
+		{
+			name:    "config in the repository root",
+			command: "./tflint --chdir=services/app --enable-plugin=testing",
+			status:  cmd.ExitCodeOK,
+			issues:  true,
+		},
+		{
+			name:    "stop at the nested repository root",
+			command: "./tflint --chdir=vendor_repo/app --enable-plugin=testing",
+			status:  cmd.ExitCodeIssuesFound,
+			issues:  true,
+		},
+		{
+			name:    "no config discovery",
+			command: "./tflint --chdir=modules/vpc --enable-plugin=testing --no-config-discovery",
+			status:  cmd.ExitCodeIssuesFound,
+			issues:  true,
+		},
+		{
+			name:    "explicit config",
+			command: "./tflint --chdir=modules/vpc --enable-plugin=testing --config={{dir}}/.tflint.hcl",
+			status:  cmd.ExitCodeOK,
+			issues:  true,
+		},
+	}
+
+	src, _ := os.Getwd()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.CopyFS(dir, os.DirFS(filepath.Join(src, "repo"))); err != nil {
+				t.Fatal(err)
+			}
+			for _, gitDir := range []string{".git", filepath.Join("vendor_repo", ".git")} {
+				if err := os.Mkdir(filepath.Join(dir, gitDir), 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			t.Chdir(dir)
+
+			outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+			cli, err := cmd.NewCLI(outStream, errStream)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Relative paths are resolved in both the current directory and --chdir, so use an absolute path
+			command := strings.ReplaceAll(test.command, "{{dir}}", filepath.ToSlash(dir))
+			got := cli.Run(strings.Split(command, " "))
+
+			if got != test.status {
+				t.Errorf("expected status is %d, but got %d: stderr=%s", test.status, got, errStream.String())
+			}
+			if issues := strings.Contains(outStream.String(), "instance type is t2.micro"); issues != test.issues {
+				t.Errorf("expected issues found is %t, but got %t: stdout=%s", test.issues, issues, outStream.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,3 @@
+config {
+  force = true
+}
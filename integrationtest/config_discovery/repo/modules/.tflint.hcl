@@ -0,0 +1,3 @@
+rule "aws_instance_example_type" {
+  enabled = false
+}
@@ -0,0 +1,11 @@
+plugin "testing" {
+  enabled = true
+}
+
+rule "aws_instance_example_type" {
+  enabled = true
+}
+
+rule "terraform_deprecated_index" {
+  enabled = false
+}
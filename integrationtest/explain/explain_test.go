@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/terraform-linters/tflint/cmd"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func TestIntegration(t *testing.T) {
+	// Disable the default github format in GitHub Actions
+	t.Setenv("GITHUB_ACTIONS", "")
+
+	// Disable the bundled plugin because the `os.Executable()` is go(1) in the tests
+	tflint.DisableBundledPlugin = true
+	defer func() {
+		tflint.DisableBundledPlugin = false
+	}()
+
+	tests := []struct {
+		name    string
+		command string
+		status  int
+		stdout  []string
+		stderr  string
+	}{
+		{
+			name:    "built-in rule",
+			command: "./tflint explain terraform_deprecated_index",
+			status:  cmd.ExitCodeOK,
+			stdout: []string{
+				"terraform_deprecated_index\n\n",
+				"Plugin: terraform (bundled)\n",
+				"Severity: warning\n",
+				"Enabled by default: true\n",
+				"Status: disabled\n",
+				"Link: https://github.com/terraform-linters/tflint-ruleset-terraform/blob/",
+				"# terraform_deprecated_index\n",
+				"## Example\n",
+				"## How To Fix\n",
+			},
+		},
+		{
+			name:    "plugin rule",
+			command: "./tflint explain aws_instance_example_type",
+			status:  cmd.ExitCodeOK,
+			stdout: []string{
+				"aws_instance_example_type\n\n",
+				"Plugin: testing\n",
+				"Status: enabled\n",
+				"The plugin does not provide details of the rule.",
+			},
+		},
+		{
+			name:    "not found",
+			command: "./tflint explain unknown_rule",
+			status:  cmd.ExitCodeError,
+			stderr:  `Rule "unknown_rule" not found; run --list-rules to see available rules`,
+		},
+		{
+			name:    "no rule name",
+			command: "./tflint explain",
+			status:  cmd.ExitCodeError,
+			stderr:  "Usage: tflint explain RULE_NAME",
+		},
+	}
+
+	dir, _ := os.Getwd()
+	t.Chdir(filepath.Join(dir, "basic"))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+			cli, err := cmd.NewCLI(outStream, errStream)
+			if err != nil {
+				t.Fatal(err)
+			}
+			status := cli.Run(strings.Split(test.command, " "))
+			if status != test.status {
+				t.Fatalf("expected status is %d, but got %d: stderr=%s", test.status, status, errStream.String())
+			}
+
+			for _, want := range test.stdout {
+				if !strings.Contains(outStream.String(), want) {
+					t.Errorf("stdout does not contain %q: %s", want, outStream.String())
+				}
+			}
+			if !strings.Contains(errStream.String(), test.stderr) {
+				t.Errorf("stderr does not contain %q: %s", test.stderr, errStream.String())
+			}
+		})
+	}
+}
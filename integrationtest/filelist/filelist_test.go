@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/terraform-linters/tflint/formatter"
+)
+
+func TestIntegration(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		// list is the content of the file list. {{dir}} is replaced with the absolute path of the module.
+		list   string
+		stdin  bool
+		result string
+	}{
+		{
+			name:    "file list",
+			command: "tflint --format json --force --file-list={{list}}",
+			list:    "# generated by scaffolding\n\nmain.tf\n",
+			result:  "result.json",
+		},
+		{
+			name:    "absolute paths",
+			command: "tflint --format json --force --file-list={{list}}",
+			list:    filepath.Join("{{dir}}", "other.tf"),
+			result:  "result_other.json",
+		},
+		{
+			name:    "file list + filter",
+			command: "tflint --format json --force --file-list={{list}} --filter=other.tf",
+			list:    "main.tf\nother.tf\n",
+			result:  "result_other.json",
+		},
+		{
+			name:    "stdin",
+			command: "tflint --format json --force --file-list=-",
+			list:    "other.tf\n",
+			stdin:   true,
+			result:  "result_other.json",
+		},
+		{
+			name:    "file list + chdir",
+			command: "tflint --chdir=subdir --format json --force --file-list={{list}}",
+			list:    "main.tf\n",
+			result:  "result_chdir.json",
+		},
+		{
+			name:    "file list + recursive",
+			command: "tflint --recursive --format json --force --file-list={{list}}",
+			list:    "main.tf\nsubdir/main.tf\n",
+			result:  "result_recursive.json",
+		},
+		{
+			name:    "no listed files",
+			command: "tflint --format json --force --file-list={{list}}",
+			list:    "# nothing to inspect\n",
+			result:  "result_empty.json",
+		},
+	}
+
+	dir, _ := os.Getwd()
+	testDir := filepath.Join(dir, "module")
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Chdir(testDir)
+			// Do not use ignore patterns set in the environment running the tests
+			t.Setenv("TFLINT_IGNORE", "")
+
+			list := strings.ReplaceAll(test.list, "{{dir}}", testDir)
+			listFile := filepath.Join(t.TempDir(), "files.txt")
+			if err := os.WriteFile(listFile, []byte(list), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			args := strings.Split(strings.ReplaceAll(test.command, "{{list}}", listFile), " ")
+			var cmd *exec.Cmd
+			if runtime.GOOS == "windows" {
+				cmd = exec.Command("tflint.exe", args[1:]...)
+			} else {
+				cmd = exec.Command("tflint", args[1:]...)
+			}
+			outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+			cmd.Stdout = outStream
+			cmd.Stderr = errStream
+			if test.stdin {
+				cmd.Stdin = strings.NewReader(list)
+			}
+
+			if err := cmd.Run(); err != nil {
+				t.Fatalf("Failed to exec command: %s; stderr=%s", err, errStream.String())
+			}
+
+			result := test.result
+			windowsResult := strings.TrimSuffix(result, ".json") + "_windows.json"
+			if runtime.GOOS == "windows" && IsWindowsResultExist(windowsResult) {
+				result = windowsResult
+			}
+			b, err := os.ReadFile(filepath.Join(testDir, result))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var expected *formatter.JSONOutput
+			if err := json.Unmarshal(b, &expected); err != nil {
+				t.Fatal(err)
+			}
+
+			var got *formatter.JSONOutput
+			if err := json.Unmarshal(outStream.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			opt := cmpopts.IgnoreFields(formatter.JSONRule{}, "Link")
+			if diff := cmp.Diff(got, expected, opt); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func IsWindowsResultExist(name string) bool {
+	_, err := os.Stat(name)
+	return !os.IsNotExist(err)
+}
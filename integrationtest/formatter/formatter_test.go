@@ -0,0 +1,338 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/terraform-linters/tflint/cmd"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+type meta struct {
+	Version string
+}
+
+func TestIntegration(t *testing.T) {
+	// Disable the bundled plugin because the `os.Executable()` is go(1) in the tests
+	tflint.DisableBundledPlugin = true
+	defer func() {
+		tflint.DisableBundledPlugin = false
+	}()
+
+	tests := []struct {
+		name    string
+		command string
+		dir     string
+		env     map[string]string
+		status  int
+		result  string
+	}{
+		{
+			name:    "junit with no issues",
+			command: "./tflint --format junit",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeOK,
+			result:  "junit.xml",
+		},
+		{
+			name:    "junit with issues",
+			command: "./tflint --format junit",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			result:  "junit.xml",
+		},
+		{
+			name:    "junit with load errors",
+			command: "./tflint --format junit",
+			dir:     "load_errors",
+			status:  cmd.ExitCodeError,
+			result:  "junit.xml",
+		},
+		{
+			name:    "gitlab with issues",
+			command: "./tflint --format gitlab",
+			dir:     "issues_found",
+			// Paths are relative to the project dir, not the working directory
+			env:    map[string]string{"CI_PROJECT_DIR": ".."},
+			status: cmd.ExitCodeIssuesFound,
+			result: "gitlab.json",
+		},
+		{
+			name:    "gitlab with load errors",
+			command: "./tflint --format gitlab",
+			dir:     "load_errors",
+			// Paths are relative to the project dir, not the working directory
+			env:    map[string]string{"CI_PROJECT_DIR": ".."},
+			status: cmd.ExitCodeError,
+			result: "gitlab.json",
+		},
+		{
+			name:    "rdjson with issues",
+			command: "./tflint --format rdjson",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			result:  "rdjson.json",
+		},
+		{
+			name:    "rdjson with load errors",
+			command: "./tflint --format rdjson",
+			dir:     "load_errors",
+			status:  cmd.ExitCodeError,
+			result:  "rdjson.json",
+		},
+		{
+			name:    "tap with no issues",
+			command: "./tflint --format tap",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeOK,
+			result:  "tap.txt",
+		},
+		{
+			name:    "tap with issues",
+			command: "./tflint --format tap",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			result:  "tap.txt",
+		},
+		{
+			name:    "tap with load errors",
+			command: "./tflint --format tap",
+			dir:     "load_errors",
+			status:  cmd.ExitCodeError,
+			result:  "tap.txt",
+		},
+		{
+			name:    "csv with no issues",
+			command: "./tflint --format csv",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeOK,
+			result:  "result.csv",
+		},
+		{
+			name:    "csv with issues",
+			command: "./tflint --format csv",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			result:  "result.csv",
+		},
+		{
+			name:    "markdown with issues",
+			command: "./tflint --format markdown",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			result:  "result.md",
+		},
+		{
+			name:    "markdown with load errors",
+			command: "./tflint --format markdown",
+			dir:     "load_errors",
+			status:  cmd.ExitCodeError,
+			result:  "result.md",
+		},
+		{
+			name:    "azure-devops with issues",
+			command: "./tflint --format azure-devops",
+			dir:     "issues_found",
+			env:     map[string]string{"BUILD_SOURCESDIRECTORY": ""},
+			status:  cmd.ExitCodeIssuesFound,
+			result:  "azure-devops.txt",
+		},
+		{
+			name:    "azure-devops with load errors",
+			command: "./tflint --format azure-devops",
+			dir:     "load_errors",
+			env:     map[string]string{"BUILD_SOURCESDIRECTORY": ""},
+			status:  cmd.ExitCodeError,
+			result:  "azure-devops.txt",
+		},
+		{
+			name:    "teamcity with issues",
+			command: "./tflint --format teamcity",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			result:  "teamcity.txt",
+		},
+		{
+			name:    "teamcity with load errors",
+			command: "./tflint --format teamcity",
+			dir:     "load_errors",
+			status:  cmd.ExitCodeError,
+			result:  "teamcity.txt",
+		},
+		{
+			name:    "sonarqube with issues",
+			command: "./tflint --format sonarqube",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			result:  "sonarqube.json",
+		},
+		{
+			name:    "sonarqube with severity mappings",
+			command: "./tflint --format sonarqube --sonarqube-severity=error=BLOCKER",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			result:  "sonarqube_severity.json",
+		},
+		{
+			name:    "template with issues",
+			command: "./tflint --format template --format-template-file format.tmpl",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			result:  "template.txt",
+		},
+		{
+			name:    "template with load errors",
+			command: "./tflint --format template --format-template-file format.tmpl",
+			dir:     "load_errors",
+			status:  cmd.ExitCodeError,
+			result:  "template.txt",
+		},
+		{
+			name:    "jsonl with issues",
+			command: "./tflint --format jsonl",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			result:  "jsonl.jsonl",
+		},
+		{
+			name:    "jsonl with load errors",
+			command: "./tflint --format jsonl",
+			dir:     "load_errors",
+			status:  cmd.ExitCodeError,
+			result:  "jsonl.jsonl",
+		},
+		{
+			name:    "stream with issues",
+			command: "./tflint --format stream",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			result:  "stream.jsonl",
+		},
+		{
+			name:    "stream with load errors",
+			command: "./tflint --format stream",
+			dir:     "load_errors",
+			status:  cmd.ExitCodeError,
+			result:  "stream.jsonl",
+		},
+		{
+			name:    "unix with no issues",
+			command: "./tflint --format unix",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeOK,
+			result:  "unix.txt",
+		},
+		{
+			name:    "unix with issues",
+			command: "./tflint --format unix",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			result:  "unix.txt",
+		},
+		{
+			name:    "unix with load errors",
+			command: "./tflint --format unix",
+			dir:     "load_errors",
+			status:  cmd.ExitCodeError,
+			result:  "unix.txt",
+		},
+		{
+			name:    "compact with severity prefixes",
+			command: "./tflint --format compact --format-option severity_prefix=true",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			result:  "compact_prefixed.txt",
+		},
+		{
+			name:    "github with issues",
+			command: "./tflint --format github",
+			dir:     "issues_found",
+			env:     map[string]string{"GITHUB_WORKSPACE": ""},
+			status:  cmd.ExitCodeIssuesFound,
+			result:  "github.txt",
+		},
+		{
+			name:    "github with load errors",
+			command: "./tflint --format github",
+			dir:     "load_errors",
+			env:     map[string]string{"GITHUB_WORKSPACE": ""},
+			status:  cmd.ExitCodeError,
+			result:  "github.txt",
+		},
+		{
+			name:    "github by default in GitHub Actions",
+			command: "./tflint",
+			dir:     "issues_found",
+			env:     map[string]string{"GITHUB_ACTIONS": "true", "GITHUB_WORKSPACE": ""},
+			status:  cmd.ExitCodeIssuesFound,
+			result:  "github.txt",
+		},
+		{
+			name:    "explicit format in GitHub Actions",
+			command: "./tflint --format junit",
+			dir:     "issues_found",
+			env:     map[string]string{"GITHUB_ACTIONS": "true", "GITHUB_WORKSPACE": ""},
+			status:  cmd.ExitCodeIssuesFound,
+			result:  "junit.xml",
+		},
+	}
+
+	dir, _ := os.Getwd()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testDir := filepath.Join(dir, test.dir)
+			t.Chdir(testDir)
+			for k, v := range test.env {
+				t.Setenv(k, v)
+			}
+
+			outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+			cli, err := cmd.NewCLI(outStream, errStream)
+			if err != nil {
+				t.Fatal(err)
+			}
+			args := strings.Split(test.command, " ")
+
+			got := cli.Run(args)
+
+			if got != test.status {
+				t.Errorf("expected status is %d, but got %d", test.status, got)
+			}
+
+			want, err := readResultFile(filepath.Join(testDir, test.result))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, outStream.String()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+// readResultFile reads the golden file. Files are rendered as templates
+// so that version-dependent output can be expressed as {{.Version}}.
+func readResultFile(path string) (string, error) {
+	tmpl, err := template.ParseFiles(path)
+	if err != nil {
+		return "", err
+	}
+	want := new(bytes.Buffer)
+	if err := tmpl.Execute(want, meta{Version: tflint.Version.String()}); err != nil {
+		return "", err
+	}
+	return want.String(), nil
+}
@@ -12,6 +12,9 @@ import (
 )
 
 func TestIntegration(t *testing.T) {
+	// Disable the default github format in GitHub Actions
+	t.Setenv("GITHUB_ACTIONS", "")
+
 	// Disable the bundled plugin because the `os.Executable()` is go(1) in the tests
 	tflint.DisableBundledPlugin = true
 	defer func() {
@@ -0,0 +1,13 @@
+resource "aws_instance" "foo" {
+  instance_type = "t2.micro" # tflint-ignore: aws_instance_example_type reason separated by space
+}
+
+resource "aws_instance" "bar" {
+  # tflint-ignore: aws_instance_exmaple_type
+  instance_type = "t2.micro"
+}
+
+resource "aws_instance" "baz" {
+  # tflint-ignore: aws_instance_example_type terraform_required_providers
+  instance_type = "t2.micro"
+}
@@ -29,17 +29,26 @@ type meta struct {
 }
 
 func TestIntegration(t *testing.T) {
+	// Disable the default github format in GitHub Actions
+	t.Setenv("GITHUB_ACTIONS", "")
+
 	cases := []struct {
 		Name    string
 		Command string
 		Env     map[string]string
 		Dir     string
+		Result  string
 	}{
 		{
 			Name:    "basic",
 			Command: "./tflint --format json",
 			Dir:     "basic",
 		},
+		{
+			Name:    "annotations",
+			Command: "./tflint --format json",
+			Dir:     "annotation",
+		},
 		{
 			Name:    "override",
 			Command: "./tflint --format json",
@@ -120,6 +129,12 @@ func TestIntegration(t *testing.T) {
 			Command: "./tflint --format json",
 			Dir:     "dynblock",
 		},
+		{
+			Name:    "dynamic blocks without deduplication",
+			Command: "./tflint --format json --no-deduplicate",
+			Dir:     "dynblock",
+			Result:  "result_no_deduplicate.json",
+		},
 		{
 			Name:    "unknown dynamic blocks",
 			Command: "./tflint --format json",
@@ -135,6 +150,31 @@ func TestIntegration(t *testing.T) {
 			Command: "./tflint --format json",
 			Dir:     "rule-config",
 		},
+		{
+			Name:    "override config with exact path",
+			Command: "./tflint --format json",
+			Dir:     "override-config-exact",
+		},
+		{
+			Name:    "override config with glob path",
+			Command: "./tflint --format json",
+			Dir:     "override-config-glob",
+		},
+		{
+			Name:    "override config without matching files",
+			Command: "./tflint --format json",
+			Dir:     "override-config-no-match",
+		},
+		{
+			Name:    "override config enabling disabled rules",
+			Command: "./tflint --format json",
+			Dir:     "override-config-enable-disabled",
+		},
+		{
+			Name:    "rule severity override",
+			Command: "./tflint --format json",
+			Dir:     "rule-severity",
+		},
 		{
 			Name:    "disabled rules",
 			Command: "./tflint --format json",
@@ -239,7 +279,7 @@ func TestIntegration(t *testing.T) {
 
 			cli.Run(args)
 
-			rawWant, err := readResultFile(testDir)
+			rawWant, err := readResultFile(testDir, tc.Result)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -260,11 +300,14 @@ func TestIntegration(t *testing.T) {
 	}
 }
 
-func readResultFile(dir string) ([]byte, error) {
-	resultFile := "result.json"
+func readResultFile(dir string, resultFile string) ([]byte, error) {
+	if resultFile == "" {
+		resultFile = "result.json"
+	}
 	if runtime.GOOS == "windows" {
-		if _, err := os.Stat(filepath.Join(dir, "result_windows.json")); !os.IsNotExist(err) {
-			resultFile = "result_windows.json"
+		windowsResultFile := strings.TrimSuffix(resultFile, ".json") + "_windows.json"
+		if _, err := os.Stat(filepath.Join(dir, windowsResultFile)); !os.IsNotExist(err) {
+			resultFile = windowsResultFile
 		}
 	}
 	if _, err := os.Stat(fmt.Sprintf("%s.tmpl", resultFile)); !os.IsNotExist(err) {
@@ -0,0 +1,16 @@
+plugin "testing" {
+  enabled = true
+}
+
+rule "aws_instance_example_type" {
+  enabled = false
+}
+
+// Disabled rules are not run, so they cannot be enabled only for some files
+override {
+  path = "main.tf"
+
+  rule "aws_instance_example_type" {
+    enabled = true
+  }
+}
@@ -0,0 +1,20 @@
+plugin "testing" {
+  enabled = true
+}
+
+override {
+  path = "legacy_*.tf"
+
+  rule "aws_instance_example_type" {
+    enabled = false
+  }
+}
+
+// More specific overrides take precedence
+override {
+  path = "legacy_web.tf"
+
+  rule "aws_instance_example_type" {
+    enabled = true
+  }
+}
@@ -0,0 +1,3 @@
+resource "aws_instance" "legacy_db" {
+  instance_type = "t2.micro"
+}
@@ -0,0 +1,3 @@
+resource "aws_instance" "legacy_web" {
+  instance_type = "t2.micro"
+}
@@ -0,0 +1,11 @@
+plugin "testing" {
+  enabled = true
+}
+
+override {
+  path = "modules/**"
+
+  rule "aws_instance_example_type" {
+    enabled = false
+  }
+}
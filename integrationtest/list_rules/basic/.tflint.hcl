@@ -0,0 +1,11 @@
+plugin "testing" {
+  enabled = true
+}
+
+rule "aws_instance_example_type" {
+  enabled = true
+}
+
+rule "aws_s3_bucket_with_config_example" {
+  enabled = false
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/terraform-linters/tflint/cmd"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+type listedRule struct {
+	Plugin string `json:"plugin"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+func TestIntegration(t *testing.T) {
+	// Disable the default github format in GitHub Actions
+	t.Setenv("GITHUB_ACTIONS", "")
+
+	// Disable the bundled plugin because the `os.Executable()` is go(1) in the tests
+	tflint.DisableBundledPlugin = true
+	defer func() {
+		tflint.DisableBundledPlugin = false
+	}()
+
+	tests := []struct {
+		name    string
+		command string
+		// want is the expected status of each rule. Rules not listed here must be "default".
+		want map[string]string
+		// absent is rules that must not be printed
+		absent []string
+	}{
+		{
+			name:    "rule blocks",
+			command: "./tflint --list-rules --format json",
+			want: map[string]string{
+				"aws_instance_example_type":         "enabled",
+				"aws_s3_bucket_with_config_example": "disabled",
+			},
+		},
+		{
+			name:    "CLI flags",
+			command: "./tflint --list-rules --format json --disable-rule aws_instance_example_type --enable-rule aws_iam_policy_example",
+			want: map[string]string{
+				"aws_instance_example_type":         "disabled",
+				"aws_s3_bucket_with_config_example": "disabled",
+				"aws_iam_policy_example":            "enabled",
+			},
+		},
+		{
+			name:    "only",
+			command: "./tflint --list-rules --format json --only aws_iam_policy_example",
+			want: map[string]string{
+				"aws_iam_policy_example": "enabled",
+			},
+		},
+		{
+			name:    "enabled only",
+			command: "./tflint --list-rules --format json --enabled-only",
+			want: map[string]string{
+				"aws_instance_example_type": "enabled",
+			},
+			absent: []string{"aws_s3_bucket_with_config_example"},
+		},
+	}
+
+	dir, _ := os.Getwd()
+	t.Chdir(filepath.Join(dir, "basic"))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+			cli, err := cmd.NewCLI(outStream, errStream)
+			if err != nil {
+				t.Fatal(err)
+			}
+			status := cli.Run(strings.Split(test.command, " "))
+			if status != cmd.ExitCodeOK {
+				t.Fatalf("expected status is %d, but got %d: stderr=%s", cmd.ExitCodeOK, status, errStream.String())
+			}
+
+			var rules []listedRule
+			if err := json.Unmarshal(outStream.Bytes(), &rules); err != nil {
+				t.Fatalf("failed to parse output: %s: %s", err, outStream.String())
+			}
+			if len(rules) == 0 {
+				t.Fatal("no rules are printed")
+			}
+
+			got := map[string]string{}
+			for _, rule := range rules {
+				if rule.Plugin != "testing" {
+					t.Errorf("unexpected plugin: %s", rule.Plugin)
+				}
+				got[rule.Name] = rule.Status
+			}
+			for name, status := range test.want {
+				if got[name] != status {
+					t.Errorf("expected %s is %q, but got %q", name, status, got[name])
+				}
+			}
+			for name, status := range got {
+				if _, exists := test.want[name]; exists {
+					continue
+				}
+				// In only mode, other rules are disabled
+				if strings.Contains(test.command, "--only") {
+					if status != "disabled" {
+						t.Errorf("expected %s is disabled, but got %q", name, status)
+					}
+				} else if status != "default" {
+					t.Errorf("expected %s is default, but got %q", name, status)
+				}
+			}
+			for _, name := range test.absent {
+				if _, exists := got[name]; exists {
+					t.Errorf("%s should not be printed", name)
+				}
+			}
+		})
+	}
+}
+
+func TestIntegration_table(t *testing.T) {
+	// Disable the bundled plugin because the `os.Executable()` is go(1) in the tests
+	tflint.DisableBundledPlugin = true
+	defer func() {
+		tflint.DisableBundledPlugin = false
+	}()
+
+	dir, _ := os.Getwd()
+	t.Chdir(filepath.Join(dir, "basic"))
+
+	outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+	cli, err := cmd.NewCLI(outStream, errStream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := cli.Run([]string{"./tflint", "--list-rules"})
+	if status != cmd.ExitCodeOK {
+		t.Fatalf("expected status is %d, but got %d: stderr=%s", cmd.ExitCodeOK, status, errStream.String())
+	}
+
+	lines := strings.Split(strings.TrimSuffix(outStream.String(), "\n"), "\n")
+	if fields := strings.Fields(lines[0]); strings.Join(fields, " ") != "PLUGIN RULE STATUS" {
+		t.Errorf("unexpected header: %s", lines[0])
+	}
+	found := false
+	for _, line := range lines[1:] {
+		if strings.Join(strings.Fields(line), " ") == "testing aws_instance_example_type enabled" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("aws_instance_example_type is not printed as enabled: %s", outStream.String())
+	}
+}
@@ -0,0 +1,5 @@
+module "shared" {
+  source = "../modules/shared"
+
+  name = "a"
+}
@@ -0,0 +1,9 @@
+variable "name" {
+  type = string
+}
+
+resource "aws_instance" "main" {
+  tags = {
+    Name = var.name
+  }
+}
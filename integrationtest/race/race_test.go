@@ -14,8 +14,10 @@ import (
 	"text/template"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
 	"github.com/terraform-linters/tflint/cmd"
 	"github.com/terraform-linters/tflint/formatter"
+	"github.com/terraform-linters/tflint/terraform"
 	"github.com/terraform-linters/tflint/tflint"
 )
 
@@ -84,6 +86,46 @@ func TestIntegration(t *testing.T) {
 	}
 }
 
+func TestLoadInParallel(t *testing.T) {
+	// Loaders for different directories share a cache and run in parallel.
+	// They read files from the base directories without changing the current directory.
+	dirs := []string{"a", "b", "c"}
+	cache := terraform.NewLoaderCache()
+
+	ch := make(chan error, len(dirs)*4)
+	for range 4 {
+		for _, dir := range dirs {
+			go func(dir string) {
+				ch <- loadInBaseDir(filepath.Join("load_in_parallel", dir), cache)
+			}(dir)
+		}
+	}
+	for range len(dirs) * 4 {
+		if err := <-ch; err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func loadInBaseDir(baseDir string, cache *terraform.LoaderCache) error {
+	loader, err := terraform.NewLoaderWithBaseDir(afero.Afero{Fs: afero.NewOsFs()}, baseDir, cache)
+	if err != nil {
+		return err
+	}
+	if _, diags := loader.LoadConfig(".", terraform.CallLocalModule); diags.HasErrors() {
+		return diags
+	}
+
+	want := []string{
+		filepath.Join(baseDir, "main.tf"),
+		filepath.Join("load_in_parallel", "modules", "shared", "main.tf"),
+	}
+	if diff := cmp.Diff(want, loader.ScannedFiles()); diff != "" {
+		return fmt.Errorf("%s: %s", baseDir, diff)
+	}
+	return nil
+}
+
 func readResultFile(dir string) ([]byte, error) {
 	resultFile := "result.json"
 	if runtime.GOOS == "windows" {
@@ -0,0 +1,3 @@
+resource "aws_instance" "existing" {
+  instance_type = "t2.micro"
+}
@@ -0,0 +1,3 @@
+resource "aws_instance" "new" {
+  instance_type = "t3.micro"
+}
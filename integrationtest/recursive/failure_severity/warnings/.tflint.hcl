@@ -0,0 +1,17 @@
+# Applied only to issues in this directory in recursive inspection, unless the flag is passed.
+config {
+  minimum_failure_severity = "error"
+}
+
+plugin "terraform" {
+  enabled = false
+}
+
+plugin "testing" {
+  enabled = true
+}
+
+rule "aws_s3_bucket_with_config_example" {
+  enabled = true
+  name = "bucket"
+}
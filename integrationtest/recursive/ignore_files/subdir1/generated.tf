@@ -0,0 +1,3 @@
+resource "aws_instance" "generated" {
+  instance_type = "t2.micro"
+}
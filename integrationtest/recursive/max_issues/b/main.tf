@@ -0,0 +1,7 @@
+resource "aws_instance" "foo" {
+  instance_type = "t2.micro"
+}
+
+resource "aws_instance" "bar" {
+  instance_type = "t2.micro"
+}
@@ -6,22 +6,42 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"slices"
 	"strings"
 	"testing"
+	"text/template"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/terraform-linters/tflint/formatter"
+	"github.com/terraform-linters/tflint/tflint"
 )
 
+// stripLogs removes log lines from details of errors, which are stderr of workers.
+// Logs of loading configs are always printed, but they include timestamps.
+var stripLogs = cmp.Transformer("StripLogs", func(e formatter.JSONError) formatter.JSONError {
+	lines := strings.SplitAfter(e.Detail, "\n")
+	e.Detail = strings.Join(slices.DeleteFunc(lines, func(line string) bool {
+		return logLine.MatchString(line)
+	}), "")
+	return e
+})
+
+var logLine = regexp.MustCompile(`^\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2} \[[A-Z]+\]`)
+
 func TestIntegration(t *testing.T) {
 	tests := []struct {
-		name        string
-		command     string
-		dir         string
-		error       bool
-		ignoreOrder bool
+		name    string
+		command string
+		dir     string
+		env     map[string]string
+		result  string
+		error   bool
+		// symlinks is true if the fixture has symlinks, which are not checked out as symlinks on Windows
+		symlinks bool
 	}{
 		{
 			name:    "recursive",
@@ -34,25 +54,137 @@ func TestIntegration(t *testing.T) {
 			dir:     "filter",
 		},
 		{
-			name:        "recursive with errors",
-			command:     "tflint --recursive --format json --force",
-			dir:         "errors",
-			error:       true,
-			ignoreOrder: true,
+			name:    "recursive with errors",
+			command: "tflint --recursive --format json --force",
+			dir:     "errors",
+			error:   true,
 		},
 		{
 			name:    "recursive + chdir",
 			command: "tflint --chdir=subdir1 --recursive --format json --force",
 			dir:     "chdir",
 		},
+		{
+			name:    "multiple chdir",
+			command: "tflint --chdir=subdir1 --chdir=subdir2 --format json --force",
+			dir:     "basic",
+		},
+		{
+			name:    "multiple chdir + filter",
+			command: "tflint --chdir=subdir1 --chdir=subdir2 --filter=main.tf --format json --force",
+			dir:     "filter",
+		},
+		{
+			name:    "recursive + max-depth=0",
+			command: "tflint --recursive --max-depth=0 --format json --force",
+			dir:     "max_depth",
+			result:  "result_depth0.json",
+		},
+		{
+			name:    "recursive + max-depth=1",
+			command: "tflint --recursive --max-depth=1 --format json --force",
+			dir:     "max_depth",
+			result:  "result_depth1.json",
+		},
+		{
+			name:    "recursive + max-depth=2",
+			command: "tflint --recursive --max-depth=2 --format json --force",
+			dir:     "max_depth",
+			result:  "result_depth2.json",
+		},
+		{
+			name:    "recursive without max-depth",
+			command: "tflint --recursive --format json --force",
+			dir:     "max_depth",
+		},
+		{
+			name:    "recursive + baseline",
+			command: "tflint --recursive --baseline=tflint-baseline.json --format json --force",
+			dir:     "baseline",
+		},
+		{
+			name:    "recursive + tflintignore",
+			command: "tflint --recursive --format json --force",
+			dir:     "ignore",
+		},
+		{
+			name:    "recursive + nested tflintignore",
+			command: "tflint --recursive --format json --force",
+			dir:     "ignore_files",
+		},
+		{
+			name:    "recursive + TFLINT_IGNORE + no-tflintignore",
+			command: "tflint --recursive --no-tflintignore --format json --force",
+			dir:     "ignore",
+			env:     map[string]string{"TFLINT_IGNORE": "modules"},
+			result:  "result_env.json",
+		},
+		{
+			name:    "recursive + chdir + path-mode=relative",
+			command: "tflint --chdir=subdir1 --recursive --path-mode=relative --format json --force",
+			dir:     "chdir",
+			result:  "result_relative.json",
+		},
+		{
+			name:    "recursive + chdir + path-mode=absolute",
+			command: "tflint --chdir=subdir1 --recursive --path-mode=absolute --format json --force",
+			dir:     "chdir",
+			result:  "result_absolute.json",
+		},
+		{
+			name:    "recursive + statistics",
+			command: "tflint --recursive --statistics --format json --force",
+			dir:     "basic",
+			result:  "result_statistics.json",
+		},
+		{
+			name:    "recursive + exclude-dir",
+			command: "tflint --recursive --exclude-dir=subdir2 --statistics --format json --force",
+			dir:     "basic",
+			result:  "result_exclude_dir.json",
+		},
+		{
+			name:     "recursive + follow-symlinks",
+			command:  "tflint --recursive --follow-symlinks --format json --force",
+			dir:      "symlinks",
+			symlinks: true,
+		},
+		{
+			name:     "recursive without follow-symlinks",
+			command:  "tflint --recursive --format json --force",
+			dir:      "symlinks",
+			result:   "result_no_follow.json",
+			symlinks: true,
+		},
+		{
+			name:    "recursive + group_by_dir",
+			command: "tflint --recursive --format json --format-option group_by_dir=true --force",
+			dir:     "basic",
+			result:  "result_group_by_dir.json",
+		},
+		{
+			name:    "recursive with errors + group_by_dir",
+			command: "tflint --recursive --format json --format-option group_by_dir=true --force",
+			dir:     "errors",
+			result:  "result_group_by_dir.json",
+			error:   true,
+		},
 	}
 
 	dir, _ := os.Getwd()
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
+			if test.symlinks && runtime.GOOS == "windows" {
+				t.Skip("symlinks are not checked out on Windows")
+			}
 			testDir := filepath.Join(dir, test.dir)
 			t.Chdir(testDir)
+			// Do not use ignore patterns set in the environment running the tests
+			t.Setenv("TFLINT_IGNORE", "")
+			for k, v := range test.env {
+				t.Setenv(k, v)
+			}
 
 			args := strings.Split(test.command, " ")
 			var cmd *exec.Cmd
@@ -69,16 +201,28 @@ func TestIntegration(t *testing.T) {
 				t.Fatalf("Failed to exec command: %s", err)
 			}
 
+			result := test.result
+			if result == "" {
+				result = "result.json"
+			}
+			windowsResult := strings.TrimSuffix(result, ".json") + "_windows.json"
+
 			var b []byte
 			var err error
-			if runtime.GOOS == "windows" && IsWindowsResultExist() {
-				b, err = os.ReadFile(filepath.Join(testDir, "result_windows.json"))
+			if runtime.GOOS == "windows" && IsWindowsResultExist(windowsResult) {
+				b, err = os.ReadFile(filepath.Join(testDir, windowsResult))
 			} else {
-				b, err = os.ReadFile(filepath.Join(testDir, "result.json"))
+				b, err = os.ReadFile(filepath.Join(testDir, result))
 			}
 			if err != nil {
 				t.Fatal(err)
 			}
+			// Results of absolute paths contain the test directory
+			escapedDir, err := json.Marshal(testDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b = bytes.ReplaceAll(b, []byte("{{dir}}"), bytes.Trim(escapedDir, `"`))
 
 			var expected *formatter.JSONOutput
 			if err := json.Unmarshal(b, &expected); err != nil {
@@ -92,19 +236,213 @@ func TestIntegration(t *testing.T) {
 
 			opts := []cmp.Option{
 				cmpopts.IgnoreFields(formatter.JSONRule{}, "Link"),
-				// Only compare error messages up to the double new line.
-				// After this, stderr will be printed which is verbose.
-				cmp.Transformer("TruncateMessage", func(e formatter.JSONError) formatter.JSONError {
-					if parts := strings.Split(e.Message, "\n\n"); len(parts) > 1 {
-						e.Message = parts[0]
+				// The duration depends on the environment
+				cmpopts.IgnoreFields(formatter.JSONStatistics{}, "DurationMS"),
+				stripLogs,
+			}
+			if diff := cmp.Diff(got, expected, opts...); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestIntegrationSARIF(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		dir     string
+		result  string
+	}{
+		{
+			name:    "recursive + sarif",
+			command: "tflint --recursive --format sarif --force",
+			dir:     "sarif",
+		},
+		{
+			name:    "recursive + sarif + path-mode=absolute",
+			command: "tflint --recursive --path-mode=absolute --format sarif --force",
+			dir:     "sarif",
+			result:  "result_absolute.sarif.tmpl",
+		},
+	}
+
+	dir, _ := os.Getwd()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testDir := filepath.Join(dir, test.dir)
+			t.Chdir(testDir)
+
+			args := strings.Split(test.command, " ")
+			var cmd *exec.Cmd
+			if runtime.GOOS == "windows" {
+				cmd = exec.Command("tflint.exe", args[1:]...)
+			} else {
+				cmd = exec.Command("tflint", args[1:]...)
+			}
+			outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+			cmd.Stdout = outStream
+			cmd.Stderr = errStream
+
+			if err := cmd.Run(); err != nil {
+				t.Fatalf("Failed to exec command: %s", err)
+			}
+
+			result := test.result
+			if result == "" {
+				result = "result.sarif.tmpl"
+			}
+			// SARIF always uses forward slashes, so there is no Windows-specific result
+			tmpl := template.Must(template.ParseFiles(filepath.Join(testDir, result)))
+			dirURI := filepath.ToSlash(testDir)
+			if !strings.HasPrefix(dirURI, "/") {
+				dirURI = "/" + dirURI
+			}
+			data := struct {
+				Version string
+				DirURI  string
+			}{
+				Version: tflint.Version.String(),
+				DirURI:  "file://" + dirURI,
+			}
+			want := new(bytes.Buffer)
+			if err := tmpl.Execute(want, data); err != nil {
+				t.Fatal(err)
+			}
+
+			var expected map[string]any
+			if err := json.Unmarshal(want.Bytes(), &expected); err != nil {
+				t.Fatal(err)
+			}
+
+			var got map[string]any
+			if err := json.Unmarshal(outStream.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(got, expected); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestIntegrationJSONL(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		dir     string
+		error   bool
+		// If ordered, lines must be printed in the order of directories
+		ordered bool
+	}{
+		{
+			name:    "recursive + jsonl",
+			command: "tflint --recursive --format jsonl --force",
+			dir:     "basic",
+		},
+		{
+			name:    "recursive + jsonl with errors",
+			command: "tflint --recursive --format jsonl --force",
+			dir:     "errors",
+			error:   true,
+		},
+		{
+			name:    "recursive + jsonl + ordered",
+			command: "tflint --recursive --format jsonl --force --ordered",
+			dir:     "basic",
+			ordered: true,
+		},
+		{
+			name:    "recursive + jsonl + ordered with errors",
+			command: "tflint --recursive --format jsonl --force --ordered",
+			dir:     "errors",
+			error:   true,
+			ordered: true,
+		},
+	}
+
+	dir, _ := os.Getwd()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testDir := filepath.Join(dir, test.dir)
+			t.Chdir(testDir)
+			t.Setenv("TFLINT_IGNORE", "")
+
+			args := strings.Split(test.command, " ")
+			var cmd *exec.Cmd
+			if runtime.GOOS == "windows" {
+				cmd = exec.Command("tflint.exe", args[1:]...)
+			} else {
+				cmd = exec.Command("tflint", args[1:]...)
+			}
+			outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+			cmd.Stdout = outStream
+			cmd.Stderr = errStream
+
+			if err := cmd.Run(); err != nil && !test.error {
+				t.Fatalf("Failed to exec command: %s", err)
+			}
+
+			// Results are the same as the json format, but streamed line by line
+			result := "result.json"
+			if runtime.GOOS == "windows" && IsWindowsResultExist("result_windows.json") {
+				result = "result_windows.json"
+			}
+			b, err := os.ReadFile(filepath.Join(testDir, result))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var expected *formatter.JSONOutput
+			if err := json.Unmarshal(b, &expected); err != nil {
+				t.Fatal(err)
+			}
+
+			got := &formatter.JSONOutput{Issues: []formatter.JSONIssue{}, Errors: []formatter.JSONError{}}
+			for _, line := range strings.Split(strings.TrimSuffix(outStream.String(), "\n"), "\n") {
+				var typed struct {
+					Type string `json:"type"`
+				}
+				if err := json.Unmarshal([]byte(line), &typed); err != nil {
+					t.Fatalf("Failed to parse line `%s`; %s", line, err)
+				}
+				switch typed.Type {
+				case "issue":
+					var issue formatter.JSONIssue
+					if err := json.Unmarshal([]byte(line), &issue); err != nil {
+						t.Fatal(err)
+					}
+					got.Issues = append(got.Issues, issue)
+				case "error":
+					var e formatter.JSONError
+					if err := json.Unmarshal([]byte(line), &e); err != nil {
+						t.Fatal(err)
 					}
-					return e
-				}),
+					got.Errors = append(got.Errors, e)
+				default:
+					t.Fatalf("Unexpected line type: %s", typed.Type)
+				}
 			}
-			if test.ignoreOrder {
-				opts = append(opts, cmpopts.SortSlices(func(a, b formatter.JSONError) bool {
-					return a.Message > b.Message
-				}))
+
+			opts := []cmp.Option{
+				cmpopts.IgnoreFields(formatter.JSONRule{}, "Link"),
+				// JSONL lines have no envelope to carry the format version, the summary, and the scanned files
+				cmpopts.IgnoreFields(formatter.JSONOutput{}, "FormatVersion", "Summary", "ScannedFiles"),
+				stripLogs,
+			}
+			if !test.ordered {
+				// Lines are printed in the order that workers finish
+				opts = append(
+					opts,
+					cmpopts.SortSlices(func(a, b formatter.JSONIssue) bool {
+						return a.Range.Filename < b.Range.Filename
+					}),
+					cmpopts.SortSlices(func(a, b formatter.JSONError) bool {
+						return a.Message > b.Message
+					}),
+				)
 			}
 			if diff := cmp.Diff(got, expected, opts...); diff != "" {
 				t.Error(diff)
@@ -113,7 +451,435 @@ func TestIntegration(t *testing.T) {
 	}
 }
 
-func IsWindowsResultExist() bool {
-	_, err := os.Stat("result_windows.json")
+func TestIntegrationStream(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		dir     string
+		error   bool
+		// If ordered, lines must be printed in the order of directories
+		ordered bool
+	}{
+		{
+			name:    "recursive + stream",
+			command: "tflint --recursive --format stream --force",
+			dir:     "basic",
+		},
+		{
+			name:    "recursive + stream with errors",
+			command: "tflint --recursive --format stream --force",
+			dir:     "errors",
+			error:   true,
+		},
+		{
+			name:    "recursive + stream + ordered",
+			command: "tflint --recursive --format stream --force --ordered",
+			dir:     "basic",
+			ordered: true,
+		},
+		{
+			name:    "recursive + stream + ordered with errors",
+			command: "tflint --recursive --format stream --force --ordered",
+			dir:     "errors",
+			error:   true,
+			ordered: true,
+		},
+	}
+
+	dir, _ := os.Getwd()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testDir := filepath.Join(dir, test.dir)
+			t.Chdir(testDir)
+			t.Setenv("TFLINT_IGNORE", "")
+
+			args := strings.Split(test.command, " ")
+			var cmd *exec.Cmd
+			if runtime.GOOS == "windows" {
+				cmd = exec.Command("tflint.exe", args[1:]...)
+			} else {
+				cmd = exec.Command("tflint", args[1:]...)
+			}
+			outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+			cmd.Stdout = outStream
+			cmd.Stderr = errStream
+
+			if err := cmd.Run(); err != nil && !test.error {
+				t.Fatalf("Failed to exec command: %s", err)
+			}
+
+			// Results are the same as the json format, but streamed line by line and closed by the summary
+			result := "result.json"
+			if runtime.GOOS == "windows" && IsWindowsResultExist("result_windows.json") {
+				result = "result_windows.json"
+			}
+			b, err := os.ReadFile(filepath.Join(testDir, result))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var expected *formatter.JSONOutput
+			if err := json.Unmarshal(b, &expected); err != nil {
+				t.Fatal(err)
+			}
+
+			got := &formatter.JSONOutput{Issues: []formatter.JSONIssue{}, Errors: []formatter.JSONError{}}
+			lines := strings.Split(strings.TrimSuffix(outStream.String(), "\n"), "\n")
+			for idx, line := range lines {
+				var typed struct {
+					Type string          `json:"type"`
+					Data json.RawMessage `json:"data"`
+				}
+				if err := json.Unmarshal([]byte(line), &typed); err != nil {
+					t.Fatalf("Failed to parse line `%s`; %s", line, err)
+				}
+				switch typed.Type {
+				case "issue":
+					var issue formatter.JSONIssue
+					if err := json.Unmarshal(typed.Data, &issue); err != nil {
+						t.Fatal(err)
+					}
+					got.Issues = append(got.Issues, issue)
+				case "error":
+					var e formatter.JSONError
+					if err := json.Unmarshal(typed.Data, &e); err != nil {
+						t.Fatal(err)
+					}
+					got.Errors = append(got.Errors, e)
+				case "summary":
+					if idx != len(lines)-1 {
+						t.Fatalf("The summary must be the last line, but got at line %d", idx+1)
+					}
+					if err := json.Unmarshal(typed.Data, &got.Summary); err != nil {
+						t.Fatal(err)
+					}
+				default:
+					t.Fatalf("Unexpected line type: %s", typed.Type)
+				}
+			}
+
+			opts := []cmp.Option{
+				cmpopts.IgnoreFields(formatter.JSONRule{}, "Link"),
+				// Stream lines have no envelope to carry the format version and the scanned files
+				cmpopts.IgnoreFields(formatter.JSONOutput{}, "FormatVersion", "ScannedFiles"),
+				stripLogs,
+			}
+			if !test.ordered {
+				// Lines are printed in the order that workers finish
+				opts = append(
+					opts,
+					cmpopts.SortSlices(func(a, b formatter.JSONIssue) bool {
+						return a.Range.Filename < b.Range.Filename
+					}),
+					cmpopts.SortSlices(func(a, b formatter.JSONError) bool {
+						return a.Message > b.Message
+					}),
+				)
+			}
+			if diff := cmp.Diff(got, expected, opts...); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestIntegrationOrdered(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		dir     string
+	}{
+		{
+			name:    "recursive + jsonl + ordered",
+			command: "tflint --recursive --format jsonl --force --ordered",
+			dir:     "basic",
+		},
+		{
+			name:    "recursive + stream + ordered",
+			command: "tflint --recursive --format stream --force --ordered",
+			dir:     "basic",
+		},
+	}
+
+	dir, _ := os.Getwd()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testDir := filepath.Join(dir, test.dir)
+			t.Chdir(testDir)
+			t.Setenv("TFLINT_IGNORE", "")
+
+			// The output of the parallel scan must be the same every time
+			outputs := []string{}
+			for range 2 {
+				args := strings.Split(test.command, " ")
+				var cmd *exec.Cmd
+				if runtime.GOOS == "windows" {
+					cmd = exec.Command("tflint.exe", args[1:]...)
+				} else {
+					cmd = exec.Command("tflint", args[1:]...)
+				}
+				outStream := new(bytes.Buffer)
+				cmd.Stdout = outStream
+
+				if err := cmd.Run(); err != nil {
+					t.Fatalf("Failed to exec command: %s", err)
+				}
+				outputs = append(outputs, outStream.String())
+			}
+
+			if diff := cmp.Diff(outputs[0], outputs[1]); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestIntegrationDirTimeout(t *testing.T) {
+	// The slow plugin is a shell script
+	if runtime.GOOS == "windows" {
+		t.Skip("skip on windows")
+	}
+
+	dir, _ := os.Getwd()
+	testDir := filepath.Join(dir, "timeout")
+	t.Chdir(testDir)
+	t.Setenv("TFLINT_IGNORE", "")
+
+	cmd := exec.Command("tflint", "--recursive", "--dir-timeout=1s", "--format", "json", "--force")
+	outStream := new(bytes.Buffer)
+	cmd.Stdout = outStream
+
+	start := time.Now()
+	if err := cmd.Run(); err == nil {
+		t.Fatal("expected to fail, but succeeded")
+	}
+	// The slow plugin sleeps for 10 seconds, so the scan must be finished without waiting for it
+	if elapsed := time.Since(start); elapsed >= 10*time.Second {
+		t.Fatalf("the scan took %s, but the timeout is 1s", elapsed)
+	}
+
+	b, err := os.ReadFile(filepath.Join(testDir, "result.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected *formatter.JSONOutput
+	if err := json.Unmarshal(b, &expected); err != nil {
+		t.Fatal(err)
+	}
+
+	var got *formatter.JSONOutput
+	if err := json.Unmarshal(outStream.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := []cmp.Option{
+		cmpopts.IgnoreFields(formatter.JSONRule{}, "Link"),
+		stripLogs,
+	}
+	if diff := cmp.Diff(got, expected, opts...); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestIntegrationMinimumFailureSeverity(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		status  int
+	}{
+		{
+			name:    "issues found",
+			command: "tflint --recursive",
+			status:  2,
+		},
+		{
+			name:    "the maximum severity across directories exceeds the minimum failure severity",
+			command: "tflint --recursive --minimum-failure-severity=error",
+			status:  2,
+		},
+		{
+			name:    "no issues exceed the minimum failure severity",
+			command: "tflint --recursive --chdir=warnings --minimum-failure-severity=error",
+			status:  0,
+		},
+		{
+			name:    "minimum_failure_severity in config files is applied to each directory",
+			command: "tflint --recursive --chdir=warnings",
+			status:  0,
+		},
+		{
+			name:    "minimum_failure_severity in config files is applied to each chdir",
+			command: "tflint --chdir=warnings --chdir=errors",
+			status:  2,
+		},
+		{
+			name:    "flag takes precedence over config files",
+			command: "tflint --recursive --chdir=warnings --minimum-failure-severity=warning",
+			status:  2,
+		},
+		{
+			name:    "force takes precedence",
+			command: "tflint --recursive --minimum-failure-severity=warning --force",
+			status:  0,
+		},
+	}
+
+	dir, _ := os.Getwd()
+	testDir := filepath.Join(dir, "failure_severity")
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Chdir(testDir)
+			t.Setenv("TFLINT_IGNORE", "")
+
+			args := strings.Split(test.command, " ")
+			var cmd *exec.Cmd
+			if runtime.GOOS == "windows" {
+				cmd = exec.Command("tflint.exe", args[1:]...)
+			} else {
+				cmd = exec.Command("tflint", args[1:]...)
+			}
+			errStream := new(bytes.Buffer)
+			cmd.Stderr = errStream
+
+			status := 0
+			if err := cmd.Run(); err != nil {
+				exitErr, ok := err.(*exec.ExitError)
+				if !ok {
+					t.Fatalf("Failed to exec command: %s", err)
+				}
+				status = exitErr.ExitCode()
+			}
+			if status != test.status {
+				t.Errorf("expected exit status %d, but got %d; stderr=%s", test.status, status, errStream.String())
+			}
+		})
+	}
+}
+
+func TestIntegrationRuleThreshold(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		status  int
+	}{
+		{
+			// Each directory has 3 issues, but the threshold is evaluated for 6 issues in all directories
+			name:    "issues in all directories exceed the threshold",
+			command: "tflint --recursive",
+			status:  2,
+		},
+		{
+			name:    "issues within the threshold",
+			command: "tflint --recursive --chdir=a",
+			status:  0,
+		},
+	}
+
+	dir, _ := os.Getwd()
+	testDir := filepath.Join(dir, "rule_threshold")
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Chdir(testDir)
+			t.Setenv("TFLINT_IGNORE", "")
+
+			args := strings.Split(test.command, " ")
+			var cmd *exec.Cmd
+			if runtime.GOOS == "windows" {
+				cmd = exec.Command("tflint.exe", args[1:]...)
+			} else {
+				cmd = exec.Command("tflint", args[1:]...)
+			}
+			errStream := new(bytes.Buffer)
+			cmd.Stderr = errStream
+
+			status := 0
+			if err := cmd.Run(); err != nil {
+				exitErr, ok := err.(*exec.ExitError)
+				if !ok {
+					t.Fatalf("Failed to exec command: %s", err)
+				}
+				status = exitErr.ExitCode()
+			}
+			if status != test.status {
+				t.Errorf("expected exit status %d, but got %d; stderr=%s", test.status, status, errStream.String())
+			}
+		})
+	}
+}
+
+func TestIntegrationMaxIssues(t *testing.T) {
+	tests := []struct {
+		name      string
+		command   string
+		issues    int
+		scanned   int
+		truncated bool
+	}{
+		{
+			// Every directory has 2 issues, so directories after the first one are never inspected by a single worker
+			name:      "stop inspecting directories once the limit is exceeded",
+			command:   "tflint --recursive --format json --max-issues=1 --max-workers=1",
+			issues:    1,
+			scanned:   1,
+			truncated: true,
+		},
+		{
+			name:    "issues under the limit",
+			command: "tflint --recursive --format json --max-issues=6",
+			issues:  6,
+			scanned: 3,
+		},
+	}
+
+	dir, _ := os.Getwd()
+	testDir := filepath.Join(dir, "max_issues")
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Chdir(testDir)
+			t.Setenv("TFLINT_IGNORE", "")
+
+			args := strings.Split(test.command, " ")
+			var cmd *exec.Cmd
+			if runtime.GOOS == "windows" {
+				cmd = exec.Command("tflint.exe", args[1:]...)
+			} else {
+				cmd = exec.Command("tflint", args[1:]...)
+			}
+			outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+			cmd.Stdout = outStream
+			cmd.Stderr = errStream
+
+			err := cmd.Run()
+			exitErr, ok := err.(*exec.ExitError)
+			if !ok || exitErr.ExitCode() != 2 {
+				t.Fatalf("expected exit status 2, but got %s; stderr=%s", err, errStream.String())
+			}
+
+			var got formatter.JSONOutput
+			if err := json.Unmarshal(outStream.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if len(got.Issues) != test.issues {
+				t.Errorf("expected %d issues, but got %d", test.issues, len(got.Issues))
+			}
+			if len(got.ScannedFiles) != test.scanned {
+				t.Errorf("expected %d scanned files, but got %v", test.scanned, got.ScannedFiles)
+			}
+			if got.IssuesTruncated != test.truncated {
+				t.Errorf("expected issues_truncated=%t, but got %t", test.truncated, got.IssuesTruncated)
+			}
+			if len(got.Errors) != 0 {
+				t.Errorf("expected no errors, but got %v", got.Errors)
+			}
+		})
+	}
+}
+
+func IsWindowsResultExist(name string) bool {
+	_, err := os.Stat(name)
 	return !os.IsNotExist(err)
 }
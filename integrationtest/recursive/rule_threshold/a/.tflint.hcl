@@ -0,0 +1,12 @@
+plugin "terraform" {
+  enabled = false
+}
+
+plugin "testing" {
+  enabled = true
+}
+
+rule "aws_instance_example_type" {
+  enabled   = true
+  threshold = 5
+}
@@ -0,0 +1,11 @@
+resource "aws_instance" "foo" {
+  instance_type = "t2.micro"
+}
+
+resource "aws_instance" "bar" {
+  instance_type = "t2.micro"
+}
+
+resource "aws_instance" "baz" {
+  instance_type = "t2.micro"
+}
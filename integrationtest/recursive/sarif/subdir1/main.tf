@@ -0,0 +1,4 @@
+resource "aws_instance" "foo" {
+  # インスタンスタイプ
+  instance_type = "t2.micro"
+}
@@ -83,6 +83,7 @@ func NewHandler(configPath string, cliConfig *tflint.Config) (jsonrpc2.Handler,
 		cliConfig:         cliConfig,
 		config:            cfg,
 		fs:                afero.NewCopyOnWriteFs(afero.NewOsFs(), afero.NewMemMapFs()),
+		loaderCache:       terraform.NewLoaderCache(),
 		plugin:            rulsetPlugin,
 		clientSDKVersions: clientSDKVersions,
 		diagsPaths:        []string{},
@@ -97,6 +98,7 @@ type handler struct {
 	rootDir           string
 	plugin            *plugin.Plugin
 	clientSDKVersions map[string]*version.Version
+	loaderCache       *terraform.LoaderCache
 	shutdown          bool
 	diagsPaths        []string
 }
@@ -151,6 +153,7 @@ func (h *handler) handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2
 
 func (h *handler) chdir(dir string) error {
 	if h.rootDir != dir {
+		//nolint:TODO // Use terraform.NewLoaderWithBaseDir with the root dir instead of changing the directory of the process.
 		log.Printf("Changing directory: %s", dir)
 		if err := os.Chdir(dir); err != nil {
 			return fmt.Errorf("Failed to chdir to %s: %s", dir, err)
@@ -163,7 +166,7 @@ func (h *handler) chdir(dir string) error {
 func (h *handler) inspect() (map[string][]lsp.Diagnostic, error) {
 	ret := map[string][]lsp.Diagnostic{}
 
-	loader, err := terraform.NewLoader(afero.Afero{Fs: h.fs}, h.rootDir)
+	loader, err := terraform.NewLoader(afero.Afero{Fs: h.fs}, h.rootDir, h.loaderCache)
 	if err != nil {
 		return ret, fmt.Errorf("Failed to prepare loading: %w", err)
 	}
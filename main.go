@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"runtime"
@@ -12,7 +13,7 @@ import (
 )
 
 func main() {
-	cli, err := cmd.NewCLI(colorable.NewColorable(os.Stdout), colorable.NewColorable(os.Stderr))
+	cli, err := cmd.NewCLI(newColorableWriter(os.Stdout), newColorableWriter(os.Stderr))
 	if err != nil {
 		fmt.Fprint(os.Stderr, err.Error())
 		os.Exit(cmd.ExitCodeError)
@@ -37,3 +38,19 @@ Please attach an output log, describe the situation and version that occurred an
 
 	os.Exit(cli.Run(os.Args))
 }
+
+// colorableWriter is a writer that translates escape sequences on Windows.
+// It keeps the file descriptor of the file, so that the CLI can detect whether it is a terminal.
+type colorableWriter struct {
+	io.Writer
+	fd uintptr
+}
+
+func newColorableWriter(file *os.File) *colorableWriter {
+	return &colorableWriter{Writer: colorable.NewColorable(file), fd: file.Fd()}
+}
+
+// Fd returns the file descriptor of the file
+func (w *colorableWriter) Fd() uintptr {
+	return w.fd
+}
@@ -167,6 +167,8 @@ func (s *GRPCServer) EvaluateExpr(expr hcl.Expression, opts sdk.EvaluateExprOpti
 // However, some ranges may be syntactically valid but not actually represent an expression.
 // In these cases, the "expression" is still provided as context and the client should ignore any errors when attempting to evaluate it.
 func (s *GRPCServer) EmitIssue(rule sdk.Rule, message string, location hcl.Range, fixable bool) (bool, error) {
+	rule = s.overrideRuleSeverity(rule)
+
 	// If the issue range represents an expression, it is emitted based on that context.
 	// This is required to emit issues in called modules.
 	expr, err := s.getExprFromRange(location)
@@ -183,6 +185,31 @@ func (s *GRPCServer) EmitIssue(rule sdk.Rule, message string, location hcl.Range
 	return applied, err
 }
 
+// overrideRuleSeverity replaces the severity reported by the plugin with the one in the rule config.
+func (s *GRPCServer) overrideRuleSeverity(rule sdk.Rule) sdk.Rule {
+	config := s.runner.RuleConfig(rule.Name())
+	if config == nil || config.Severity == "" {
+		return rule
+	}
+	severity, err := tflint.NewSeverity(config.Severity)
+	if err != nil {
+		// Invalid severities are rejected when loading the config
+		return rule
+	}
+	return &severityOverriddenRule{Rule: rule, severity: severity}
+}
+
+// severityOverriddenRule is a rule whose severity is overridden by the config.
+type severityOverriddenRule struct {
+	sdk.Rule
+	severity tflint.Severity
+}
+
+// Severity returns the overridden severity
+func (r *severityOverriddenRule) Severity() tflint.Severity {
+	return r.severity
+}
+
 func (s *GRPCServer) getExprFromRange(location hcl.Range) (hcl.Expression, error) {
 	file := s.runner.File(location.Filename)
 	if file == nil {
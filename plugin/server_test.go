@@ -681,6 +681,49 @@ resource "aws_instance" "foo" {
 	}
 }
 
+func TestEmitIssue_severityOverride(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Severity string
+		Want     sdk.Severity
+	}{
+		{
+			Name:     "no override",
+			Severity: "",
+			Want:     sdk.ERROR,
+		},
+		{
+			Name:     "override",
+			Severity: "warning",
+			Want:     sdk.WARNING,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			config := tflint.EmptyConfig()
+			config.Rules["test_rule"] = &tflint.RuleConfig{Name: "test_rule", Enabled: true, Severity: test.Severity}
+			runner := tflint.TestRunnerWithConfig(t, map[string]string{"main.tf": ""}, config)
+
+			server := NewGRPCServer(runner, nil, runner.Files(), SDKVersion)
+
+			if _, err := server.EmitIssue(&testRule{}, "error", hcl.Range{Filename: "main.tf"}, false); err != nil {
+				t.Fatalf("failed to call EmitIssue: %s", err)
+			}
+
+			if len(runner.Issues) != 1 {
+				t.Fatalf("expected to 1 issue, but got %d issues", len(runner.Issues))
+			}
+			if got := runner.Issues[0].Rule.Severity(); got != test.Want {
+				t.Errorf("expected severity is %s, but got %s", test.Want, got)
+			}
+			if got := runner.Issues[0].Rule.Name(); got != "test_rule" {
+				t.Errorf("expected rule name is test_rule, but got %s", got)
+			}
+		})
+	}
+}
+
 func TestApplyChanges(t *testing.T) {
 	tests := []struct {
 		name    string
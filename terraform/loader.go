@@ -2,9 +2,11 @@ package terraform
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl/v2"
@@ -35,9 +37,15 @@ type Loader struct {
 //
 // If an original working dir is passed, the paths of the loaded files will
 // be relative to that directory.
-func NewLoader(fs afero.Afero, originalWd string) (*Loader, error) {
-	log.Print("[INFO] Initialize new loader")
-
+//
+// If a cache is passed, parsed files are shared with other loaders using the
+// same cache. Pass nil to disable caching.
+//
+// The base directory is determined by the current directory, which is changed
+// by os.Chdir and global to the process. Use NewLoaderWithBaseDir instead
+// to read configuration in another directory.
+func NewLoader(fs afero.Afero, originalWd string, cache *LoaderCache) (*Loader, error) {
+	//nolint:TODO // Remove NewLoader once all callers use NewLoaderWithBaseDir.
 	wd, err := os.Getwd()
 	if err != nil {
 		return nil, fmt.Errorf("failed to determine current working directory: %s", err)
@@ -47,16 +55,42 @@ func NewLoader(fs afero.Afero, originalWd string) (*Loader, error) {
 		return nil, fmt.Errorf("failed to determine base dir: %s", err)
 	}
 
+	return newLoader(fs, baseDir, "", cache)
+}
+
+// NewLoaderWithBaseDir creates and returns a loader that reads configuration
+// in the given base directory without changing the current directory.
+//
+// Relative paths passed to the loader, the module manifest, and TF_DATA_DIR are
+// resolved from the base directory, and the paths of the loaded files are
+// prefixed with it. It is the same as calling NewLoader after changing the
+// current directory to the base directory, so loaders for different directories
+// can be used in parallel.
+//
+// If a cache is passed, parsed files are shared with other loaders using the
+// same cache. Pass nil to disable caching.
+func NewLoaderWithBaseDir(fs afero.Afero, baseDir string, cache *LoaderCache) (*Loader, error) {
+	return newLoader(fs, filepath.Clean(baseDir), filepath.Clean(baseDir), cache)
+}
+
+func newLoader(fs afero.Afero, baseDir string, rootDir string, cache *LoaderCache) (*Loader, error) {
+	log.Print("[INFO] Initialize new loader")
+
+	parser := NewParser(fs)
+	parser.cache = cache
+	parser.rootDir = rootDir
+
 	ret := &Loader{
-		parser: NewParser(fs),
+		parser: parser,
 		modules: moduleMgr{
 			fs:       fs,
 			manifest: moduleManifest{},
+			rootDir:  rootDir,
 		},
 		baseDir: baseDir,
 	}
 
-	err = ret.modules.readModuleManifest()
+	err := ret.modules.readModuleManifest()
 	if err != nil {
 		return nil, fmt.Errorf("failed to read module manifest: %s", err)
 	}
@@ -149,6 +183,51 @@ func (l *Loader) moduleWalkerFunc(walkLocal, walkRemote bool) ModuleWalkerFunc {
 	}
 }
 
+// LoadFromReader reads configuration from the given reader as if it were the file
+// with the given name, instead of reading the file from the filesystem.
+//
+// The content replaces the file with the same name in the filesystem, or is added
+// as a new file, while other files in the directory are still read from the
+// filesystem, so references to them resolve. The filesystem is never modified.
+func (l *Loader) LoadFromReader(name string, r io.Reader) error {
+	src, err := readConfigFile(name, r)
+	if err != nil {
+		return err
+	}
+
+	if l.parser.overlay == nil {
+		l.parser.overlay = map[string][]byte{}
+	}
+	l.parser.overlay[filepath.Clean(name)] = src
+	return nil
+}
+
+// readConfigFile reads the source of a configuration file with the given name from the reader.
+func readConfigFile(name string, r io.Reader) ([]byte, error) {
+	if filepath.IsAbs(name) {
+		return nil, fmt.Errorf("the file name must be a relative path: %s", name)
+	}
+	if configFileExt(name) == "" {
+		return nil, fmt.Errorf("the file name must have a .tf or .tf.json extension: %s", name)
+	}
+
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %s", name, err)
+	}
+	return src, nil
+}
+
+// IgnoreFiles excludes configuration files from modules if the given function
+// returns true. The path passed to the function is relative to the current
+// directory, or to the base directory of NewLoaderWithBaseDir.
+//
+// A directory whose files are all excluded is not a module. Files read by
+// LoadFromReader are also excluded.
+func (l *Loader) IgnoreFiles(ignored func(path string) bool) {
+	l.parser.ignored = ignored
+}
+
 var defaultVarsFilename = "terraform.tfvars"
 
 // LoadValuesFiles reads Terraform's autoloaded values files in the given directory
@@ -166,7 +245,7 @@ func (l *Loader) LoadValuesFiles(dir string, files ...string) ([]InputValues, hc
 		return nil, diags
 	}
 	defaultVarsFile := filepath.Join(dir, defaultVarsFilename)
-	if _, err := os.Stat(defaultVarsFile); err == nil {
+	if l.parser.Exists(defaultVarsFile) {
 		autoLoadFiles = append([]string{defaultVarsFile}, autoLoadFiles...)
 	}
 
@@ -218,3 +297,16 @@ func (l *Loader) Sources() map[string][]byte {
 func (l *Loader) Files() map[string]*hcl.File {
 	return l.parser.Files()
 }
+
+// ScannedFiles returns paths of all configuration files loaded so far, including files in called modules.
+// Values files are excluded. The paths are sorted lexicographically.
+func (l *Loader) ScannedFiles() []string {
+	ret := []string{}
+	for path := range l.parser.Files() {
+		if configFileExt(path) != "" {
+			ret = append(ret, path)
+		}
+	}
+	slices.Sort(ret)
+	return ret
+}
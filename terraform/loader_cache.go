@@ -0,0 +1,88 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/spf13/afero"
+)
+
+// LoaderCache holds parsed files that can be reused across loaders.
+// Creating a loader for each inspection, e.g. in watch mode or in the language server,
+// parses all files again. Passing the same cache to NewLoader skips parsing unchanged files.
+//
+// Files are keyed by absolute path and invalidated when their modification time or size changes.
+// Parsed files are read-only, so they can be shared safely. It is safe to use from multiple goroutines.
+type LoaderCache struct {
+	mu    sync.RWMutex
+	files map[string]*cachedFile
+}
+
+type cachedFile struct {
+	// filename is the name used in ranges of the parsed file.
+	// Files loaded from another base directory have different ranges, so they are not reused.
+	filename string
+	modTime  time.Time
+	size     int64
+
+	file  *hcl.File
+	diags hcl.Diagnostics
+}
+
+// NewLoaderCache returns an empty cache.
+func NewLoaderCache() *LoaderCache {
+	return &LoaderCache{files: map[string]*cachedFile{}}
+}
+
+// get returns the parsed file if it is cached and not changed since it was parsed.
+// A nil cache always misses.
+func (c *LoaderCache) get(path string, filename string, info os.FileInfo) (*hcl.File, hcl.Diagnostics, bool) {
+	if c == nil || info == nil {
+		return nil, nil, false
+	}
+	key, err := filepath.Abs(path)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	cached, exists := c.files[key]
+	if !exists || cached.filename != filename || !cached.modTime.Equal(info.ModTime()) || cached.size != info.Size() {
+		return nil, nil, false
+	}
+	return cached.file, cached.diags, true
+}
+
+// put stores the parsed file. The file info must be read before reading the file,
+// so that changes made while parsing are detected on the next get.
+func (c *LoaderCache) put(path string, filename string, info os.FileInfo, file *hcl.File, diags hcl.Diagnostics) {
+	if c == nil || info == nil {
+		return
+	}
+	key, err := filepath.Abs(path)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.files[key] = &cachedFile{filename: filename, modTime: info.ModTime(), size: info.Size(), file: file, diags: diags}
+}
+
+// stat returns the file info used to validate cached files, or nil if it cannot be read.
+func (c *LoaderCache) stat(fs afero.Afero, path string) os.FileInfo {
+	if c == nil {
+		return nil
+	}
+	info, err := fs.Stat(path)
+	if err != nil {
+		return nil
+	}
+	return info
+}
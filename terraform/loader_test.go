@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -15,7 +16,7 @@ import (
 
 func TestLoadConfig(t *testing.T) {
 	withinFixtureDir(t, "v0.15.0_module", func(dir string) {
-		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir)
+		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -80,7 +81,7 @@ func TestLoadConfig(t *testing.T) {
 func TestLoadConfig_withBaseDir(t *testing.T) {
 	withinFixtureDir(t, "v0.15.0_module", func(dir string) {
 		// The current dir is test-fixtures/v0.15.0_module, but the base dir is test-fixtures
-		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, filepath.Dir(dir))
+		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, filepath.Dir(dir), nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -144,7 +145,7 @@ func TestLoadConfig_withBaseDir(t *testing.T) {
 
 func TestLoadConfig_callLocalModules(t *testing.T) {
 	withinFixtureDir(t, "v0.15.0_module", func(dir string) {
-		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir)
+		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -166,9 +167,211 @@ func TestLoadConfig_callLocalModules(t *testing.T) {
 	})
 }
 
+func TestScannedFiles(t *testing.T) {
+	withinFixtureDir(t, "v0.15.0_module", func(dir string) {
+		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, diags := loader.LoadConfig(".", CallLocalModule); diags.HasErrors() {
+			t.Fatal(diags)
+		}
+
+		expected := []string{filepath.Join("ec2", "main.tf"), "module.tf"}
+		if diff := cmp.Diff(expected, loader.ScannedFiles()); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
+
+func TestLoadFromReader_invalidName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{
+			name: filepath.Join(t.TempDir(), "main.tf"),
+			want: "the file name must be a relative path",
+		},
+		{
+			name: "main.tfvars",
+			want: "the file name must have a .tf or .tf.json extension",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			loader, err := NewLoaderWithBaseDir(afero.Afero{Fs: afero.NewMemMapFs()}, ".", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = loader.LoadFromReader(test.name, strings.NewReader(""))
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("error: want=%s, got=%v", test.want, err)
+			}
+		})
+	}
+}
+
+func TestLoadFromReader(t *testing.T) {
+	withinFixtureDir(t, "v0.15.0_module", func(dir string) {
+		tests := []struct {
+			name string
+			file string
+			src  string
+			want []string
+		}{
+			{
+				name: "override an existing file",
+				file: "module.tf",
+				src:  `module "instance" { source = "./ec2" }`,
+				want: []string{filepath.Join("ec2", "main.tf"), "module.tf"},
+			},
+			{
+				name: "new file",
+				file: "stdin.tf",
+				src:  `locals { instance = module.instance }`,
+				want: []string{filepath.Join("ec2", "main.tf"), "module.tf", "stdin.tf"},
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := loader.LoadFromReader(test.file, strings.NewReader(test.src)); err != nil {
+					t.Fatal(err)
+				}
+				config, diags := loader.LoadConfig(".", CallLocalModule)
+				if diags.HasErrors() {
+					t.Fatal(diags)
+				}
+				testChildModule(t, config, "instance", "ec2")
+
+				if diff := cmp.Diff(test.want, loader.ScannedFiles()); diff != "" {
+					t.Fatal(diff)
+				}
+				if got := string(loader.Sources()[test.file]); got != test.src {
+					t.Fatalf("source: want=%s, got=%s", test.src, got)
+				}
+			})
+		}
+
+		// The filesystem is never modified
+		if _, err := os.Stat("stdin.tf"); !os.IsNotExist(err) {
+			t.Fatalf("stdin.tf should not be created: %v", err)
+		}
+		src, err := os.ReadFile("module.tf")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(src), `module "consul"`) {
+			t.Fatalf("module.tf should not be modified: %s", src)
+		}
+	})
+}
+
+func TestIgnoreFiles(t *testing.T) {
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	files := map[string]string{
+		"main.tf":             `module "generated" { source = "./generated" }`,
+		"broken.tf":           `resource "null_resource" "broken" {`,
+		"generated/output.tf": `output "foo" { value = "bar" }`,
+	}
+	for name, src := range files {
+		if err := fs.WriteFile(name, []byte(src), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	loader, err := NewLoaderWithBaseDir(fs, ".", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loader.IgnoreFiles(func(path string) bool {
+		return path == "broken.tf" || filepath.Dir(path) == "generated"
+	})
+
+	if _, diags := loader.LoadConfig(".", CallLocalModule); diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	if diff := cmp.Diff([]string{"main.tf"}, loader.ScannedFiles()); diff != "" {
+		t.Fatal(diff)
+	}
+	if loader.IsConfigDir("generated") {
+		t.Fatal("generated should not be a module because all files are ignored")
+	}
+}
+
+func TestNewLoaderWithBaseDir(t *testing.T) {
+	// The current directory is not changed, so that the base directory is used to read files
+	baseDir := filepath.Join("test-fixtures", "v0.15.0_module")
+
+	loader, err := NewLoaderWithBaseDir(afero.Afero{Fs: afero.NewOsFs()}, baseDir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, diags := loader.LoadConfig(".", CallLocalModule)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	// SourceDir does not contain the base dir because it affects `path.module` and `path.root` values.
+	if config.Module.SourceDir != "." {
+		t.Fatalf("root module path: want=%s, got=%s", ".", config.Module.SourceDir)
+	}
+	testChildModule(t, config, "instance", "ec2")
+
+	expected := []string{filepath.Join(baseDir, "ec2", "main.tf"), filepath.Join(baseDir, "module.tf")}
+	if diff := cmp.Diff(expected, loader.ScannedFiles()); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestLoadValuesFiles_withBaseDirWithoutChdir(t *testing.T) {
+	baseDir := filepath.Join("test-fixtures", "values_files")
+
+	loader, err := NewLoaderWithBaseDir(afero.Afero{Fs: afero.NewOsFs()}, baseDir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Files passed manually are relative to the base directory.
+	ret, diags := loader.LoadValuesFiles(".", "cli1.tfvars")
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	expected := []InputValues{
+		{"default": {Value: cty.StringVal("terraform.tfvars")}},
+		{"auto1": {Value: cty.StringVal("auto1.auto.tfvars")}},
+		{"auto2": {Value: cty.StringVal("auto2.auto.tfvars")}},
+		{"cli1": {Value: cty.StringVal("cli1.tfvars")}},
+	}
+	if !reflect.DeepEqual(expected, ret) {
+		t.Fatalf("Unexpected input values are received: expected=%#v actual=%#v", expected, ret)
+	}
+
+	want := []string{
+		filepath.Join(baseDir, "auto1.auto.tfvars"),
+		filepath.Join(baseDir, "auto2.auto.tfvars"),
+		filepath.Join(baseDir, "cli1.tfvars"),
+		filepath.Join(baseDir, "terraform.tfvars"),
+	}
+	loadedFiles := []string{}
+	for name := range loader.Files() {
+		loadedFiles = append(loadedFiles, name)
+	}
+	opt := cmpopts.SortSlices(func(x, y string) bool { return x > y })
+	if diff := cmp.Diff(want, loadedFiles, opt); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
 func TestLoadConfig_withoutModuleManifest(t *testing.T) {
 	withinFixtureDir(t, "without_module_manifest", func(dir string) {
-		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir)
+		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -186,7 +389,7 @@ func TestLoadConfig_withoutModuleManifest(t *testing.T) {
 
 func TestLoadConfig_withoutModuleManifest_callLocalModules(t *testing.T) {
 	withinFixtureDir(t, "without_module_manifest", func(dir string) {
-		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir)
+		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -210,7 +413,7 @@ func TestLoadConfig_withoutModuleManifest_callLocalModules(t *testing.T) {
 
 func TestLoadConfig_moduleNotFound(t *testing.T) {
 	withinFixtureDir(t, "module_not_found", func(dir string) {
-		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir)
+		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -228,7 +431,7 @@ func TestLoadConfig_moduleNotFound(t *testing.T) {
 
 func TestLoadConfig_moduleNotFound_callNoModules(t *testing.T) {
 	withinFixtureDir(t, "module_not_found", func(dir string) {
-		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir)
+		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -248,7 +451,7 @@ func TestLoadConfig_moduleNotFound_callNoModules(t *testing.T) {
 
 func TestLoadConfig_moduleNotFound_callNoModules_withArgDir(t *testing.T) {
 	withinFixtureDir(t, ".", func(dir string) {
-		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir)
+		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -268,7 +471,7 @@ func TestLoadConfig_moduleNotFound_callNoModules_withArgDir(t *testing.T) {
 
 func TestLoadConfig_invalidConfiguration(t *testing.T) {
 	withinFixtureDir(t, "invalid_configuration", func(dir string) {
-		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir)
+		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -286,7 +489,7 @@ func TestLoadConfig_invalidConfiguration(t *testing.T) {
 
 func TestLoadConfig_circularReferencingModules(t *testing.T) {
 	withinFixtureDir(t, "circular_referencing_modules", func(dir string) {
-		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir)
+		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -305,7 +508,7 @@ func TestLoadConfig_circularReferencingModules(t *testing.T) {
 
 func TestLoadValuesFiles(t *testing.T) {
 	withinFixtureDir(t, "values_files", func(dir string) {
-		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir)
+		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -367,7 +570,7 @@ func TestLoadValuesFiles(t *testing.T) {
 func TestLoadValuesFiles_withBaseDir(t *testing.T) {
 	withinFixtureDir(t, "values_files", func(dir string) {
 		// The current dir is test-fixtures/values_files, but the base dir is test-fixtures
-		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, filepath.Dir(dir))
+		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, filepath.Dir(dir), nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -433,7 +636,7 @@ func TestLoadValuesFiles_withBaseDir(t *testing.T) {
 
 func TestLoadValuesFiles_withArgDir(t *testing.T) {
 	withinFixtureDir(t, ".", func(dir string) {
-		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir)
+		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -498,7 +701,7 @@ func TestLoadValuesFiles_withArgDir(t *testing.T) {
 
 func TestLoadValuesFiles_invalidValuesFile(t *testing.T) {
 	withinFixtureDir(t, "invalid_values_files", func(dir string) {
-		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir)
+		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -516,7 +719,7 @@ func TestLoadValuesFiles_invalidValuesFile(t *testing.T) {
 
 func TestLoadConfigDirFiles_loader(t *testing.T) {
 	withinFixtureDir(t, "v0.15.0_module", func(dir string) {
-		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir)
+		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -540,7 +743,7 @@ func TestLoadConfigDirFiles_loader(t *testing.T) {
 func TestLoadConfigDirFiles_loader_withBaseDir(t *testing.T) {
 	withinFixtureDir(t, "v0.15.0_module", func(dir string) {
 		// The current dir is test-fixtures/v0.15.0_module, but the base dir is test-fixtures
-		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, filepath.Dir(dir))
+		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, filepath.Dir(dir), nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -563,7 +766,7 @@ func TestLoadConfigDirFiles_loader_withBaseDir(t *testing.T) {
 
 func TestLoadConfigDirFiles_loader_withArgDir(t *testing.T) {
 	withinFixtureDir(t, ".", func(dir string) {
-		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir)
+		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dir, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -584,6 +787,106 @@ func TestLoadConfigDirFiles_loader_withArgDir(t *testing.T) {
 	})
 }
 
+func TestLoadConfig_withCache(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.WriteFile("main.tf", []byte(`resource "null_resource" "foo" {}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fs := afero.Afero{Fs: afero.NewOsFs()}
+	cache := NewLoaderCache()
+
+	load := func() *Config {
+		loader, err := NewLoader(fs, dir, cache)
+		if err != nil {
+			t.Fatal(err)
+		}
+		config, diags := loader.LoadConfig(".", CallLocalModule)
+		if diags.HasErrors() {
+			t.Fatal(diags)
+		}
+		return config
+	}
+
+	first := load()
+	// Unchanged files are reused
+	second := load()
+	if first.Module.Files["main.tf"] != second.Module.Files["main.tf"] {
+		t.Fatal("main.tf should be reused from the cache")
+	}
+	if string(second.Module.Sources["main.tf"]) != `resource "null_resource" "foo" {}` {
+		t.Fatalf("unexpected source: %s", second.Module.Sources["main.tf"])
+	}
+
+	// Changed files are parsed again
+	if err := os.WriteFile("main.tf", []byte(`resource "null_resource" "foobar" {}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	third := load()
+	if second.Module.Files["main.tf"] == third.Module.Files["main.tf"] {
+		t.Fatal("main.tf should be parsed again after changes")
+	}
+	if _, exists := third.Module.Resources["null_resource"]["foobar"]; !exists {
+		t.Fatalf("null_resource.foobar should be loaded, but got %+v", third.Module.Resources)
+	}
+}
+
+// BenchmarkRecursiveWithCache loads 20 identical directories that call a shared module,
+// and compares loading with and without LoaderCache, e.g. in watch mode.
+func BenchmarkRecursiveWithCache(b *testing.B) {
+	dir := b.TempDir()
+	b.Chdir(dir)
+
+	shared := ""
+	for i := range 100 {
+		shared += fmt.Sprintf("variable \"var_%d\" {\n  default = \"%d\"\n}\n\nresource \"null_resource\" \"resource_%d\" {\n  triggers = {\n    value = var.var_%d\n  }\n}\n\n", i, i, i, i)
+	}
+	if err := os.MkdirAll("modules/shared", 0755); err != nil {
+		b.Fatal(err)
+	}
+	if err := os.WriteFile("modules/shared/main.tf", []byte(shared), 0644); err != nil {
+		b.Fatal(err)
+	}
+	for i := range 20 {
+		subdir := fmt.Sprintf("subdir%d", i)
+		if err := os.MkdirAll(subdir, 0755); err != nil {
+			b.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(subdir, "main.tf"), []byte(shared+"module \"shared\" {\n  source = \"../modules/shared\"\n}\n"), 0644); err != nil {
+			b.Fatal(err)
+		}
+	}
+	fs := afero.Afero{Fs: afero.NewOsFs()}
+
+	bench := func(b *testing.B, cache func() *LoaderCache) {
+		for b.Loop() {
+			c := cache()
+			for i := range 20 {
+				loader, err := NewLoader(fs, dir, c)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if _, diags := loader.LoadConfig(fmt.Sprintf("subdir%d", i), CallLocalModule); diags.HasErrors() {
+					b.Fatal(diags)
+				}
+			}
+		}
+	}
+
+	b.Run("without cache", func(b *testing.B) {
+		bench(b, func() *LoaderCache { return nil })
+	})
+	b.Run("with cache", func(b *testing.B) {
+		// The shared module is parsed once per scan
+		bench(b, NewLoaderCache)
+	})
+	b.Run("with warm cache", func(b *testing.B) {
+		// All files are reused when the scan is repeated, e.g. in watch mode
+		cache := NewLoaderCache()
+		bench(b, func() *LoaderCache { return cache })
+	})
+}
+
 func withinFixtureDir(t *testing.T, dir string, test func(string)) {
 	t.Helper()
 
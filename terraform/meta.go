@@ -25,6 +25,7 @@ func Workspace() string {
 		return envVar
 	}
 
+	//nolint:TODO // Read the environment file from the base directory of the loader instead of the current directory.
 	envData, _ := os.ReadFile(filepath.Join(dataDir(), "environment"))
 	current := string(bytes.TrimSpace(envData))
 	if current != "" {
@@ -24,6 +24,10 @@ func moduleManifestPath() string {
 type moduleMgr struct {
 	fs       afero.Afero
 	manifest moduleManifest
+
+	// rootDir is the directory where the manifest is read from.
+	// If empty, it is read from the current directory.
+	rootDir string
 }
 
 // moduleRecord is a fork of modsdir.Record. This describes the structure of
@@ -50,7 +54,11 @@ func (m moduleManifest) moduleKey(path addrs.Module) string {
 }
 
 func (l *moduleMgr) readModuleManifest() error {
-	r, err := l.fs.Open(moduleManifestPath())
+	path := moduleManifestPath()
+	if l.rootDir != "" && !filepath.IsAbs(path) {
+		path = filepath.Join(l.rootDir, path)
+	}
+	r, err := l.fs.Open(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			// We'll treat a missing file as an empty manifest
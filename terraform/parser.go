@@ -2,6 +2,7 @@ package terraform
 
 import (
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
 	"sort"
@@ -21,6 +22,20 @@ import (
 type Parser struct {
 	fs afero.Afero
 	p  *hclparse.Parser
+
+	// cache is shared with other parsers to skip parsing unchanged files. It may be nil.
+	cache *LoaderCache
+
+	// rootDir is the directory that relative paths are read from.
+	// If empty, they are read from the current directory.
+	rootDir string
+
+	// overlay is sources of files that are not read from fs, such as a file read from stdin.
+	// An overlay file replaces the file with the same path in fs, or is added to the directory.
+	overlay map[string][]byte
+
+	// ignored reports whether a configuration file should be excluded from modules. It may be nil.
+	ignored func(path string) bool
 }
 
 // NewParser creates and returns a new Parser that reads files from the given
@@ -186,10 +201,23 @@ func (p *Parser) LoadValuesFile(baseDir, path string) (map[string]cty.Value, hcl
 }
 
 func (p *Parser) loadHCLFile(baseDir, path string) (*hcl.File, hcl.Diagnostics) {
-	src, err := p.fs.ReadFile(path)
-
 	realPath := filepath.Join(baseDir, path)
 
+	if src, exists := p.overlay[filepath.Clean(path)]; exists {
+		return p.parseHCLFile(src, path, realPath)
+	}
+
+	info := p.cache.stat(p.fs, p.resolve(path))
+	if file, diags, ok := p.cache.get(p.resolve(path), realPath, info); ok {
+		// Record the file so that it is included in Sources() and Files()
+		if file != nil {
+			p.p.AddFile(realPath, file)
+		}
+		return file, diags
+	}
+
+	src, err := p.fs.ReadFile(p.resolve(path))
+
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, hcl.Diagnostics{
@@ -211,12 +239,16 @@ func (p *Parser) loadHCLFile(baseDir, path string) (*hcl.File, hcl.Diagnostics)
 		}
 	}
 
-	switch {
-	case strings.HasSuffix(path, ".json"):
+	file, diags := p.parseHCLFile(src, path, realPath)
+	p.cache.put(p.resolve(path), realPath, info, file, diags)
+	return file, diags
+}
+
+func (p *Parser) parseHCLFile(src []byte, path string, realPath string) (*hcl.File, hcl.Diagnostics) {
+	if strings.HasSuffix(path, ".json") {
 		return p.p.ParseJSON(src, realPath)
-	default:
-		return p.p.ParseHCL(src, realPath)
 	}
+	return p.p.ParseHCL(src, realPath)
 }
 
 // Sources returns a map of the cached source buffers for all files that
@@ -243,13 +275,25 @@ func (p *Parser) IsConfigDir(baseDir, path string) bool {
 
 // Exists returns true if the given path exists in fs.
 func (p *Parser) Exists(path string) bool {
-	_, err := p.fs.Stat(path)
+	_, err := p.fs.Stat(p.resolve(path))
 	return err == nil
 }
 
+// resolve returns the path to read from the filesystem.
+// Relative paths are joined with rootDir, so that the current directory does not need to be changed.
+func (p *Parser) resolve(path string) string {
+	if p.rootDir == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(p.rootDir, path)
+}
+
 func (p *Parser) configDirFiles(baseDir, dir string) (primary, override []string, diags hcl.Diagnostics) {
-	infos, err := p.fs.ReadDir(dir)
-	if err != nil {
+	overlay := p.overlayDirFiles(dir)
+
+	infos, err := p.fs.ReadDir(p.resolve(dir))
+	// Overlay files can be in a directory that does not exist in fs
+	if err != nil && len(overlay) == 0 {
 		diags = append(diags, &hcl.Diagnostic{
 			Severity: hcl.DiagError,
 			Summary:  "Failed to read module directory",
@@ -259,6 +303,7 @@ func (p *Parser) configDirFiles(baseDir, dir string) (primary, override []string
 		return
 	}
 
+	paths := overlay
 	for _, info := range infos {
 		if info.IsDir() {
 			// We only care about files
@@ -271,14 +316,24 @@ func (p *Parser) configDirFiles(baseDir, dir string) (primary, override []string
 			continue
 		}
 
-		baseName := name[:len(name)-len(ext)] // strip extension
-		isOverride := baseName == "override" || strings.HasSuffix(baseName, "_override")
-
 		fullPath := filepath.Join(dir, name)
-		if isOverride {
-			override = append(override, fullPath)
+		if _, exists := p.overlay[fullPath]; exists {
+			// Already listed as an overlay file
+			continue
+		}
+		paths = append(paths, fullPath)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if p.ignored != nil && p.ignored(path) {
+			log.Printf("[DEBUG] Ignore %s", filepath.Join(baseDir, path))
+			continue
+		}
+		if isOverrideFile(path) {
+			override = append(override, path)
 		} else {
-			primary = append(primary, fullPath)
+			primary = append(primary, path)
 		}
 	}
 
@@ -286,7 +341,7 @@ func (p *Parser) configDirFiles(baseDir, dir string) (primary, override []string
 }
 
 func (p *Parser) autoLoadValuesDirFiles(baseDir, dir string) (files []string, diags hcl.Diagnostics) {
-	infos, err := p.fs.ReadDir(dir)
+	infos, err := p.fs.ReadDir(p.resolve(dir))
 	if err != nil {
 		diags = append(diags, &hcl.Diagnostic{
 			Severity: hcl.DiagError,
@@ -317,6 +372,24 @@ func (p *Parser) autoLoadValuesDirFiles(baseDir, dir string) (files []string, di
 	return
 }
 
+// overlayDirFiles returns paths of overlay files in the given directory, sorted lexicographically.
+func (p *Parser) overlayDirFiles(dir string) []string {
+	ret := []string{}
+	for path := range p.overlay {
+		if filepath.Dir(path) == filepath.Clean(dir) {
+			ret = append(ret, path)
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func isOverrideFile(path string) bool {
+	name := filepath.Base(path)
+	baseName := name[:len(name)-len(configFileExt(name))] // strip extension
+	return baseName == "override" || strings.HasSuffix(baseName, "_override")
+}
+
 // configFileExt returns the Terraform configuration extension of the given
 // path, or a blank string if it is not a recognized extension.
 func configFileExt(path string) string {
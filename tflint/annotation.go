@@ -9,6 +9,7 @@ import (
 
 	hcl "github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 // Annotation represents comments with special meaning in TFLint
@@ -46,8 +47,10 @@ func hclAnnotations(path string, file *hcl.File) (Annotations, hcl.Diagnostics)
 		// tflint-ignore annotation
 		match := lineAnnotationPattern.FindStringSubmatch(string(token.Bytes))
 		if len(match) == 2 {
+			content, reason := splitAnnotationReason(match[1])
 			ret = append(ret, &LineAnnotation{
-				Content: strings.TrimSpace(match[1]),
+				Content: content,
+				Reason:  reason,
 				Token:   token,
 			})
 			continue
@@ -65,8 +68,10 @@ func hclAnnotations(path string, file *hcl.File) (Annotations, hcl.Diagnostics)
 				})
 				continue
 			}
+			content, reason := splitAnnotationReason(match[1])
 			ret = append(ret, &FileAnnotation{
-				Content: strings.TrimSpace(match[1]),
+				Content: content,
+				Reason:  reason,
 				Token:   token,
 			})
 			continue
@@ -104,8 +109,10 @@ func jsonAnnotations(path string, file *hcl.File) (Annotations, hcl.Diagnostics)
 			})
 			return ret, diags
 		}
+		content, reason := splitAnnotationReason(config.Comment[matchIndexes[2]:matchIndexes[3]])
 		ret = append(ret, &FileAnnotation{
-			Content: strings.TrimSpace(config.Comment[matchIndexes[2]:matchIndexes[3]]),
+			Content: content,
+			Reason:  reason,
 			Token: hclsyntax.Token{
 				Range: hcl.Range{
 					// Cannot set Start/End because encoding/json does not expose it
@@ -122,12 +129,84 @@ type jsonConfigWithComment struct {
 	Comment string `json:"//,omitempty"`
 }
 
+// annotationRulesPattern matches a comma-separated list of rules.
+// Anything after the list separated by whitespace is treated as a reason.
+var annotationRulesPattern = regexp.MustCompile(`^[^\s,]+(?:\s*,\s*[^\s,]+)*`)
+
+// splitAnnotationReason splits the annotation content into the list of rules and the reason.
+// e.g. "rule_a, rule_b reason" => "rule_a, rule_b", "reason"
+func splitAnnotationReason(content string) (string, string) {
+	content = strings.TrimSpace(content)
+	if match := annotationRulesPattern.FindString(content); match != "" {
+		return match, strings.TrimSpace(content[len(match):])
+	}
+	return content, ""
+}
+
+// annotationRuleNames returns the rules in the comma-separated list of the annotation content
+func annotationRuleNames(content string) []string {
+	rules := strings.Split(content, ",")
+	for i, rule := range rules {
+		rules[i] = strings.TrimSpace(rule)
+	}
+	return rules
+}
+
+// invalidAnnotationRule is the rule of issues for annotations that refer to unknown rules or have extra tokens
+var invalidAnnotationRule = &rule{
+	RawName:     "tflint_invalid_annotation",
+	RawSeverity: sdk.WARNING,
+	RawLink:     "https://github.com/terraform-linters/tflint/blob/master/docs/user-guide/annotations.md",
+}
+
+// Validate returns issues for annotations that refer to rules not in the given rule names.
+// Reasons starting with a rule name are also reported as extra tokens, since they are likely rules without a comma.
+func (a Annotations) Validate(ruleNames []string) Issues {
+	known := func(name string) bool {
+		return name == "all" || name == invalidAnnotationRule.Name() || slices.Contains(ruleNames, name)
+	}
+
+	issues := Issues{}
+	for _, annotation := range a {
+		var kind, content, reason string
+		var rng hcl.Range
+		switch annotation := annotation.(type) {
+		case *LineAnnotation:
+			kind, content, reason, rng = "tflint-ignore", annotation.Content, annotation.Reason, annotation.Token.Range
+		case *FileAnnotation:
+			kind, content, reason, rng = "tflint-ignore-file", annotation.Content, annotation.Reason, annotation.Token.Range
+		default:
+			continue
+		}
+
+		for _, name := range annotationRuleNames(content) {
+			if !known(name) {
+				issues = append(issues, &Issue{
+					Rule:    invalidAnnotationRule,
+					Message: fmt.Sprintf(`%s annotation refers to unknown rule "%s"`, kind, name),
+					Range:   rng,
+				})
+			}
+		}
+		if fields := strings.Fields(reason); len(fields) > 0 && known(fields[0]) {
+			issues = append(issues, &Issue{
+				Rule:    invalidAnnotationRule,
+				Message: fmt.Sprintf(`"%s" in %s annotation is treated as a reason. Separate rules with commas to ignore multiple rules`, fields[0], kind),
+				Range:   rng,
+			})
+		}
+	}
+	return issues
+}
+
 var lineAnnotationPattern = regexp.MustCompile(`tflint-ignore: ([^\n*/#]+)`)
 
 // LineAnnotation is an annotation for ignoring issues in a line
 type LineAnnotation struct {
 	Content string
-	Token   hclsyntax.Token
+	// Reason is the text after the list of rules, if any
+	Reason string
+	Token  hclsyntax.Token
 }
 
 // IsAffected checks if the passed issue is affected with the annotation
@@ -136,10 +215,7 @@ func (a *LineAnnotation) IsAffected(issue *Issue) bool {
 		return false
 	}
 
-	rules := strings.Split(a.Content, ",")
-	for i, rule := range rules {
-		rules[i] = strings.TrimSpace(rule)
-	}
+	rules := annotationRuleNames(a.Content)
 
 	if slices.Contains(rules, issue.Rule.Name()) || slices.Contains(rules, "all") {
 		if a.Token.Range.Start.Line == issue.Range.Start.Line {
@@ -162,7 +238,9 @@ var fileAnnotationPattern = regexp.MustCompile(`tflint-ignore-file: ([^\n*/#]+)`
 // FileAnnotation is an annotation for ignoring issues in a file
 type FileAnnotation struct {
 	Content string
-	Token   hclsyntax.Token
+	// Reason is the text after the list of rules, if any
+	Reason string
+	Token  hclsyntax.Token
 }
 
 // IsAffected checks if the passed issue is affected with the annotation
@@ -171,10 +249,7 @@ func (a *FileAnnotation) IsAffected(issue *Issue) bool {
 		return false
 	}
 
-	rules := strings.Split(a.Content, ",")
-	for i, rule := range rules {
-		rules[i] = strings.TrimSpace(rule)
-	}
+	rules := annotationRuleNames(a.Content)
 
 	if slices.Contains(rules, issue.Rule.Name()) || slices.Contains(rules, "all") {
 		return true
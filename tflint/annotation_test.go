@@ -9,6 +9,7 @@ import (
 	hcl "github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclparse"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 func Test_NewAnnotations(t *testing.T) {
@@ -156,6 +157,53 @@ resource "aws_instance" "foo" {
 				},
 			},
 		},
+		{
+			name:     "with reason separated by space",
+			filename: "resource.tf",
+			src: `
+resource "aws_instance" "foo" {
+  # tflint-ignore: aws_instance_invalid_type, terraform_deprecated_syntax This is a reason
+  instance_type = "t2.micro"
+}`,
+			want: Annotations{
+				&LineAnnotation{
+					Content: "aws_instance_invalid_type, terraform_deprecated_syntax",
+					Reason:  "This is a reason",
+					Token: hclsyntax.Token{
+						Type:  hclsyntax.TokenComment,
+						Bytes: []byte("# tflint-ignore: aws_instance_invalid_type, terraform_deprecated_syntax This is a reason\n"),
+						Range: hcl.Range{
+							Filename: "resource.tf",
+							Start:    hcl.Pos{Line: 3, Column: 3},
+							End:      hcl.Pos{Line: 4, Column: 1},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "tflint-ignore-file annotation with reason separated by space",
+			filename: "resource.tf",
+			src: `# tflint-ignore-file: aws_instance_invalid_type This is a reason
+resource "aws_instance" "foo" {
+  instance_type = "t2.micro"
+}`,
+			want: Annotations{
+				&FileAnnotation{
+					Content: "aws_instance_invalid_type",
+					Reason:  "This is a reason",
+					Token: hclsyntax.Token{
+						Type:  hclsyntax.TokenComment,
+						Bytes: []byte("# tflint-ignore-file: aws_instance_invalid_type This is a reason\n"),
+						Range: hcl.Range{
+							Filename: "resource.tf",
+							Start:    hcl.Pos{Line: 1, Column: 1},
+							End:      hcl.Pos{Line: 2, Column: 1},
+						},
+					},
+				},
+			},
+		},
 		{
 			name:     "tflint-ignore-file annotation",
 			filename: "resource.tf",
@@ -554,3 +602,62 @@ func TestFileAnnotation_IsAffected(t *testing.T) {
 		})
 	}
 }
+
+func TestAnnotations_Validate(t *testing.T) {
+	rng := hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 1, Column: 1}, End: hcl.Pos{Line: 2, Column: 1}}
+	ruleNames := []string{"test_rule", "other_rule"}
+
+	tests := []struct {
+		Name        string
+		Annotations Annotations
+		Expected    []string
+	}{
+		{
+			Name: "known rules",
+			Annotations: Annotations{
+				&LineAnnotation{Content: "test_rule, other_rule", Token: hclsyntax.Token{Range: rng}},
+				&FileAnnotation{Content: "all", Token: hclsyntax.Token{Range: rng}},
+				&LineAnnotation{Content: "tflint_invalid_annotation", Token: hclsyntax.Token{Range: rng}},
+			},
+			Expected: []string{},
+		},
+		{
+			Name: "unknown rules",
+			Annotations: Annotations{
+				&LineAnnotation{Content: "test_rule, unknown_rule", Token: hclsyntax.Token{Range: rng}},
+				&FileAnnotation{Content: "unknown_file_rule", Token: hclsyntax.Token{Range: rng}},
+			},
+			Expected: []string{
+				`tflint-ignore annotation refers to unknown rule "unknown_rule"`,
+				`tflint-ignore-file annotation refers to unknown rule "unknown_file_rule"`,
+			},
+		},
+		{
+			Name: "reasons",
+			Annotations: Annotations{
+				&LineAnnotation{Content: "test_rule", Reason: "this is a reason", Token: hclsyntax.Token{Range: rng}},
+				&LineAnnotation{Content: "test_rule", Reason: "other_rule", Token: hclsyntax.Token{Range: rng}},
+				&FileAnnotation{Content: "test_rule", Reason: "all rules", Token: hclsyntax.Token{Range: rng}},
+			},
+			Expected: []string{
+				`"other_rule" in tflint-ignore annotation is treated as a reason. Separate rules with commas to ignore multiple rules`,
+				`"all" in tflint-ignore-file annotation is treated as a reason. Separate rules with commas to ignore multiple rules`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got := []string{}
+			for _, issue := range test.Annotations.Validate(ruleNames) {
+				if issue.Rule.Name() != "tflint_invalid_annotation" || issue.Rule.Severity() != sdk.WARNING || issue.Range != rng {
+					t.Errorf("unexpected issue: %#v", issue)
+				}
+				got = append(got, issue.Message)
+			}
+			if diff := cmp.Diff(test.Expected, got); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package tflint
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+)
+
+// BaselineVersion is the version of the baseline file format
+const BaselineVersion = 1
+
+// Baseline is a set of fingerprints of issues that existed when the baseline was generated.
+// Fingerprints are the same as Issue.Fingerprint, so issues are still suppressed after unrelated lines are moved.
+type Baseline struct {
+	Version      int      `json:"version"`
+	Fingerprints []string `json:"fingerprints"`
+}
+
+// WriteBaseline writes fingerprints of the issues to the given path.
+// Fingerprints are sorted and deduplicated to minimize diffs when regenerated.
+func (issues Issues) WriteBaseline(path string) error {
+	fingerprints := make([]string, len(issues))
+	for i, issue := range issues {
+		fingerprints[i] = issue.Fingerprint()
+	}
+	slices.Sort(fingerprints)
+
+	out, err := json.MarshalIndent(&Baseline{Version: BaselineVersion, Fingerprints: slices.Compact(fingerprints)}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(out, '\n'), 0644)
+}
+
+// ApplyBaseline returns issues whose fingerprints are not in the baseline file,
+// and the number of suppressed issues.
+func (issues Issues) ApplyBaseline(path string) (Issues, int, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return issues, 0, err
+	}
+
+	var baseline Baseline
+	if err := json.Unmarshal(content, &baseline); err != nil {
+		return issues, 0, fmt.Errorf("%s is not a valid baseline file; %w", path, err)
+	}
+	if baseline.Version != BaselineVersion {
+		return issues, 0, fmt.Errorf("%s has unsupported baseline version %d. Supported version is %d", path, baseline.Version, BaselineVersion)
+	}
+
+	suppressed := map[string]bool{}
+	for _, fingerprint := range baseline.Fingerprints {
+		suppressed[fingerprint] = true
+	}
+
+	ret := Issues{}
+	for _, issue := range issues {
+		if !suppressed[issue.Fingerprint()] {
+			ret = append(ret, issue)
+		}
+	}
+	return ret, len(issues) - len(ret), nil
+}
@@ -0,0 +1,109 @@
+package tflint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_ApplyBaseline(t *testing.T) {
+	// newIssue returns an issue for the first occurrence of the code in the source
+	newIssue := func(name string, source string, code string) *Issue {
+		start := strings.Index(source, code)
+		line := strings.Count(source[:start], "\n") + 1
+		column := start - strings.LastIndex(source[:start], "\n")
+		return &Issue{
+			Rule:    &rule{RawName: name, RawSeverity: sdk.ERROR},
+			Message: "test",
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: line, Column: column, Byte: start},
+				End:      hcl.Pos{Line: line, Column: column + len(code), Byte: start + len(code)},
+			},
+			Source: []byte(source),
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "tflint-baseline.json")
+	before := `foo = 1
+bar = 2
+`
+	beforeIssues := Issues{
+		newIssue("rule_a", before, "foo"),
+		newIssue("rule_b", before, "bar"),
+		newIssue("rule_a", before, "foo"), // duplicated
+	}
+	if err := beforeIssues.WriteBaseline(path); err != nil {
+		t.Fatal(err)
+	}
+
+	// A line is added above the issues, and the code of rule_b is changed
+	after := `# comment
+foo = 1
+baz = 2
+qux = 3
+`
+	afterIssues := Issues{
+		newIssue("rule_a", after, "foo"),
+		newIssue("rule_b", after, "baz"),
+		newIssue("rule_c", after, "qux"),
+	}
+	got, suppressed, err := afterIssues.ApplyBaseline(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if suppressed != 1 {
+		t.Errorf("expected 1 suppressed issue, but got %d", suppressed)
+	}
+	if diff := cmp.Diff(Issues{afterIssues[1], afterIssues[2]}, got); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func Test_ApplyBaseline_errors(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "invalid JSON",
+			content: "{",
+			want:    "is not a valid baseline file; unexpected end of JSON input",
+		},
+		{
+			name:    "unsupported version",
+			content: `{"version": 2, "fingerprints": []}`,
+			want:    "has unsupported baseline version 2. Supported version is 1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(dir, "tflint-baseline.json")
+			if err := os.WriteFile(path, []byte(test.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, _, err := Issues{}.ApplyBaseline(path)
+			if err == nil {
+				t.Fatal("should return an error")
+			}
+			if diff := cmp.Diff(path+" "+test.want, err.Error()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+
+	if _, _, err := (Issues{}).ApplyBaseline(filepath.Join(dir, "not_found.json")); !os.IsNotExist(err) {
+		t.Errorf("expected not exist error, but got %s", err)
+	}
+}
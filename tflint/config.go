@@ -1,11 +1,16 @@
 package tflint
 
 import (
+	"cmp"
 	"fmt"
 	"log"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/bmatcuk/doublestar"
 	"github.com/hashicorp/go-version"
 	hcl "github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
@@ -37,6 +42,24 @@ var configSchema = &hcl.BodySchema{
 			Type:       "plugin",
 			LabelNames: []string{"name"},
 		},
+		{
+			Type: "override",
+		},
+		{
+			Type: "format",
+		},
+	},
+}
+
+var overrideConfigSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "path", Required: true},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{
+			Type:       "rule",
+			LabelNames: []string{"name"},
+		},
 	},
 }
 
@@ -50,6 +73,9 @@ var innerConfigSchema = &hcl.BodySchema{
 		{Name: "disabled_by_default"},
 		{Name: "plugin_dir"},
 		{Name: "format"},
+		{Name: "minimum_failure_severity"},
+		{Name: "exclude_dirs"},
+		{Name: "skip_dirs"},
 
 		// Removed attributes
 		{Name: "module"},
@@ -63,6 +89,20 @@ var validFormats = []string{
 	"junit",
 	"compact",
 	"sarif",
+	"gitlab",
+	"github",
+	"rdjson",
+	"tap",
+	"csv",
+	"markdown",
+	"html",
+	"azure-devops",
+	"teamcity",
+	"sonarqube",
+	"template",
+	"jsonl",
+	"stream",
+	"unix",
 }
 
 // Config describes the behavior of TFLint
@@ -75,12 +115,24 @@ type Config struct {
 
 	DisabledByDefault    bool
 	DisabledByDefaultSet bool
+	// DisabledByDefaultWithoutFile disables rules by default when merged into a config
+	// that is not loaded from any file. It is always false in config files.
+	DisabledByDefaultWithoutFile bool
 
 	PluginDir    string
 	PluginDirSet bool
 
 	Format    string
 	FormatSet bool
+	// FormatOptions are options for each format declared in "format" blocks.
+	// They are validated by the formatter because accepted options depend on the format.
+	FormatOptions map[string]map[string]string
+
+	OutputFile    string
+	OutputFileSet bool
+
+	MinimumFailureSeverity    string
+	MinimumFailureSeveritySet bool
 
 	Varfiles      []string
 	Variables     []string
@@ -88,15 +140,41 @@ type Config struct {
 	IgnoreModules map[string]bool
 	Rules         map[string]*RuleConfig
 	Plugins       map[string]*PluginConfig
+	Overrides     []*OverrideConfig
+
+	// ExcludeDirs are doublestar patterns of directories pruned from the walk in recursive inspection.
+	// They are relative to the base directory of the walk.
+	ExcludeDirs []string
+	// SkipDirs are doublestar patterns of directories that never contain root modules, e.g. vendored modules.
+	// They are skipped in recursive inspection in addition to the built-in ones, and not counted as excluded.
+	SkipDirs []string
 
 	sources map[string][]byte
 }
 
 // RuleConfig is a TFLint's rule config
 type RuleConfig struct {
-	Name    string   `hcl:"name,label"`
-	Enabled bool     `hcl:"enabled"`
-	Body    hcl.Body `hcl:",remain"`
+	Name    string `hcl:"name,label"`
+	Enabled bool   `hcl:"enabled"`
+	// Severity overrides the default severity of the rule. The default is used if empty.
+	Severity string `hcl:"severity,optional"`
+	// Threshold is the number of issues of the rule tolerated in a run. Issues of the rule
+	// affect the exit status only if their number exceeds it. All issues affect it if nil.
+	Threshold *int     `hcl:"threshold,optional"`
+	Body      hcl.Body `hcl:",remain"`
+}
+
+// OverrideConfig is a config applied only to files matching the path
+type OverrideConfig struct {
+	// Path is a glob pattern relative to the working directory with forward slashes
+	Path  string
+	Rules map[string]*RuleConfig
+}
+
+// formatConfig is a "format" block
+type formatConfig struct {
+	Name    string            `hcl:"name"`
+	Options map[string]string `hcl:"options,optional"`
 }
 
 // PluginConfig is a TFLint's plugin config
@@ -134,16 +212,26 @@ func EmptyConfig() *Config {
 // The priority of the configuration files is as follows:
 //
 // 1. file passed by the --config option
-// 2. file set by the TFLINT_CONFIG_FILE environment variable
+// 2. file set by the TFLINT_CONFIG_FILE (or TFLINT_CONFIG) environment variable
 // 3. current directory (./.tflint.hcl)
-// 4. home directory (~/.tflint.hcl)
+// 4. parent directories up to the git repository root (../.tflint.hcl, ../../.tflint.hcl, ...)
+// 5. home directory (~/.tflint.hcl)
 //
 // For 1 and 2, if the file does not exist, an error will be returned immediately.
-// If 3 fails, fallback to 4, and If it fails, an empty configuration is returned.
+// If 3 fails, fallback to 4 and 5, and If they fail, an empty configuration is returned.
 //
 // It also automatically enables bundled plugin if the "terraform"
 // plugin block is not explicitly declared.
 func LoadConfig(fs afero.Afero, file string) (*Config, error) {
+	return loadConfigFile(fs, file, true)
+}
+
+// LoadConfigWithoutDiscovery is the same as LoadConfig, but does not search parent directories.
+func LoadConfigWithoutDiscovery(fs afero.Afero, file string) (*Config, error) {
+	return loadConfigFile(fs, file, false)
+}
+
+func loadConfigFile(fs afero.Afero, file string, discovery bool) (*Config, error) {
 	// Load the file passed by the --config option
 	if file != "" {
 		log.Printf("[INFO] Load config: %s", file)
@@ -159,9 +247,15 @@ func LoadConfig(fs afero.Afero, file string) (*Config, error) {
 	}
 
 	// Load the file set by the environment variable
-	envFile := os.Getenv("TFLINT_CONFIG_FILE")
+	// TFLINT_CONFIG is an alias of TFLINT_CONFIG_FILE. If both are set, TFLINT_CONFIG_FILE takes precedence.
+	envName := "TFLINT_CONFIG_FILE"
+	envFile := os.Getenv(envName)
+	if envFile == "" {
+		envName = "TFLINT_CONFIG"
+		envFile = os.Getenv(envName)
+	}
 	if envFile != "" {
-		log.Printf("[INFO] Found TFLINT_CONFIG_FILE. Load config: %s", envFile)
+		log.Printf("[INFO] Found %s. Load config: %s", envName, envFile)
 		f, err := fs.Open(envFile)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load file: %w", err)
@@ -184,6 +278,21 @@ func LoadConfig(fs afero.Afero, file string) (*Config, error) {
 	}
 	log.Printf("[INFO] file not found")
 
+	// Load the config file in parent directories
+	if discovery {
+		if parent, exists := discoverConfigFile(fs); exists {
+			f, err := fs.Open(parent)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load file: %w", err)
+			}
+			cfg, err := loadConfig(f)
+			if err != nil {
+				return nil, err
+			}
+			return cfg.enableBundledPlugin(), nil
+		}
+	}
+
 	// Load the fallback config file
 	fallback, err := homedir.Expand(fallbackConfigFile)
 	if err != nil {
@@ -300,6 +409,37 @@ func loadConfig(file afero.File) (*Config, error) {
 						return config, fmt.Errorf("%s is invalid format. Allowed formats are: %s", config.Format, strings.Join(validFormats, ", "))
 					}
 
+				case "minimum_failure_severity":
+					config.MinimumFailureSeveritySet = true
+					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.MinimumFailureSeverity); err != nil {
+						return config, err
+					}
+					if _, err := NewSeverity(config.MinimumFailureSeverity); err != nil {
+						return config, fmt.Errorf("%s is invalid minimum_failure_severity. Allowed values are: error, warning, notice", config.MinimumFailureSeverity)
+					}
+
+				case "exclude_dirs":
+					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.ExcludeDirs); err != nil {
+						return config, err
+					}
+					for idx, pattern := range config.ExcludeDirs {
+						config.ExcludeDirs[idx], err = ParseExcludeDirPattern(pattern)
+						if err != nil {
+							return config, err
+						}
+					}
+
+				case "skip_dirs":
+					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.SkipDirs); err != nil {
+						return config, err
+					}
+					for idx, pattern := range config.SkipDirs {
+						config.SkipDirs[idx], err = ParseExcludeDirPattern(pattern)
+						if err != nil {
+							return config, err
+						}
+					}
+
 				// Removed attributes
 				case "module":
 					return config, fmt.Errorf(`"module" attribute was removed in v0.54.0. Use "call_module_type" instead`)
@@ -314,8 +454,37 @@ func loadConfig(file afero.File) (*Config, error) {
 			if err := gohcl.DecodeBody(block.Body, nil, ruleConfig); err != nil {
 				return config, err
 			}
+			if err := ruleConfig.validate(); err != nil {
+				return config, err
+			}
 			config.Rules[block.Labels[0]] = ruleConfig
 
+		case "override":
+			overrideConfig, err := decodeOverrideConfig(block)
+			if err != nil {
+				return config, err
+			}
+			config.Overrides = append(config.Overrides, overrideConfig)
+
+		case "format":
+			formatConfig := &formatConfig{}
+			if err := gohcl.DecodeBody(block.Body, nil, formatConfig); err != nil {
+				return config, err
+			}
+			if !slices.Contains(validFormats, formatConfig.Name) {
+				return config, fmt.Errorf(`format "%s": invalid format. Allowed formats are: %s`, formatConfig.Name, strings.Join(validFormats, ", "))
+			}
+			if _, exists := config.FormatOptions[formatConfig.Name]; exists {
+				return config, fmt.Errorf(`format "%s": multiple "format" blocks for the same format are not allowed`, formatConfig.Name)
+			}
+			if formatConfig.Options == nil {
+				formatConfig.Options = map[string]string{}
+			}
+			if config.FormatOptions == nil {
+				config.FormatOptions = map[string]map[string]string{}
+			}
+			config.FormatOptions[formatConfig.Name] = formatConfig.Options
+
 		case "plugin":
 			pluginConfig := &PluginConfig{Name: block.Labels[0]}
 			if err := gohcl.DecodeBody(block.Body, nil, pluginConfig); err != nil {
@@ -342,9 +511,16 @@ func loadConfig(file afero.File) (*Config, error) {
 	log.Printf("[DEBUG]   PluginDirSet: %t", config.PluginDirSet)
 	log.Printf("[DEBUG]   Format: %s", config.Format)
 	log.Printf("[DEBUG]   FormatSet: %t", config.FormatSet)
+	log.Printf("[DEBUG]   MinimumFailureSeverity: %s", config.MinimumFailureSeverity)
+	log.Printf("[DEBUG]   FormatOptions:")
+	for name, options := range config.FormatOptions {
+		log.Printf("[DEBUG]     %s: %v", name, options)
+	}
 	log.Printf("[DEBUG]   Varfiles: %s", strings.Join(config.Varfiles, ", "))
 	log.Printf("[DEBUG]   Variables: %s", strings.Join(config.Variables, ", "))
 	log.Printf("[DEBUG]   Only: %s", strings.Join(config.Only, ", "))
+	log.Printf("[DEBUG]   ExcludeDirs: %s", strings.Join(config.ExcludeDirs, ", "))
+	log.Printf("[DEBUG]   SkipDirs: %s", strings.Join(config.SkipDirs, ", "))
 	log.Printf("[DEBUG]   IgnoreModules:")
 	for name, ignore := range config.IgnoreModules {
 		log.Printf("[DEBUG]     %s: %t", name, ignore)
@@ -357,6 +533,75 @@ func loadConfig(file afero.File) (*Config, error) {
 	for name, plugin := range config.Plugins {
 		log.Printf("[DEBUG]     %s: enabled=%t, version=%s, source=%s", name, plugin.Enabled, plugin.Version, plugin.Source)
 	}
+	log.Printf("[DEBUG]   Overrides:")
+	for _, override := range config.Overrides {
+		log.Printf("[DEBUG]     %s:", override.Path)
+		for name, rule := range override.Rules {
+			log.Printf("[DEBUG]       %s: %t", name, rule.Enabled)
+		}
+	}
+
+	return config, nil
+}
+
+// discoverConfigFile searches the config file in parent directories of the current directory.
+// The search stops at the git repository root (a directory containing .git) or the filesystem root.
+// The current directory itself is not searched, so it returns false if the current directory is the repository root.
+func discoverConfigFile(fs afero.Afero) (string, bool) {
+	dir, err := filepath.Abs(".")
+	if err != nil {
+		log.Printf("[ERROR] Failed to get the current directory: %s", err)
+		return "", false
+	}
+
+	for {
+		if _, err := fs.Stat(filepath.Join(dir, ".git")); err == nil {
+			log.Printf("[DEBUG] Stop searching config files at the repository root: %s", dir)
+			return "", false
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+
+		path := filepath.Join(dir, defaultConfigFile)
+		log.Printf("[INFO] Load config: %s", path)
+		if _, err := fs.Stat(path); err == nil {
+			return path, true
+		}
+		log.Printf("[INFO] file not found")
+	}
+}
+
+func decodeOverrideConfig(block *hcl.Block) (*OverrideConfig, error) {
+	content, diags := block.Body.Content(overrideConfigSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	config := &OverrideConfig{Rules: map[string]*RuleConfig{}}
+	if err := gohcl.DecodeExpression(content.Attributes["path"].Expr, nil, &config.Path); err != nil {
+		return nil, err
+	}
+	config.Path = filepath.ToSlash(config.Path)
+	// Matching the pattern against itself walks the entire pattern, so that syntax errors are detected
+	if _, err := doublestar.Match(config.Path, config.Path); err != nil {
+		return nil, fmt.Errorf("Failed to parse override path `%s`; %w", config.Path, err)
+	}
+
+	for _, block := range content.Blocks {
+		ruleConfig := &RuleConfig{Name: block.Labels[0]}
+		if err := gohcl.DecodeBody(block.Body, nil, ruleConfig); err != nil {
+			return nil, err
+		}
+		// Rule options are passed to plugins for the whole module, so they cannot be applied per file
+		attrs, diags := ruleConfig.Body.JustAttributes()
+		if diags.HasErrors() || len(attrs) > 0 || ruleConfig.Severity != "" || ruleConfig.Threshold != nil {
+			return nil, fmt.Errorf(`override "%s": rule "%s": only "enabled" can be set in rule blocks in override blocks`, config.Path, ruleConfig.Name)
+		}
+		config.Rules[ruleConfig.Name] = ruleConfig
+	}
 
 	return config, nil
 }
@@ -476,6 +721,14 @@ func (c *Config) enableBundledPlugin() *Config {
 	return c
 }
 
+// isBundledPluginConfigBody checks whether the body is the implicit config of the bundled plugin
+func isBundledPluginConfigBody(body hcl.Body) bool {
+	if body == nil {
+		return false
+	}
+	return body.MissingItemRange().Filename == bundledPluginConfigFilename
+}
+
 // Sources returns parsed config file sources.
 // To support bundle plugin config, this function returns c.sources
 // with a merge of the pseudo config file.
@@ -501,9 +754,18 @@ func (c *Config) Merge(other *Config) {
 		c.ForceSet = true
 		c.Force = other.Force
 	}
-	if other.DisabledByDefaultSet {
+	disabledByDefault, disabledByDefaultSet := other.DisabledByDefault, other.DisabledByDefaultSet
+	if other.DisabledByDefaultWithoutFile && len(c.sources) == 0 {
+		disabledByDefault, disabledByDefaultSet = true, true
+	}
+	if disabledByDefaultSet {
 		c.DisabledByDefaultSet = true
-		c.DisabledByDefault = other.DisabledByDefault
+		c.DisabledByDefault = disabledByDefault
+
+		// Implicit preset is ignored if you enable DisabledByDefault through the CLI
+		if plugin, exists := c.Plugins["terraform"]; exists && c.DisabledByDefault && isBundledPluginConfigBody(plugin.Body) {
+			plugin.Body = nil
+		}
 	}
 	if other.PluginDirSet {
 		c.PluginDirSet = true
@@ -513,10 +775,29 @@ func (c *Config) Merge(other *Config) {
 		c.FormatSet = true
 		c.Format = other.Format
 	}
+	for name, options := range other.FormatOptions {
+		if c.FormatOptions == nil {
+			c.FormatOptions = map[string]map[string]string{}
+		}
+		if _, exists := c.FormatOptions[name]; !exists {
+			c.FormatOptions[name] = map[string]string{}
+		}
+		maps.Copy(c.FormatOptions[name], options)
+	}
+	if other.OutputFileSet {
+		c.OutputFileSet = true
+		c.OutputFile = other.OutputFile
+	}
+	if other.MinimumFailureSeveritySet {
+		c.MinimumFailureSeveritySet = true
+		c.MinimumFailureSeverity = other.MinimumFailureSeverity
+	}
 
 	c.Varfiles = append(c.Varfiles, other.Varfiles...)
 	c.Variables = append(c.Variables, other.Variables...)
 	c.Only = append(c.Only, other.Only...)
+	c.ExcludeDirs = append(c.ExcludeDirs, other.ExcludeDirs...)
+	c.SkipDirs = append(c.SkipDirs, other.SkipDirs...)
 
 	for name, ignore := range other.IgnoreModules {
 		c.IgnoreModules[name] = ignore
@@ -532,6 +813,8 @@ func (c *Config) Merge(other *Config) {
 		}
 	}
 
+	c.Overrides = append(c.Overrides, other.Overrides...)
+
 	for name, plugin := range other.Plugins {
 		// HACK: If you enable the plugin through the CLI instead of the file, its hcl.Body will be nil.
 		//       In this case, only override Enabled flag
@@ -543,6 +826,74 @@ func (c *Config) Merge(other *Config) {
 	}
 }
 
+// ParseExcludeDirPattern normalizes the pattern of exclude_dirs, skip_dirs, and --exclude-dir to forward slashes
+// and returns an error if it is not a valid doublestar pattern. Trailing slashes are allowed.
+func ParseExcludeDirPattern(pattern string) (string, error) {
+	normalized := strings.TrimSuffix(filepath.ToSlash(pattern), "/")
+	// Matching the pattern against itself walks the entire pattern, so that syntax errors are detected
+	if _, err := doublestar.Match(normalized, normalized); err != nil {
+		return "", fmt.Errorf("Failed to parse exclude pattern `%s`; %w", pattern, err)
+	}
+	return normalized, nil
+}
+
+// ConfigForFile returns the config applied to the file.
+// The path must be relative to the working directory. Rules in override blocks
+// matching the path are layered in order of specificity, so more specific paths take precedence.
+func (c *Config) ConfigForFile(path string) *Config {
+	path = filepath.ToSlash(filepath.Clean(path))
+
+	overrides := []*OverrideConfig{}
+	for _, override := range c.Overrides {
+		if matched, _ := doublestar.Match(override.Path, path); matched {
+			overrides = append(overrides, override)
+		}
+	}
+	if len(overrides) == 0 {
+		return c
+	}
+	// Overrides with the same specificity are applied in the declared order
+	slices.SortStableFunc(overrides, func(a, b *OverrideConfig) int {
+		return compareOverrideSpecificity(a.Path, b.Path)
+	})
+
+	ret := *c
+	ret.Rules = maps.Clone(c.Rules)
+	for _, override := range overrides {
+		for name, rule := range override.Rules {
+			if existing, exists := ret.Rules[name]; exists {
+				// Keep rule options in the global config
+				merged := *existing
+				merged.Enabled = rule.Enabled
+				ret.Rules[name] = &merged
+			} else {
+				ret.Rules[name] = rule
+			}
+		}
+	}
+	return &ret
+}
+
+// compareOverrideSpecificity compares the specificity of paths.
+// Paths without wildcards are more specific than globs,
+// and globs with more literal characters are more specific.
+func compareOverrideSpecificity(a, b string) int {
+	literal := func(pattern string) (bool, int) {
+		wildcards := strings.Count(pattern, "*") + strings.Count(pattern, "?") + strings.Count(pattern, "[") + strings.Count(pattern, "{")
+		return wildcards == 0, len(pattern) - wildcards
+	}
+	aExact, aLen := literal(a)
+	bExact, bLen := literal(b)
+
+	if aExact != bExact {
+		if aExact {
+			return 1
+		}
+		return -1
+	}
+	return cmp.Compare(aLen, bLen)
+}
+
 // ToPluginConfig converts self into the plugin configuration format
 func (c *Config) ToPluginConfig() *sdk.Config {
 	cfg := &sdk.Config{
@@ -580,31 +931,89 @@ type RuleSet interface {
 
 // ValidateRules checks for duplicate rule names, for invalid rule names, and so on.
 func (c *Config) ValidateRules(rulesets ...RuleSet) error {
+	unknown, err := c.UnknownRules(rulesets...)
+	if err != nil {
+		return err
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("Rule not found: %s", strings.Join(unknown, ", "))
+	}
+	return c.ValidateOverrides()
+}
+
+// ValidateOverrides checks that override blocks do not enable rules disabled in the global config.
+// Disabled rules are not run by plugins, so they cannot be enabled only for files matching the path.
+func (c *Config) ValidateOverrides() error {
+	for _, override := range c.Overrides {
+		for _, name := range slices.Sorted(maps.Keys(override.Rules)) {
+			if override.Rules[name].Enabled && c.ruleDisabled(name) {
+				return fmt.Errorf(`override "%s": rule "%s": rules disabled in the global config cannot be enabled in override blocks`, override.Path, name)
+			}
+		}
+	}
+	return nil
+}
+
+// ruleDisabled returns true if the rule is disabled in the global config by --only, the rule block, or disabled_by_default
+func (c *Config) ruleDisabled(name string) bool {
+	if len(c.Only) > 0 {
+		return !slices.Contains(c.Only, name)
+	}
+	if rule, exists := c.Rules[name]; exists {
+		return !rule.Enabled
+	}
+	return c.DisabledByDefault
+}
+
+// UnknownRules returns sorted names of rules declared in the config but not provided by any of the rulesets.
+// Rule names duplicated in the rulesets are returned as an error.
+func (c *Config) UnknownRules(rulesets ...RuleSet) ([]string, error) {
 	rulesMap := map[string]string{}
 	for _, ruleset := range rulesets {
 		rulesetName, err := ruleset.RuleSetName()
 		if err != nil {
-			return err
+			return nil, err
 		}
 		ruleNames, err := ruleset.RuleNames()
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		for _, rule := range ruleNames {
 			if existsName, exists := rulesMap[rule]; exists {
-				return fmt.Errorf(`"%s" is duplicated in %s and %s`, rule, existsName, rulesetName)
+				return nil, fmt.Errorf(`"%s" is duplicated in %s and %s`, rule, existsName, rulesetName)
 			}
 			rulesMap[rule] = rulesetName
 		}
 	}
 
+	unknown := []string{}
 	for _, rule := range c.Rules {
 		if _, exists := rulesMap[rule.Name]; !exists {
-			return fmt.Errorf("Rule not found: %s", rule.Name)
+			unknown = append(unknown, rule.Name)
 		}
 	}
+	for _, override := range c.Overrides {
+		for _, rule := range override.Rules {
+			if _, exists := rulesMap[rule.Name]; !exists {
+				unknown = append(unknown, rule.Name)
+			}
+		}
+	}
+	slices.Sort(unknown)
 
+	return slices.Compact(unknown), nil
+}
+
+func (c *RuleConfig) validate() error {
+	if c.Severity != "" {
+		if _, err := NewSeverity(c.Severity); err != nil {
+			return fmt.Errorf(`rule "%s": "severity" must be one of "error", "warning", or "notice", but got "%s"`, c.Name, c.Severity)
+		}
+	}
+	if c.Threshold != nil && *c.Threshold < 0 {
+		return fmt.Errorf(`rule "%s": "threshold" must be greater than or equal to 0, but got %d`, c.Name, *c.Threshold)
+	}
 	return nil
 }
 
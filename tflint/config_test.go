@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -19,6 +20,7 @@ import (
 func TestLoadConfig(t *testing.T) {
 	// default error check helper
 	neverHappend := func(err error) bool { return err != nil }
+	threshold := 5
 
 	tests := []struct {
 		name     string
@@ -43,6 +45,7 @@ config {
 
 	call_module_type = "all"
 	force = true
+	minimum_failure_severity = "warning"
 
 	ignore_module = {
 		"github.com/terraform-linters/example-module" = true
@@ -51,6 +54,10 @@ config {
 	varfile = ["example1.tfvars", "example2.tfvars"]
 
 	variables = ["foo=bar", "bar=['foo']"]
+
+	exclude_dirs = ["**/examples", "test/fixtures/"]
+
+	skip_dirs = ["**/third_party/modules/"]
 }
 
 rule "aws_instance_invalid_type" {
@@ -86,13 +93,17 @@ plugin "baz" {
 				IgnoreModules: map[string]bool{
 					"github.com/terraform-linters/example-module": true,
 				},
-				Varfiles:          []string{"example1.tfvars", "example2.tfvars"},
-				Variables:         []string{"foo=bar", "bar=['foo']"},
-				DisabledByDefault: false,
-				PluginDir:         "~/.tflint.d/plugins",
-				PluginDirSet:      true,
-				Format:            "compact",
-				FormatSet:         true,
+				Varfiles:                  []string{"example1.tfvars", "example2.tfvars"},
+				Variables:                 []string{"foo=bar", "bar=['foo']"},
+				DisabledByDefault:         false,
+				PluginDir:                 "~/.tflint.d/plugins",
+				PluginDirSet:              true,
+				Format:                    "compact",
+				FormatSet:                 true,
+				MinimumFailureSeverity:    "warning",
+				MinimumFailureSeveritySet: true,
+				ExcludeDirs:               []string{"**/examples", "test/fixtures"},
+				SkipDirs:                  []string{"**/third_party/modules"},
 				Rules: map[string]*RuleConfig{
 					"aws_instance_invalid_type": {
 						Name:    "aws_instance_invalid_type",
@@ -169,6 +180,69 @@ config {
 			},
 			errCheck: neverHappend,
 		},
+		{
+			name: "TFLINT_CONFIG",
+			file: "",
+			files: map[string]string{
+				"env.hcl": `
+config {
+	force = true
+}`,
+			},
+			envs: map[string]string{
+				"TFLINT_CONFIG": "env.hcl",
+			},
+			want: &Config{
+				CallModuleType: terraform.CallLocalModule,
+				Force:          true,
+				ForceSet:       true,
+				IgnoreModules:  map[string]bool{},
+				Varfiles:       []string{},
+				Variables:      []string{},
+				Rules:          map[string]*RuleConfig{},
+				Plugins: map[string]*PluginConfig{
+					"terraform": {
+						Name:    "terraform",
+						Enabled: true,
+					},
+				},
+			},
+			errCheck: neverHappend,
+		},
+		{
+			name: "prefer TFLINT_CONFIG_FILE over TFLINT_CONFIG",
+			file: "",
+			files: map[string]string{
+				"env.hcl": `
+config {
+	force = true
+}`,
+				"alias.hcl": `
+config {
+	disabled_by_default = true
+}`,
+			},
+			envs: map[string]string{
+				"TFLINT_CONFIG_FILE": "env.hcl",
+				"TFLINT_CONFIG":      "alias.hcl",
+			},
+			want: &Config{
+				CallModuleType: terraform.CallLocalModule,
+				Force:          true,
+				ForceSet:       true,
+				IgnoreModules:  map[string]bool{},
+				Varfiles:       []string{},
+				Variables:      []string{},
+				Rules:          map[string]*RuleConfig{},
+				Plugins: map[string]*PluginConfig{
+					"terraform": {
+						Name:    "terraform",
+						Enabled: true,
+					},
+				},
+			},
+			errCheck: neverHappend,
+		},
 		{
 			name: "default home config",
 			file: "",
@@ -237,6 +311,16 @@ plugin "terraform" {
 				return err == nil || err.Error() != "failed to load file: open not_found.hcl: file does not exist"
 			},
 		},
+		{
+			name: "file not found with TFLINT_CONFIG",
+			file: "",
+			envs: map[string]string{
+				"TFLINT_CONFIG": "not_found.hcl",
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != "failed to load file: open not_found.hcl: file does not exist"
+			},
+		},
 		{
 			name: "file not found with TFLINT_CONFIG_FILE",
 			file: "",
@@ -280,7 +364,46 @@ config {
 }`,
 			},
 			errCheck: func(err error) bool {
-				return err == nil || err.Error() != "invalid is invalid format. Allowed formats are: default, json, checkstyle, junit, compact, sarif"
+				return err == nil || err.Error() != "invalid is invalid format. Allowed formats are: default, json, checkstyle, junit, compact, sarif, gitlab, github, rdjson, tap, csv, markdown, html, azure-devops, teamcity, sonarqube, template, jsonl, stream, unix"
+			},
+		},
+		{
+			name: "invalid minimum_failure_severity",
+			file: "invalid_minimum_failure_severity.hcl",
+			files: map[string]string{
+				"invalid_minimum_failure_severity.hcl": `
+config {
+	minimum_failure_severity = "info"
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != "info is invalid minimum_failure_severity. Allowed values are: error, warning, notice"
+			},
+		},
+		{
+			name: "invalid exclude_dirs",
+			file: "invalid_exclude_dirs.hcl",
+			files: map[string]string{
+				"invalid_exclude_dirs.hcl": `
+config {
+	exclude_dirs = ["["]
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != "Failed to parse exclude pattern `[`; syntax error in pattern"
+			},
+		},
+		{
+			name: "invalid skip_dirs",
+			file: "invalid_skip_dirs.hcl",
+			files: map[string]string{
+				"invalid_skip_dirs.hcl": `
+config {
+	skip_dirs = ["["]
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != "Failed to parse exclude pattern `[`; syntax error in pattern"
 			},
 		},
 		{
@@ -417,6 +540,39 @@ config {
 			},
 			errCheck: neverHappend,
 		},
+		{
+			name: "prefer the passed file over TFLINT_CONFIG",
+			file: "cli.hcl",
+			files: map[string]string{
+				"cli.hcl": `
+config {
+	force = true
+}`,
+				"env.hcl": `
+config {
+	disabled_by_default = true
+}`,
+			},
+			envs: map[string]string{
+				"TFLINT_CONFIG": "env.hcl",
+			},
+			want: &Config{
+				CallModuleType: terraform.CallLocalModule,
+				Force:          true,
+				ForceSet:       true,
+				IgnoreModules:  map[string]bool{},
+				Varfiles:       []string{},
+				Variables:      []string{},
+				Rules:          map[string]*RuleConfig{},
+				Plugins: map[string]*PluginConfig{
+					"terraform": {
+						Name:    "terraform",
+						Enabled: true,
+					},
+				},
+			},
+			errCheck: neverHappend,
+		},
 		{
 			name: "valid required_version",
 			file: "config.hcl",
@@ -426,37 +582,320 @@ tflint {
   required_version = ">= 0.50"
 }`,
 			},
-			want:     EmptyConfig().enableBundledPlugin(),
+			want:     EmptyConfig().enableBundledPlugin(),
+			errCheck: neverHappend,
+		},
+		{
+			name: "invalid required_version",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+tflint {
+  required_version = "< 0.50"
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != fmt.Sprintf(`config.hcl:3,22-30: Unsupported TFLint version; This config does not support the currently used TFLint version %s. Please update to another supported version or change the version requirement.`, Version)
+			},
+		},
+		{
+			name: "multiple required_versions",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+tflint {
+  required_version = ">= 0.50"
+}
+
+tflint {
+  required_version = "< 0.50"
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != `config.hcl:6,1-7: Multiple "tflint" blocks are not allowed; The "tflint" block is already found in config.hcl:2,1-7, but found the second one.`
+			},
+		},
+		{
+			name: "override blocks",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+rule "aws_instance_invalid_type" {
+  enabled = true
+}
+
+override {
+  path = "modules/**"
+
+  rule "aws_instance_invalid_type" {
+    enabled = false
+  }
+}`,
+			},
+			want: &Config{
+				CallModuleType: terraform.CallLocalModule,
+				IgnoreModules:  map[string]bool{},
+				Varfiles:       []string{},
+				Variables:      []string{},
+				Rules: map[string]*RuleConfig{
+					"aws_instance_invalid_type": {
+						Name:    "aws_instance_invalid_type",
+						Enabled: true,
+					},
+				},
+				Plugins: map[string]*PluginConfig{
+					"terraform": {
+						Name:    "terraform",
+						Enabled: true,
+					},
+				},
+				Overrides: []*OverrideConfig{
+					{
+						Path: "modules/**",
+						Rules: map[string]*RuleConfig{
+							"aws_instance_invalid_type": {
+								Name:    "aws_instance_invalid_type",
+								Enabled: false,
+							},
+						},
+					},
+				},
+			},
+			errCheck: neverHappend,
+		},
+		{
+			name: "override block without path",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+override {
+  rule "aws_instance_invalid_type" {
+    enabled = false
+  }
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != `config.hcl:2,10-10: Missing required argument; The argument "path" is required, but no definition was found.`
+			},
+		},
+		{
+			name: "override block with rule options",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+override {
+  path = "modules/**"
+
+  rule "aws_instance_invalid_type" {
+    enabled = false
+    foo     = "bar"
+  }
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != `override "modules/**": rule "aws_instance_invalid_type": only "enabled" can be set in rule blocks in override blocks`
+			},
+		},
+		{
+			name: "rule severity",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+rule "aws_instance_invalid_type" {
+  enabled  = true
+  severity = "warning"
+}`,
+			},
+			want: &Config{
+				CallModuleType: terraform.CallLocalModule,
+				IgnoreModules:  map[string]bool{},
+				Varfiles:       []string{},
+				Variables:      []string{},
+				Rules: map[string]*RuleConfig{
+					"aws_instance_invalid_type": {
+						Name:     "aws_instance_invalid_type",
+						Enabled:  true,
+						Severity: "warning",
+					},
+				},
+				Plugins: map[string]*PluginConfig{
+					"terraform": {
+						Name:    "terraform",
+						Enabled: true,
+					},
+				},
+			},
+			errCheck: neverHappend,
+		},
+		{
+			name: "invalid rule severity",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+rule "aws_instance_invalid_type" {
+  enabled  = true
+  severity = "info"
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != `rule "aws_instance_invalid_type": "severity" must be one of "error", "warning", or "notice", but got "info"`
+			},
+		},
+		{
+			name: "rule threshold",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+rule "aws_instance_invalid_type" {
+  enabled   = true
+  threshold = 5
+}`,
+			},
+			want: &Config{
+				CallModuleType: terraform.CallLocalModule,
+				IgnoreModules:  map[string]bool{},
+				Varfiles:       []string{},
+				Variables:      []string{},
+				Rules: map[string]*RuleConfig{
+					"aws_instance_invalid_type": {
+						Name:      "aws_instance_invalid_type",
+						Enabled:   true,
+						Threshold: &threshold,
+					},
+				},
+				Plugins: map[string]*PluginConfig{
+					"terraform": {
+						Name:    "terraform",
+						Enabled: true,
+					},
+				},
+			},
+			errCheck: neverHappend,
+		},
+		{
+			name: "negative rule threshold",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+rule "aws_instance_invalid_type" {
+  enabled   = true
+  threshold = -1
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != `rule "aws_instance_invalid_type": "threshold" must be greater than or equal to 0, but got -1`
+			},
+		},
+		{
+			name: "override block with rule threshold",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+override {
+  path = "modules/**"
+
+  rule "aws_instance_invalid_type" {
+    enabled   = true
+    threshold = 5
+  }
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != `override "modules/**": rule "aws_instance_invalid_type": only "enabled" can be set in rule blocks in override blocks`
+			},
+		},
+		{
+			name: "override block with rule severity",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+override {
+  path = "modules/**"
+
+  rule "aws_instance_invalid_type" {
+    enabled  = true
+    severity = "notice"
+  }
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != `override "modules/**": rule "aws_instance_invalid_type": only "enabled" can be set in rule blocks in override blocks`
+			},
+		},
+		{
+			name: "override block with invalid path",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+override {
+  path = "modules/[**"
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != "Failed to parse override path `modules/[**`; syntax error in pattern"
+			},
+		},
+		{
+			name: "format blocks",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+format {
+  name    = "compact"
+  options = { links = true, context = 2 }
+}
+
+format {
+  name = "json"
+}`,
+			},
+			want: &Config{
+				CallModuleType: terraform.CallLocalModule,
+				IgnoreModules:  map[string]bool{},
+				Varfiles:       []string{},
+				Variables:      []string{},
+				FormatOptions: map[string]map[string]string{
+					"compact": {"links": "true", "context": "2"},
+					"json":    {},
+				},
+				Rules: map[string]*RuleConfig{},
+				Plugins: map[string]*PluginConfig{
+					"terraform": {
+						Name:    "terraform",
+						Enabled: true,
+					},
+				},
+			},
 			errCheck: neverHappend,
 		},
 		{
-			name: "invalid required_version",
+			name: "format block with invalid format",
 			file: "config.hcl",
 			files: map[string]string{
 				"config.hcl": `
-tflint {
-  required_version = "< 0.50"
+format {
+  name = "invalid"
 }`,
 			},
 			errCheck: func(err error) bool {
-				return err == nil || err.Error() != fmt.Sprintf(`config.hcl:3,22-30: Unsupported TFLint version; This config does not support the currently used TFLint version %s. Please update to another supported version or change the version requirement.`, Version)
+				return err == nil || err.Error() != `format "invalid": invalid format. Allowed formats are: default, json, checkstyle, junit, compact, sarif, gitlab, github, rdjson, tap, csv, markdown, html, azure-devops, teamcity, sonarqube, template, jsonl, stream, unix`
 			},
 		},
 		{
-			name: "multiple required_versions",
+			name: "duplicate format blocks",
 			file: "config.hcl",
 			files: map[string]string{
 				"config.hcl": `
-tflint {
-  required_version = ">= 0.50"
+format {
+  name = "json"
 }
 
-tflint {
-  required_version = "< 0.50"
+format {
+  name = "json"
 }`,
 			},
 			errCheck: func(err error) bool {
-				return err == nil || err.Error() != `config.hcl:6,1-7: Multiple "tflint" blocks are not allowed; The "tflint" block is already found in config.hcl:2,1-7, but found the second one.`
+				return err == nil || err.Error() != `format "json": multiple "format" blocks for the same format are not allowed`
 			},
 		},
 		{
@@ -504,6 +943,111 @@ config {
 	}
 }
 
+func TestLoadConfig_discovery(t *testing.T) {
+	config := func(format string) string {
+		return fmt.Sprintf("config {\n  format = \"%s\"\n}\n", format)
+	}
+
+	tests := []struct {
+		name      string
+		files     map[string]string
+		dirs      []string
+		dir       string
+		discovery bool
+		want      string
+	}{
+		{
+			name: "nearest parent directory",
+			files: map[string]string{
+				"repo/.tflint.hcl":         config("json"),
+				"repo/modules/.tflint.hcl": config("compact"),
+			},
+			dirs:      []string{"repo/.git", "repo/modules/vpc"},
+			dir:       "repo/modules/vpc",
+			discovery: true,
+			want:      "compact",
+		},
+		{
+			name: "repository root",
+			files: map[string]string{
+				"repo/.tflint.hcl": config("json"),
+			},
+			dirs:      []string{"repo/.git", "repo/modules/vpc"},
+			dir:       "repo/modules/vpc",
+			discovery: true,
+			want:      "json",
+		},
+		{
+			name: "current directory takes precedence",
+			files: map[string]string{
+				"repo/.tflint.hcl":             config("json"),
+				"repo/modules/vpc/.tflint.hcl": config("compact"),
+			},
+			dirs:      []string{"repo/.git"},
+			dir:       "repo/modules/vpc",
+			discovery: true,
+			want:      "compact",
+		},
+		{
+			name: "stop at the repository root",
+			files: map[string]string{
+				".tflint.hcl": config("json"),
+			},
+			dirs:      []string{"repo/.git", "repo/modules/vpc"},
+			dir:       "repo/modules/vpc",
+			discovery: true,
+			want:      "",
+		},
+		{
+			name: "no discovery",
+			files: map[string]string{
+				"repo/.tflint.hcl": config("json"),
+			},
+			dirs:      []string{"repo/.git", "repo/modules/vpc"},
+			dir:       "repo/modules/vpc",
+			discovery: false,
+			want:      "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := t.TempDir()
+			// Do not use the config file in the home directory
+			t.Setenv("HOME", filepath.Join(root, "home"))
+			for _, dir := range test.dirs {
+				if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for name, src := range test.files {
+				if err := os.MkdirAll(filepath.Join(root, filepath.Dir(name)), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(root, name), []byte(src), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			t.Chdir(filepath.Join(root, test.dir))
+
+			fs := afero.Afero{Fs: afero.NewOsFs()}
+			var got *Config
+			var err error
+			if test.discovery {
+				got, err = LoadConfig(fs, "")
+			} else {
+				got, err = LoadConfigWithoutDiscovery(fs, "")
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Format != test.want {
+				t.Errorf("expected format is %q, but got %q", test.want, got.Format)
+			}
+		})
+	}
+}
+
 func TestMerge(t *testing.T) {
 	file1, diags := hclsyntax.ParseConfig([]byte(`foo = "bar"`), "test.hcl", hcl.Pos{})
 	if diags.HasErrors() {
@@ -530,6 +1074,8 @@ func TestMerge(t *testing.T) {
 		PluginDirSet:      true,
 		Format:            "compact",
 		FormatSet:         true,
+		OutputFile:        "result.json",
+		OutputFileSet:     true,
 		Rules: map[string]*RuleConfig{
 			"aws_instance_invalid_type": {
 				Name:    "aws_instance_invalid_type",
@@ -702,6 +1248,28 @@ func TestMerge(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "merge format options",
+			base: &Config{
+				FormatOptions: map[string]map[string]string{
+					"compact": {"links": "true", "context": "2"},
+					"json":    {"include_source": "true"},
+				},
+			},
+			other: &Config{
+				FormatOptions: map[string]map[string]string{
+					"compact": {"context": "3"},
+					"sarif":   {},
+				},
+			},
+			want: &Config{
+				FormatOptions: map[string]map[string]string{
+					"compact": {"links": "true", "context": "3"},
+					"json":    {"include_source": "true"},
+					"sarif":   {},
+				},
+			},
+		},
 		{
 			name: "CLI --only argument and merge",
 			base: &Config{
@@ -751,6 +1319,7 @@ func TestMerge(t *testing.T) {
 				DisabledByDefault:    true,
 				DisabledByDefaultSet: true,
 				Only:                 []string{"aws_instance_invalid_type", "aws_instance_previous_type"},
+				ExcludeDirs:          []string{"**/examples"},
 				Rules: map[string]*RuleConfig{
 					"aws_instance_invalid_type": {
 						Name:    "aws_instance_invalid_type",
@@ -789,6 +1358,7 @@ func TestMerge(t *testing.T) {
 				DisabledByDefault:    true,
 				DisabledByDefaultSet: true,
 				Only:                 []string{"aws_instance_invalid_type", "aws_instance_previous_type"},
+				ExcludeDirs:          []string{"**/examples"},
 				Rules: map[string]*RuleConfig{
 					"aws_instance_invalid_type": {
 						Name:    "aws_instance_invalid_type",
@@ -873,6 +1443,129 @@ func TestMerge(t *testing.T) {
 				Plugins: map[string]*PluginConfig{},
 			},
 		},
+		{
+			name: "merge rule severity with CLI-based config",
+			base: &Config{
+				CallModuleType:    terraform.CallLocalModule,
+				Force:             false,
+				IgnoreModules:     map[string]bool{},
+				Varfiles:          []string{},
+				Variables:         []string{},
+				DisabledByDefault: false,
+				Rules: map[string]*RuleConfig{
+					"aws_instance_invalid_type": {
+						Name:     "aws_instance_invalid_type",
+						Enabled:  false,
+						Severity: "warning",
+						Body:     file1.Body,
+					},
+				},
+				Plugins: map[string]*PluginConfig{},
+			},
+			other: &Config{
+				CallModuleType:    terraform.CallLocalModule,
+				Force:             false,
+				IgnoreModules:     map[string]bool{},
+				Varfiles:          []string{},
+				Variables:         []string{},
+				DisabledByDefault: false,
+				Rules: map[string]*RuleConfig{
+					"aws_instance_invalid_type": {
+						Name:    "aws_instance_invalid_type",
+						Enabled: true,
+						Body:    nil,
+					},
+				},
+				Plugins: map[string]*PluginConfig{},
+			},
+			want: &Config{
+				CallModuleType:    terraform.CallLocalModule,
+				Force:             false,
+				IgnoreModules:     map[string]bool{},
+				Varfiles:          []string{},
+				Variables:         []string{},
+				DisabledByDefault: false,
+				Rules: map[string]*RuleConfig{
+					"aws_instance_invalid_type": {
+						Name:     "aws_instance_invalid_type",
+						Enabled:  true,       // overridden
+						Severity: "warning",  // keep
+						Body:     file1.Body, // keep
+					},
+				},
+				Plugins: map[string]*PluginConfig{},
+			},
+		},
+		{
+			name: "merge DisabledByDefaultWithoutFile without config files",
+			base: &Config{
+				CallModuleType:    terraform.CallLocalModule,
+				Force:             false,
+				IgnoreModules:     map[string]bool{},
+				Varfiles:          []string{},
+				Variables:         []string{},
+				DisabledByDefault: false,
+				Rules:             map[string]*RuleConfig{},
+				Plugins:           map[string]*PluginConfig{},
+			},
+			other: &Config{
+				CallModuleType:               terraform.CallLocalModule,
+				Force:                        false,
+				IgnoreModules:                map[string]bool{},
+				Varfiles:                     []string{},
+				Variables:                    []string{},
+				DisabledByDefault:            false,
+				DisabledByDefaultWithoutFile: true,
+				Rules:                        map[string]*RuleConfig{},
+				Plugins:                      map[string]*PluginConfig{},
+			},
+			want: &Config{
+				CallModuleType:       terraform.CallLocalModule,
+				Force:                false,
+				IgnoreModules:        map[string]bool{},
+				Varfiles:             []string{},
+				Variables:            []string{},
+				DisabledByDefault:    true,
+				DisabledByDefaultSet: true,
+				Rules:                map[string]*RuleConfig{},
+				Plugins:              map[string]*PluginConfig{},
+			},
+		},
+		{
+			name: "merge DisabledByDefaultWithoutFile with config files",
+			base: &Config{
+				CallModuleType:    terraform.CallLocalModule,
+				Force:             false,
+				IgnoreModules:     map[string]bool{},
+				Varfiles:          []string{},
+				Variables:         []string{},
+				DisabledByDefault: false,
+				Rules:             map[string]*RuleConfig{},
+				Plugins:           map[string]*PluginConfig{},
+				sources:           map[string][]byte{"test.hcl": []byte(`foo = "bar"`)},
+			},
+			other: &Config{
+				CallModuleType:               terraform.CallLocalModule,
+				Force:                        false,
+				IgnoreModules:                map[string]bool{},
+				Varfiles:                     []string{},
+				Variables:                    []string{},
+				DisabledByDefault:            false,
+				DisabledByDefaultWithoutFile: true,
+				Rules:                        map[string]*RuleConfig{},
+				Plugins:                      map[string]*PluginConfig{},
+			},
+			want: &Config{
+				CallModuleType:    terraform.CallLocalModule,
+				Force:             false,
+				IgnoreModules:     map[string]bool{},
+				Varfiles:          []string{},
+				Variables:         []string{},
+				DisabledByDefault: false,
+				Rules:             map[string]*RuleConfig{},
+				Plugins:           map[string]*PluginConfig{},
+			},
+		},
 		{
 			name: "merge plugin config with CLI-based config",
 			base: &Config{
@@ -951,6 +1644,80 @@ func TestMerge(t *testing.T) {
 	}
 }
 
+func TestConfigForFile(t *testing.T) {
+	config := &Config{
+		Rules: map[string]*RuleConfig{
+			"aws_instance_invalid_type": {Name: "aws_instance_invalid_type", Enabled: true},
+			"aws_instance_invalid_ami":  {Name: "aws_instance_invalid_ami", Enabled: true},
+		},
+		Overrides: []*OverrideConfig{
+			{
+				Path: "legacy_web.tf",
+				Rules: map[string]*RuleConfig{
+					"aws_instance_invalid_type": {Name: "aws_instance_invalid_type", Enabled: true},
+				},
+			},
+			{
+				Path: "legacy_*.tf",
+				Rules: map[string]*RuleConfig{
+					"aws_instance_invalid_type": {Name: "aws_instance_invalid_type", Enabled: false},
+				},
+			},
+			{
+				Path: "modules/**",
+				Rules: map[string]*RuleConfig{
+					"aws_instance_invalid_ami":   {Name: "aws_instance_invalid_ami", Enabled: false},
+					"aws_instance_previous_type": {Name: "aws_instance_previous_type", Enabled: false},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want map[string]bool
+	}{
+		{
+			name: "no match",
+			path: "main.tf",
+			want: map[string]bool{"aws_instance_invalid_type": true, "aws_instance_invalid_ami": true},
+		},
+		{
+			name: "glob",
+			path: "legacy_db.tf",
+			want: map[string]bool{"aws_instance_invalid_type": false, "aws_instance_invalid_ami": true},
+		},
+		{
+			name: "exact path takes precedence over glob",
+			path: "legacy_web.tf",
+			want: map[string]bool{"aws_instance_invalid_type": true, "aws_instance_invalid_ami": true},
+		},
+		{
+			name: "double star",
+			path: "modules/instance/main.tf",
+			want: map[string]bool{"aws_instance_invalid_type": true, "aws_instance_invalid_ami": false, "aws_instance_previous_type": false},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := map[string]bool{}
+			for name, rule := range config.ConfigForFile(test.path).Rules {
+				got[name] = rule.Enabled
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+
+	// The original config must not be changed
+	if !config.Rules["aws_instance_invalid_type"].Enabled {
+		t.Error("the original rule config is changed")
+	}
+}
+
 func Test_ToPluginConfig(t *testing.T) {
 	src := `
 config {
@@ -1230,3 +1997,113 @@ func Test_ValidateRules(t *testing.T) {
 		}
 	}
 }
+
+func Test_ValidateOverrides(t *testing.T) {
+	override := func(enabled bool) []*OverrideConfig {
+		return []*OverrideConfig{
+			{
+				Path: "modules/**",
+				Rules: map[string]*RuleConfig{
+					"aws_instance_invalid_type": {Name: "aws_instance_invalid_type", Enabled: enabled},
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		Name   string
+		Config *Config
+		Err    string
+	}{
+		{
+			Name: "enable rules enabled by default",
+			Config: &Config{
+				Rules:     map[string]*RuleConfig{},
+				Overrides: override(true),
+			},
+		},
+		{
+			Name: "disable rules enabled in the global config",
+			Config: &Config{
+				Rules: map[string]*RuleConfig{
+					"aws_instance_invalid_type": {Name: "aws_instance_invalid_type", Enabled: true},
+				},
+				Overrides: override(false),
+			},
+		},
+		{
+			Name: "enable rules disabled in the global config",
+			Config: &Config{
+				Rules: map[string]*RuleConfig{
+					"aws_instance_invalid_type": {Name: "aws_instance_invalid_type", Enabled: false},
+				},
+				Overrides: override(true),
+			},
+			Err: `override "modules/**": rule "aws_instance_invalid_type": rules disabled in the global config cannot be enabled in override blocks`,
+		},
+		{
+			Name: "enable rules disabled by default",
+			Config: &Config{
+				Rules:             map[string]*RuleConfig{},
+				DisabledByDefault: true,
+				Overrides:         override(true),
+			},
+			Err: `override "modules/**": rule "aws_instance_invalid_type": rules disabled in the global config cannot be enabled in override blocks`,
+		},
+		{
+			Name: "enable rules not passed to --only",
+			Config: &Config{
+				Rules:     map[string]*RuleConfig{},
+				Only:      []string{"aws_instance_invalid_ami"},
+				Overrides: override(true),
+			},
+			Err: `override "modules/**": rule "aws_instance_invalid_type": rules disabled in the global config cannot be enabled in override blocks`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := tc.Config.ValidateOverrides()
+			if tc.Err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.Err {
+				t.Fatalf("want error %q, got %v", tc.Err, err)
+			}
+		})
+	}
+}
+
+func Test_UnknownRules(t *testing.T) {
+	config := &Config{
+		Rules: map[string]*RuleConfig{
+			"aws_instance_invalid_type": {Name: "aws_instance_invalid_type", Enabled: false},
+			"aws_instance_invalid_ami":  {Name: "aws_instance_invalid_ami", Enabled: true},
+			"nosuchrule":                {Name: "nosuchrule", Enabled: true},
+		},
+		Overrides: []*OverrideConfig{
+			{
+				Path: "*_test.tf",
+				Rules: map[string]*RuleConfig{
+					"nosuchrule":           {Name: "nosuchrule", Enabled: false},
+					"another_no_such_rule": {Name: "another_no_such_rule", Enabled: false},
+				},
+			},
+		},
+	}
+
+	got, err := config.UnknownRules(&ruleSetA{}, &ruleSetB{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"another_no_such_rule", "nosuchrule"}, got); diff != "" {
+		t.Error(diff)
+	}
+
+	if _, err := config.UnknownRules(&ruleSetB{}, &ruleSetB{}); err == nil {
+		t.Error("an error is expected for duplicated rules")
+	}
+}
@@ -0,0 +1,197 @@
+package tflint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// FixConflict is a pair of autofixes by different rules that change the same lines.
+// Both fixes are skipped because applying them together may produce invalid HCL.
+type FixConflict struct {
+	Filename string
+	// Line is the first changed line in the source before the conflicting fixes
+	Line  int
+	Rules [2]string
+}
+
+func (c *FixConflict) String() string {
+	return fmt.Sprintf("conflicting fixes for %s:%d from %s and %s; skipping both", c.Filename, c.Line, c.Rules[0], c.Rules[1])
+}
+
+// skippedFix is a range of lines changed by a fix that is skipped due to a conflict.
+// Lines are 1-based and inclusive, and they are lines of the source that the rule fixed,
+// so that they can be compared with ranges of issues emitted by the rule.
+type skippedFix struct {
+	rule  string
+	start int
+	end   int
+}
+
+// overlaps returns true if the fix changes lines in the range
+func (f skippedFix) overlaps(rng hcl.Range) bool {
+	end := rng.End.Line
+	if end < rng.Start.Line {
+		end = rng.Start.Line
+	}
+	return f.start <= end && rng.Start.Line <= f.end
+}
+
+// fixStep is a change of a file applied by autofixes of a rule.
+// Plugins send the whole source after fixes of each rule, so changes are recorded as snapshots.
+type fixStep struct {
+	rule   string
+	before []byte
+	after  []byte
+}
+
+// fixHunk is a hunk of lines changed by a fix step
+type fixHunk struct {
+	rule string
+	// start and end are the range of lines [start, end) replaced in the source before all steps.
+	// They are only set if the hunk does not conflict with others.
+	start int
+	end   int
+	lines []string
+	// line is the 1-based line reported in conflicts
+	line    int
+	skipped bool
+	// fixed is the range of lines changed in the source before the step
+	fixed skippedFix
+}
+
+// resolveFixConflicts applies hunks of all steps to the source before the first step, except hunks of different rules
+// that change the same lines. Two hunks conflict if one changes lines produced by the other, or if they are adjacent.
+// It also returns the ranges of skipped hunks, so that only issues of the skipped hunks are treated as not fixed.
+func resolveFixConflicts(filename string, steps []fixStep) ([]byte, []*FixConflict, []skippedFix) {
+	original := splitLines(steps[0].before)
+
+	// origin is the origin of each line in the current source. A non-negative value is
+	// a line of the original source, and a negative value -(i+1) is a line produced by hunks[i].
+	origin := make([]int, len(original))
+	// deletedBy is the hunk that removed each line of the original source, or -1
+	deletedBy := make([]int, len(original))
+	for i := range original {
+		origin[i] = i
+		deletedBy[i] = -1
+	}
+
+	hunks := []*fixHunk{}
+	conflicts := []*FixConflict{}
+
+	for _, step := range steps {
+		before, after := splitLines(step.before), splitLines(step.after)
+		// Each step must start from the result of the previous step. Otherwise, the last result is used as is.
+		if len(before) != len(origin) {
+			return steps[len(steps)-1].after, []*FixConflict{}, []skippedFix{}
+		}
+
+		newOrigin := []int{}
+		for _, op := range difflib.NewMatcher(before, after).GetOpCodes() {
+			if op.Tag == 'e' {
+				newOrigin = append(newOrigin, origin[op.I1:op.I2]...)
+				continue
+			}
+
+			idx := len(hunks)
+			hunk := &fixHunk{rule: step.rule, lines: after[op.J1:op.J2], fixed: skippedFix{rule: step.rule, start: op.I1 + 1, end: op.I2}}
+			if op.I1 == op.I2 {
+				// Insertions are issues of the adjacent lines
+				hunk.fixed.start, hunk.fixed.end = max(op.I1, 1), op.I1+1
+			}
+			hunks = append(hunks, hunk)
+
+			if other := conflictingHunk(origin[op.I1:op.I2], origin, op.I1, deletedBy); other >= 0 {
+				hunk.skipped = true
+				hunk.line = hunks[other].line
+				hunks[other].skipped = true
+				conflicts = append(conflicts, &FixConflict{Filename: filename, Line: hunk.line, Rules: [2]string{hunks[other].rule, step.rule}})
+			} else if op.I1 < op.I2 {
+				hunk.start, hunk.end = origin[op.I1], origin[op.I2-1]+1
+				hunk.line = hunk.start + 1
+			} else {
+				// Insertions are placed before the next original line
+				hunk.start = len(original)
+				if op.I1 < len(origin) {
+					hunk.start = origin[op.I1]
+				}
+				hunk.end = hunk.start
+				hunk.line = hunk.start + 1
+			}
+
+			for _, o := range origin[op.I1:op.I2] {
+				if o >= 0 {
+					deletedBy[o] = idx
+				}
+			}
+			for range hunk.lines {
+				newOrigin = append(newOrigin, -(idx + 1))
+			}
+		}
+		origin = newOrigin
+	}
+
+	kept := []*fixHunk{}
+	skipped := []skippedFix{}
+	for _, hunk := range hunks {
+		if hunk.skipped {
+			skipped = append(skipped, hunk.fixed)
+		} else {
+			kept = append(kept, hunk)
+		}
+	}
+	// Insertions come before replacements at the same line
+	sort.SliceStable(kept, func(i, j int) bool {
+		if kept[i].start != kept[j].start {
+			return kept[i].start < kept[j].start
+		}
+		return kept[i].end < kept[j].end
+	})
+
+	applied := make([]FixHunk, len(kept))
+	for i, hunk := range kept {
+		applied[i] = FixHunk{Start: hunk.start, End: hunk.end, Lines: hunk.lines}
+	}
+
+	return []byte(applyHunks(original, applied)), conflicts, skipped
+}
+
+// conflictingHunk returns the index of the hunk that conflicts with a hunk replacing the lines at the position,
+// or -1 if there is no conflict. A hunk with no replaced lines is an insertion, and it conflicts with adjacent hunks.
+func conflictingHunk(replaced []int, origin []int, pos int, deletedBy []int) int {
+	if len(replaced) == 0 {
+		for _, i := range []int{pos - 1, pos} {
+			if i >= 0 && i < len(origin) && origin[i] < 0 {
+				return -(origin[i] + 1)
+			}
+		}
+		return -1
+	}
+
+	for i, o := range replaced {
+		if o < 0 {
+			return -(o + 1)
+		}
+		// Lines between non-consecutive original lines were removed by another hunk
+		if i > 0 {
+			for d := replaced[i-1] + 1; d < o; d++ {
+				if deletedBy[d] >= 0 {
+					return deletedBy[d]
+				}
+			}
+		}
+	}
+	return -1
+}
+
+// splitLines splits the source into lines with line endings
+func splitLines(src []byte) []string {
+	lines := strings.SplitAfter(string(src), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,170 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+func Test_resolveFixConflicts(t *testing.T) {
+	tests := []struct {
+		name      string
+		steps     []fixStep
+		want      string
+		conflicts []*FixConflict
+		skipped   []skippedFix
+	}{
+		{
+			name: "no conflicts",
+			steps: []fixStep{
+				{rule: "rule_a", before: []byte("a = 1\nb = 2\nc = 3\n"), after: []byte("a = 10\nb = 2\nc = 3\n")},
+				{rule: "rule_b", before: []byte("a = 10\nb = 2\nc = 3\n"), after: []byte("a = 10\nb = 2\nc = 30\n")},
+			},
+			want:      "a = 10\nb = 2\nc = 30\n",
+			conflicts: []*FixConflict{},
+			skipped:   []skippedFix{},
+		},
+		{
+			name: "same line",
+			steps: []fixStep{
+				{rule: "rule_a", before: []byte("a = 1\nb = 2\nc = 3\n"), after: []byte("a = 1\nb = 20\nc = 3\n")},
+				{rule: "rule_b", before: []byte("a = 1\nb = 20\nc = 3\n"), after: []byte("a = 1\nb = 200\nc = 3\n")},
+			},
+			want:      "a = 1\nb = 2\nc = 3\n",
+			conflicts: []*FixConflict{{Filename: "main.tf", Line: 2, Rules: [2]string{"rule_a", "rule_b"}}},
+			skipped:   []skippedFix{{rule: "rule_a", start: 2, end: 2}, {rule: "rule_b", start: 2, end: 2}},
+		},
+		{
+			name: "non-conflicting fixes in the same file",
+			steps: []fixStep{
+				{rule: "rule_a", before: []byte("a = 1\nb = 2\nc = 3\nd = 4\n"), after: []byte("a = 10\nb = 20\nc = 3\nd = 4\n")},
+				{rule: "rule_b", before: []byte("a = 10\nb = 20\nc = 3\nd = 4\n"), after: []byte("a = 10\nb = 200\nc = 3\nd = 40\n")},
+			},
+			want:      "a = 1\nb = 2\nc = 3\nd = 40\n",
+			conflicts: []*FixConflict{{Filename: "main.tf", Line: 1, Rules: [2]string{"rule_a", "rule_b"}}},
+			skipped:   []skippedFix{{rule: "rule_a", start: 1, end: 2}, {rule: "rule_b", start: 2, end: 2}},
+		},
+		{
+			name: "conflicting and non-conflicting fixes of the same rule",
+			steps: []fixStep{
+				{rule: "rule_a", before: []byte("a = 1\nb = 2\nc = 3\n"), after: []byte("a = 10\nb = 2\nc = 30\n")},
+				{rule: "rule_b", before: []byte("a = 10\nb = 2\nc = 30\n"), after: []byte("a = 10\nb = 2\nc = 300\n")},
+			},
+			// Only the hunk of rule_a that conflicts is skipped
+			want:      "a = 10\nb = 2\nc = 3\n",
+			conflicts: []*FixConflict{{Filename: "main.tf", Line: 3, Rules: [2]string{"rule_a", "rule_b"}}},
+			skipped:   []skippedFix{{rule: "rule_a", start: 3, end: 3}, {rule: "rule_b", start: 3, end: 3}},
+		},
+		{
+			name: "lines removed by another rule",
+			steps: []fixStep{
+				{rule: "rule_a", before: []byte("a = 1\nb = 2\nc = 3\n"), after: []byte("a = 1\nc = 3\n")},
+				{rule: "rule_b", before: []byte("a = 1\nc = 3\n"), after: []byte("x = 1\n")},
+			},
+			want:      "a = 1\nb = 2\nc = 3\n",
+			conflicts: []*FixConflict{{Filename: "main.tf", Line: 2, Rules: [2]string{"rule_a", "rule_b"}}},
+			skipped:   []skippedFix{{rule: "rule_a", start: 2, end: 2}, {rule: "rule_b", start: 1, end: 2}},
+		},
+		{
+			name: "insertion next to lines inserted by another rule",
+			steps: []fixStep{
+				{rule: "rule_a", before: []byte("a = 1\nb = 2\n"), after: []byte("a = 1\nx = 1\nb = 2\n")},
+				{rule: "rule_b", before: []byte("a = 1\nx = 1\nb = 2\n"), after: []byte("a = 1\nx = 1\ny = 1\nb = 2\n")},
+			},
+			want:      "a = 1\nb = 2\n",
+			conflicts: []*FixConflict{{Filename: "main.tf", Line: 2, Rules: [2]string{"rule_a", "rule_b"}}},
+			skipped:   []skippedFix{{rule: "rule_a", start: 1, end: 2}, {rule: "rule_b", start: 2, end: 3}},
+		},
+		{
+			name: "insertion and removal",
+			steps: []fixStep{
+				{rule: "rule_a", before: []byte("a = 1\nb = 2\nc = 3\n"), after: []byte("a = 1\nc = 3\n")},
+				{rule: "rule_b", before: []byte("a = 1\nc = 3\n"), after: []byte("x = 1\na = 1\nc = 3\n")},
+			},
+			want:      "x = 1\na = 1\nc = 3\n",
+			conflicts: []*FixConflict{},
+			skipped:   []skippedFix{},
+		},
+		{
+			name: "without trailing newline",
+			steps: []fixStep{
+				{rule: "rule_a", before: []byte("a = 1\nb = 2"), after: []byte("a = 10\nb = 2")},
+				{rule: "rule_b", before: []byte("a = 10\nb = 2"), after: []byte("a = 10\nb = 20")},
+			},
+			want:      "a = 10\nb = 20",
+			conflicts: []*FixConflict{},
+			skipped:   []skippedFix{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, conflicts, skipped := resolveFixConflicts("main.tf", test.steps)
+
+			if diff := cmp.Diff(test.want, string(got)); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(test.conflicts, conflicts); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(test.skipped, skipped, cmp.AllowUnexported(skippedFix{})); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+// fixTestRule is a test rule with the given name
+type fixTestRule struct {
+	testRule
+	name string
+}
+
+func (r *fixTestRule) Name() string {
+	return r.name
+}
+
+func TestResolveFixConflicts(t *testing.T) {
+	src := "locals {\n  foo = \"${var.foo}\"\n}\n"
+	runner := TestRunner(t, map[string]string{"main.tf": src})
+	location := hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 2}}
+
+	// Plugins apply changes after fixes of each rule, and the next rule fixes the result
+	if applied := runner.EmitIssue(&fixTestRule{name: "rule_a"}, "rule_a", location, true); !applied {
+		t.Fatal("the fix of rule_a should be applied")
+	}
+	if diags := runner.ApplyChanges(map[string][]byte{"main.tf": []byte("locals {\n  foo = var.foo\n}\n")}); diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	if applied := runner.EmitIssue(&fixTestRule{name: "rule_b"}, "rule_b", location, true); !applied {
+		t.Fatal("the fix of rule_b should be applied")
+	}
+	if diags := runner.ApplyChanges(map[string][]byte{"main.tf": []byte("locals {\n  foo = var.bar\n}\n")}); diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	conflicts, diags := runner.ResolveFixConflicts()
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	want := []*FixConflict{{Filename: "main.tf", Line: 2, Rules: [2]string{"rule_a", "rule_b"}}}
+	if diff := cmp.Diff(want, conflicts); diff != "" {
+		t.Error(diff)
+	}
+	if got := conflicts[0].String(); got != "conflicting fixes for main.tf:2 from rule_a and rule_b; skipping both" {
+		t.Errorf("unexpected message: %s", got)
+	}
+	if changes := runner.LookupChanges(); len(changes) > 0 {
+		t.Errorf("the file should be unchanged, but got %v", changes)
+	}
+	if diff := cmp.Diff(src, string(runner.Sources()["main.tf"])); diff != "" {
+		t.Errorf("the module should be rebuilt with the original source: %s", diff)
+	}
+	for _, rule := range []string{"rule_a", "rule_b"} {
+		if !runner.FixSkipped(rule, location) {
+			t.Errorf("fixes of %s should be skipped", rule)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package tflint
+
+import (
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// FixHunk is a range of lines rewritten by autofixes in a file.
+// A change of a file is split into hunks so that fixes can be applied separately, e.g. in interactive mode.
+type FixHunk struct {
+	// Start and End are the range of lines [Start, End) replaced in the source before the change.
+	// They are 0-based, and Start equals End for insertions.
+	Start int
+	End   int
+	// Lines are lines with line endings that replace the range
+	Lines []string
+}
+
+// Overlaps returns true if the hunk changes lines in the range.
+// Insertions are treated as changes of the adjacent lines.
+func (h FixHunk) Overlaps(rng hcl.Range) bool {
+	fix := skippedFix{start: h.Start + 1, end: h.End}
+	if h.Start == h.End {
+		fix.start, fix.end = max(h.Start, 1), h.Start+1
+	}
+	return fix.overlaps(rng)
+}
+
+// SplitFixHunks returns hunks of lines changed from the source before to the source after autofixes.
+// Lines are compared in the same way as resolving conflicts. Replacements of the same number of lines
+// are split into hunks of each line, so that fixes of adjacent lines can be applied separately.
+func SplitFixHunks(before []byte, after []byte) []FixHunk {
+	beforeLines, afterLines := splitLines(before), splitLines(after)
+
+	hunks := []FixHunk{}
+	for _, op := range difflib.NewMatcher(beforeLines, afterLines).GetOpCodes() {
+		switch {
+		case op.Tag == 'e':
+			continue
+		case op.Tag == 'r' && op.I2-op.I1 == op.J2-op.J1:
+			for i := range op.I2 - op.I1 {
+				hunks = append(hunks, FixHunk{Start: op.I1 + i, End: op.I1 + i + 1, Lines: afterLines[op.J1+i : op.J1+i+1]})
+			}
+		default:
+			hunks = append(hunks, FixHunk{Start: op.I1, End: op.I2, Lines: afterLines[op.J1:op.J2]})
+		}
+	}
+	return hunks
+}
+
+// ApplyFixHunks returns the source in which the hunks are applied.
+// Hunks must be sorted by lines and must not overlap, like hunks returned by SplitFixHunks.
+func ApplyFixHunks(src []byte, hunks []FixHunk) []byte {
+	return []byte(applyHunks(splitLines(src), hunks))
+}
+
+// applyHunks replaces lines of the original source with hunks in order
+func applyHunks(original []string, hunks []FixHunk) string {
+	var out strings.Builder
+	pos := 0
+	for _, hunk := range hunks {
+		out.WriteString(strings.Join(original[pos:hunk.Start], ""))
+		out.WriteString(strings.Join(hunk.Lines, ""))
+		pos = hunk.End
+	}
+	out.WriteString(strings.Join(original[pos:], ""))
+	return out.String()
+}
@@ -0,0 +1,136 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+func TestSplitFixHunks(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   []FixHunk
+	}{
+		{
+			name:   "no changes",
+			before: "a = 1\nb = 2\n",
+			after:  "a = 1\nb = 2\n",
+			want:   []FixHunk{},
+		},
+		{
+			name:   "separated lines",
+			before: "a = 1\nb = 2\nc = 3\n",
+			after:  "a = 10\nb = 2\nc = 30\n",
+			want: []FixHunk{
+				{Start: 0, End: 1, Lines: []string{"a = 10\n"}},
+				{Start: 2, End: 3, Lines: []string{"c = 30\n"}},
+			},
+		},
+		{
+			name:   "adjacent lines",
+			before: "a = 1\nb = 2\nc = 3\n",
+			after:  "a = 10\nb = 20\nc = 3\n",
+			want: []FixHunk{
+				{Start: 0, End: 1, Lines: []string{"a = 10\n"}},
+				{Start: 1, End: 2, Lines: []string{"b = 20\n"}},
+			},
+		},
+		{
+			name:   "different number of lines",
+			before: "a = 1\nb = 2\nc = 3\n",
+			after:  "a = 1\nb = {\n  c = 3\n}\n",
+			want: []FixHunk{
+				{Start: 1, End: 3, Lines: []string{"b = {\n", "  c = 3\n", "}\n"}},
+			},
+		},
+		{
+			name:   "insertion and deletion",
+			before: "a = 1\nb = 2\nc = 3\n",
+			after:  "x = 0\na = 1\nc = 3\n",
+			want: []FixHunk{
+				{Start: 0, End: 0, Lines: []string{"x = 0\n"}},
+				{Start: 1, End: 2, Lines: []string{}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := SplitFixHunks([]byte(test.before), []byte(test.after))
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Error(diff)
+			}
+
+			// Applying all hunks must result in the source after the change
+			if applied := string(ApplyFixHunks([]byte(test.before), got)); applied != test.after {
+				t.Errorf("expected %q, but got %q", test.after, applied)
+			}
+		})
+	}
+}
+
+func TestApplyFixHunks(t *testing.T) {
+	src := []byte("a = 1\nb = 2\nc = 3\n")
+	hunks := SplitFixHunks(src, []byte("a = 10\nb = 2\nc = 30\n"))
+
+	got := string(ApplyFixHunks(src, hunks[1:]))
+	want := "a = 1\nb = 2\nc = 30\n"
+	if got != want {
+		t.Errorf("expected %q, but got %q", want, got)
+	}
+}
+
+func TestFixHunk_Overlaps(t *testing.T) {
+	lines := func(start, end int) hcl.Range {
+		return hcl.Range{Start: hcl.Pos{Line: start}, End: hcl.Pos{Line: end}}
+	}
+
+	tests := []struct {
+		name string
+		hunk FixHunk
+		rng  hcl.Range
+		want bool
+	}{
+		{
+			name: "same line",
+			hunk: FixHunk{Start: 1, End: 2},
+			rng:  lines(2, 2),
+			want: true,
+		},
+		{
+			name: "another line",
+			hunk: FixHunk{Start: 1, End: 2},
+			rng:  lines(3, 3),
+			want: false,
+		},
+		{
+			name: "range over multiple lines",
+			hunk: FixHunk{Start: 3, End: 4},
+			rng:  lines(2, 5),
+			want: true,
+		},
+		{
+			name: "insertion after the line",
+			hunk: FixHunk{Start: 2, End: 2},
+			rng:  lines(2, 2),
+			want: true,
+		},
+		{
+			name: "insertion at the beginning",
+			hunk: FixHunk{Start: 0, End: 0},
+			rng:  lines(1, 1),
+			want: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.hunk.Overlaps(test.rng); got != test.want {
+				t.Errorf("expected %t, but got %t", test.want, got)
+			}
+		})
+	}
+}
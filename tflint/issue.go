@@ -1,10 +1,15 @@
 package tflint
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"sort"
+	"strings"
 
+	"github.com/bmatcuk/doublestar"
 	hcl "github.com/hashicorp/hcl/v2"
 	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
@@ -15,6 +20,8 @@ type Issue struct {
 	Message string
 	Range   hcl.Range
 	Fixable bool
+	// Fixed is true if the issue was fixed and the file was rewritten by --fix.
+	Fixed   bool
 	Callers []hcl.Range
 
 	// Source is the source code of the file where the issue was found.
@@ -77,16 +84,146 @@ func (issues Issues) Sort() Issues {
 		if iRange.End.Column != jRange.End.Column {
 			return iRange.End.Column > jRange.End.Column
 		}
+		if issues[i].Rule.Name() != issues[j].Rule.Name() {
+			return issues[i].Rule.Name() < issues[j].Rule.Name()
+		}
 		return issues[i].Message < issues[j].Message
 	})
 	return issues
 }
 
+// FilterBySeverity returns issues whose rule severity is the given severity or higher
+func (issues Issues) FilterBySeverity(min Severity) Issues {
+	minInt32, err := SeverityToInt32(min)
+	if err != nil {
+		return issues
+	}
+
+	ret := Issues{}
+	for _, issue := range issues {
+		severity, err := SeverityToInt32(issue.Rule.Severity())
+		if err != nil || severity >= minInt32 {
+			ret = append(ret, issue)
+		}
+	}
+	return ret
+}
+
+// FilterByFile returns issues whose filename matches any of the doublestar glob patterns.
+// Patterns use forward slashes as the separator and are matched against cleaned filenames.
+// All issues are returned if no pattern is given, and an empty result is returned if no files match.
+// Patterns are validated even if there are no issues, so that invalid patterns are always reported.
+func (issues Issues) FilterByFile(patterns []string) (Issues, error) {
+	for _, pattern := range patterns {
+		// Matching the pattern against itself walks the entire pattern, so that syntax errors are detected
+		if _, err := doublestar.Match(pattern, pattern); err != nil {
+			return nil, fmt.Errorf("Failed to parse the file pattern `%s`; %w", pattern, err)
+		}
+	}
+	if len(patterns) == 0 {
+		return issues, nil
+	}
+
+	ret := Issues{}
+	for _, issue := range issues {
+		filename := filepath.ToSlash(filepath.Clean(issue.Range.Filename))
+		for _, pattern := range patterns {
+			if matched, _ := doublestar.Match(pattern, filename); matched {
+				ret = append(ret, issue)
+				break
+			}
+		}
+	}
+	return ret, nil
+}
+
+// Fingerprint returns a stable ID of the issue, so that baselines and integrations can track it across runs.
+// It is a hex-encoded SHA-256 hash of the following, separated by NUL characters:
+//
+//   - The rule name
+//   - The filename with forward slashes
+//   - The hex-encoded SHA-256 hash of the source of the issue range
+//
+// Line numbers are not included so that moving unrelated lines does not change it,
+// and issues in different files never share a fingerprint because the filename is included.
+// Changing the algorithm changes IDs tracked by integrations, so it must not be changed.
+func (i *Issue) Fingerprint() string {
+	var code []byte
+	if i.Source != nil && !i.Range.Empty() && i.Range.End.Byte <= len(i.Source) {
+		code = i.Range.SliceBytes(i.Source)
+	}
+	codeHash := sha256.Sum256(code)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s", i.Rule.Name(), filepath.ToSlash(i.Range.Filename), hex.EncodeToString(codeHash[:]))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Deduplicate returns issues without duplicates.
+// Issues are duplicates if they have the same message at the same position, even if reported by different rules,
+// e.g. when expanded dynamic blocks are inspected. Issues in modules called by different module calls are not duplicates.
+// Fixed or fixable issues take precedence so that fixes are not hidden. Otherwise, the issue of the rule
+// with the smallest name is kept so that the result does not depend on the order in which rules are run.
+func (issues Issues) Deduplicate() Issues {
+	type key struct {
+		filename string
+		line     int
+		column   int
+		message  string
+		callers  string
+	}
+
+	seen := map[key]int{}
+	ret := Issues{}
+	for _, issue := range issues {
+		callers := make([]string, len(issue.Callers))
+		for i, caller := range issue.Callers {
+			callers[i] = caller.String()
+		}
+		k := key{
+			filename: issue.Range.Filename,
+			line:     issue.Range.Start.Line,
+			column:   issue.Range.Start.Column,
+			message:  issue.Message,
+			callers:  strings.Join(callers, ","),
+		}
+		if idx, exists := seen[k]; exists {
+			if precedes(issue, ret[idx]) {
+				ret[idx] = issue
+			}
+			continue
+		}
+		seen[k] = len(ret)
+		ret = append(ret, issue)
+	}
+	return ret
+}
+
+// precedes returns true if the issue should be kept over the other duplicate
+func precedes(issue *Issue, other *Issue) bool {
+	if p, q := fixPriority(issue), fixPriority(other); p != q {
+		return p > q
+	}
+	return issue.Rule.Name() < other.Rule.Name()
+}
+
+func fixPriority(issue *Issue) int {
+	switch {
+	case issue.Fixed:
+		return 2
+	case issue.Fixable:
+		return 1
+	default:
+		return 0
+	}
+}
+
 type issue struct {
 	Rule    *rule       `json:"rule"`
 	Message string      `json:"message"`
 	Range   hcl.Range   `json:"range"`
 	Fixable bool        `json:"fixable"`
+	Fixed   bool        `json:"fixed"`
 	Callers []hcl.Range `json:"callers"`
 	Source  []byte      `json:"source"`
 }
@@ -113,6 +250,7 @@ func (i *Issue) MarshalJSON() ([]byte, error) {
 		Message: i.Message,
 		Range:   i.Range,
 		Fixable: i.Fixable,
+		Fixed:   i.Fixed,
 		Callers: i.Callers,
 		Source:  i.Source,
 	})
@@ -129,6 +267,7 @@ func (i *Issue) UnmarshalJSON(data []byte) error {
 	i.Message = out.Message
 	i.Range = out.Range
 	i.Fixable = out.Fixable
+	i.Fixed = out.Fixed
 	i.Callers = out.Callers
 	i.Source = out.Source
 
@@ -3,6 +3,7 @@ package tflint
 import (
 	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -163,6 +164,15 @@ func Test_Sort(t *testing.T) {
 				End:      hcl.Pos{Line: 2, Column: 4},
 			},
 		},
+		{
+			Rule:    &rule{RawName: "a_rule", RawSeverity: sdk.ERROR},
+			Message: "test",
+			Range: hcl.Range{
+				Filename: "test2.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1},
+				End:      hcl.Pos{Line: 1, Column: 2},
+			},
+		},
 	}
 
 	expected := Issues{
@@ -211,6 +221,15 @@ func Test_Sort(t *testing.T) {
 				End:      hcl.Pos{Line: 2, Column: 3},
 			},
 		},
+		{
+			Rule:    &rule{RawName: "a_rule", RawSeverity: sdk.ERROR},
+			Message: "test",
+			Range: hcl.Range{
+				Filename: "test2.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1},
+				End:      hcl.Pos{Line: 1, Column: 2},
+			},
+		},
 		{
 			Rule:    &testRule{},
 			Message: "test",
@@ -228,6 +247,285 @@ func Test_Sort(t *testing.T) {
 	}
 }
 
+func Test_FilterBySeverity(t *testing.T) {
+	errorIssue := &Issue{Rule: &rule{RawName: "error_rule", RawSeverity: sdk.ERROR}, Message: "error"}
+	warningIssue := &Issue{Rule: &rule{RawName: "warning_rule", RawSeverity: sdk.WARNING}, Message: "warning"}
+	noticeIssue := &Issue{Rule: &rule{RawName: "notice_rule", RawSeverity: sdk.NOTICE}, Message: "notice"}
+	issues := Issues{errorIssue, warningIssue, noticeIssue}
+
+	tests := []struct {
+		name string
+		min  Severity
+		want Issues
+	}{
+		{
+			name: "error",
+			min:  sdk.ERROR,
+			want: Issues{errorIssue},
+		},
+		{
+			name: "warning",
+			min:  sdk.WARNING,
+			want: Issues{errorIssue, warningIssue},
+		},
+		{
+			name: "notice",
+			min:  sdk.NOTICE,
+			want: Issues{errorIssue, warningIssue, noticeIssue},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := issues.FilterBySeverity(test.min)
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_FilterByFile(t *testing.T) {
+	issue := func(filename string) *Issue {
+		return &Issue{Rule: &rule{RawName: "test_rule", RawSeverity: sdk.ERROR}, Message: "test", Range: hcl.Range{Filename: filename}}
+	}
+	main := issue("main.tf")
+	variables := issue("variables.tf")
+	module := issue(filepath.Join("modules", "vpc", "main.tf"))
+	unclean := issue(filepath.Join(".", "outputs.tf"))
+	issues := Issues{main, variables, module, unclean}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		want     Issues
+		err      string
+	}{
+		{
+			name:     "no patterns",
+			patterns: []string{},
+			want:     issues,
+		},
+		{
+			name:     "single file",
+			patterns: []string{"main.tf"},
+			want:     Issues{main},
+		},
+		{
+			name:     "single glob",
+			patterns: []string{"*.tf"},
+			want:     Issues{main, variables, unclean},
+		},
+		{
+			name:     "doublestar glob",
+			patterns: []string{"**/main.tf"},
+			want:     Issues{main, module},
+		},
+		{
+			name:     "multi glob",
+			patterns: []string{"var*.tf", "modules/*/*.tf"},
+			want:     Issues{variables, module},
+		},
+		{
+			name:     "overlapping globs",
+			patterns: []string{"main.tf", "m*.tf"},
+			want:     Issues{main},
+		},
+		{
+			name:     "no match",
+			patterns: []string{"*.tfvars"},
+			want:     Issues{},
+		},
+		{
+			name:     "invalid pattern",
+			patterns: []string{"*.tf", "[main.tf"},
+			err:      "Failed to parse the file pattern `[main.tf`; syntax error in pattern",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := issues.FilterByFile(test.patterns)
+			if err != nil {
+				if err.Error() != test.err {
+					t.Errorf("expected error is `%s`, but got `%s`", test.err, err)
+				}
+				return
+			}
+			if test.err != "" {
+				t.Fatalf("expected error is `%s`, but got nil", test.err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_Fingerprint(t *testing.T) {
+	src := []byte("resource \"aws_instance\" \"main\" {\n  instance_type = \"t2.micro\"\n}\n")
+	issue := &Issue{
+		Rule:    &rule{RawName: "test_rule", RawSeverity: sdk.ERROR},
+		Message: "test",
+		Range: hcl.Range{
+			Filename: filepath.Join("subdir", "test.tf"),
+			Start:    hcl.Pos{Line: 2, Column: 19, Byte: 51},
+			End:      hcl.Pos{Line: 2, Column: 29, Byte: 61},
+		},
+		Source: src,
+	}
+
+	// The algorithm must not be changed. If this test fails, integrations will lose track of issues.
+	// sha256("test_rule\x00subdir/test.tf\x00" + hex(sha256(`"t2.micro"`)))
+	want := "29c693a8dad88dda103163b1a0091aa5bf9f46f41a2cf8b606d11a03b78eb919"
+	if got := issue.Fingerprint(); got != want {
+		t.Errorf("expected %s, but got %s", want, got)
+	}
+
+	// sha256("test_rule\x00subdir/test.tf\x00" + hex(sha256("")))
+	withoutSource := *issue
+	withoutSource.Source = nil
+	if got, want := withoutSource.Fingerprint(), "8a45300dd9e254b3145e47eb0d83eec399851f919d26a49331856cf2f39644e3"; got != want {
+		t.Errorf("expected %s without source, but got %s", want, got)
+	}
+
+	moved := *issue
+	moved.Range.Start.Line, moved.Range.End.Line = 3, 3
+	moved.Message = "moved"
+	if issue.Fingerprint() != moved.Fingerprint() {
+		t.Error("fingerprints should be the same if only the line and message are different")
+	}
+
+	otherFile := *issue
+	otherFile.Range.Filename = "test.tf"
+	if issue.Fingerprint() == otherFile.Fingerprint() {
+		t.Error("fingerprints should be different if the file is different")
+	}
+
+	otherRule := *issue
+	otherRule.Rule = &rule{RawName: "other_rule", RawSeverity: sdk.ERROR}
+	if issue.Fingerprint() == otherRule.Fingerprint() {
+		t.Error("fingerprints should be different if the rule is different")
+	}
+}
+
+func Test_Deduplicate(t *testing.T) {
+	errorRule := &rule{RawName: "error_rule", RawSeverity: sdk.ERROR}
+	warningRule := &rule{RawName: "warning_rule", RawSeverity: sdk.WARNING}
+	rng := hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1, Column: 1}, End: hcl.Pos{Line: 1, Column: 5}}
+	otherRng := hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 2, Column: 1}, End: hcl.Pos{Line: 2, Column: 5}}
+	caller1 := hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 10, Column: 1}, End: hcl.Pos{Line: 10, Column: 5}}
+	caller2 := hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 20, Column: 1}, End: hcl.Pos{Line: 20, Column: 5}}
+
+	tests := []struct {
+		name   string
+		issues Issues
+		want   Issues
+	}{
+		{
+			name:   "no issues",
+			issues: Issues{},
+			want:   Issues{},
+		},
+		{
+			name: "same rule",
+			issues: Issues{
+				{Rule: errorRule, Message: "test", Range: rng},
+				{Rule: errorRule, Message: "test", Range: rng},
+			},
+			want: Issues{
+				{Rule: errorRule, Message: "test", Range: rng},
+			},
+		},
+		{
+			name: "different rules",
+			issues: Issues{
+				{Rule: warningRule, Message: "test", Range: rng},
+				{Rule: errorRule, Message: "test", Range: rng},
+			},
+			want: Issues{
+				{Rule: errorRule, Message: "test", Range: rng},
+			},
+		},
+		{
+			name: "fixable issue",
+			issues: Issues{
+				{Rule: warningRule, Message: "test", Range: rng},
+				{Rule: errorRule, Message: "test", Range: rng, Fixable: true},
+				{Rule: errorRule, Message: "test", Range: rng},
+			},
+			want: Issues{
+				{Rule: errorRule, Message: "test", Range: rng, Fixable: true},
+			},
+		},
+		{
+			name: "fixed issue",
+			issues: Issues{
+				{Rule: warningRule, Message: "test", Range: rng, Fixable: true},
+				{Rule: errorRule, Message: "test", Range: rng, Fixable: true, Fixed: true},
+			},
+			want: Issues{
+				{Rule: errorRule, Message: "test", Range: rng, Fixable: true, Fixed: true},
+			},
+		},
+		{
+			name: "different end positions",
+			issues: Issues{
+				{Rule: errorRule, Message: "test", Range: rng},
+				{Rule: errorRule, Message: "test", Range: hcl.Range{Filename: rng.Filename, Start: rng.Start, End: hcl.Pos{Line: 3, Column: 1}}},
+			},
+			want: Issues{
+				{Rule: errorRule, Message: "test", Range: rng},
+			},
+		},
+		{
+			name: "different messages",
+			issues: Issues{
+				{Rule: errorRule, Message: "test1", Range: rng},
+				{Rule: errorRule, Message: "test2", Range: rng},
+			},
+			want: Issues{
+				{Rule: errorRule, Message: "test1", Range: rng},
+				{Rule: errorRule, Message: "test2", Range: rng},
+			},
+		},
+		{
+			name: "different positions",
+			issues: Issues{
+				{Rule: errorRule, Message: "test", Range: rng},
+				{Rule: errorRule, Message: "test", Range: otherRng},
+				{Rule: errorRule, Message: "test", Range: hcl.Range{Filename: "other.tf", Start: rng.Start, End: rng.End}},
+			},
+			want: Issues{
+				{Rule: errorRule, Message: "test", Range: rng},
+				{Rule: errorRule, Message: "test", Range: otherRng},
+				{Rule: errorRule, Message: "test", Range: hcl.Range{Filename: "other.tf", Start: rng.Start, End: rng.End}},
+			},
+		},
+		{
+			name: "different callers",
+			issues: Issues{
+				{Rule: errorRule, Message: "test", Range: rng, Callers: []hcl.Range{caller1, rng}},
+				{Rule: errorRule, Message: "test", Range: rng, Callers: []hcl.Range{caller2, rng}},
+				{Rule: errorRule, Message: "test", Range: rng, Callers: []hcl.Range{caller1, rng}},
+			},
+			want: Issues{
+				{Rule: errorRule, Message: "test", Range: rng, Callers: []hcl.Range{caller1, rng}},
+				{Rule: errorRule, Message: "test", Range: rng, Callers: []hcl.Range{caller2, rng}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.issues.Deduplicate()
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
 func TestMarshalJSON(t *testing.T) {
 	tests := []struct {
 		name   string
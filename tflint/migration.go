@@ -0,0 +1,187 @@
+package tflint
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ConfigMigration rewrites a config file written for an older version of TFLint,
+// so that it can be loaded by a newer version.
+type ConfigMigration struct {
+	// From and To are the versions of TFLint before and after the change, e.g. "v0.53" and "v0.54".
+	// The migration is applied if To is newer than the version to migrate from and not newer than the version to migrate to.
+	From string
+	To   string
+	// Migrate rewrites the file in place and returns warnings.
+	// It must be idempotent, so that migrating a migrated file does not change it.
+	Migrate func(f *hclwrite.File) []string
+}
+
+// configMigrations are migrations applied by MigrateConfig, in order of versions.
+var configMigrations = []ConfigMigration{
+	{From: "v0.11", To: "v0.12", Migrate: migrateIgnoreRuleAttribute},
+	{From: "v0.53", To: "v0.54", Migrate: migrateModuleAttribute},
+}
+
+// MigrateConfig applies migrations between the versions to the config file and returns the formatted source.
+// Attributes and blocks unknown to this version are preserved as they are, and reported as warnings.
+func MigrateConfig(src []byte, filename string, from *version.Version, to *version.Version) ([]byte, []string, error) {
+	if from.GreaterThan(to) {
+		return nil, nil, fmt.Errorf("The version to migrate from (%s) must not be newer than the version to migrate to (%s)", from, to)
+	}
+
+	f, diags := hclwrite.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, nil, diags
+	}
+
+	warnings := []string{}
+	for _, migration := range configMigrations {
+		// Migrations are applied if the change was made after the version to migrate from
+		changedIn := version.Must(version.NewVersion(migration.To))
+		if !changedIn.GreaterThan(from) || changedIn.GreaterThan(to) {
+			continue
+		}
+		warnings = append(warnings, migration.Migrate(f)...)
+	}
+	warnings = append(warnings, unknownConfigWarnings(f)...)
+
+	return hclwrite.Format(f.Bytes()), warnings, nil
+}
+
+// migrateIgnoreRuleAttribute replaces the "ignore_rule" attribute removed in v0.12 with rule blocks.
+// Rules ignored by "ignore_rule" are disabled with "enabled = false". Since "ignore_rule" was overridden by rule blocks,
+// rules that already have a rule block are not changed.
+func migrateIgnoreRuleAttribute(f *hclwrite.File) []string {
+	warnings := []string{}
+	for _, block := range f.Body().Blocks() {
+		if block.Type() != "config" {
+			continue
+		}
+		body := block.Body()
+		attr := body.GetAttribute("ignore_rule")
+		if attr == nil {
+			continue
+		}
+
+		ignored, ok := ignoredRules(attr)
+		if !ok {
+			warnings = append(warnings, `"ignore_rule" attribute is not migrated because the value is not a literal map of booleans. Use rule blocks instead`)
+			continue
+		}
+
+		body.RemoveAttribute("ignore_rule")
+		for _, name := range ignored {
+			exists := slices.ContainsFunc(f.Body().Blocks(), func(b *hclwrite.Block) bool {
+				return b.Type() == "rule" && slices.Equal(b.Labels(), []string{name})
+			})
+			if exists {
+				warnings = append(warnings, fmt.Sprintf(`"%s" in "ignore_rule" attribute is not migrated because the rule block is already declared`, name))
+				continue
+			}
+			f.Body().AppendNewline()
+			rule := f.Body().AppendNewBlock("rule", []string{name})
+			rule.Body().SetAttributeValue("enabled", cty.False)
+		}
+	}
+	return warnings
+}
+
+// ignoredRules returns sorted names of rules set to true in the "ignore_rule" attribute.
+// It returns false if the value is not a literal map of booleans.
+func ignoredRules(attr *hclwrite.Attribute) ([]string, bool) {
+	expr, diags := hclsyntax.ParseExpression(attr.Expr().BuildTokens(nil).Bytes(), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, false
+	}
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() || val.IsNull() || !val.IsWhollyKnown() || !(val.Type().IsObjectType() || val.Type().IsMapType()) {
+		return nil, false
+	}
+
+	ignored := []string{}
+	for name, v := range val.AsValueMap() {
+		if v.IsNull() || v.Type() != cty.Bool {
+			return nil, false
+		}
+		if v.True() {
+			ignored = append(ignored, name)
+		}
+	}
+	slices.Sort(ignored)
+	return ignored, true
+}
+
+// migrateModuleAttribute replaces the "module" attribute removed in v0.54 with "call_module_type".
+// "module = true" is the same as "call_module_type = "all"", and "module = false" is the same as "call_module_type = "none"".
+func migrateModuleAttribute(f *hclwrite.File) []string {
+	warnings := []string{}
+	for _, block := range f.Body().Blocks() {
+		if block.Type() != "config" {
+			continue
+		}
+		body := block.Body()
+		attr := body.GetAttribute("module")
+		if attr == nil {
+			continue
+		}
+
+		if body.GetAttribute("call_module_type") != nil {
+			body.RemoveAttribute("module")
+			warnings = append(warnings, `"module" attribute is removed because "call_module_type" is already set`)
+			continue
+		}
+
+		var callModuleType string
+		switch strings.TrimSpace(string(attr.Expr().BuildTokens(nil).Bytes())) {
+		case "true":
+			callModuleType = "all"
+		case "false":
+			callModuleType = "none"
+		default:
+			warnings = append(warnings, `"module" attribute is not migrated because the value is not a literal boolean. Use "call_module_type" instead`)
+			continue
+		}
+		// Rename first to keep the position of the attribute
+		body.RenameAttribute("module", "call_module_type")
+		body.SetAttributeValue("call_module_type", cty.StringVal(callModuleType))
+	}
+	return warnings
+}
+
+// unknownConfigWarnings returns warnings for attributes and blocks that are not in the config schema of this version.
+func unknownConfigWarnings(f *hclwrite.File) []string {
+	warnings := []string{}
+	for _, name := range slices.Sorted(maps.Keys(f.Body().Attributes())) {
+		warnings = append(warnings, fmt.Sprintf(`Unknown attribute "%s" is preserved as is`, name))
+	}
+	for _, block := range f.Body().Blocks() {
+		known := slices.ContainsFunc(configSchema.Blocks, func(schema hcl.BlockHeaderSchema) bool {
+			return schema.Type == block.Type()
+		})
+		if !known {
+			warnings = append(warnings, fmt.Sprintf(`Unknown block "%s" is preserved as is`, block.Type()))
+			continue
+		}
+		if block.Type() != "config" {
+			continue
+		}
+		for _, name := range slices.Sorted(maps.Keys(block.Body().Attributes())) {
+			known := slices.ContainsFunc(innerConfigSchema.Attributes, func(schema hcl.AttributeSchema) bool {
+				return schema.Name == name
+			})
+			if !known {
+				warnings = append(warnings, fmt.Sprintf(`Unknown attribute "%s" in the config block is preserved as is`, name))
+			}
+		}
+	}
+	return warnings
+}
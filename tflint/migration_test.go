@@ -0,0 +1,276 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+)
+
+func TestMigrateConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		from     string
+		to       string
+		want     string
+		warnings []string
+		err      string
+	}{
+		{
+			name: "module = true",
+			src: `config {
+  format = "compact"
+  module = true
+}
+`,
+			from: "v0.53",
+			to:   "v0.54",
+			want: `config {
+  format           = "compact"
+  call_module_type = "all"
+}
+`,
+			warnings: []string{},
+		},
+		{
+			name: "module = false",
+			src: `config {
+  module = false
+}
+`,
+			from: "v0.40",
+			to:   "v0.57",
+			want: `config {
+  call_module_type = "none"
+}
+`,
+			warnings: []string{},
+		},
+		{
+			name: "module with call_module_type",
+			src: `config {
+  module           = true
+  call_module_type = "local"
+}
+`,
+			from: "v0.53",
+			to:   "v0.54",
+			want: `config {
+  call_module_type = "local"
+}
+`,
+			warnings: []string{`"module" attribute is removed because "call_module_type" is already set`},
+		},
+		{
+			name: "module with non-literal value",
+			src: `config {
+  module = !false
+}
+`,
+			from: "v0.53",
+			to:   "v0.54",
+			want: `config {
+  module = !false
+}
+`,
+			warnings: []string{`"module" attribute is not migrated because the value is not a literal boolean. Use "call_module_type" instead`},
+		},
+		{
+			name: "module before the version to migrate to",
+			src: `config {
+  module = true
+}
+`,
+			from: "v0.40",
+			to:   "v0.50",
+			want: `config {
+  module = true
+}
+`,
+			warnings: []string{},
+		},
+		{
+			name: "module after the version to migrate from",
+			src: `config {
+  module = true
+}
+`,
+			from: "v0.54",
+			to:   "v0.57",
+			want: `config {
+  module = true
+}
+`,
+			warnings: []string{},
+		},
+		{
+			name: "ignore_rule",
+			src: `config {
+  format = "compact"
+  ignore_rule = {
+    terraform_unused_declarations = true
+    aws_instance_invalid_type     = true
+    terraform_comment_syntax      = false
+  }
+}
+`,
+			from: "v0.11",
+			to:   "v0.12",
+			want: `config {
+  format = "compact"
+}
+
+rule "aws_instance_invalid_type" {
+  enabled = false
+}
+
+rule "terraform_unused_declarations" {
+  enabled = false
+}
+`,
+			warnings: []string{},
+		},
+		{
+			name: "ignore_rule with rule blocks",
+			src: `config {
+  ignore_rule = {
+    "aws_instance_invalid_type" = true
+  }
+}
+
+rule "aws_instance_invalid_type" {
+  enabled = true
+}
+`,
+			from: "v0.11",
+			to:   "v0.57",
+			want: `config {
+}
+
+rule "aws_instance_invalid_type" {
+  enabled = true
+}
+`,
+			warnings: []string{`"aws_instance_invalid_type" in "ignore_rule" attribute is not migrated because the rule block is already declared`},
+		},
+		{
+			name: "ignore_rule with non-literal value",
+			src: `config {
+  ignore_rule = var.rules
+}
+`,
+			from: "v0.11",
+			to:   "v0.12",
+			want: `config {
+  ignore_rule = var.rules
+}
+`,
+			warnings: []string{
+				`"ignore_rule" attribute is not migrated because the value is not a literal map of booleans. Use rule blocks instead`,
+				`Unknown attribute "ignore_rule" in the config block is preserved as is`,
+			},
+		},
+		{
+			name: "ignore_rule after the version to migrate from",
+			src: `config {
+  ignore_rule = {
+    aws_instance_invalid_type = true
+  }
+}
+`,
+			from: "v0.12",
+			to:   "v0.54",
+			want: `config {
+  ignore_rule = {
+    aws_instance_invalid_type = true
+  }
+}
+`,
+			warnings: []string{`Unknown attribute "ignore_rule" in the config block is preserved as is`},
+		},
+		{
+			name: "unknown attributes and blocks",
+			src: `future = true
+
+config {
+  module = true
+  future_option = "value"
+}
+
+future_block {
+  enabled = true
+}
+`,
+			from: "v0.53",
+			to:   "v0.54",
+			want: `future = true
+
+config {
+  call_module_type = "all"
+  future_option    = "value"
+}
+
+future_block {
+  enabled = true
+}
+`,
+			warnings: []string{
+				`Unknown attribute "future" is preserved as is`,
+				`Unknown attribute "future_option" in the config block is preserved as is`,
+				`Unknown block "future_block" is preserved as is`,
+			},
+		},
+		{
+			name: "invalid versions",
+			src:  "config {}\n",
+			from: "v0.54",
+			to:   "v0.53",
+			err:  "The version to migrate from (0.54.0) must not be newer than the version to migrate to (0.53.0)",
+		},
+		{
+			name: "invalid syntax",
+			src:  "config {\n",
+			from: "v0.53",
+			to:   "v0.54",
+			err:  ".tflint.hcl:1,8-9: Unclosed configuration block; There is no closing brace for this block before the end of the file. This may be caused by incorrect brace nesting elsewhere in this file.",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			from := version.Must(version.NewVersion(test.from))
+			to := version.Must(version.NewVersion(test.to))
+
+			got, warnings, err := MigrateConfig([]byte(test.src), ".tflint.hcl", from, to)
+			if err != nil {
+				if test.err == "" {
+					t.Fatal(err)
+				}
+				if err.Error() != test.err {
+					t.Fatalf("expected error is %q, but got %q", test.err, err)
+				}
+				return
+			}
+			if test.err != "" {
+				t.Fatal("an error is expected, but got nil")
+			}
+
+			if diff := cmp.Diff(test.want, string(got)); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(test.warnings, warnings); diff != "" {
+				t.Error(diff)
+			}
+
+			// Migrating the migrated config must not change it
+			again, _, err := MigrateConfig(got, ".tflint.hcl", from, to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(string(got), string(again)); diff != "" {
+				t.Errorf("migration is not idempotent: %s", diff)
+			}
+		})
+	}
+}
@@ -1,9 +1,14 @@
 package tflint
 
 import (
+	"bytes"
 	"fmt"
 	"log"
+	"maps"
+	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	hcl "github.com/hashicorp/hcl/v2"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
@@ -26,6 +31,16 @@ type Runner struct {
 	currentExpr hcl.Expression
 	modVars     map[string]*moduleVariable
 	changes     map[string][]byte
+	// Sources of changed files before the first change. They are kept even if changes are cleared.
+	originals map[string][]byte
+	// Rules whose autofixes are allowed. All autofixes are allowed if empty.
+	fixRules map[string]bool
+	// Rules whose fixes are emitted since the last changes. Plugins apply changes after each rule, so they are attributed to the rules.
+	pendingFixRules []string
+	// Changes of each file applied by rules since changes are cleared
+	fixSteps map[string][]fixStep
+	// Fixes skipped due to conflicts in each file in the last attempt. They are kept even if changes are cleared.
+	skippedFixes map[string][]skippedFix
 }
 
 // Rule is interface for building the issue
@@ -67,6 +82,7 @@ func NewRunner(originalWorkingDir string, c *Config, ants map[string]Annotations
 		annotations: ants,
 		config:      c,
 		changes:     map[string][]byte{},
+		originals:   map[string][]byte{},
 	}
 
 	return runner, nil
@@ -228,13 +244,22 @@ func (r *Runner) Sources() map[string][]byte {
 // Returns true if the issue was not ignored by annotations.
 func (r *Runner) EmitIssue(rule Rule, message string, location hcl.Range, fixable bool) bool {
 	if r.TFConfig.Path.IsRoot() {
-		return r.emitIssue(&Issue{
+		applied := r.emitIssue(&Issue{
 			Rule:    rule,
 			Message: message,
 			Range:   location,
 			Fixable: fixable,
 			Source:  r.Sources()[location.Filename],
 		})
+		// The issue is still reported, but the fix is discarded by the plugin.
+		if applied && fixable && !r.FixAllowed(rule.Name()) {
+			log.Printf("[INFO] The fix of %s (%s) is not applied because the rule is not passed to --fix-rule", location.String(), rule.Name())
+			return false
+		}
+		if applied && fixable && !slices.Contains(r.pendingFixRules, rule.Name()) {
+			r.pendingFixRules = append(r.pendingFixRules, rule.Name())
+		}
+		return applied
 	} else {
 		modVars := r.listModuleVars(r.currentExpr)
 		// Returns true only if all issues have not been ignored in called modules.
@@ -280,22 +305,141 @@ func (r *Runner) ApplyChanges(changes map[string][]byte) hcl.Diagnostics {
 		return nil
 	}
 
+	originals := map[string][]byte{}
+	for path := range changes {
+		originals[path] = r.TFConfig.Module.Sources[path]
+	}
+
 	diags := r.TFConfig.Module.Rebuild(changes)
 	if diags.HasErrors() {
 		return diags
 	}
+
+	rule := strings.Join(r.pendingFixRules, ", ")
+	if rule == "" {
+		rule = "unknown rule"
+	}
+	r.pendingFixRules = nil
+	if r.fixSteps == nil {
+		r.fixSteps = map[string][]fixStep{}
+	}
+
 	for path, source := range changes {
 		r.changes[path] = source
+		if _, exists := r.originals[path]; !exists {
+			r.originals[path] = originals[path]
+		}
+		r.fixSteps[path] = append(r.fixSteps[path], fixStep{rule: rule, before: originals[path], after: source})
 	}
 	return nil
 }
 
+// ResolveFixConflicts skips fixes by different rules that change the same lines since changes are cleared,
+// and applies the remaining fixes to the Terraform module. It returns the skipped conflicts.
+// Fixes are applied by each rule on the result of the previous rule, so applying all of them may produce invalid HCL.
+func (r *Runner) ResolveFixConflicts() ([]*FixConflict, hcl.Diagnostics) {
+	conflicts := []*FixConflict{}
+	resolved := map[string][]byte{}
+	r.skippedFixes = map[string][]skippedFix{}
+
+	paths := make([]string, 0, len(r.fixSteps))
+	for path := range r.fixSteps {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	for _, path := range paths {
+		steps := r.fixSteps[path]
+		if len(steps) < 2 {
+			continue
+		}
+		source, found, skipped := resolveFixConflicts(path, steps)
+		if len(found) == 0 {
+			continue
+		}
+		conflicts = append(conflicts, found...)
+		resolved[path] = source
+		r.skippedFixes[path] = skipped
+	}
+	if len(resolved) == 0 {
+		return conflicts, nil
+	}
+
+	if diags := r.TFConfig.Module.Rebuild(resolved); diags.HasErrors() {
+		return conflicts, diags
+	}
+	for path, source := range resolved {
+		// If all fixes are skipped, the file is no longer changed
+		if bytes.Equal(source, r.fixSteps[path][0].before) {
+			delete(r.changes, path)
+		} else {
+			r.changes[path] = source
+		}
+	}
+	return conflicts, nil
+}
+
+// FixSkipped returns true if the fix of the rule for the issue range is skipped due to conflicts in the last attempt.
+// Plugins do not send which fix belongs to which issue, so the fix of an issue is the one that changes lines of the range.
+// Other fixes of the rule in the same file are still applied.
+func (r *Runner) FixSkipped(ruleName string, rng hcl.Range) bool {
+	return slices.ContainsFunc(r.skippedFixes[rng.Filename], func(fix skippedFix) bool {
+		return fix.rule == ruleName && fix.overlaps(rng)
+	})
+}
+
+// FileChange is a file rewritten by autofixes, with the sources before and after the changes.
+type FileChange struct {
+	Filename string `json:"filename"`
+	Before   []byte `json:"before"`
+	After    []byte `json:"after"`
+}
+
+// LookupOriginal returns the source of the file before changes are applied.
+// It returns false if the file has never been changed.
+func (r *Runner) LookupOriginal(path string) ([]byte, bool) {
+	source, exists := r.originals[path]
+	return source, exists
+}
+
+// SetFixRules limits autofixes to the given rules. All autofixes are allowed if no rules are given.
+func (r *Runner) SetFixRules(rules []string) {
+	r.fixRules = map[string]bool{}
+	for _, rule := range rules {
+		r.fixRules[rule] = true
+	}
+}
+
+// FixAllowed returns true if autofixes of the rule are allowed
+func (r *Runner) FixAllowed(ruleName string) bool {
+	return len(r.fixRules) == 0 || r.fixRules[ruleName]
+}
+
 // ClearChanges clears changes
 func (r *Runner) ClearChanges() {
 	r.changes = map[string][]byte{}
+	r.fixSteps = map[string][]fixStep{}
+	r.pendingFixRules = nil
+}
+
+// ValidateAnnotations emits issues for annotations in the module that refer to unknown rules.
+// Rule names are only known after plugins are launched, so annotations cannot be validated when they are parsed.
+func (r *Runner) ValidateAnnotations(ruleNames []string) {
+	for _, path := range slices.Sorted(maps.Keys(r.annotations)) {
+		for _, issue := range r.annotations[path].Validate(ruleNames) {
+			issue.Source = r.Sources()[path]
+			r.emitIssue(issue)
+		}
+	}
 }
 
 func (r *Runner) emitIssue(issue *Issue) bool {
+	if len(r.config.Overrides) > 0 {
+		if rule, exists := r.config.ConfigForFile(r.workingDirRelPath(issue.Range.Filename)).Rules[issue.Rule.Name()]; exists && !rule.Enabled {
+			log.Printf("[INFO] %s (%s) is ignored by override blocks", issue.Range.String(), issue.Rule.Name())
+			return false
+		}
+	}
 	if annotations, ok := r.annotations[issue.Range.Filename]; ok {
 		for _, annotation := range annotations {
 			if annotation.IsAffected(issue) {
@@ -338,3 +482,21 @@ func listVarRefs(expr hcl.Expression) map[string]addrs.InputVariable {
 
 	return ret
 }
+
+// workingDirRelPath returns the path relative to the current working directory.
+// Filenames of issues are relative to the original working directory, which differs when --chdir is used.
+func (r *Runner) workingDirRelPath(filename string) string {
+	if !filepath.IsAbs(filename) {
+		filename = filepath.Join(r.Ctx.Meta.OriginalWorkingDir, filename)
+	}
+	//nolint:TODO // Take the working directory explicitly instead of the directory changed by os.Chdir.
+	wd, err := os.Getwd()
+	if err != nil {
+		return filename
+	}
+	rel, err := filepath.Rel(wd, filename)
+	if err != nil {
+		return filename
+	}
+	return rel
+}
@@ -470,6 +470,7 @@ func Test_EmitIssue(t *testing.T) {
 		Fixable     bool
 		Annotations map[string]Annotations
 		Module      *moduleConfig
+		FixRules    []string
 		Expected    Issues
 		Applied     bool
 	}{
@@ -519,6 +520,79 @@ func Test_EmitIssue(t *testing.T) {
 			},
 			Applied: true,
 		},
+		{
+			Name:    "fixable with allowed fix rules",
+			Rule:    &testRule{},
+			Message: "This is test message",
+			Location: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1},
+			},
+			Fixable:     true,
+			Annotations: map[string]Annotations{},
+			FixRules:    []string{"other_rule", "test_rule"},
+			Expected: Issues{
+				{
+					Rule:    &testRule{},
+					Message: "This is test message",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1},
+					},
+					Fixable: true,
+					Source:  []byte("foo = 1"),
+				},
+			},
+			Applied: true,
+		},
+		{
+			Name:    "fixable with other fix rules",
+			Rule:    &testRule{},
+			Message: "This is test message",
+			Location: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1},
+			},
+			Fixable:     true,
+			Annotations: map[string]Annotations{},
+			FixRules:    []string{"other_rule"},
+			Expected: Issues{
+				{
+					Rule:    &testRule{},
+					Message: "This is test message",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1},
+					},
+					Fixable: true,
+					Source:  []byte("foo = 1"),
+				},
+			},
+			Applied: false,
+		},
+		{
+			Name:    "not fixable with other fix rules",
+			Rule:    &testRule{},
+			Message: "This is test message",
+			Location: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1},
+			},
+			Annotations: map[string]Annotations{},
+			FixRules:    []string{"other_rule"},
+			Expected: Issues{
+				{
+					Rule:    &testRule{},
+					Message: "This is test message",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1},
+					},
+					Source: []byte("foo = 1"),
+				},
+			},
+			Applied: true,
+		},
 		{
 			Name:    "ignore",
 			Rule:    &testRule{},
@@ -724,6 +798,7 @@ func Test_EmitIssue(t *testing.T) {
 				runner.currentExpr = tc.Module.currentExpr
 				runner.modVars = tc.Module.variables
 			}
+			runner.SetFixRules(tc.FixRules)
 
 			got := runner.EmitIssue(tc.Rule, tc.Message, tc.Location, tc.Fixable)
 			if got != tc.Applied {
@@ -779,6 +854,33 @@ func TestApplyChanges(t *testing.T) {
 	}
 }
 
+func Test_LookupOriginal(t *testing.T) {
+	runner := TestRunner(t, map[string]string{
+		"main.tf":      `variable "foo" {}`,
+		"variables.tf": `variable "bar" {}`,
+	})
+
+	if diags := runner.ApplyChanges(map[string][]byte{"variables.tf": []byte(`variable "bar" { type = string }`)}); diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	runner.ClearChanges()
+	// The original is the source before the first change, even after changes are cleared
+	if diags := runner.ApplyChanges(map[string][]byte{"variables.tf": []byte(`variable "bar" { type = number }`)}); diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	got, exists := runner.LookupOriginal("variables.tf")
+	if !exists {
+		t.Fatal("the original of variables.tf is not found")
+	}
+	if diff := cmp.Diff(`variable "bar" {}`, string(got)); diff != "" {
+		t.Error(diff)
+	}
+	if _, exists := runner.LookupOriginal("main.tf"); exists {
+		t.Error("the original of main.tf should not be found because it is not changed")
+	}
+}
+
 func Test_listVarRefs(t *testing.T) {
 	cases := []struct {
 		Name     string
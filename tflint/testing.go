@@ -30,7 +30,7 @@ func TestRunnerWithConfig(t *testing.T, files map[string]string, config *Config)
 		t.Fatal(err)
 	}
 
-	loader, err := terraform.NewLoader(fs, originalWd)
+	loader, err := terraform.NewLoader(fs, originalWd, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -32,7 +32,7 @@ func testRunnerWithOsFs(t *testing.T, config *Config) *Runner {
 		t.Fatal(err)
 	}
 
-	loader, err := terraform.NewLoader(afero.Afero{Fs: afero.NewOsFs()}, originalWd)
+	loader, err := terraform.NewLoader(afero.Afero{Fs: afero.NewOsFs()}, originalWd, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -65,7 +65,7 @@ func testRunnerWithAnnotations(t *testing.T, files map[string]string, annotation
 		t.Fatal(err)
 	}
 
-	loader, err := terraform.NewLoader(fs, originalWd)
+	loader, err := terraform.NewLoader(fs, originalWd, nil)
 	if err != nil {
 		t.Fatal(err)
 	}